@@ -0,0 +1,125 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-chassis/cari/pkg/errsvc"
+
+	"github.com/apache/servicecomb-service-center/pkg/alarm/model"
+	"github.com/apache/servicecomb-service-center/pkg/dump"
+)
+
+const (
+	apiAlarmsURL       = "/v4/default/admin/alarms"
+	apiAlarmHistoryURL = "/v4/default/admin/alarms/history"
+	apiAlarmAckURL     = "/v4/default/admin/alarms/%s/acknowledge"
+	apiExportURL       = "/v4/default/admin/export"
+	apiImportURL       = "/v4/default/admin/import"
+)
+
+// Dump returns the cache of service center, options can be 'cache', 'config'
+// and 'all', empty means 'all'
+func (c *Client) Dump(ctx context.Context, options ...string) (*dump.Response, *errsvc.Error) {
+	// only default domain has admin permission
+	headers := c.domainHeaders(ctx, "default")
+	api := apiDumpURL
+	if len(options) > 0 {
+		query := url.Values{}
+		query.Set("options", strings.Join(options, ","))
+		api += "?" + query.Encode()
+	}
+	dumpResp := &dump.Response{}
+	if err := c.do(ctx, http.MethodGet, api, headers, nil, dumpResp); err != nil {
+		return nil, err
+	}
+	return dumpResp, nil
+}
+
+// Export returns the archive of the registry, it can be imported into
+// the service center of any datasource kind
+func (c *Client) Export(ctx context.Context) (*dump.Archive, *errsvc.Error) {
+	archive := &dump.Archive{}
+	if err := c.do(ctx, http.MethodGet, apiExportURL, c.domainHeaders(ctx, "default"), nil, archive); err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+// Import writes the archive into the registry, the existing resources are skipped
+func (c *Client) Import(ctx context.Context, archive *dump.Archive) (*dump.ImportResult, *errsvc.Error) {
+	result := &dump.ImportResult{}
+	if err := c.do(ctx, http.MethodPost, apiImportURL, c.domainHeaders(ctx, "default"), archive, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetAlarms returns the alarms filtered by the status, empty means all
+func (c *Client) GetAlarms(ctx context.Context, status model.Status) ([]*model.Alarm, *errsvc.Error) {
+	headers := c.domainHeaders(ctx, "default")
+	api := apiAlarmsURL
+	if len(status) > 0 {
+		api += "?status=" + url.QueryEscape(string(status))
+	}
+	alarmsResp := &dump.AlarmListResponse{}
+	if err := c.do(ctx, http.MethodGet, api, headers, nil, alarmsResp); err != nil {
+		return nil, err
+	}
+	return alarmsResp.Details, nil
+}
+
+func (c *Client) GetAlarmHistory(ctx context.Context, request *model.HistoryRequest) ([]*model.History, *errsvc.Error) {
+	headers := c.domainHeaders(ctx, "default")
+	query := url.Values{}
+	if len(request.ID) > 0 {
+		query.Set("id", string(request.ID))
+	}
+	if len(request.Status) > 0 {
+		query.Set("status", string(request.Status))
+	}
+	for key, v := range map[string]int64{"start": request.Start, "end": request.End, "limit": request.Limit} {
+		if v > 0 {
+			query.Set(key, strconv.FormatInt(v, 10))
+		}
+	}
+	historyResp := &dump.AlarmHistoryResponse{}
+	err := c.do(ctx, http.MethodGet, apiAlarmHistoryURL+"?"+query.Encode(), headers, nil, historyResp)
+	if err != nil {
+		return nil, err
+	}
+	return historyResp.Histories, nil
+}
+
+func (c *Client) AcknowledgeAlarm(ctx context.Context, id model.ID) (*model.Alarm, *errsvc.Error) {
+	headers := c.domainHeaders(ctx, "default")
+	alarm := &model.Alarm{}
+	err := c.do(ctx, http.MethodPut, fmt.Sprintf(apiAlarmAckURL, url.PathEscape(string(id))), headers, nil, alarm)
+	if err != nil {
+		return nil, err
+	}
+	return alarm, nil
+}
+
+func (c *Client) ClearAlarms(ctx context.Context) *errsvc.Error {
+	return c.do(ctx, http.MethodDelete, apiAlarmsURL, c.domainHeaders(ctx, "default"), nil, nil)
+}
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chassis/cari/discovery"
 	"github.com/go-chassis/cari/pkg/errsvc"
@@ -48,6 +49,49 @@ func (c *Client) toError(body []byte) *errsvc.Error {
 	return message
 }
 
+func (c *Client) domainHeaders(ctx context.Context, domain string) http.Header {
+	headers := c.CommonHeaders(ctx)
+	headers.Set("X-Domain-Name", domain)
+	return headers
+}
+
+// do sends the request with the JSON encoded reqObj and decodes the response
+// body into respObj, the reqObj and respObj can be nil
+func (c *Client) do(ctx context.Context, method, api string, headers http.Header, reqObj, respObj interface{}) *errsvc.Error {
+	var reqBody []byte
+	if reqObj != nil {
+		var err error
+		reqBody, err = json.Marshal(reqObj)
+		if err != nil {
+			return discovery.NewError(discovery.ErrInternal, err.Error())
+		}
+	}
+
+	resp, err := c.RestDoWithContext(ctx, method, api, headers, reqBody)
+	if err != nil {
+		return discovery.NewError(discovery.ErrInternal, err.Error())
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return discovery.NewError(discovery.ErrInternal, err.Error())
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return c.toError(body)
+	}
+
+	if respObj == nil || len(body) == 0 {
+		return nil
+	}
+	err = json.Unmarshal(body, respObj)
+	if err != nil {
+		return discovery.NewError(discovery.ErrInternal, err.Error())
+	}
+	return nil
+}
+
 func (c *Client) parseQuery(ctx context.Context) (q string) {
 	switch {
 	case ctx.Value(QueryGlobal) == "1":
@@ -84,10 +128,20 @@ func (c *Client) GetScVersion(ctx context.Context) (*version.Set, *errsvc.Error)
 }
 
 func (c *Client) GetScCache(ctx context.Context) (*dump.Cache, *errsvc.Error) {
+	return c.GetScCacheSince(ctx, 0)
+}
+
+// GetScCacheSince returns the kvs changed after the revision, the full cache
+// is returned if rev is 0 or the remote does not support incremental dump
+func (c *Client) GetScCacheSince(ctx context.Context, rev int64) (*dump.Cache, *errsvc.Error) {
 	headers := c.CommonHeaders(ctx)
 	// only default domain has admin permission
 	headers.Set("X-Domain-Name", "default")
-	resp, err := c.RestDoWithContext(ctx, http.MethodGet, apiDumpURL, headers, nil)
+	api := apiDumpURL
+	if rev > 0 {
+		api += "?since=" + strconv.FormatInt(rev, 10)
+	}
+	resp, err := c.RestDoWithContext(ctx, http.MethodGet, api, headers, nil)
 	if err != nil {
 		return nil, discovery.NewError(discovery.ErrInternal, err.Error())
 	}
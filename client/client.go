@@ -18,10 +18,13 @@ package client
 import (
 	"context"
 	"net/http"
+	"strings"
+
+	"github.com/apache/servicecomb-service-center/pkg/log"
 )
 
 func NewSCClient(cfg Config) (*Client, error) {
-	client, err := NewLBClient(cfg.Endpoints, cfg.Merge())
+	client, err := NewLBClientWithOptions(cfg.LBOptions(), cfg.Merge())
 	if err != nil {
 		return nil, err
 	}
@@ -31,6 +34,8 @@ func NewSCClient(cfg Config) (*Client, error) {
 type Client struct {
 	*LBClient
 	Cfg Config
+
+	tokens tokenCache
 }
 
 func (c *Client) CommonHeaders(ctx context.Context) http.Header {
@@ -39,5 +44,35 @@ func (c *Client) CommonHeaders(ctx context.Context) http.Header {
 	if len(c.Cfg.Token) > 0 {
 		headers.Set("X-Auth-Token", c.Cfg.Token)
 	}
+	if c.Cfg.Account != nil {
+		token, err := c.token(ctx)
+		if err != nil {
+			log.Error("acquire token failed", err)
+			return headers
+		}
+		headers.Set(headerAuth, bearerPrefix+token)
+	}
 	return headers
 }
+
+// RestDoWithContext acquires a new token and retries once when the server
+// responds 401 to the request authenticated by the cached token
+func (c *Client) RestDoWithContext(ctx context.Context, method string, api string, headers http.Header, body []byte) (*http.Response, error) {
+	resp, err := c.LBClient.RestDoWithContext(ctx, method, api, headers, body)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || c.Cfg.Account == nil {
+		return resp, err
+	}
+	auth := headers.Get(headerAuth)
+	if len(auth) == 0 {
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	c.tokens.Invalidate(strings.TrimPrefix(auth, bearerPrefix))
+	token, tokenErr := c.token(ctx)
+	if tokenErr != nil {
+		return nil, tokenErr
+	}
+	headers.Set(headerAuth, bearerPrefix+token)
+	return c.LBClient.RestDoWithContext(ctx, method, api, headers, body)
+}
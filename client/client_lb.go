@@ -27,13 +27,21 @@ import (
 )
 
 func NewLBClient(endpoints []string, options rest.URLClientOption) (*LBClient, error) {
+	return NewLBClientWithOptions(lb.Options{Endpoints: endpoints}, options)
+}
+
+func NewLBClientWithOptions(lbOptions lb.Options, options rest.URLClientOption) (*LBClient, error) {
+	balancer, err := lb.New(lbOptions)
+	if err != nil {
+		return nil, err
+	}
 	client, err := rest.GetURLClient(options)
 	if err != nil {
 		return nil, err
 	}
 	return &LBClient{
-		Retries:   len(endpoints),
-		LB:        lb.NewRoundRobinLB(endpoints),
+		Retries:   len(lbOptions.Endpoints),
+		LB:        balancer,
 		URLClient: client,
 	}, nil
 }
@@ -53,6 +61,9 @@ func (c *LBClient) RestDoWithContext(ctx context.Context, method string, api str
 	for i := 0; i < c.Retries; i++ {
 		addr := c.Next()
 		resp, err = c.HTTPDoWithContext(ctx, method, addr+api, headers, body)
+		if fb, ok := c.LB.(lb.Feedback); ok {
+			fb.Done(addr, err)
+		}
 		if err != nil {
 			errs = append(errs, fmt.Sprintf("[%s]: %s", addr, err.Error()))
 			continue
@@ -24,6 +24,9 @@ import (
 	"os"
 	"testing"
 
+	"github.com/gorilla/websocket"
+
+	"github.com/apache/servicecomb-service-center/pkg/lb"
 	"github.com/apache/servicecomb-service-center/pkg/rest"
 )
 
@@ -55,3 +58,91 @@ func TestNewLBClient(t *testing.T) {
 		t.Fatal("TestNewLBClient", err)
 	}
 }
+
+func TestNewLBClientWithOptions(t *testing.T) {
+	_, err := NewLBClientWithOptions(lb.Options{Kind: "unknown"}, rest.DefaultURLClientOption())
+	if err == nil {
+		t.Fatal("TestNewLBClientWithOptions")
+	}
+
+	dead := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	dead.Close()
+	count := 0
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		count++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svc.Close()
+
+	cfg := Config{Endpoints: []string{dead.URL, svc.URL}, Ejection: true}
+	client, err := NewLBClientWithOptions(cfg.LBOptions(), rest.DefaultURLClientOption())
+	if err != nil {
+		t.Fatal("TestNewLBClientWithOptions", err)
+	}
+	for i := 0; i < 3; i++ {
+		_, err = client.RestDoWithContext(context.Background(), http.MethodGet, "", nil, nil)
+		if err != nil {
+			t.Fatal("TestNewLBClientWithOptions", err)
+		}
+	}
+	if count != 3 {
+		t.Fatal("TestNewLBClientWithOptions", count)
+	}
+	if !client.LB.(*lb.EjectionLB).Ejected(dead.URL) {
+		t.Fatal("TestNewLBClientWithOptions")
+	}
+}
+
+func TestLBClient_WebsocketDial(t *testing.T) {
+	dead := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	dead.Close()
+
+	cfg := Config{Endpoints: []string{dead.URL}, Ejection: true}
+	client, err := NewLBClientWithOptions(cfg.LBOptions(), rest.DefaultURLClientOption())
+	if err != nil {
+		t.Fatal("TestLBClient_WebsocketDial", err)
+	}
+	_, err = client.WebsocketDial(context.Background(), "/ws", nil)
+	if err == nil {
+		t.Fatal("TestLBClient_WebsocketDial")
+	}
+	if !client.LB.(*lb.EjectionLB).Ejected(dead.URL) {
+		t.Fatal("TestLBClient_WebsocketDial")
+	}
+}
+
+func TestLBClient_WebsocketDialInFlight(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		conn, err := upgrader.Upgrade(w, req, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer svc.Close()
+
+	cfg := Config{Endpoints: []string{svc.URL}, Balancer: lb.LeastInFlight, Ejection: true}
+	client, err := NewLBClientWithOptions(cfg.LBOptions(), rest.DefaultURLClientOption())
+	if err != nil {
+		t.Fatal("TestLBClient_WebsocketDialInFlight", err)
+	}
+	inner := client.LB.(*lb.EjectionLB).LoadBalancer.(*lb.LeastInFlightLB)
+	conn, err := client.WebsocketDial(context.Background(), "/ws", nil)
+	if err != nil {
+		t.Fatal("TestLBClient_WebsocketDialInFlight", err)
+	}
+	if inner.InFlight(svc.URL) != 1 {
+		t.Fatal("TestLBClient_WebsocketDialInFlight", inner.InFlight(svc.URL))
+	}
+	conn.Close()
+	conn.Close()
+	if inner.InFlight(svc.URL) != 0 {
+		t.Fatal("TestLBClient_WebsocketDialInFlight", inner.InFlight(svc.URL))
+	}
+}
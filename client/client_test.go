@@ -0,0 +1,256 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chassis/cari/discovery"
+	"github.com/go-chassis/cari/pkg/errsvc"
+	"github.com/go-chassis/cari/rbac"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/servicecomb-service-center/pkg/alarm/model"
+	"github.com/apache/servicecomb-service-center/pkg/gov"
+)
+
+type request struct {
+	Method string
+	Path   string
+	Query  string
+	Header http.Header
+	Body   string
+}
+
+// newTestServer records the requests and responds the registered objects
+// with 200, or 404 if the route is not registered
+func newTestServer(t *testing.T, routes map[string]interface{}) (*httptest.Server, *[]request) {
+	var requests []request
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, request{Method: r.Method, Path: r.URL.EscapedPath(), Query: r.URL.RawQuery, Header: r.Header, Body: string(body)})
+		obj, ok := routes[r.Method+" "+r.URL.EscapedPath()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			b, _ := json.Marshal(discovery.NewError(discovery.ErrInvalidParams, "not found"))
+			w.Write(b)
+			return
+		}
+		if obj == nil {
+			return
+		}
+		b, err := json.Marshal(obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(b)
+	}))
+	return svc, &requests
+}
+
+func newTestClient(t *testing.T, svc *httptest.Server, account *rbac.Account) *Client {
+	c, err := NewSCClient(Config{Endpoints: []string{svc.URL}, Account: account})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func jwt(exp time.Time) string {
+	payload, _ := json.Marshal(map[string]interface{}{"account": "root", "exp": exp.Unix()})
+	return "e30." + base64.RawURLEncoding.EncodeToString(payload) + ".sig"
+}
+
+func TestClient_Token(t *testing.T) {
+	ctx := context.Background()
+	account := &rbac.Account{Name: "root", Password: "pwd"}
+
+	t.Run("acquire token once and reuse it", func(t *testing.T) {
+		token := jwt(time.Now().Add(time.Hour))
+		svc, requests := newTestServer(t, map[string]interface{}{
+			"POST /v4/token": &rbac.Token{TokenStr: token},
+			"GET /v4/default/registry/microservices/s1/tags": &discovery.GetServiceTagsResponse{Tags: map[string]string{"a": "b"}},
+		})
+		defer svc.Close()
+
+		c := newTestClient(t, svc, account)
+		for i := 0; i < 2; i++ {
+			tags, err := c.GetTags(ctx, "default", "default", "s1")
+			assert.Nil(t, err)
+			assert.Equal(t, map[string]string{"a": "b"}, tags)
+		}
+		assert.Equal(t, 3, len(*requests))
+		assert.Equal(t, `{"name":"root","password":"pwd"}`, (*requests)[0].Body)
+		assert.Equal(t, "Bearer "+token, (*requests)[1].Header.Get("Authorization"))
+		assert.Equal(t, "Bearer "+token, (*requests)[2].Header.Get("Authorization"))
+	})
+
+	t.Run("refresh the token before expiration", func(t *testing.T) {
+		svc, requests := newTestServer(t, map[string]interface{}{
+			"POST /v4/token":                  &rbac.Token{TokenStr: jwt(time.Now().Add(tokenRefreshAhead / 2))},
+			"DELETE /v4/default/admin/alarms": nil,
+		})
+		defer svc.Close()
+
+		c := newTestClient(t, svc, account)
+		assert.Nil(t, c.ClearAlarms(ctx))
+		assert.Nil(t, c.ClearAlarms(ctx))
+		assert.Equal(t, 4, len(*requests))
+		assert.Equal(t, "/v4/token", (*requests)[2].Path)
+	})
+
+	t.Run("refresh the token and retry once when the server rejects it", func(t *testing.T) {
+		logins := 0
+		svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == apiTokenURL {
+				logins++
+				b, _ := json.Marshal(&rbac.Token{TokenStr: fmt.Sprintf("token%d", logins)})
+				w.Write(b)
+				return
+			}
+			if r.Header.Get("Authorization") != "Bearer token2" {
+				w.WriteHeader(http.StatusUnauthorized)
+				b, _ := json.Marshal(discovery.NewError(rbac.ErrTokenExpired, ""))
+				w.Write(b)
+				return
+			}
+			body, _ := ioutil.ReadAll(r.Body)
+			w.Write(body)
+		}))
+		defer svc.Close()
+
+		c := newTestClient(t, svc, account)
+		resp, err := c.RestDoWithContext(ctx, http.MethodPost, "/echo", c.CommonHeaders(ctx), []byte("hello"))
+		assert.NoError(t, err)
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, "hello", string(body))
+		assert.Equal(t, 2, logins)
+
+		// the new token is cached
+		_, err = c.RestDoWithContext(ctx, http.MethodGet, "/echo", c.CommonHeaders(ctx), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, logins)
+	})
+
+	t.Run("login failed", func(t *testing.T) {
+		svc, _ := newTestServer(t, map[string]interface{}{})
+		defer svc.Close()
+
+		c := newTestClient(t, svc, account)
+		_, err := c.GetToken(ctx, account)
+		assert.NotNil(t, err)
+		assert.Empty(t, c.CommonHeaders(ctx).Get("Authorization"))
+	})
+}
+
+func TestTokenExpiration(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Truncate(time.Second)
+	assert.True(t, exp.Equal(tokenExpiration(jwt(exp))))
+	assert.True(t, tokenExpiration("opaque").IsZero())
+	assert.True(t, tokenExpiration("a.!.c").IsZero())
+}
+
+func TestClient_APIs(t *testing.T) {
+	ctx := context.Background()
+	svc, requests := newTestServer(t, map[string]interface{}{
+		"POST /v4/p/registry/microservices/s1/rules":              &discovery.AddServiceRulesResponse{RuleIds: []string{"r1"}},
+		"DELETE /v4/p/registry/microservices/s1/rules/r1,r2":      nil,
+		"PUT /v4/p/registry/dependencies":                         nil,
+		"GET /v4/p/registry/microservices/s1/providers":           &discovery.GetConDependenciesResponse{Providers: []*discovery.MicroService{{ServiceId: "s2"}}},
+		"PUT /v4/p/registry/microservices/s1/instances/i1/status": nil,
+		"POST /v4/p/registry/instances/action":                    &discovery.BatchFindInstancesResponse{Services: &discovery.BatchFindResult{}},
+		"DELETE /v4/p/registry/microservices":                     &discovery.DelServicesResponse{Services: []*discovery.DelServicesRspInfo{{ServiceId: "s1"}}},
+		"POST /v1/p/gov/match-group":                              &DistributeResponse{Policy: &gov.Policy{GovernancePolicy: &gov.GovernancePolicy{ID: "g1"}}, Distributors: []*DistributeResult{{Name: "istio", Type: "istio"}}},
+		"GET /v1/p/gov/match-group":                               []*gov.Policy{{GovernancePolicy: &gov.GovernancePolicy{ID: "g1"}, Kind: "match-group"}},
+		"GET /v4/accounts":                                        &rbac.AccountResponse{Total: 1, Accounts: []*rbac.Account{{Name: "root"}}},
+		"PUT /v4/default/admin/alarms/a1/acknowledge":             &model.Alarm{ID: "a1", Acknowledged: true},
+		"GET /v4/default/admin/alarms/history":                    &struct{ Histories []*model.History }{[]*model.History{{ID: "a1"}}},
+	})
+	defer svc.Close()
+	c := newTestClient(t, svc, nil)
+
+	last := func() request {
+		return (*requests)[len(*requests)-1]
+	}
+
+	ids, err := c.AddRules(ctx, "d", "p", "s1", []*discovery.AddOrUpdateServiceRule{{RuleType: "WHITE", Attribute: "ServiceName", Pattern: ".*"}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"r1"}, ids)
+	assert.Equal(t, "d", last().Header.Get("X-Domain-Name"))
+	assert.Equal(t, `{"rules":[{"ruleType":"WHITE","attribute":"ServiceName","pattern":".*"}]}`, last().Body)
+
+	assert.Nil(t, c.DeleteRules(ctx, "d", "p", "s1", "r1", "r2"))
+
+	assert.Nil(t, c.CreateDependencies(ctx, "d", "p", []*discovery.ConsumerDependency{{Consumer: &discovery.MicroServiceKey{ServiceName: "c"}}}))
+	assert.Equal(t, `{"dependencies":[{"consumer":{"serviceName":"c"}}]}`, last().Body)
+
+	providers, err := c.GetProviders(ctx, "d", "p", "s1", DependencyOptions{SameDomain: true, NoSelf: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "s2", providers[0].ServiceId)
+	assert.Equal(t, "noSelf=1&sameDomain=1", last().Query)
+
+	assert.Nil(t, c.UpdateInstanceStatus(ctx, "d", "p", "s1", "i1", discovery.MSI_DOWN))
+	assert.Equal(t, "value=DOWN", last().Query)
+
+	found, err := c.BatchFindInstances(ctx, "d", "p", "s1", &discovery.BatchFindInstancesRequest{})
+	assert.Nil(t, err)
+	assert.NotNil(t, found.Services)
+	assert.Equal(t, "s1", last().Header.Get("X-ConsumerId"))
+	assert.Equal(t, "type=query&global=false", last().Query)
+
+	results, err := c.DeleteServices(ctx, "d", "p", []string{"s1"}, true)
+	assert.Nil(t, err)
+	assert.Equal(t, "s1", results[0].ServiceId)
+	assert.Equal(t, `{"serviceIds":["s1"],"force":true}`, last().Body)
+
+	created, err := c.CreatePolicy(ctx, "p", "match-group", &gov.Policy{GovernancePolicy: &gov.GovernancePolicy{Name: "m1"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "g1", created.ID)
+	assert.Equal(t, "istio", created.Distributors[0].Name)
+
+	policies, err := c.ListPolicies(ctx, "p", "match-group", "app", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "g1", policies[0].ID)
+	assert.Equal(t, "app=app", last().Query)
+
+	accounts, err := c.ListAccounts(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "root", accounts.Accounts[0].Name)
+
+	alarm, err := c.AcknowledgeAlarm(ctx, "a1")
+	assert.Nil(t, err)
+	assert.True(t, alarm.Acknowledged)
+	assert.Equal(t, "default", last().Header.Get("X-Domain-Name"))
+
+	histories, err := c.GetAlarmHistory(ctx, &model.HistoryRequest{ID: "a1", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, model.ID("a1"), histories[0].ID)
+	assert.Equal(t, "id=a1&limit=10", last().Query)
+
+	var scErr *errsvc.Error
+	_, scErr = c.GetRole(ctx, "none")
+	assert.NotNil(t, scErr)
+	assert.Equal(t, discovery.ErrInvalidParams, scErr.Code)
+}
@@ -20,7 +20,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/apache/servicecomb-service-center/pkg/lb"
 	"github.com/apache/servicecomb-service-center/pkg/rest"
+	"github.com/go-chassis/cari/rbac"
 )
 
 const defaultRequestTimeout = 10 * time.Second
@@ -32,6 +34,25 @@ type Config struct {
 	// TODO Expandable header not only token header
 	Token          string
 	CertKeyPWDPath string
+	// Account acquires the token from /v4/token and refreshes it before
+	// expiration when the service center enables RBAC
+	Account *rbac.Account
+	// Balancer selects the endpoints, one of roundrobin, random, weighted
+	// and leastinflight, default is roundrobin
+	Balancer string
+	// Weights of the endpoints for the weighted balancer, default is 1
+	Weights map[string]int
+	// Ejection skips the endpoints returned connection errors with backoff
+	Ejection bool
+}
+
+func (cfg *Config) LBOptions() lb.Options {
+	return lb.Options{
+		Kind:      cfg.Balancer,
+		Endpoints: cfg.Endpoints,
+		Weights:   cfg.Weights,
+		Ejection:  cfg.Ejection,
+	}
 }
 
 func (cfg *Config) Merge() rest.URLClientOption {
@@ -0,0 +1,87 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	pb "github.com/go-chassis/cari/discovery"
+	"github.com/go-chassis/cari/pkg/errsvc"
+)
+
+const (
+	apiDependenciesURL = "/v4/%s/registry/dependencies"
+	apiProvidersURL    = "/v4/%s/registry/microservices/%s/providers"
+	apiConsumersURL    = "/v4/%s/registry/microservices/%s/consumers"
+)
+
+// DependencyOptions filters the providers or consumers of the service
+type DependencyOptions struct {
+	// SameDomain only returns the services in the same domain
+	SameDomain bool
+	// NoSelf excludes the service itself
+	NoSelf bool
+}
+
+func (opts DependencyOptions) encode() string {
+	query := url.Values{}
+	if opts.SameDomain {
+		query.Set("sameDomain", "1")
+	}
+	if opts.NoSelf {
+		query.Set("noSelf", "1")
+	}
+	return query.Encode()
+}
+
+// AddDependencies appends the providers to the consumers' dependencies
+func (c *Client) AddDependencies(ctx context.Context, domain, project string, dependencies []*pb.ConsumerDependency) *errsvc.Error {
+	return c.do(ctx, http.MethodPost,
+		fmt.Sprintf(apiDependenciesURL, project),
+		c.domainHeaders(ctx, domain), &pb.AddDependenciesRequest{Dependencies: dependencies}, nil)
+}
+
+// CreateDependencies overwrites the consumers' dependencies
+func (c *Client) CreateDependencies(ctx context.Context, domain, project string, dependencies []*pb.ConsumerDependency) *errsvc.Error {
+	return c.do(ctx, http.MethodPut,
+		fmt.Sprintf(apiDependenciesURL, project),
+		c.domainHeaders(ctx, domain), &pb.CreateDependenciesRequest{Dependencies: dependencies}, nil)
+}
+
+func (c *Client) GetProviders(ctx context.Context, domain, project, consumerID string, opts DependencyOptions) ([]*pb.MicroService, *errsvc.Error) {
+	depResp := &pb.GetConDependenciesResponse{}
+	err := c.do(ctx, http.MethodGet,
+		fmt.Sprintf(apiProvidersURL, project, consumerID)+"?"+opts.encode(),
+		c.domainHeaders(ctx, domain), nil, depResp)
+	if err != nil {
+		return nil, err
+	}
+	return depResp.Providers, nil
+}
+
+func (c *Client) GetConsumers(ctx context.Context, domain, project, providerID string, opts DependencyOptions) ([]*pb.MicroService, *errsvc.Error) {
+	depResp := &pb.GetProDependenciesResponse{}
+	err := c.do(ctx, http.MethodGet,
+		fmt.Sprintf(apiConsumersURL, project, providerID)+"?"+opts.encode(),
+		c.domainHeaders(ctx, domain), nil, depResp)
+	if err != nil {
+		return nil, err
+	}
+	return depResp.Consumers, nil
+}
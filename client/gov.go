@@ -0,0 +1,129 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chassis/cari/pkg/errsvc"
+
+	"github.com/apache/servicecomb-service-center/pkg/gov"
+)
+
+const (
+	apiPoliciesURL = "/v1/%s/gov/%s"
+	apiPolicyURL   = "/v1/%s/gov/%s/%s"
+
+	govKindDisplay = "display"
+)
+
+// DistributeResult is the result of the secondary governance distributor
+type DistributeResult struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Error string `json:"error,omitempty"`
+}
+
+// DistributeResponse is the response of the governance write operations
+type DistributeResponse struct {
+	*gov.Policy
+	Distributors []*DistributeResult `json:"distributors,omitempty"`
+}
+
+// CreatePolicy creates the governance policy of the kind, e.g. 'match-group',
+// 'rate-limiting', the response contains the id of the created policy
+func (c *Client) CreatePolicy(ctx context.Context, project, kind string, policy *gov.Policy) (*DistributeResponse, *errsvc.Error) {
+	distResp := &DistributeResponse{}
+	err := c.do(ctx, http.MethodPost,
+		fmt.Sprintf(apiPoliciesURL, project, kind),
+		c.CommonHeaders(ctx), policy, distResp)
+	if err != nil {
+		return nil, err
+	}
+	return distResp, nil
+}
+
+func (c *Client) UpdatePolicy(ctx context.Context, project, kind, id string, policy *gov.Policy) ([]*DistributeResult, *errsvc.Error) {
+	distResp := &DistributeResponse{}
+	err := c.do(ctx, http.MethodPut,
+		fmt.Sprintf(apiPolicyURL, project, kind, id),
+		c.CommonHeaders(ctx), policy, distResp)
+	if err != nil {
+		return nil, err
+	}
+	return distResp.Distributors, nil
+}
+
+func (c *Client) GetPolicy(ctx context.Context, project, kind, id string) (*gov.Policy, *errsvc.Error) {
+	policy := &gov.Policy{}
+	err := c.do(ctx, http.MethodGet,
+		fmt.Sprintf(apiPolicyURL, project, kind, id),
+		c.CommonHeaders(ctx), nil, policy)
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// ListPolicies returns the policies of the kind filtered by app and environment,
+// the empty filter matches all
+func (c *Client) ListPolicies(ctx context.Context, project, kind, app, environment string) ([]*gov.Policy, *errsvc.Error) {
+	var policies []*gov.Policy
+	err := c.do(ctx, http.MethodGet,
+		fmt.Sprintf(apiPoliciesURL, project, kind)+"?"+govQuery(app, environment),
+		c.CommonHeaders(ctx), nil, &policies)
+	if err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+// DisplayPolicies returns the policies grouped by the match groups
+func (c *Client) DisplayPolicies(ctx context.Context, project, app, environment string) ([]*gov.DisplayData, *errsvc.Error) {
+	var data []*gov.DisplayData
+	err := c.do(ctx, http.MethodGet,
+		fmt.Sprintf(apiPoliciesURL, project, govKindDisplay)+"?"+govQuery(app, environment),
+		c.CommonHeaders(ctx), nil, &data)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (c *Client) DeletePolicy(ctx context.Context, project, kind, id string) ([]*DistributeResult, *errsvc.Error) {
+	distResp := &DistributeResponse{}
+	err := c.do(ctx, http.MethodDelete,
+		fmt.Sprintf(apiPolicyURL, project, kind, id),
+		c.CommonHeaders(ctx), nil, distResp)
+	if err != nil {
+		return nil, err
+	}
+	return distResp.Distributors, nil
+}
+
+func govQuery(app, environment string) string {
+	query := url.Values{}
+	if len(app) > 0 {
+		query.Set("app", app)
+	}
+	if len(environment) > 0 {
+		query.Set("environment", environment)
+	}
+	return query.Encode()
+}
@@ -25,6 +25,8 @@ import (
 
 	"github.com/go-chassis/cari/discovery"
 	"github.com/go-chassis/cari/pkg/errsvc"
+
+	"github.com/apache/servicecomb-service-center/pkg/util"
 )
 
 const (
@@ -33,6 +35,9 @@ const (
 	apiInstancesURL          = "/v4/%s/registry/microservices/%s/instances"
 	apiInstanceURL           = "/v4/%s/registry/microservices/%s/instances/%s"
 	apiInstanceHeartbeatURL  = "/v4/%s/registry/microservices/%s/instances/%s/heartbeat"
+	apiInstanceStatusURL     = "/v4/%s/registry/microservices/%s/instances/%s/status"
+	apiInstancePropsURL      = "/v4/%s/registry/microservices/%s/instances/%s/properties"
+	apiBatchFindInstancesURL = "/v4/%s/registry/instances/action"
 )
 
 func (c *Client) RegisterInstance(ctx context.Context, domain, project, serviceID string, instance *discovery.MicroServiceInstance) (string, *errsvc.Error) {
@@ -129,6 +134,53 @@ func (c *Client) DiscoveryInstances(ctx context.Context, domain, project, consum
 	return instancesResp.Instances, nil
 }
 
+// FindInstances finds the instances of the provider key with the revision
+// returned by the last call, it returns the latest revision and a nil
+// response if the instances are not modified since the revision
+func (c *Client) FindInstances(ctx context.Context, domain, project, consumerID string, key *discovery.MicroServiceKey, rev string) (*discovery.FindInstancesResponse, string, *errsvc.Error) {
+	headers := c.CommonHeaders(ctx)
+	headers.Set("X-Domain-Name", domain)
+	headers.Set("X-ConsumerId", consumerID)
+
+	query := url.Values{}
+	query.Set("appId", key.AppId)
+	query.Set("serviceName", key.ServiceName)
+	query.Set("version", key.Version)
+	query.Set("env", key.Environment)
+	if len(rev) > 0 {
+		query.Set("rev", rev)
+	}
+
+	resp, err := c.RestDoWithContext(ctx, http.MethodGet,
+		fmt.Sprintf(apiDiscoveryInstancesURL, project)+"?"+c.parseQuery(ctx)+"&"+query.Encode(),
+		headers, nil)
+	if err != nil {
+		return nil, "", discovery.NewError(discovery.ErrInternal, err.Error())
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", discovery.NewError(discovery.ErrInternal, err.Error())
+	}
+
+	newRev := resp.Header.Get(util.HeaderRev)
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return nil, newRev, nil
+	default:
+		return nil, "", c.toError(body)
+	}
+
+	instancesResp := &discovery.FindInstancesResponse{}
+	err = json.Unmarshal(body, instancesResp)
+	if err != nil {
+		return nil, "", discovery.NewError(discovery.ErrInternal, err.Error())
+	}
+	return instancesResp, newRev, nil
+}
+
 func (c *Client) Heartbeat(ctx context.Context, domain, project, serviceID, instanceID string) *errsvc.Error {
 	headers := c.CommonHeaders(ctx)
 	headers.Set("X-Domain-Name", domain)
@@ -247,3 +299,32 @@ func (c *Client) GetInstanceByInstanceID(ctx context.Context, domain, project, p
 
 	return instanceResp.Instance, nil
 }
+
+func (c *Client) UpdateInstanceStatus(ctx context.Context, domain, project, serviceID, instanceID, status string) *errsvc.Error {
+	query := url.Values{}
+	query.Set("value", status)
+	return c.do(ctx, http.MethodPut,
+		fmt.Sprintf(apiInstanceStatusURL, project, serviceID, instanceID)+"?"+query.Encode(),
+		c.domainHeaders(ctx, domain), nil, nil)
+}
+
+func (c *Client) UpdateInstanceProperties(ctx context.Context, domain, project, serviceID, instanceID string, properties map[string]string) *errsvc.Error {
+	return c.do(ctx, http.MethodPut,
+		fmt.Sprintf(apiInstancePropsURL, project, serviceID, instanceID),
+		c.domainHeaders(ctx, domain), &discovery.UpdateInstancePropsRequest{Properties: properties}, nil)
+}
+
+// BatchFindInstances finds the instances of the services or the instances
+// in one request, the results are indexed by the request elements
+func (c *Client) BatchFindInstances(ctx context.Context, domain, project, consumerID string, request *discovery.BatchFindInstancesRequest) (*discovery.BatchFindInstancesResponse, *errsvc.Error) {
+	headers := c.domainHeaders(ctx, domain)
+	headers.Set("X-ConsumerId", consumerID)
+	findResp := &discovery.BatchFindInstancesResponse{}
+	err := c.do(ctx, http.MethodPost,
+		fmt.Sprintf(apiBatchFindInstancesURL, project)+"?type=query&"+c.parseQuery(ctx),
+		headers, request, findResp)
+	if err != nil {
+		return nil, err
+	}
+	return findResp, nil
+}
@@ -31,6 +31,8 @@ const (
 	apiExistenceURL     = "/v4/%s/registry/existence"
 	apiMicroServicesURL = "/v4/%s/registry/microservices"
 	apiMicroServiceURL  = "/v4/%s/registry/microservices/%s"
+
+	apiMicroServicePropsURL = "/v4/%s/registry/microservices/%s/properties"
 )
 
 func (c *Client) CreateService(ctx context.Context, domain, project string, service *pb.MicroService) (string, *errsvc.Error) {
@@ -136,3 +138,51 @@ func (c *Client) existence(ctx context.Context, domain, project string, query ur
 
 	return existenceResp, nil
 }
+
+func (c *Client) GetServices(ctx context.Context, domain, project string) ([]*pb.MicroService, *errsvc.Error) {
+	servicesResp := &pb.GetServicesResponse{}
+	err := c.do(ctx, http.MethodGet,
+		fmt.Sprintf(apiMicroServicesURL, project),
+		c.domainHeaders(ctx, domain), nil, servicesResp)
+	if err != nil {
+		return nil, err
+	}
+	return servicesResp.Services, nil
+}
+
+func (c *Client) GetService(ctx context.Context, domain, project, serviceID string) (*pb.MicroService, *errsvc.Error) {
+	serviceResp := &pb.GetServiceResponse{}
+	err := c.do(ctx, http.MethodGet,
+		fmt.Sprintf(apiMicroServiceURL, project, serviceID),
+		c.domainHeaders(ctx, domain), nil, serviceResp)
+	if err != nil {
+		return nil, err
+	}
+	return serviceResp.Service, nil
+}
+
+func (c *Client) UpdateServiceProperties(ctx context.Context, domain, project, serviceID string, properties map[string]string) *errsvc.Error {
+	return c.do(ctx, http.MethodPut,
+		fmt.Sprintf(apiMicroServicePropsURL, project, serviceID),
+		c.domainHeaders(ctx, domain), &pb.UpdateServicePropsRequest{Properties: properties}, nil)
+}
+
+// ForceDeleteService deletes the service with its instances
+func (c *Client) ForceDeleteService(ctx context.Context, domain, project, serviceID string) *errsvc.Error {
+	return c.do(ctx, http.MethodDelete,
+		fmt.Sprintf(apiMicroServiceURL, project, serviceID)+"?force=true",
+		c.domainHeaders(ctx, domain), nil, nil)
+}
+
+// DeleteServices deletes the services in batch, it returns the result of
+// each service
+func (c *Client) DeleteServices(ctx context.Context, domain, project string, serviceIDs []string, force bool) ([]*pb.DelServicesRspInfo, *errsvc.Error) {
+	delResp := &pb.DelServicesResponse{}
+	err := c.do(ctx, http.MethodDelete,
+		fmt.Sprintf(apiMicroServicesURL, project),
+		c.domainHeaders(ctx, domain), &pb.DelServicesRequest{ServiceIds: serviceIDs, Force: force}, delResp)
+	if err != nil {
+		return nil, err
+	}
+	return delResp.Services, nil
+}
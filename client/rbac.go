@@ -0,0 +1,120 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chassis/cari/pkg/errsvc"
+	"github.com/go-chassis/cari/rbac"
+)
+
+const (
+	apiTokenURL           = "/v4/token"
+	apiAccountsURL        = "/v4/accounts"
+	apiAccountURL         = "/v4/accounts/%s"
+	apiAccountPasswordURL = "/v4/accounts/%s/password"
+	apiRolesURL           = "/v4/roles"
+	apiRoleURL            = "/v4/roles/%s"
+)
+
+// GetToken logins with the account, the account can specify the
+// TokenExpirationTime, e.g. "30m"
+func (c *Client) GetToken(ctx context.Context, account *rbac.Account) (*rbac.Token, *errsvc.Error) {
+	token := &rbac.Token{}
+	// do not authenticate the login request itself
+	err := c.do(ctx, http.MethodPost, apiTokenURL, make(http.Header), &rbac.Account{
+		Name:                account.Name,
+		Password:            account.Password,
+		TokenExpirationTime: account.TokenExpirationTime,
+	}, token)
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (c *Client) CreateAccount(ctx context.Context, account *rbac.Account) *errsvc.Error {
+	return c.do(ctx, http.MethodPost, apiAccountsURL, c.CommonHeaders(ctx), account, nil)
+}
+
+func (c *Client) GetAccount(ctx context.Context, name string) (*rbac.Account, *errsvc.Error) {
+	account := &rbac.Account{}
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf(apiAccountURL, url.PathEscape(name)), c.CommonHeaders(ctx), nil, account)
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+func (c *Client) ListAccounts(ctx context.Context) (*rbac.AccountResponse, *errsvc.Error) {
+	accounts := &rbac.AccountResponse{}
+	err := c.do(ctx, http.MethodGet, apiAccountsURL, c.CommonHeaders(ctx), nil, accounts)
+	if err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// UpdateAccount updates the roles or status of the account
+func (c *Client) UpdateAccount(ctx context.Context, name string, account *rbac.Account) *errsvc.Error {
+	return c.do(ctx, http.MethodPut, fmt.Sprintf(apiAccountURL, url.PathEscape(name)), c.CommonHeaders(ctx), account, nil)
+}
+
+func (c *Client) DeleteAccount(ctx context.Context, name string) *errsvc.Error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf(apiAccountURL, url.PathEscape(name)), c.CommonHeaders(ctx), nil, nil)
+}
+
+// ChangePassword changes the password of the account, the current password
+// is required when the account changes its own password, the admin can
+// change the other accounts' password without it
+func (c *Client) ChangePassword(ctx context.Context, name, currentPassword, password string) *errsvc.Error {
+	return c.do(ctx, http.MethodPost, fmt.Sprintf(apiAccountPasswordURL, url.PathEscape(name)), c.CommonHeaders(ctx),
+		&rbac.Account{CurrentPassword: currentPassword, Password: password}, nil)
+}
+
+func (c *Client) CreateRole(ctx context.Context, role *rbac.Role) *errsvc.Error {
+	return c.do(ctx, http.MethodPost, apiRolesURL, c.CommonHeaders(ctx), role, nil)
+}
+
+func (c *Client) GetRole(ctx context.Context, name string) (*rbac.Role, *errsvc.Error) {
+	role := &rbac.Role{}
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf(apiRoleURL, url.PathEscape(name)), c.CommonHeaders(ctx), nil, role)
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (c *Client) ListRoles(ctx context.Context) (*rbac.RoleResponse, *errsvc.Error) {
+	roles := &rbac.RoleResponse{}
+	err := c.do(ctx, http.MethodGet, apiRolesURL, c.CommonHeaders(ctx), nil, roles)
+	if err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (c *Client) UpdateRole(ctx context.Context, name string, role *rbac.Role) *errsvc.Error {
+	return c.do(ctx, http.MethodPut, fmt.Sprintf(apiRoleURL, url.PathEscape(name)), c.CommonHeaders(ctx), role, nil)
+}
+
+func (c *Client) DeleteRole(ctx context.Context, name string) *errsvc.Error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf(apiRoleURL, url.PathEscape(name)), c.CommonHeaders(ctx), nil, nil)
+}
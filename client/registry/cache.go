@@ -0,0 +1,267 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package registry provides the local registry cache and the instance keeper
+// built on the service center client
+package registry
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	pb "github.com/go-chassis/cari/discovery"
+	"github.com/go-chassis/cari/pkg/errsvc"
+
+	"github.com/apache/servicecomb-service-center/client"
+	"github.com/apache/servicecomb-service-center/pkg/backoff"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+)
+
+const (
+	DefaultResyncInterval = time.Minute
+	// watchHealthyDuration resets the reconnect backoff if the watch lasted longer
+	watchHealthyDuration = time.Minute
+)
+
+type CacheOptions struct {
+	Domain  string
+	Project string
+	// ConsumerID is the service id of the consumer, the watch stream pushes
+	// the instance events of the providers the consumer found
+	ConsumerID string
+	// ResyncInterval is the interval to resync all the cached providers
+	// with the revisions, default is DefaultResyncInterval
+	ResyncInterval time.Duration
+	Backoff        backoff.Backoff
+}
+
+type entry struct {
+	key *pb.MicroServiceKey
+	rev string
+	// service id -> instance id -> instance
+	services map[string]map[string]*pb.MicroServiceInstance
+}
+
+func newEntry(key *pb.MicroServiceKey) *entry {
+	return &entry{key: key, services: make(map[string]map[string]*pb.MicroServiceInstance)}
+}
+
+func (e *entry) reset(instances []*pb.MicroServiceInstance) {
+	e.services = make(map[string]map[string]*pb.MicroServiceInstance)
+	for _, instance := range instances {
+		e.put(instance)
+	}
+}
+
+func (e *entry) put(instance *pb.MicroServiceInstance) {
+	instances, ok := e.services[instance.ServiceId]
+	if !ok {
+		instances = make(map[string]*pb.MicroServiceInstance)
+		e.services[instance.ServiceId] = instances
+	}
+	instances[instance.InstanceId] = instance
+}
+
+func (e *entry) list() []*pb.MicroServiceInstance {
+	var instances []*pb.MicroServiceInstance
+	for _, m := range e.services {
+		for _, instance := range m {
+			instances = append(instances, instance)
+		}
+	}
+	return instances
+}
+
+// Cache caches the provider instances found by the consumer, the cache
+// is kept fresh by the watch stream after Run
+type Cache struct {
+	client *client.Client
+	opts   CacheOptions
+
+	lock    sync.RWMutex
+	entries map[string]*entry
+}
+
+func NewCache(c *client.Client, opts CacheOptions) *Cache {
+	if opts.ResyncInterval <= 0 {
+		opts.ResyncInterval = DefaultResyncInterval
+	}
+	if opts.Backoff == nil {
+		opts.Backoff = backoff.GetBackoff()
+	}
+	return &Cache{
+		client:  c,
+		opts:    opts,
+		entries: make(map[string]*entry),
+	}
+}
+
+func toCacheKey(key *pb.MicroServiceKey) string {
+	return util.StringJoin([]string{key.Environment, key.AppId, key.ServiceName, key.Version}, "/")
+}
+
+// FindInstances returns the cached instances of the provider key, the
+// Version of the key is the version rule, it finds the instances from
+// service center at the first time
+func (c *Cache) FindInstances(ctx context.Context, key *pb.MicroServiceKey) ([]*pb.MicroServiceInstance, *errsvc.Error) {
+	cacheKey := toCacheKey(key)
+	c.lock.RLock()
+	e, ok := c.entries[cacheKey]
+	if ok {
+		instances := e.list()
+		c.lock.RUnlock()
+		return instances, nil
+	}
+	c.lock.RUnlock()
+
+	e = newEntry(key)
+	if err := c.refresh(ctx, e); err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	if exist, ok := c.entries[cacheKey]; ok {
+		// found by the other goroutine
+		e = exist
+	} else {
+		c.entries[cacheKey] = e
+	}
+	instances := e.list()
+	c.lock.Unlock()
+	return instances, nil
+}
+
+// refresh finds the instances with the revision of the entry, it does not
+// change the entry if the instances are not modified or the entry has been
+// refreshed by the other goroutine during the request, the response may be
+// older than the one stored
+func (c *Cache) refresh(ctx context.Context, e *entry) *errsvc.Error {
+	c.lock.RLock()
+	rev := e.rev
+	c.lock.RUnlock()
+
+	resp, newRev, err := c.client.FindInstances(ctx, c.opts.Domain, c.opts.Project, c.opts.ConsumerID, e.key, rev)
+	if err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if e.rev != rev {
+		return nil
+	}
+	if resp != nil {
+		e.reset(resp.Instances)
+	}
+	e.rev = newRev
+	return nil
+}
+
+// Resync refreshes all the cached providers, the unchanged providers cost
+// a revision check only
+func (c *Cache) Resync(ctx context.Context) {
+	c.lock.RLock()
+	entries := make([]*entry, 0, len(c.entries))
+	for _, e := range c.entries {
+		entries = append(entries, e)
+	}
+	c.lock.RUnlock()
+
+	for _, e := range entries {
+		if err := c.refresh(ctx, e); err != nil {
+			log.Errorf(err, "resync provider[%s] instances failed", toCacheKey(e.key))
+		}
+	}
+}
+
+// OnEvent applies the instance event of the watch stream
+func (c *Cache) OnEvent(resp *pb.WatchInstanceResponse) {
+	if resp.Key == nil || resp.Instance == nil {
+		return
+	}
+	instance := resp.Instance
+
+	var stale []*entry
+	c.lock.Lock()
+	for _, e := range c.entries {
+		if e.key.AppId != resp.Key.AppId || e.key.ServiceName != resp.Key.ServiceName ||
+			e.key.Environment != resp.Key.Environment {
+			continue
+		}
+		instances, ok := e.services[instance.ServiceId]
+		switch {
+		case resp.Action == string(pb.EVT_DELETE):
+			if ok {
+				delete(instances, instance.InstanceId)
+			}
+		case ok:
+			instances[instance.InstanceId] = instance
+		default:
+			// the version of the instance may or may not match the version rule
+			stale = append(stale, e)
+		}
+	}
+	c.lock.Unlock()
+
+	for _, e := range stale {
+		if err := c.refresh(context.Background(), e); err != nil {
+			log.Errorf(err, "refresh provider[%s] instances failed", toCacheKey(e.key))
+		}
+	}
+}
+
+// Run watches the instance events and resyncs the cache periodically until
+// the ctx is done, it reconnects with backoff and resyncs all the providers
+// after the watch stream is broken
+func (c *Cache) Run(ctx context.Context) {
+	go c.resyncLoop(ctx)
+
+	retries := 0
+	for {
+		start := time.Now()
+		err := c.client.Watch(ctx, c.opts.Domain, c.opts.Project, c.opts.ConsumerID, c.OnEvent)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Errorf(err, "watch consumer[%s] failed", c.opts.ConsumerID)
+		if time.Since(start) > watchHealthyDuration {
+			retries = 0
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(c.opts.Backoff.Delay(retries)):
+		}
+		retries++
+		// the events may be lost during the reconnection
+		c.Resync(ctx)
+	}
+}
+
+func (c *Cache) resyncLoop(ctx context.Context) {
+	ticker := time.NewTicker(c.opts.ResyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Resync(ctx)
+		}
+	}
+}
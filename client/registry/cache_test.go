@@ -0,0 +1,218 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/go-chassis/cari/discovery"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/servicecomb-service-center/client"
+	"github.com/apache/servicecomb-service-center/pkg/backoff"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+)
+
+// fakeRegistry serves the find and watch APIs of the provider 'p'
+type fakeRegistry struct {
+	lock      sync.Mutex
+	rev       int
+	instances []*pb.MicroServiceInstance
+	finds     int
+	notMods   int
+	conns     chan *websocket.Conn
+}
+
+func (f *fakeRegistry) set(instances ...*pb.MicroServiceInstance) {
+	f.lock.Lock()
+	f.rev++
+	f.instances = instances
+	f.lock.Unlock()
+}
+
+func (f *fakeRegistry) counts() (int, int) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.finds, f.notMods
+}
+
+func (f *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/v4/default/registry/microservices/consumer/watcher":
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		f.conns <- conn
+	case "/v4/default/registry/instances":
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		f.finds++
+		rev := string(rune('0' + f.rev))
+		w.Header().Set(util.HeaderRev, rev)
+		if r.URL.Query().Get("rev") == rev {
+			f.notMods++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		b, _ := json.Marshal(&pb.FindInstancesResponse{Instances: f.instances})
+		w.Write(b)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newInstance(serviceID, instanceID string) *pb.MicroServiceInstance {
+	return &pb.MicroServiceInstance{ServiceId: serviceID, InstanceId: instanceID, Status: pb.MSI_UP}
+}
+
+func ids(instances []*pb.MicroServiceInstance) map[string]bool {
+	m := make(map[string]bool)
+	for _, instance := range instances {
+		m[instance.InstanceId] = true
+	}
+	return m
+}
+
+func TestCache(t *testing.T) {
+	f := &fakeRegistry{conns: make(chan *websocket.Conn, 1)}
+	f.set(newInstance("s1", "i1"))
+	svc := httptest.NewServer(f)
+	defer svc.Close()
+
+	c, err := client.NewSCClient(client.Config{Endpoints: []string{svc.URL}})
+	assert.NoError(t, err)
+	cache := NewCache(c, CacheOptions{
+		Domain: "default", Project: "default", ConsumerID: "consumer",
+		ResyncInterval: time.Hour,
+		Backoff:        &backoff.PowerBackoff{InitDelay: 10 * time.Millisecond, MaxDelay: 10 * time.Millisecond},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	key := &pb.MicroServiceKey{AppId: "a", ServiceName: "p", Version: "1.0.0+"}
+
+	t.Run("find from the server at the first time", func(t *testing.T) {
+		instances, err := cache.FindInstances(ctx, key)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]bool{"i1": true}, ids(instances))
+
+		instances, err = cache.FindInstances(ctx, key)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(instances))
+		finds, _ := f.counts()
+		assert.Equal(t, 1, finds)
+	})
+
+	go cache.Run(ctx)
+	conn := <-f.conns
+	send := func(action pb.EventType, instance *pb.MicroServiceInstance) {
+		b, _ := json.Marshal(&pb.WatchInstanceResponse{
+			Action:   string(action),
+			Key:      &pb.MicroServiceKey{AppId: "a", ServiceName: "p", Version: "1.0.0"},
+			Instance: instance,
+		})
+		assert.NoError(t, conn.WriteMessage(websocket.TextMessage, b))
+	}
+	waitFor := func(expected map[string]bool) {
+		assert.Eventually(t, func() bool {
+			instances, _ := cache.FindInstances(ctx, key)
+			return assert.ObjectsAreEqual(expected, ids(instances))
+		}, 3*time.Second, 10*time.Millisecond)
+	}
+
+	t.Run("apply the events of the known services", func(t *testing.T) {
+		send(pb.EVT_CREATE, newInstance("s1", "i2"))
+		waitFor(map[string]bool{"i1": true, "i2": true})
+
+		send(pb.EVT_DELETE, newInstance("s1", "i1"))
+		waitFor(map[string]bool{"i2": true})
+		finds, _ := f.counts()
+		assert.Equal(t, 1, finds)
+	})
+
+	t.Run("refresh when the instance of the new service is created", func(t *testing.T) {
+		f.set(newInstance("s1", "i2"), newInstance("s2", "i3"))
+		send(pb.EVT_CREATE, newInstance("s2", "i3"))
+		waitFor(map[string]bool{"i2": true, "i3": true})
+	})
+
+	t.Run("resync after the reconnection", func(t *testing.T) {
+		f.set(newInstance("s2", "i3"))
+		conn.Close()
+		conn = <-f.conns
+		waitFor(map[string]bool{"i3": true})
+
+		// not modified since the last resync
+		finds, _ := f.counts()
+		cache.Resync(ctx)
+		newFinds, notMods := f.counts()
+		assert.Equal(t, finds+1, newFinds)
+		assert.Equal(t, 1, notMods)
+	})
+}
+
+func TestCache_RefreshConcurrently(t *testing.T) {
+	var (
+		lock    sync.Mutex
+		finds   int
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		finds++
+		n := finds
+		lock.Unlock()
+		rev, instance := "new", newInstance("s1", "i2")
+		if n == 1 {
+			// the older response returns after the newer one
+			close(started)
+			<-release
+			rev, instance = "old", newInstance("s1", "i1")
+		}
+		w.Header().Set(util.HeaderRev, rev)
+		b, _ := json.Marshal(&pb.FindInstancesResponse{Instances: []*pb.MicroServiceInstance{instance}})
+		w.Write(b)
+	}))
+	defer svc.Close()
+
+	c, err := client.NewSCClient(client.Config{Endpoints: []string{svc.URL}})
+	assert.NoError(t, err)
+	cache := NewCache(c, CacheOptions{Domain: "default", Project: "default", ConsumerID: "consumer"})
+	e := newEntry(&pb.MicroServiceKey{AppId: "a", ServiceName: "p", Version: "1.0.0+"})
+
+	done := make(chan struct{})
+	go func() {
+		assert.Nil(t, cache.refresh(context.Background(), e))
+		close(done)
+	}()
+	<-started
+	assert.Nil(t, cache.refresh(context.Background(), e))
+	close(release)
+	<-done
+
+	cache.lock.RLock()
+	defer cache.lock.RUnlock()
+	assert.Equal(t, "new", e.rev)
+	assert.Equal(t, map[string]bool{"i2": true}, ids(e.list()))
+}
@@ -0,0 +1,208 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	pb "github.com/go-chassis/cari/discovery"
+	"github.com/go-chassis/cari/pkg/errsvc"
+
+	"github.com/apache/servicecomb-service-center/client"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+)
+
+const DefaultHeartbeatInterval = 30 * time.Second
+
+type KeeperOptions struct {
+	Domain  string
+	Project string
+	// Interval is the heartbeat interval, default is the health check
+	// interval of the instance or DefaultHeartbeatInterval
+	Interval time.Duration
+}
+
+// Keeper sends the heartbeats of the self registered instances, and
+// registers the service and instance again when the server has lost them
+type Keeper struct {
+	client *client.Client
+	opts   KeeperOptions
+
+	// lock guards the instances and the ids of the registrations, the
+	// ids change when the instance is registered again
+	lock      sync.RWMutex
+	instances map[string]*registration
+}
+
+type registration struct {
+	service  *pb.MicroService
+	instance *pb.MicroServiceInstance
+}
+
+func NewKeeper(c *client.Client, opts KeeperOptions) *Keeper {
+	return &Keeper{
+		client:    c,
+		opts:      opts,
+		instances: make(map[string]*registration),
+	}
+}
+
+// Register registers the service if not exist and the instance, then keeps
+// the instance alive, it returns the registered instance with the ids
+func (k *Keeper) Register(ctx context.Context, service *pb.MicroService, instance *pb.MicroServiceInstance) (*pb.MicroServiceInstance, *errsvc.Error) {
+	r := &registration{service: service, instance: instance}
+	if err := k.register(ctx, r); err != nil {
+		return nil, err
+	}
+
+	k.lock.Lock()
+	k.instances[instance.InstanceId] = r
+	k.lock.Unlock()
+	return instance, nil
+}
+
+// Unregister stops keeping the instance and unregisters it
+func (k *Keeper) Unregister(ctx context.Context, instanceID string) *errsvc.Error {
+	k.lock.Lock()
+	r, ok := k.instances[instanceID]
+	delete(k.instances, instanceID)
+	var serviceID string
+	if ok {
+		serviceID = r.instance.ServiceId
+	}
+	k.lock.Unlock()
+	if !ok {
+		return nil
+	}
+	return k.client.UnregisterInstance(ctx, k.opts.Domain, k.opts.Project, serviceID, instanceID)
+}
+
+// ids returns the service id and instance id of the registration
+func (k *Keeper) ids(r *registration) (string, string) {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	return r.instance.ServiceId, r.instance.InstanceId
+}
+
+// register reuses the ids of the last registration, so the ids do not
+// change after the recovery
+func (k *Keeper) register(ctx context.Context, r *registration) *errsvc.Error {
+	service := r.service
+	k.lock.RLock()
+	serviceID := service.ServiceId
+	k.lock.RUnlock()
+	if len(serviceID) == 0 {
+		var err *errsvc.Error
+		serviceID, err = k.client.ServiceExistence(ctx, k.opts.Domain, k.opts.Project,
+			service.AppId, service.ServiceName, service.Version, service.Environment)
+		if err != nil && err.Code != pb.ErrServiceNotExists {
+			return err
+		}
+	}
+	if len(serviceID) == 0 {
+		var err *errsvc.Error
+		serviceID, err = k.client.CreateService(ctx, k.opts.Domain, k.opts.Project, service)
+		if err != nil {
+			return err
+		}
+	}
+
+	k.lock.Lock()
+	service.ServiceId = serviceID
+	r.instance.ServiceId = serviceID
+	k.lock.Unlock()
+	instanceID, err := k.client.RegisterInstance(ctx, k.opts.Domain, k.opts.Project, serviceID, r.instance)
+	if err != nil {
+		return err
+	}
+	k.lock.Lock()
+	r.instance.InstanceId = instanceID
+	k.lock.Unlock()
+	log.Infof("register instance[%s/%s] successfully", serviceID, instanceID)
+	return nil
+}
+
+// recover registers the service and instance again, the service is created
+// again with the same id if it is deleted
+func (k *Keeper) recover(ctx context.Context, r *registration) *errsvc.Error {
+	serviceID, _ := k.ids(r)
+	_, err := k.client.GetService(ctx, k.opts.Domain, k.opts.Project, serviceID)
+	if err != nil && err.Code != pb.ErrServiceNotExists {
+		return err
+	}
+	if err != nil {
+		if _, err := k.client.CreateService(ctx, k.opts.Domain, k.opts.Project, r.service); err != nil {
+			return err
+		}
+	}
+	return k.register(ctx, r)
+}
+
+func (k *Keeper) heartbeat(ctx context.Context) {
+	k.lock.RLock()
+	registrations := make([]*registration, 0, len(k.instances))
+	for _, r := range k.instances {
+		registrations = append(registrations, r)
+	}
+	k.lock.RUnlock()
+
+	for _, r := range registrations {
+		serviceID, instanceID := k.ids(r)
+		err := k.client.Heartbeat(ctx, k.opts.Domain, k.opts.Project, serviceID, instanceID)
+		if err == nil {
+			continue
+		}
+		if err.Code != pb.ErrInstanceNotExists && err.Code != pb.ErrServiceNotExists {
+			log.Errorf(err, "instance[%s/%s] heartbeat failed", serviceID, instanceID)
+			continue
+		}
+		log.Warnf("instance[%s/%s] does not exist, register it again", serviceID, instanceID)
+		if err := k.recover(ctx, r); err != nil {
+			log.Errorf(err, "register instance[%s/%s] again failed", serviceID, instanceID)
+		}
+	}
+}
+
+func (k *Keeper) interval() time.Duration {
+	if k.opts.Interval > 0 {
+		return k.opts.Interval
+	}
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	interval := DefaultHeartbeatInterval
+	for _, r := range k.instances {
+		if hc := r.instance.HealthCheck; hc != nil && hc.Interval > 0 {
+			if d := time.Duration(hc.Interval) * time.Second; d < interval {
+				interval = d
+			}
+		}
+	}
+	return interval
+}
+
+// Run sends the heartbeats periodically until the ctx is done
+func (k *Keeper) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(k.interval()):
+			k.heartbeat(ctx)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/go-chassis/cari/discovery"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/servicecomb-service-center/client"
+)
+
+func TestKeeper(t *testing.T) {
+	var (
+		lock     sync.Mutex
+		calls    []string
+		lost     = true
+		services = 0
+	)
+	svc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		var resp interface{}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v4/default/registry/existence":
+			w.WriteHeader(http.StatusBadRequest)
+			resp = pb.NewError(pb.ErrServiceNotExists, "")
+		case "POST /v4/default/registry/microservices":
+			services++
+			resp = &pb.CreateServiceResponse{ServiceId: "s1"}
+		case "GET /v4/default/registry/microservices/s1":
+			w.WriteHeader(http.StatusBadRequest)
+			resp = pb.NewError(pb.ErrServiceNotExists, "")
+		case "POST /v4/default/registry/microservices/s1/instances":
+			lost = false
+			resp = &pb.RegisterInstanceResponse{InstanceId: "i1"}
+		case "PUT /v4/default/registry/microservices/s1/instances/i1/heartbeat":
+			if lost {
+				w.WriteHeader(http.StatusBadRequest)
+				resp = pb.NewError(pb.ErrInstanceNotExists, "")
+			}
+		case "DELETE /v4/default/registry/microservices/s1/instances/i1":
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+		if resp != nil {
+			b, _ := json.Marshal(resp)
+			w.Write(b)
+		}
+	}))
+	defer svc.Close()
+
+	c, err := client.NewSCClient(client.Config{Endpoints: []string{svc.URL}})
+	assert.NoError(t, err)
+	keeper := NewKeeper(c, KeeperOptions{Domain: "default", Project: "default", Interval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	instance, scErr := keeper.Register(ctx,
+		&pb.MicroService{AppId: "a", ServiceName: "s", Version: "1.0.0"},
+		&pb.MicroServiceInstance{HostName: "h", Endpoints: []string{"rest://127.0.0.1:8080"}})
+	assert.Nil(t, scErr)
+	assert.Equal(t, "s1", instance.ServiceId)
+	assert.Equal(t, "i1", instance.InstanceId)
+
+	go keeper.Run(ctx)
+
+	t.Run("recover when the instance is lost", func(t *testing.T) {
+		lock.Lock()
+		lost = true
+		lock.Unlock()
+		assert.Eventually(t, func() bool {
+			lock.Lock()
+			defer lock.Unlock()
+			return !lost
+		}, 3*time.Second, 10*time.Millisecond)
+
+		lock.Lock()
+		// the service is created again with the same id
+		assert.Equal(t, 2, services)
+		lock.Unlock()
+	})
+
+	t.Run("stop keeping after unregistered", func(t *testing.T) {
+		assert.Nil(t, keeper.Unregister(ctx, "i1"))
+		// wait for the heartbeat in flight
+		time.Sleep(50 * time.Millisecond)
+		lock.Lock()
+		n := len(calls)
+		assert.Contains(t, calls, "DELETE /v4/default/registry/microservices/s1/instances/i1")
+		lock.Unlock()
+
+		time.Sleep(50 * time.Millisecond)
+		lock.Lock()
+		assert.Equal(t, n, len(calls))
+		lock.Unlock()
+	})
+}
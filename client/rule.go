@@ -0,0 +1,65 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	pb "github.com/go-chassis/cari/discovery"
+	"github.com/go-chassis/cari/pkg/errsvc"
+)
+
+const (
+	apiRulesURL = "/v4/%s/registry/microservices/%s/rules"
+	apiRuleURL  = "/v4/%s/registry/microservices/%s/rules/%s"
+)
+
+func (c *Client) AddRules(ctx context.Context, domain, project, serviceID string, rules []*pb.AddOrUpdateServiceRule) ([]string, *errsvc.Error) {
+	rulesResp := &pb.AddServiceRulesResponse{}
+	err := c.do(ctx, http.MethodPost,
+		fmt.Sprintf(apiRulesURL, project, serviceID),
+		c.domainHeaders(ctx, domain), &pb.AddServiceRulesRequest{Rules: rules}, rulesResp)
+	if err != nil {
+		return nil, err
+	}
+	return rulesResp.RuleIds, nil
+}
+
+func (c *Client) UpdateRule(ctx context.Context, domain, project, serviceID, ruleID string, rule *pb.AddOrUpdateServiceRule) *errsvc.Error {
+	return c.do(ctx, http.MethodPut,
+		fmt.Sprintf(apiRuleURL, project, serviceID, ruleID),
+		c.domainHeaders(ctx, domain), rule, nil)
+}
+
+func (c *Client) GetRules(ctx context.Context, domain, project, serviceID string) ([]*pb.ServiceRule, *errsvc.Error) {
+	rulesResp := &pb.GetServiceRulesResponse{}
+	err := c.do(ctx, http.MethodGet,
+		fmt.Sprintf(apiRulesURL, project, serviceID),
+		c.domainHeaders(ctx, domain), nil, rulesResp)
+	if err != nil {
+		return nil, err
+	}
+	return rulesResp.Rules, nil
+}
+
+func (c *Client) DeleteRules(ctx context.Context, domain, project, serviceID string, ruleIDs ...string) *errsvc.Error {
+	return c.do(ctx, http.MethodDelete,
+		fmt.Sprintf(apiRuleURL, project, serviceID, strings.Join(ruleIDs, ",")),
+		c.domainHeaders(ctx, domain), nil, nil)
+}
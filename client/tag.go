@@ -0,0 +1,63 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	pb "github.com/go-chassis/cari/discovery"
+	"github.com/go-chassis/cari/pkg/errsvc"
+)
+
+const (
+	apiTagsURL = "/v4/%s/registry/microservices/%s/tags"
+	apiTagURL  = "/v4/%s/registry/microservices/%s/tags/%s"
+)
+
+func (c *Client) AddTags(ctx context.Context, domain, project, serviceID string, tags map[string]string) *errsvc.Error {
+	return c.do(ctx, http.MethodPost,
+		fmt.Sprintf(apiTagsURL, project, serviceID),
+		c.domainHeaders(ctx, domain), &pb.AddServiceTagsRequest{Tags: tags}, nil)
+}
+
+func (c *Client) UpdateTag(ctx context.Context, domain, project, serviceID, key, value string) *errsvc.Error {
+	query := url.Values{}
+	query.Set("value", value)
+	return c.do(ctx, http.MethodPut,
+		fmt.Sprintf(apiTagURL, project, serviceID, url.PathEscape(key))+"?"+query.Encode(),
+		c.domainHeaders(ctx, domain), nil, nil)
+}
+
+func (c *Client) GetTags(ctx context.Context, domain, project, serviceID string) (map[string]string, *errsvc.Error) {
+	tagsResp := &pb.GetServiceTagsResponse{}
+	err := c.do(ctx, http.MethodGet,
+		fmt.Sprintf(apiTagsURL, project, serviceID),
+		c.domainHeaders(ctx, domain), nil, tagsResp)
+	if err != nil {
+		return nil, err
+	}
+	return tagsResp.Tags, nil
+}
+
+func (c *Client) DeleteTags(ctx context.Context, domain, project, serviceID string, keys ...string) *errsvc.Error {
+	return c.do(ctx, http.MethodDelete,
+		fmt.Sprintf(apiTagURL, project, serviceID, url.PathEscape(strings.Join(keys, ","))),
+		c.domainHeaders(ctx, domain), nil, nil)
+}
@@ -0,0 +1,97 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chassis/cari/pkg/errsvc"
+)
+
+const (
+	headerAuth   = "Authorization"
+	bearerPrefix = "Bearer "
+	// tokenRefreshAhead is the duration before the expiration to acquire a new token
+	tokenRefreshAhead = time.Minute
+)
+
+// tokenCache holds the token acquired by the Config.Account,
+// the zero value is ready to use
+type tokenCache struct {
+	lock   sync.Mutex
+	token  string
+	expire time.Time
+}
+
+// Get returns the cached token, it calls acquire when the token is empty
+// or about to expire
+func (tc *tokenCache) Get(acquire func() (string, *errsvc.Error)) (string, *errsvc.Error) {
+	tc.lock.Lock()
+	defer tc.lock.Unlock()
+	if len(tc.token) > 0 && (tc.expire.IsZero() || time.Now().Add(tokenRefreshAhead).Before(tc.expire)) {
+		return tc.token, nil
+	}
+	token, err := acquire()
+	if err != nil {
+		return "", err
+	}
+	tc.token, tc.expire = token, tokenExpiration(token)
+	return token, nil
+}
+
+// Invalidate drops the cached token if it is still the rejected one
+func (tc *tokenCache) Invalidate(token string) {
+	tc.lock.Lock()
+	if tc.token == token {
+		tc.token, tc.expire = "", time.Time{}
+	}
+	tc.lock.Unlock()
+}
+
+// tokenExpiration returns the 'exp' claim of the JWT, zero if the token is
+// not a JWT, then the token is refreshed only when the server rejects it
+func tokenExpiration(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(claims.Exp, 0)
+}
+
+func (c *Client) token(ctx context.Context) (string, *errsvc.Error) {
+	return c.tokens.Get(func() (string, *errsvc.Error) {
+		token, err := c.GetToken(ctx, c.Cfg.Account)
+		if err != nil {
+			return "", err
+		}
+		return token.TokenStr, nil
+	})
+}
@@ -39,21 +39,33 @@ func (c *Client) Watch(ctx context.Context, domain, project, selfServiceID strin
 		return pb.NewError(pb.ErrInternal, err.Error())
 	}
 
+	defer conn.Close()
+
+	// unblock the ReadMessage when the ctx is canceled
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-stopCh:
+		}
+	}()
+
 	for {
 		messageType, message, err := conn.ReadMessage()
 		if err != nil {
 			log.Println(err)
-			break
+			return pb.NewError(pb.ErrInternal, err.Error())
 		}
 		if messageType == websocket.TextMessage {
 			data := &pb.WatchInstanceResponse{}
 			err := json.Unmarshal(message, data)
 			if err != nil {
 				log.Println(err)
-				break
+				return pb.NewError(pb.ErrInternal, err.Error())
 			}
 			callback(data)
 		}
 	}
-	return pb.NewError(pb.ErrInternal, err.Error())
 }
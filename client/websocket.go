@@ -19,37 +19,111 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
+	"sync"
 
 	"github.com/gorilla/websocket"
 
+	"github.com/apache/servicecomb-service-center/pkg/lb"
 	"github.com/apache/servicecomb-service-center/pkg/util"
 )
 
+// WebsocketDial connects to the endpoint selected by the balancer, the feedback
+// of the endpoint is reported when the connection is closed, so LeastInFlight
+// counts the connection in flight until then
 func (c *LBClient) WebsocketDial(ctx context.Context, api string, headers http.Header) (conn *websocket.Conn, err error) {
-	dialer := &websocket.Dialer{TLSClientConfig: c.TLS}
 	var errs []string
 	for i := 0; i < c.Retries; i++ {
-		var addr *url.URL
-		addr, err = url.Parse(c.Next())
+		endpoint := c.Next()
+		var rc *releaseConn
+		conn, rc, err = c.dial(ctx, endpoint, api, headers)
 		if err != nil {
-			errs = append(errs, fmt.Sprintf("[%s]: %s", addr, err.Error()))
+			c.done(endpoint, err)
+			errs = append(errs, fmt.Sprintf("[%s]: %s", endpoint, err.Error()))
 			continue
 		}
-		if addr.Scheme == "https" {
-			addr.Scheme = "wss"
-		} else {
-			addr.Scheme = "ws"
-		}
-		conn, _, err = dialer.Dial(addr.String()+api, headers)
-		if err == nil {
-			break
-		}
-		errs = append(errs, fmt.Sprintf("[%s]: %s", addr, err.Error()))
+		rc.setRelease(func() {
+			c.done(endpoint, nil)
+		})
+		break
 	}
 	if err != nil {
 		err = errors.New(util.StringJoin(errs, ", "))
 	}
 	return
 }
+
+func (c *LBClient) done(endpoint string, err error) {
+	if fb, ok := c.LB.(lb.Feedback); ok {
+		fb.Done(endpoint, err)
+	}
+}
+
+func (c *LBClient) dial(ctx context.Context, endpoint, api string, headers http.Header) (*websocket.Conn, *releaseConn, error) {
+	addr, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, nil, err
+	}
+	if addr.Scheme == "https" {
+		addr.Scheme = "wss"
+	} else {
+		addr.Scheme = "ws"
+	}
+	var rc *releaseConn
+	dialer := &websocket.Dialer{
+		TLSClientConfig: c.TLS,
+		NetDialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			conn, err := (&net.Dialer{}).DialContext(ctx, network, addr)
+			if err != nil {
+				return nil, err
+			}
+			rc = &releaseConn{Conn: conn}
+			return rc, nil
+		},
+	}
+	conn, _, err := dialer.DialContext(ctx, addr.String()+api, headers)
+	return conn, rc, err
+}
+
+// releaseConn calls release once when it is closed, the release is set
+// after the websocket handshake succeeded
+type releaseConn struct {
+	net.Conn
+	lock     sync.Mutex
+	closed   bool
+	released bool
+	release  func()
+}
+
+func (c *releaseConn) setRelease(f func()) {
+	c.lock.Lock()
+	c.release = f
+	closed := c.closed
+	c.lock.Unlock()
+	if closed {
+		c.doRelease()
+	}
+}
+
+func (c *releaseConn) Close() error {
+	err := c.Conn.Close()
+	c.lock.Lock()
+	c.closed = true
+	c.lock.Unlock()
+	c.doRelease()
+	return err
+}
+
+func (c *releaseConn) doRelease() {
+	c.lock.Lock()
+	f := c.release
+	if f == nil || c.released {
+		c.lock.Unlock()
+		return
+	}
+	c.released = true
+	c.lock.Unlock()
+	f()
+}
@@ -20,6 +20,7 @@ package datasource
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/go-chassis/cari/rbac"
 )
@@ -56,6 +57,8 @@ type AccountLockManager interface {
 	GetLock(ctx context.Context, key string) (*AccountLock, error)
 	DeleteLock(ctx context.Context, key string) error
 	Ban(ctx context.Context, key string) error
+	// SetReleaseAfter changes the duration of the locks banned later
+	SetReleaseAfter(d time.Duration)
 }
 type AccountLock struct {
 	Key       string `json:"key,omitempty"`
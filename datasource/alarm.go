@@ -0,0 +1,41 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package datasource
+
+import (
+	"context"
+	"errors"
+
+	"github.com/apache/servicecomb-service-center/pkg/alarm/model"
+)
+
+var ErrAlarmNotExist = errors.New("alarm not exist")
+
+// AlarmManager persists the alarms and their transition history
+type AlarmManager interface {
+	UpsertAlarm(ctx context.Context, a *model.Alarm) error
+	GetAlarm(ctx context.Context, node string, id model.ID) (*model.Alarm, error)
+	// ListAlarms returns the alarms of the node, or of all nodes if node is empty
+	ListAlarms(ctx context.Context, node string) ([]*model.Alarm, error)
+	// DeleteAlarms removes the alarms of the node, or of all nodes if node is empty
+	DeleteAlarms(ctx context.Context, node string) error
+	AddAlarmHistory(ctx context.Context, h *model.History) error
+	ListAlarmHistory(ctx context.Context, request *model.HistoryRequest) ([]*model.History, error)
+	// DeleteAlarmHistory removes the histories recorded before the unix time
+	DeleteAlarmHistory(ctx context.Context, before int64) error
+}
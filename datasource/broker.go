@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package datasource
+
+import (
+	"context"
+
+	"github.com/apache/servicecomb-service-center/pkg/broker/brokerpb"
+)
+
+// the kinds of broker data which the ID is generated incrementally
+const (
+	BrokerParticipant  = "participant"
+	BrokerVersion      = "version"
+	BrokerPact         = "pact"
+	BrokerPactVersion  = "pact-version"
+	BrokerVerification = "verification"
+)
+
+// BrokerManager persists the participants, versions, pacts and
+// verification results of the contract broker, the getters return nil
+// if the data does not exist
+type BrokerManager interface {
+	GetParticipant(ctx context.Context, domainProject, appID, serviceName string) (*brokerpb.Participant, error)
+	ListParticipants(ctx context.Context, domainProject string) ([]*brokerpb.Participant, error)
+	CreateParticipant(ctx context.Context, domainProject string, participant *brokerpb.Participant) error
+
+	GetVersion(ctx context.Context, domainProject, number string, participantID int32) (*brokerpb.Version, error)
+	ListVersions(ctx context.Context, domainProject string) ([]*brokerpb.Version, error)
+	CreateVersion(ctx context.Context, domainProject string, version *brokerpb.Version) error
+
+	GetPact(ctx context.Context, domainProject string, consumerParticipantID, providerParticipantID int32,
+		sha []byte) (*brokerpb.Pact, error)
+	ListPacts(ctx context.Context, domainProject string) ([]*brokerpb.Pact, error)
+	CreatePact(ctx context.Context, domainProject string, pact *brokerpb.Pact) error
+
+	GetPactVersion(ctx context.Context, domainProject string, versionID, pactID int32) (*brokerpb.PactVersion, error)
+	ListPactVersions(ctx context.Context, domainProject string) ([]*brokerpb.PactVersion, error)
+	CreatePactVersion(ctx context.Context, domainProject string, pactVersion *brokerpb.PactVersion) error
+
+	// ListVerifications returns the verification results of the pact version
+	ListVerifications(ctx context.Context, domainProject string, pactVersionID int32) ([]*brokerpb.Verification, error)
+	CreateVerification(ctx context.Context, domainProject string, verification *brokerpb.Verification) error
+
+	// GetLatestID returns the max ID of the kind, -1 if no data created
+	GetLatestID(ctx context.Context, kind string) (int32, error)
+	// DeleteAll removes all the broker data
+	DeleteAll(ctx context.Context) error
+}
@@ -0,0 +1,111 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package datasource_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/broker/brokerpb"
+)
+
+func TestBroker(t *testing.T) {
+	var (
+		ctx           = context.Background()
+		domainProject = "broker_test/broker_test"
+		participant   *brokerpb.Participant
+		version       *brokerpb.Version
+		pact          *brokerpb.Pact
+		pactVersion   *brokerpb.PactVersion
+	)
+
+	t.Run("create participant should success", func(t *testing.T) {
+		id, err := datasource.GetBrokerManager().GetLatestID(ctx, datasource.BrokerParticipant)
+		assert.NoError(t, err)
+		participant = &brokerpb.Participant{Id: id + 1, AppId: "broker_test", ServiceName: "consumer"}
+		err = datasource.GetBrokerManager().CreateParticipant(ctx, domainProject, participant)
+		assert.NoError(t, err)
+
+		latest, err := datasource.GetBrokerManager().GetLatestID(ctx, datasource.BrokerParticipant)
+		assert.NoError(t, err)
+		assert.Equal(t, participant.Id, latest)
+
+		p, err := datasource.GetBrokerManager().GetParticipant(ctx, domainProject, "broker_test", "consumer")
+		assert.NoError(t, err)
+		assert.Equal(t, participant, p)
+
+		ps, err := datasource.GetBrokerManager().ListParticipants(ctx, domainProject)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(ps))
+	})
+
+	t.Run("get not exist participant should return nil", func(t *testing.T) {
+		p, err := datasource.GetBrokerManager().GetParticipant(ctx, domainProject, "broker_test", "not_exist")
+		assert.NoError(t, err)
+		assert.Nil(t, p)
+	})
+
+	t.Run("create version and pact should success", func(t *testing.T) {
+		id, err := datasource.GetBrokerManager().GetLatestID(ctx, datasource.BrokerVersion)
+		assert.NoError(t, err)
+		version = &brokerpb.Version{Id: id + 1, Number: "1.0.0", ParticipantId: participant.Id, Order: 0}
+		err = datasource.GetBrokerManager().CreateVersion(ctx, domainProject, version)
+		assert.NoError(t, err)
+		v, err := datasource.GetBrokerManager().GetVersion(ctx, domainProject, "1.0.0", participant.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, version, v)
+
+		id, err = datasource.GetBrokerManager().GetLatestID(ctx, datasource.BrokerPact)
+		assert.NoError(t, err)
+		pact = &brokerpb.Pact{Id: id + 1, ConsumerParticipantId: participant.Id,
+			ProviderParticipantId: participant.Id, Sha: []byte("sha"), Content: []byte("{}")}
+		err = datasource.GetBrokerManager().CreatePact(ctx, domainProject, pact)
+		assert.NoError(t, err)
+		p, err := datasource.GetBrokerManager().GetPact(ctx, domainProject, participant.Id, participant.Id, []byte("sha"))
+		assert.NoError(t, err)
+		assert.Equal(t, pact, p)
+
+		id, err = datasource.GetBrokerManager().GetLatestID(ctx, datasource.BrokerPactVersion)
+		assert.NoError(t, err)
+		pactVersion = &brokerpb.PactVersion{Id: id + 1, VersionId: version.Id, PactId: pact.Id,
+			ProviderParticipantId: participant.Id}
+		err = datasource.GetBrokerManager().CreatePactVersion(ctx, domainProject, pactVersion)
+		assert.NoError(t, err)
+		pv, err := datasource.GetBrokerManager().GetPactVersion(ctx, domainProject, version.Id, pact.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, pactVersion, pv)
+	})
+
+	t.Run("list verifications should return the results of the pact version", func(t *testing.T) {
+		id, err := datasource.GetBrokerManager().GetLatestID(ctx, datasource.BrokerVerification)
+		assert.NoError(t, err)
+		verification := &brokerpb.Verification{Id: id + 1, Number: 0, PactVersionId: pactVersion.Id, Success: true}
+		err = datasource.GetBrokerManager().CreateVerification(ctx, domainProject, verification)
+		assert.NoError(t, err)
+		other := &brokerpb.Verification{Id: id + 2, Number: 0, PactVersionId: pactVersion.Id*10 + 1}
+		err = datasource.GetBrokerManager().CreateVerification(ctx, domainProject, other)
+		assert.NoError(t, err)
+
+		vs, err := datasource.GetBrokerManager().ListVerifications(ctx, domainProject, pactVersion.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, []*brokerpb.Verification{verification}, vs)
+	})
+}
@@ -22,9 +22,13 @@ type DataSource interface {
 	SystemManager() SystemManager
 	AccountManager() AccountManager
 	AccountLockManager() AccountLockManager
+	TokenManager() TokenManager
 	RoleManager() RoleManager
 	DependencyManager() DependencyManager
 	MetadataManager() MetadataManager
 	SCManager() SCManager
 	MetricsManager() MetricsManager
+	AlarmManager() AlarmManager
+	QuotaManager() QuotaManager
+	BrokerManager() BrokerManager
 }
@@ -27,6 +27,9 @@ import (
 type DependencyManager interface {
 	SearchProviderDependency(ctx context.Context, request *pb.GetDependenciesRequest) (*pb.GetProDependenciesResponse, error)
 	SearchConsumerDependency(ctx context.Context, request *pb.GetDependenciesRequest) (*pb.GetConDependenciesResponse, error)
+	// GetConsumerDependencyRules returns the stored provider rules of the consumer,
+	// the version rules like 'latest', 'x+' and ranges are not resolved
+	GetConsumerDependencyRules(ctx context.Context, consumer *pb.MicroService) ([]*pb.MicroServiceKey, error)
 	AddOrUpdateDependencies(ctx context.Context, dependencyInfos []*pb.ConsumerDependency, override bool) (*pb.Response, error)
 	DeleteDependency()
 	DependencyHandle(ctx context.Context) error
@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/apache/servicecomb-service-center/datasource"
@@ -28,10 +29,11 @@ import (
 )
 
 type AccountLockManager struct {
-	releaseAfter time.Duration
+	// releaseAfter is time.Duration, it can be changed at runtime
+	releaseAfter int64
 }
 
-func (al AccountLockManager) GetLock(ctx context.Context, key string) (*datasource.AccountLock, error) {
+func (al *AccountLockManager) GetLock(ctx context.Context, key string) (*datasource.AccountLock, error) {
 	resp, err := client.Instance().Do(ctx, client.GET,
 		client.WithStrKey(path.GenerateAccountLockKey(key)))
 	if err != nil {
@@ -49,7 +51,7 @@ func (al AccountLockManager) GetLock(ctx context.Context, key string) (*datasour
 	return lock, nil
 }
 
-func (al AccountLockManager) DeleteLock(ctx context.Context, key string) error {
+func (al *AccountLockManager) DeleteLock(ctx context.Context, key string) error {
 	_, err := client.Delete(ctx, path.GenerateAccountLockKey(key))
 	if err != nil {
 		log.Error(fmt.Sprintf("remove lock %s failed", key), err)
@@ -60,14 +62,22 @@ func (al AccountLockManager) DeleteLock(ctx context.Context, key string) error {
 }
 
 func NewAccountLockManager(ReleaseAfter time.Duration) datasource.AccountLockManager {
-	return &AccountLockManager{releaseAfter: ReleaseAfter}
+	return &AccountLockManager{releaseAfter: int64(ReleaseAfter)}
 }
 
-func (al AccountLockManager) Ban(ctx context.Context, key string) error {
+func (al *AccountLockManager) ReleaseAfter() time.Duration {
+	return time.Duration(atomic.LoadInt64(&al.releaseAfter))
+}
+
+func (al *AccountLockManager) SetReleaseAfter(d time.Duration) {
+	atomic.StoreInt64(&al.releaseAfter, int64(d))
+}
+
+func (al *AccountLockManager) Ban(ctx context.Context, key string) error {
 	l := &datasource.AccountLock{}
 	l.Key = key
 	l.Status = datasource.StatusBanned
-	l.ReleaseAt = time.Now().Add(al.releaseAfter).Unix()
+	l.ReleaseAt = time.Now().Add(al.ReleaseAfter()).Unix()
 	value, err := json.Marshal(l)
 	if err != nil {
 		log.Errorf(err, "account lock is invalid")
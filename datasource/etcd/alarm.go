@@ -0,0 +1,145 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/path"
+	"github.com/apache/servicecomb-service-center/pkg/alarm/model"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+)
+
+type AlarmManager struct {
+}
+
+func (am *AlarmManager) UpsertAlarm(ctx context.Context, a *model.Alarm) error {
+	value, err := json.Marshal(a)
+	if err != nil {
+		log.Error(fmt.Sprintf("alarm[%s] is invalid", a.ID), err)
+		return err
+	}
+	return client.PutBytes(ctx, path.GenerateAlarmKey(a.Node, string(a.ID)), value)
+}
+
+func (am *AlarmManager) GetAlarm(ctx context.Context, node string, id model.ID) (*model.Alarm, error) {
+	resp, err := client.Instance().Do(ctx, client.GET,
+		client.WithStrKey(path.GenerateAlarmKey(node, string(id))))
+	if err != nil {
+		return nil, err
+	}
+	if resp.Count == 0 {
+		return nil, datasource.ErrAlarmNotExist
+	}
+	a := &model.Alarm{}
+	err = json.Unmarshal(resp.Kvs[0].Value, a)
+	if err != nil {
+		log.Error(fmt.Sprintf("alarm[%s] format invalid", id), err)
+		return nil, err
+	}
+	return a, nil
+}
+
+func (am *AlarmManager) ListAlarms(ctx context.Context, node string) ([]*model.Alarm, error) {
+	kvs, _, err := client.List(ctx, alarmPrefix(node))
+	if err != nil {
+		return nil, err
+	}
+	alarms := make([]*model.Alarm, 0, len(kvs))
+	for _, kv := range kvs {
+		a := &model.Alarm{}
+		if err := json.Unmarshal(kv.Value, a); err != nil {
+			log.Error(fmt.Sprintf("alarm[%s] format invalid", kv.Key), err)
+			continue
+		}
+		alarms = append(alarms, a)
+	}
+	return alarms, nil
+}
+
+func (am *AlarmManager) DeleteAlarms(ctx context.Context, node string) error {
+	_, err := client.Instance().Do(ctx, client.DEL,
+		client.WithStrKey(alarmPrefix(node)), client.WithPrefix())
+	return err
+}
+
+// alarmPrefix returns the alarm key prefix of the node, or of all nodes if node is empty
+func alarmPrefix(node string) string {
+	if len(node) == 0 {
+		return path.GetAlarmRootKey() + path.SPLIT
+	}
+	return path.GenerateAlarmKey(node, "")
+}
+
+func (am *AlarmManager) AddAlarmHistory(ctx context.Context, h *model.History) error {
+	value, err := json.Marshal(h)
+	if err != nil {
+		log.Error(fmt.Sprintf("alarm[%s] history is invalid", h.ID), err)
+		return err
+	}
+	key := path.GenerateAlarmHistoryKey(time.Now().UnixNano(), string(h.ID))
+	return client.PutBytes(ctx, key, value)
+}
+
+func (am *AlarmManager) ListAlarmHistory(ctx context.Context, request *model.HistoryRequest) ([]*model.History, error) {
+	end := request.End
+	if end <= 0 {
+		end = math.MaxInt64/int64(time.Second) - 1
+	}
+	resp, err := client.Instance().Do(ctx, client.GET,
+		client.WithStrKey(historyRangeKey(request.Start)),
+		client.WithStrEndKey(historyRangeKey(end+1)),
+		client.WithDescendOrder())
+	if err != nil {
+		return nil, err
+	}
+	histories := make([]*model.History, 0, resp.Count)
+	for _, kv := range resp.Kvs {
+		h := &model.History{}
+		if err := json.Unmarshal(kv.Value, h); err != nil {
+			log.Error(fmt.Sprintf("alarm history[%s] format invalid", kv.Key), err)
+			continue
+		}
+		if !request.Match(h) {
+			continue
+		}
+		histories = append(histories, h)
+		if request.Limit > 0 && int64(len(histories)) >= request.Limit {
+			break
+		}
+	}
+	return histories, nil
+}
+
+func (am *AlarmManager) DeleteAlarmHistory(ctx context.Context, before int64) error {
+	_, err := client.Instance().Do(ctx, client.DEL,
+		client.WithStrKey(historyRangeKey(0)),
+		client.WithStrEndKey(historyRangeKey(before)))
+	return err
+}
+
+// historyRangeKey returns the history key prefix of the unix time
+func historyRangeKey(unix int64) string {
+	return path.GenerateAlarmHistoryKey(unix*int64(time.Second), "")
+}
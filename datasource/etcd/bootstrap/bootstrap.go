@@ -29,6 +29,12 @@ import (
 	//registry etcd
 	_ "github.com/apache/servicecomb-service-center/datasource/etcd/client/embedded"
 
+	//registry local
+	_ "github.com/apache/servicecomb-service-center/datasource/etcd/client/local"
+
+	//registry sql
+	_ "github.com/apache/servicecomb-service-center/datasource/etcd/client/rdb"
+
 	//discovery
 	_ "github.com/apache/servicecomb-service-center/datasource/etcd/sd/aggregate"
 
@@ -0,0 +1,243 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/kv"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/path"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/sd"
+	"github.com/apache/servicecomb-service-center/pkg/broker/brokerpb"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+)
+
+type BrokerManager struct {
+}
+
+func (bm *BrokerManager) GetParticipant(ctx context.Context, domainProject, appID, serviceName string) (*brokerpb.Participant, error) {
+	kvs, err := searchBrokerData(ctx, kv.BrokerParticipant,
+		path.GenerateBrokerParticipantKey(domainProject, appID, serviceName), false)
+	if err != nil || len(kvs) == 0 {
+		return nil, err
+	}
+	participant := &brokerpb.Participant{}
+	if err := json.Unmarshal(kvs[0].Value.([]byte), participant); err != nil {
+		return nil, err
+	}
+	return participant, nil
+}
+
+func (bm *BrokerManager) ListParticipants(ctx context.Context, domainProject string) ([]*brokerpb.Participant, error) {
+	kvs, err := searchBrokerData(ctx, kv.BrokerParticipant, path.GetBrokerParticipantKey(domainProject)+path.SPLIT, true)
+	if err != nil {
+		return nil, err
+	}
+	participants := make([]*brokerpb.Participant, 0, len(kvs))
+	for _, keyValue := range kvs {
+		participant := &brokerpb.Participant{}
+		if err := json.Unmarshal(keyValue.Value.([]byte), participant); err != nil {
+			return nil, err
+		}
+		participants = append(participants, participant)
+	}
+	return participants, nil
+}
+
+func (bm *BrokerManager) CreateParticipant(ctx context.Context, domainProject string, participant *brokerpb.Participant) error {
+	return createBrokerData(ctx, path.GenerateBrokerParticipantKey(domainProject, participant.AppId, participant.ServiceName),
+		datasource.BrokerParticipant, participant.Id, participant)
+}
+
+func (bm *BrokerManager) GetVersion(ctx context.Context, domainProject, number string, participantID int32) (*brokerpb.Version, error) {
+	kvs, err := searchBrokerData(ctx, kv.BrokerVersion,
+		path.GenerateBrokerVersionKey(domainProject, number, participantID), false)
+	if err != nil || len(kvs) == 0 {
+		return nil, err
+	}
+	version := &brokerpb.Version{}
+	if err := json.Unmarshal(kvs[0].Value.([]byte), version); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+func (bm *BrokerManager) ListVersions(ctx context.Context, domainProject string) ([]*brokerpb.Version, error) {
+	kvs, err := searchBrokerData(ctx, kv.BrokerVersion, path.GetBrokerVersionKey(domainProject)+path.SPLIT, true)
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]*brokerpb.Version, 0, len(kvs))
+	for _, keyValue := range kvs {
+		version := &brokerpb.Version{}
+		if err := json.Unmarshal(keyValue.Value.([]byte), version); err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+func (bm *BrokerManager) CreateVersion(ctx context.Context, domainProject string, version *brokerpb.Version) error {
+	return createBrokerData(ctx, path.GenerateBrokerVersionKey(domainProject, version.Number, version.ParticipantId),
+		datasource.BrokerVersion, version.Id, version)
+}
+
+func (bm *BrokerManager) GetPact(ctx context.Context, domainProject string, consumerParticipantID, providerParticipantID int32,
+	sha []byte) (*brokerpb.Pact, error) {
+	kvs, err := searchBrokerData(ctx, kv.BrokerPact,
+		path.GenerateBrokerPactKey(domainProject, consumerParticipantID, providerParticipantID, sha), false)
+	if err != nil || len(kvs) == 0 {
+		return nil, err
+	}
+	pact := &brokerpb.Pact{}
+	if err := json.Unmarshal(kvs[0].Value.([]byte), pact); err != nil {
+		return nil, err
+	}
+	return pact, nil
+}
+
+func (bm *BrokerManager) ListPacts(ctx context.Context, domainProject string) ([]*brokerpb.Pact, error) {
+	kvs, err := searchBrokerData(ctx, kv.BrokerPact, path.GetBrokerPactKey(domainProject)+path.SPLIT, true)
+	if err != nil {
+		return nil, err
+	}
+	pacts := make([]*brokerpb.Pact, 0, len(kvs))
+	for _, keyValue := range kvs {
+		pact := &brokerpb.Pact{}
+		if err := json.Unmarshal(keyValue.Value.([]byte), pact); err != nil {
+			return nil, err
+		}
+		pacts = append(pacts, pact)
+	}
+	return pacts, nil
+}
+
+func (bm *BrokerManager) CreatePact(ctx context.Context, domainProject string, pact *brokerpb.Pact) error {
+	return createBrokerData(ctx, path.GenerateBrokerPactKey(domainProject,
+		pact.ConsumerParticipantId, pact.ProviderParticipantId, pact.Sha),
+		datasource.BrokerPact, pact.Id, pact)
+}
+
+func (bm *BrokerManager) GetPactVersion(ctx context.Context, domainProject string, versionID, pactID int32) (*brokerpb.PactVersion, error) {
+	kvs, err := searchBrokerData(ctx, kv.BrokerPactVersion,
+		path.GenerateBrokerPactVersionKey(domainProject, versionID, pactID), false)
+	if err != nil || len(kvs) == 0 {
+		return nil, err
+	}
+	pactVersion := &brokerpb.PactVersion{}
+	if err := json.Unmarshal(kvs[0].Value.([]byte), pactVersion); err != nil {
+		return nil, err
+	}
+	return pactVersion, nil
+}
+
+func (bm *BrokerManager) ListPactVersions(ctx context.Context, domainProject string) ([]*brokerpb.PactVersion, error) {
+	kvs, err := searchBrokerData(ctx, kv.BrokerPactVersion, path.GetBrokerPactVersionKey(domainProject)+path.SPLIT, true)
+	if err != nil {
+		return nil, err
+	}
+	pactVersions := make([]*brokerpb.PactVersion, 0, len(kvs))
+	for _, keyValue := range kvs {
+		pactVersion := &brokerpb.PactVersion{}
+		if err := json.Unmarshal(keyValue.Value.([]byte), pactVersion); err != nil {
+			return nil, err
+		}
+		pactVersions = append(pactVersions, pactVersion)
+	}
+	return pactVersions, nil
+}
+
+func (bm *BrokerManager) CreatePactVersion(ctx context.Context, domainProject string, pactVersion *brokerpb.PactVersion) error {
+	return createBrokerData(ctx, path.GenerateBrokerPactVersionKey(domainProject, pactVersion.VersionId, pactVersion.PactId),
+		datasource.BrokerPactVersion, pactVersion.Id, pactVersion)
+}
+
+func (bm *BrokerManager) ListVerifications(ctx context.Context, domainProject string, pactVersionID int32) ([]*brokerpb.Verification, error) {
+	key := util.StringJoin([]string{path.GetBrokerVerificationKey(domainProject), strconv.Itoa(int(pactVersionID)), ""}, path.SPLIT)
+	kvs, err := searchBrokerData(ctx, kv.BrokerVerification, key, true)
+	if err != nil {
+		return nil, err
+	}
+	verifications := make([]*brokerpb.Verification, 0, len(kvs))
+	for _, keyValue := range kvs {
+		verification := &brokerpb.Verification{}
+		if err := json.Unmarshal(keyValue.Value.([]byte), verification); err != nil {
+			return nil, err
+		}
+		verifications = append(verifications, verification)
+	}
+	return verifications, nil
+}
+
+func (bm *BrokerManager) CreateVerification(ctx context.Context, domainProject string, verification *brokerpb.Verification) error {
+	return createBrokerData(ctx, path.GenerateBrokerVerificationKey(domainProject, verification.PactVersionId, verification.Number),
+		datasource.BrokerVerification, verification.Id, verification)
+}
+
+func (bm *BrokerManager) GetLatestID(ctx context.Context, kind string) (int32, error) {
+	kvs, err := searchBrokerData(ctx, kv.BrokerLatest, path.GenerateBrokerLatestIDKey(kind), false)
+	if err != nil {
+		return -1, err
+	}
+	if len(kvs) == 0 {
+		return -1, nil
+	}
+	id, err := strconv.Atoi(string(kvs[0].Value.([]byte)))
+	if err != nil {
+		return -1, err
+	}
+	return int32(id), nil
+}
+
+func (bm *BrokerManager) DeleteAll(ctx context.Context) error {
+	_, err := client.Instance().Do(ctx, client.DEL,
+		client.WithStrKey(path.GetBrokerRootKey()), client.WithPrefix())
+	return err
+}
+
+func searchBrokerData(ctx context.Context, t sd.Type, key string, prefix bool) ([]*sd.KeyValue, error) {
+	opts := []client.PluginOpOption{client.WithStrKey(key)}
+	if prefix {
+		opts = append(opts, client.WithPrefix())
+	}
+	resp, err := kv.Store().Adaptors(t).Search(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Kvs, nil
+}
+
+// createBrokerData puts the data and updates the latest ID of the kind
+func createBrokerData(ctx context.Context, key, kind string, id int32, data interface{}) error {
+	value, err := json.Marshal(data)
+	if err != nil {
+		log.Error(fmt.Sprintf("broker %s[%s] is invalid", kind, key), err)
+		return err
+	}
+	return client.BatchCommit(ctx, []client.PluginOp{
+		client.OpPut(client.WithStrKey(key), client.WithValue(value)),
+		client.OpPut(client.WithStrKey(path.GenerateBrokerLatestIDKey(kind)), client.WithStrValue(strconv.Itoa(int(id)))),
+	})
+}
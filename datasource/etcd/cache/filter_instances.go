@@ -71,6 +71,9 @@ func (f *InstancesFilter) Find(ctx context.Context, parent *cache.Node) (
 	} else {
 		instances, rev, err = f.BatchFindInstances(ctx, provider.Tenant, pCache.ServiceIds)
 	}
+	if err == nil {
+		instances, err = filterBySelector(ctx, provider.Tenant, instances)
+	}
 	if err != nil {
 		consumer := ctx.Value(CtxFindConsumer).(*pb.MicroService)
 		findFlag := fmt.Sprintf("consumer '%s' find provider %s/%s/%s", consumer.ServiceId,
@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+
+	pb "github.com/go-chassis/cari/discovery"
+
+	"github.com/apache/servicecomb-service-center/datasource/etcd/util"
+	"github.com/apache/servicecomb-service-center/pkg/cache"
+	"github.com/apache/servicecomb-service-center/pkg/selector"
+)
+
+// SelectorFilter makes the label selector a part of the cache key,
+// the instances are filtered by InstancesFilter when they are loaded
+type SelectorFilter struct {
+}
+
+func (f *SelectorFilter) Name(ctx context.Context, _ *cache.Node) string {
+	return selector.FromContext(ctx).String()
+}
+
+func (f *SelectorFilter) Init(ctx context.Context, parent *cache.Node) (node *cache.Node, err error) {
+	node = cache.NewNode()
+	node.Cache = parent.Cache
+	return
+}
+
+// filterBySelector returns the instances matched the selector in context,
+// the instance properties take precedence over the service tags
+func filterBySelector(ctx context.Context, domainProject string,
+	instances []*pb.MicroServiceInstance) ([]*pb.MicroServiceInstance, error) {
+	s := selector.FromContext(ctx)
+	if s.Empty() || len(instances) == 0 {
+		return instances, nil
+	}
+
+	tagsCache := make(map[string]map[string]string)
+	matched := make([]*pb.MicroServiceInstance, 0, len(instances))
+	for _, instance := range instances {
+		tags, ok := tagsCache[instance.ServiceId]
+		if !ok {
+			var err error
+			tags, err = util.GetTagsUtils(ctx, domainProject, instance.ServiceId)
+			if err != nil {
+				return nil, err
+			}
+			tagsCache[instance.ServiceId] = tags
+		}
+		if s.Match(instance.Properties, tags) {
+			matched = append(matched, instance)
+		}
+	}
+	return matched, nil
+}
@@ -35,6 +35,7 @@ func init() {
 		&ServiceFilter{},
 		&VersionRuleFilter{},
 		&TagsFilter{},
+		&SelectorFilter{},
 		&AccessibleFilter{},
 		&InstancesFilter{},
 		&ConsistencyFilter{},
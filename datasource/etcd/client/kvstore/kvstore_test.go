@@ -0,0 +1,86 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kvstore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client/kvstore"
+)
+
+func TestNextKV(t *testing.T) {
+	kv, err := kvstore.NextKV(nil, client.OpPut(client.WithStrKey("/a"), client.WithLease(1)), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), kv.CreateRevision)
+	assert.Equal(t, int64(1), kv.Version)
+	assert.Equal(t, int64(1), kv.Lease)
+
+	kv, err = kvstore.NextKV(kv, client.OpPut(client.WithStrKey("/a"), client.WithIgnoreLease()), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), kv.CreateRevision)
+	assert.Equal(t, int64(3), kv.ModRevision)
+	assert.Equal(t, int64(2), kv.Version)
+	assert.Equal(t, int64(1), kv.Lease)
+
+	_, err = kvstore.NextKV(nil, client.OpPut(client.WithStrKey("/a"), client.WithIgnoreLease()), 3)
+	assert.Equal(t, rpctypes.ErrKeyNotFound, err)
+}
+
+func TestPrefixEnd(t *testing.T) {
+	assert.Equal(t, []byte("/b"), kvstore.PrefixEnd([]byte("/a")))
+	assert.Equal(t, []byte{0xff, 1}, kvstore.PrefixEnd([]byte{0xff}))
+	assert.Equal(t, []byte{0}, kvstore.PrefixEnd(nil))
+	assert.True(t, kvstore.InRange([]byte("/z"), []byte{0}))
+	assert.False(t, kvstore.InRange([]byte("/b"), []byte("/b")))
+}
+
+func TestWatcher(t *testing.T) {
+	_, err := kvstore.NewWatcher(client.OpGet())
+	assert.Error(t, err)
+
+	w, err := kvstore.NewWatcher(client.OpGet(client.WithStrKey("/a"), client.WithPrefix()))
+	assert.NoError(t, err)
+	w.Start = 2
+	w.Push([]mvccpb.Event{
+		{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte("/a/1"), ModRevision: 1}},
+		{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte("/ab"), ModRevision: 2}},
+		{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: []byte("/a/2"), ModRevision: 2}},
+		{Type: mvccpb.DELETE, Kv: &mvccpb.KeyValue{Key: []byte("/a/2"), ModRevision: 3}},
+	})
+
+	var resps []*client.PluginResponse
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err = w.Watch(ctx, nil, nil, func(message string, resp *client.PluginResponse) error {
+		resps = append(resps, resp)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(resps))
+	assert.Equal(t, client.ActionPut, resps[0].Action)
+	assert.Equal(t, "/a/2", string(resps[0].Kvs[0].Key))
+	assert.Equal(t, client.ActionDelete, resps[1].Action)
+	assert.Equal(t, int64(3), resps[1].Revision)
+}
@@ -0,0 +1,212 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package kvstore implements the etcd transaction, compare and watch semantics
+// shared by the registry clients which keep the kvs in a non-etcd storage
+package kvstore
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
+)
+
+// Txn is a write transaction of the storage, all the changes in it share
+// the same revision
+type Txn interface {
+	// Get returns the kv of the key, nil if it does not exist
+	Get(key []byte) (*mvccpb.KeyValue, error)
+	Do(op client.PluginOp) (*client.PluginResponse, error)
+}
+
+// UpdateFunc runs fn in a write transaction and returns the revision after
+// the transaction committed
+type UpdateFunc func(fn func(t Txn) error) (int64, error)
+
+// TxnWithCmp runs the success ops if all the cmps pass, otherwise the fail ops
+func TxnWithCmp(update UpdateFunc, success []client.PluginOp, cmps []client.CompareOp,
+	fail []client.PluginOp) (*client.PluginResponse, error) {
+	resp := &client.PluginResponse{Succeeded: true}
+	rev, err := update(func(t Txn) error {
+		ops := success
+		for _, cmp := range cmps {
+			ok, err := Compare(t, cmp)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				resp.Succeeded = false
+				ops = fail
+				break
+			}
+		}
+		for _, op := range ops {
+			r, err := t.Do(op)
+			if err != nil {
+				return err
+			}
+			if op.Action == client.ActionGet {
+				// plz request the same type range kv in txn success/fail options
+				resp.Kvs = append(resp.Kvs, r.Kvs...)
+				resp.Count += r.Count
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if err == rpctypes.ErrKeyNotFound {
+			// like etcd, return ErrKeyNotFound if key does not exist and
+			// the PUT options contain WithIgnoreLease
+			return &client.PluginResponse{Succeeded: false}, nil
+		}
+		return nil, err
+	}
+	resp.Revision = rev
+	return resp, nil
+}
+
+// PutNoOverride puts the kv only if the key does not exist
+func PutNoOverride(ctx context.Context, r client.Registry, opts ...client.PluginOpOption) (bool, error) {
+	op := client.OpPut(opts...)
+	resp, err := r.TxnWithCmp(ctx, []client.PluginOp{op}, []client.CompareOp{
+		client.OpCmp(client.CmpCreateRev(op.Key), client.CmpEqual, 0),
+	}, nil)
+	if err != nil {
+		return false, err
+	}
+	return resp.Succeeded, nil
+}
+
+// NextKV returns the kv after the put op applied to the prev kv in rev,
+// the lease of op should be checked by the caller
+func NextKV(prev *mvccpb.KeyValue, op client.PluginOp, rev int64) (*mvccpb.KeyValue, error) {
+	kv := &mvccpb.KeyValue{
+		Key:            append([]byte(nil), op.Key...),
+		Value:          append([]byte(nil), op.Value...),
+		CreateRevision: rev,
+		ModRevision:    rev,
+		Version:        1,
+		Lease:          op.Lease,
+	}
+	if prev != nil {
+		kv.CreateRevision = prev.CreateRevision
+		kv.Version = prev.Version + 1
+	}
+	if op.IgnoreLease {
+		if prev == nil {
+			return nil, rpctypes.ErrKeyNotFound
+		}
+		kv.Lease = prev.Lease
+	}
+	return kv, nil
+}
+
+// Compare checks the kv of the cmp key in the txn
+func Compare(t Txn, cmp client.CompareOp) (bool, error) {
+	kv, err := t.Get(cmp.Key)
+	if err != nil {
+		return false, err
+	}
+	var result int
+	switch cmp.Type {
+	case client.CmpValue:
+		if kv == nil {
+			// like etcd, the value comparison of a key does not exist always fails
+			return false, nil
+		}
+		result = bytes.Compare(kv.Value, toBytes(cmp.Value))
+	default:
+		var actual int64
+		if kv != nil {
+			switch cmp.Type {
+			case client.CmpVersion:
+				actual = kv.Version
+			case client.CmpCreate:
+				actual = kv.CreateRevision
+			case client.CmpMod:
+				actual = kv.ModRevision
+			}
+		}
+		v := toInt64(cmp.Value)
+		switch {
+		case actual < v:
+			result = -1
+		case actual > v:
+			result = 1
+		}
+	}
+	switch cmp.Result {
+	case client.CmpEqual:
+		return result == 0, nil
+	case client.CmpGreater:
+		return result > 0, nil
+	case client.CmpLess:
+		return result < 0, nil
+	case client.CmpNotEqual:
+		return result != 0, nil
+	}
+	return false, nil
+}
+
+// toBytes converts the compare value like clientv3.Compare does
+func toBytes(v interface{}) []byte {
+	switch t := v.(type) {
+	case []byte:
+		return t
+	case string:
+		return []byte(t)
+	}
+	return nil
+}
+
+func toInt64(v interface{}) int64 {
+	switch t := v.(type) {
+	case int64:
+		return t
+	case int:
+		return int64(t)
+	}
+	return 0
+}
+
+// PrefixEnd returns the end key of the prefix range, "\x00" means no limit
+func PrefixEnd(prefix []byte) []byte {
+	l := len(prefix)
+	if l == 0 {
+		return []byte{0}
+	}
+	endBytes := make([]byte, l+1)
+	copy(endBytes, prefix)
+	if endBytes[l-1] == 0xff {
+		endBytes[l] = 1
+		return endBytes
+	}
+	endBytes[l-1]++
+	return endBytes[:l]
+}
+
+// InRange checks the key is less than end, the end "\x00" means no limit
+func InRange(key, end []byte) bool {
+	if len(end) == 1 && end[0] == 0 {
+		return true
+	}
+	return bytes.Compare(key, end) < 0
+}
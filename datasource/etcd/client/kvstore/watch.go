@@ -0,0 +1,170 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kvstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/coreos/etcd/mvcc/mvccpb"
+
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
+)
+
+// Watcher queues the events of the watched key or prefix
+type Watcher struct {
+	// Start is the revision, the events before it are ignored
+	Start int64
+
+	key    []byte
+	end    []byte
+	mu     sync.Mutex
+	events []mvccpb.Event
+	notify chan struct{}
+}
+
+func (w *Watcher) match(key []byte) bool {
+	if len(w.end) == 0 {
+		return bytes.Equal(key, w.key)
+	}
+	return bytes.Compare(key, w.key) >= 0 && InRange(key, w.end)
+}
+
+// Push never blocks the writer, the events are queued until the watch
+// loop pops them
+func (w *Watcher) Push(evts []mvccpb.Event) {
+	w.mu.Lock()
+	for _, evt := range evts {
+		if evt.Kv.ModRevision >= w.Start && w.match(evt.Kv.Key) {
+			w.events = append(w.events, evt)
+		}
+	}
+	l := len(w.events)
+	w.mu.Unlock()
+
+	if l > 0 {
+		select {
+		case w.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (w *Watcher) pop() []mvccpb.Event {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	evts := w.events
+	w.events = nil
+	return evts
+}
+
+// Watch calls back the queued events until ctx is done or the registry is
+// closed, then returns errClosed
+func (w *Watcher) Watch(ctx context.Context, closed <-chan struct{}, errClosed error, cb client.WatchCallback) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-closed:
+			return errClosed
+		case <-w.notify:
+			if err := Dispatch(w.pop(), cb); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// NewWatcher creates the watcher of op key, or the key prefix if op.Prefix
+// is true, the prefix is treated as a directory like etcd registry does
+func NewWatcher(op client.PluginOp) (*Watcher, error) {
+	if len(op.Key) == 0 {
+		return nil, fmt.Errorf("no key has been watched")
+	}
+	w := &Watcher{
+		key:    op.Key,
+		notify: make(chan struct{}, 1),
+	}
+	if op.Prefix {
+		if op.Key[len(op.Key)-1] != '/' {
+			w.key = append(append([]byte(nil), op.Key...), '/')
+		}
+		w.end = PrefixEnd(w.key)
+	}
+	return w, nil
+}
+
+// Dispatch calls back the events in batches of the same action
+func Dispatch(evts []mvccpb.Event, cb client.WatchCallback) error {
+	l := len(evts)
+	kvs := make([]*mvccpb.KeyValue, l)
+	sIdx, eIdx, rev := 0, 0, int64(0)
+	action, prevEvtType := client.ActionPut, mvccpb.PUT
+
+	for _, evt := range evts {
+		if prevEvtType != evt.Type {
+			if eIdx > 0 {
+				err := callback(action, rev, kvs[sIdx:eIdx], cb)
+				if err != nil {
+					return err
+				}
+				sIdx = eIdx
+			}
+			prevEvtType = evt.Type
+		}
+
+		if rev < evt.Kv.ModRevision {
+			rev = evt.Kv.ModRevision
+		}
+		action = setKvsAndConvertAction(kvs, eIdx, evt)
+
+		eIdx++
+	}
+
+	if eIdx > 0 {
+		return callback(action, rev, kvs[sIdx:eIdx], cb)
+	}
+	return nil
+}
+
+func setKvsAndConvertAction(kvs []*mvccpb.KeyValue, pIdx int, evt mvccpb.Event) client.ActionType {
+	switch evt.Type {
+	case mvccpb.DELETE:
+		kv := evt.PrevKv
+		if kv == nil {
+			kv = evt.Kv
+		}
+		kvs[pIdx] = kv
+		return client.ActionDelete
+	default:
+		kvs[pIdx] = evt.Kv
+		return client.ActionPut
+	}
+}
+
+func callback(action client.ActionType, rev int64, kvs []*mvccpb.KeyValue, cb client.WatchCallback) error {
+	return cb("key information changed", &client.PluginResponse{
+		Action:    action,
+		Kvs:       kvs,
+		Count:     int64(len(kvs)),
+		Revision:  rev,
+		Succeeded: true,
+	})
+}
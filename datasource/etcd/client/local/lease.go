@@ -0,0 +1,183 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package local
+
+import (
+	"context"
+	"time"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
+	errorsEx "github.com/apache/servicecomb-service-center/pkg/errors"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+)
+
+// the interval of checking the expired leases
+const leaseCheckInterval = 500 * time.Millisecond
+
+type lease struct {
+	ID       int64
+	TTL      int64
+	expireAt time.Time
+	keys     map[string]struct{}
+}
+
+func (l *lease) refresh() {
+	l.expireAt = time.Now().Add(time.Duration(l.TTL) * time.Second)
+}
+
+func newLease(id, ttl int64) *lease {
+	l := &lease{ID: id, TTL: ttl, keys: make(map[string]struct{})}
+	l.refresh()
+	return l
+}
+
+func (s *Registry) LeaseGrant(ctx context.Context, TTL int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.leaseID + 1
+	err := s.DB.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(bucketLease).Put(itob(id), itob(TTL)); err != nil {
+			return err
+		}
+		return tx.Bucket(bucketMeta).Put(keyLeaseID, itob(id))
+	})
+	if err != nil {
+		return 0, errorsEx.Internal(err)
+	}
+	s.leaseID = id
+	s.leases[id] = newLease(id, TTL)
+	return id, nil
+}
+
+func (s *Registry) LeaseRenew(ctx context.Context, leaseID int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l, ok := s.leases[leaseID]
+	if !ok {
+		return 0, rpctypes.ErrLeaseNotFound
+	}
+	l.refresh()
+	return l.TTL, nil
+}
+
+func (s *Registry) LeaseRevoke(ctx context.Context, leaseID int64) error {
+	_, err := s.update(func(t *txn) error {
+		return t.revoke(leaseID)
+	})
+	return err
+}
+
+// revoke deletes the lease and the keys attached to it
+func (t *txn) revoke(leaseID int64) error {
+	l, ok := t.s.leases[leaseID]
+	if !ok {
+		return rpctypes.ErrLeaseNotFound
+	}
+	for key := range l.keys {
+		if err := t.delete(client.PluginOp{Key: []byte(key)}); err != nil {
+			return err
+		}
+	}
+	if err := t.tx.Bucket(bucketLease).Delete(itob(leaseID)); err != nil {
+		return err
+	}
+	t.revoked = append(t.revoked, leaseID)
+	return nil
+}
+
+// attach moves the keys changed in a committed transaction to their new leases
+func (s *Registry) attach(attached map[string]int64) {
+	for key, id := range attached {
+		if old, ok := s.keyLeases[key]; ok {
+			if old == id {
+				continue
+			}
+			if l, ok := s.leases[old]; ok {
+				delete(l.keys, key)
+			}
+			delete(s.keyLeases, key)
+		}
+		if l, ok := s.leases[id]; ok {
+			l.keys[key] = struct{}{}
+			s.keyLeases[key] = id
+		}
+	}
+}
+
+// loadLeases restores the leases and the attached keys from the data
+// file, like etcd, the remaining TTL of each lease is reset after restart
+func (s *Registry) loadLeases(tx *bolt.Tx) error {
+	err := tx.Bucket(bucketLease).ForEach(func(k, v []byte) error {
+		id := btoi(k)
+		s.leases[id] = newLease(id, btoi(v))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(bucketKV).ForEach(func(k, v []byte) error {
+		kv, err := decodeKV(k, v)
+		if err != nil {
+			return err
+		}
+		if kv.Lease == 0 {
+			return nil
+		}
+		if l, ok := s.leases[kv.Lease]; ok {
+			l.keys[string(kv.Key)] = struct{}{}
+			s.keyLeases[string(kv.Key)] = kv.Lease
+		}
+		return nil
+	})
+}
+
+func (s *Registry) expireLeases(ctx context.Context) {
+	ticker := time.NewTicker(leaseCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, id := range s.expiredLeases() {
+				err := s.LeaseRevoke(ctx, id)
+				if err != nil && err != rpctypes.ErrLeaseNotFound {
+					log.Errorf(err, "revoke expired lease[%d] failed", id)
+				}
+			}
+		}
+	}
+}
+
+func (s *Registry) expiredLeases() (ids []int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for id, l := range s.leases {
+		if now.After(l.expireAt) {
+			ids = append(ids, id)
+		}
+	}
+	return
+}
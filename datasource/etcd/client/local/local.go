@@ -0,0 +1,447 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package local
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	bolt "github.com/coreos/bbolt"
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client/kvstore"
+	errorsEx "github.com/apache/servicecomb-service-center/pkg/errors"
+	"github.com/apache/servicecomb-service-center/pkg/gopool"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/server/config"
+)
+
+const (
+	DefaultDataFile = "data/local.db"
+	// the timeout of acquiring the file lock, another service center
+	// process may hold the same data file
+	openTimeout = 3 * time.Second
+)
+
+var (
+	bucketKV    = []byte("kv")
+	bucketLease = []byte("lease")
+	bucketMeta  = []byte("meta")
+	keyRevision = []byte("revision")
+	keyLeaseID  = []byte("lease")
+)
+
+func init() {
+	client.Install("local", NewRegistry)
+}
+
+// Registry is a single node registry client which keeps the data in a
+// local bbolt file, it implements the same revision, lease and watch
+// semantics as etcd, but no history revisions can be read back.
+type Registry struct {
+	DB *bolt.DB
+
+	err       chan error
+	ready     chan struct{}
+	closed    chan struct{}
+	closeOnce sync.Once
+	goroutine *gopool.Pool
+
+	// mu serializes the write transactions and protects the fields below
+	mu         sync.Mutex
+	rev        int64
+	leaseID    int64
+	leases     map[int64]*lease
+	keyLeases  map[string]int64
+	watchers   map[*kvstore.Watcher]struct{}
+	history    []mvccpb.Event
+	compactRev int64
+}
+
+func (s *Registry) Err() <-chan error {
+	return s.err
+}
+
+func (s *Registry) Ready() <-chan struct{} {
+	return s.ready
+}
+
+func (s *Registry) Close() {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+		s.goroutine.Close(true)
+		if s.DB != nil {
+			if err := s.DB.Close(); err != nil {
+				log.Error("close local registry failed", err)
+			}
+		}
+		log.Debugf("local registry client stopped")
+	})
+}
+
+func (s *Registry) PutNoOverride(ctx context.Context, opts ...client.PluginOpOption) (bool, error) {
+	return kvstore.PutNoOverride(ctx, s, opts...)
+}
+
+func (s *Registry) Do(ctx context.Context, opts ...client.PluginOpOption) (*client.PluginResponse, error) {
+	op := client.OptionsToOp(opts...)
+
+	if op.Action == client.ActionGet {
+		return s.get(op)
+	}
+
+	rev, err := s.update(func(t *txn) error {
+		_, err := t.Do(op)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &client.PluginResponse{
+		Revision:  rev,
+		Succeeded: true,
+	}, nil
+}
+
+func (s *Registry) Txn(ctx context.Context, opts []client.PluginOp) (*client.PluginResponse, error) {
+	resp, err := s.TxnWithCmp(ctx, opts, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &client.PluginResponse{
+		Succeeded: resp.Succeeded,
+		Revision:  resp.Revision,
+	}, nil
+}
+
+func (s *Registry) TxnWithCmp(ctx context.Context, success []client.PluginOp, cmps []client.CompareOp,
+	fail []client.PluginOp) (*client.PluginResponse, error) {
+	return kvstore.TxnWithCmp(func(fn func(t kvstore.Txn) error) (int64, error) {
+		return s.update(func(t *txn) error {
+			return fn(t)
+		})
+	}, success, cmps, fail)
+}
+
+// Compact only trims the events kept in memory for watchers, the bbolt
+// file never saves the history revisions
+func (s *Registry) Compact(ctx context.Context, reserve int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	revToCompact := s.rev - reserve
+	if revToCompact <= s.compactRev {
+		log.Infof("revision is %d, <=%d, no nead to compact", s.rev, reserve)
+		return nil
+	}
+	s.compactHistory(revToCompact)
+	log.Infof("compacted locally, revision is %d(current: %d, reserve %d)", revToCompact, s.rev, reserve)
+	return nil
+}
+
+func (s *Registry) get(op client.PluginOp) (resp *client.PluginResponse, err error) {
+	err = s.DB.View(func(tx *bolt.Tx) error {
+		kvs, count, err := rangeKVs(tx.Bucket(bucketKV), op)
+		if err != nil {
+			return err
+		}
+		resp = &client.PluginResponse{
+			Kvs:       kvs,
+			Count:     count,
+			Revision:  btoi(tx.Bucket(bucketMeta).Get(keyRevision)),
+			Succeeded: true,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errorsEx.Internal(err)
+	}
+	return resp, nil
+}
+
+// update runs fn in a write transaction, the changes are applied to the
+// leases and published to the watchers only after the transaction committed
+func (s *Registry) update(fn func(t *txn) error) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := &txn{
+		s:        s,
+		rev:      s.rev + 1,
+		attached: make(map[string]int64),
+	}
+	err := s.DB.Update(func(tx *bolt.Tx) error {
+		t.tx = tx
+		if err := fn(t); err != nil {
+			return err
+		}
+		if len(t.events) == 0 {
+			return nil
+		}
+		return tx.Bucket(bucketMeta).Put(keyRevision, itob(t.rev))
+	})
+	if err != nil {
+		if _, ok := err.(rpctypes.EtcdError); ok {
+			return 0, err
+		}
+		return 0, errorsEx.Internal(err)
+	}
+	s.attach(t.attached)
+	for _, id := range t.revoked {
+		delete(s.leases, id)
+	}
+	if len(t.events) > 0 {
+		s.rev = t.rev
+		s.publish(t.events)
+	}
+	return s.rev, nil
+}
+
+// txn is a write transaction, all the changes in it share the same revision
+type txn struct {
+	s        *Registry
+	tx       *bolt.Tx
+	rev      int64
+	events   []mvccpb.Event
+	attached map[string]int64 // key -> lease id, 0 means the key is detached
+	revoked  []int64
+}
+
+func (t *txn) Do(op client.PluginOp) (*client.PluginResponse, error) {
+	switch op.Action {
+	case client.ActionGet:
+		kvs, count, err := rangeKVs(t.tx.Bucket(bucketKV), op)
+		if err != nil {
+			return nil, err
+		}
+		return &client.PluginResponse{Kvs: kvs, Count: count}, nil
+	case client.ActionPut:
+		return &client.PluginResponse{}, t.put(op)
+	case client.ActionDelete:
+		return &client.PluginResponse{}, t.delete(op)
+	}
+	return &client.PluginResponse{}, nil
+}
+
+func (t *txn) put(op client.PluginOp) error {
+	b := t.tx.Bucket(bucketKV)
+	prev, err := getKV(b, op.Key)
+	if err != nil {
+		return err
+	}
+	kv, err := kvstore.NextKV(prev, op, t.rev)
+	if err != nil {
+		return err
+	}
+	if !op.IgnoreLease && op.Lease > 0 {
+		if _, ok := t.s.leases[op.Lease]; !ok {
+			return rpctypes.ErrLeaseNotFound
+		}
+	}
+	if err := putKV(b, kv); err != nil {
+		return err
+	}
+	t.events = append(t.events, mvccpb.Event{Type: mvccpb.PUT, Kv: kv, PrevKv: prev})
+	t.attached[string(kv.Key)] = kv.Lease
+	return nil
+}
+
+func (t *txn) delete(op client.PluginOp) error {
+	b := t.tx.Bucket(bucketKV)
+	prevs, _, err := rangeKVs(b, client.PluginOp{Key: op.Key, EndKey: op.EndKey, Prefix: op.Prefix})
+	if err != nil {
+		return err
+	}
+	for _, prev := range prevs {
+		if err := b.Delete(prev.Key); err != nil {
+			return err
+		}
+		t.events = append(t.events, mvccpb.Event{
+			Type:   mvccpb.DELETE,
+			Kv:     &mvccpb.KeyValue{Key: prev.Key, ModRevision: t.rev},
+			PrevKv: prev,
+		})
+		t.attached[string(prev.Key)] = 0
+	}
+	return nil
+}
+
+func (t *txn) Get(key []byte) (*mvccpb.KeyValue, error) {
+	return getKV(t.tx.Bucket(bucketKV), key)
+}
+
+// rangeKVs returns the kvs in range [op.Key, end), the end is op.EndKey or
+// the prefix end of op.Key, if both empty, only returns the kv of op.Key
+func rangeKVs(b *bolt.Bucket, op client.PluginOp) ([]*mvccpb.KeyValue, int64, error) {
+	var kvs []*mvccpb.KeyValue
+	end := op.EndKey
+	if op.Prefix {
+		end = kvstore.PrefixEnd(op.Key)
+	}
+	if len(end) == 0 {
+		kv, err := getKV(b, op.Key)
+		if err != nil || kv == nil {
+			return nil, 0, err
+		}
+		kvs = append(kvs, kv)
+	} else {
+		c := b.Cursor()
+		for k, v := c.Seek(op.Key); k != nil && kvstore.InRange(k, end); k, v = c.Next() {
+			kv, err := decodeKV(k, v)
+			if err != nil {
+				return nil, 0, err
+			}
+			kvs = append(kvs, kv)
+		}
+	}
+
+	count := int64(len(kvs))
+	if op.CountOnly {
+		return nil, count, nil
+	}
+	if op.OrderBy == client.OrderByCreate && op.SortOrder != client.SortNone {
+		sort.SliceStable(kvs, func(i, j int) bool {
+			return kvs[i].CreateRevision < kvs[j].CreateRevision
+		})
+	}
+	if op.SortOrder == client.SortDescend {
+		for i, j := 0, len(kvs)-1; i < j; i, j = i+1, j-1 {
+			kvs[i], kvs[j] = kvs[j], kvs[i]
+		}
+	}
+	if op.KeyOnly {
+		for _, kv := range kvs {
+			kv.Value = nil
+		}
+	}
+	return kvs, count, nil
+}
+
+func getKV(b *bolt.Bucket, key []byte) (*mvccpb.KeyValue, error) {
+	if len(key) == 0 {
+		return nil, nil
+	}
+	v := b.Get(key)
+	if v == nil {
+		return nil, nil
+	}
+	return decodeKV(key, v)
+}
+
+func putKV(b *bolt.Bucket, kv *mvccpb.KeyValue) error {
+	// the key is saved in bucket, no need to marshal it again
+	data, err := (&mvccpb.KeyValue{
+		CreateRevision: kv.CreateRevision,
+		ModRevision:    kv.ModRevision,
+		Version:        kv.Version,
+		Value:          kv.Value,
+		Lease:          kv.Lease,
+	}).Marshal()
+	if err != nil {
+		return err
+	}
+	return b.Put(kv.Key, data)
+}
+
+func decodeKV(key, value []byte) (*mvccpb.KeyValue, error) {
+	kv := &mvccpb.KeyValue{}
+	if err := kv.Unmarshal(value); err != nil {
+		return nil, err
+	}
+	// the memory of key is only valid in the bbolt transaction
+	kv.Key = append([]byte(nil), key...)
+	return kv, nil
+}
+
+func itob(v int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(v))
+	return b
+}
+
+func btoi(b []byte) int64 {
+	if len(b) != 8 {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(b))
+}
+
+func (s *Registry) open(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return err
+	}
+	s.DB = db
+	return db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{bucketKV, bucketLease, bucketMeta} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		meta := tx.Bucket(bucketMeta)
+		s.rev = btoi(meta.Get(keyRevision))
+		s.leaseID = btoi(meta.Get(keyLeaseID))
+		// the watchers can not catch up the events before restart
+		s.compactRev = s.rev
+		return s.loadLeases(tx)
+	})
+}
+
+func NewRegistry(opts datasource.Options) client.Registry {
+	log.Warnf("enable local registry mode")
+
+	return newRegistry(config.GetString("registry.local.path", DefaultDataFile))
+}
+
+func newRegistry(path string) *Registry {
+	inst := &Registry{
+		err:       make(chan error, 1),
+		ready:     make(chan struct{}),
+		closed:    make(chan struct{}),
+		goroutine: gopool.New(context.Background()),
+		leases:    make(map[int64]*lease),
+		keyLeases: make(map[string]int64),
+		watchers:  make(map[*kvstore.Watcher]struct{}),
+	}
+
+	if err := inst.open(path); err != nil {
+		log.Errorf(err, "open local registry data file %s failed", path)
+		if inst.DB != nil {
+			_ = inst.DB.Close()
+			inst.DB = nil
+		}
+		inst.err <- err
+		return inst
+	}
+	log.Infof("local registry data file is %s, current revision is %d", path, inst.rev)
+
+	inst.goroutine.Do(inst.expireLeases)
+	close(inst.ready)
+	return inst
+}
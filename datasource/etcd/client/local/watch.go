@@ -0,0 +1,94 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package local
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client/kvstore"
+)
+
+// the max number of events kept in memory for the watchers to catch up,
+// the older events are compacted automatically
+const maxHistoryEvents = 10000
+
+var ErrClosed = errors.New("local registry is closed")
+
+func (s *Registry) Watch(ctx context.Context, opts ...client.PluginOpOption) error {
+	op := client.OpGet(opts...)
+	w, err := kvstore.NewWatcher(op)
+	if err != nil {
+		return err
+	}
+	if err := s.addWatcher(w, op.Revision); err != nil {
+		return err
+	}
+	defer s.removeWatcher(w)
+
+	return w.Watch(ctx, s.closed, ErrClosed, op.WatchCallback)
+}
+
+// addWatcher registers the watcher and replays the events since rev,
+// returns ErrCompacted if the events have been compacted
+func (s *Registry) addWatcher(w *kvstore.Watcher, rev int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rev > 0 {
+		if rev <= s.compactRev {
+			return rpctypes.ErrCompacted
+		}
+		i := sort.Search(len(s.history), func(i int) bool {
+			return s.history[i].Kv.ModRevision >= rev
+		})
+		w.Push(s.history[i:])
+	}
+	s.watchers[w] = struct{}{}
+	return nil
+}
+
+func (s *Registry) removeWatcher(w *kvstore.Watcher) {
+	s.mu.Lock()
+	delete(s.watchers, w)
+	s.mu.Unlock()
+}
+
+func (s *Registry) publish(evts []mvccpb.Event) {
+	s.history = append(s.history, evts...)
+	if l := len(s.history); l > maxHistoryEvents {
+		s.compactHistory(s.history[l-maxHistoryEvents-1].Kv.ModRevision)
+	}
+	for w := range s.watchers {
+		w.Push(evts)
+	}
+}
+
+// compactHistory removes the events whose revision is less than or equal to rev
+func (s *Registry) compactHistory(rev int64) {
+	i := sort.Search(len(s.history), func(i int) bool {
+		return s.history[i].Kv.ModRevision > rev
+	})
+	s.history = append([]mvccpb.Event(nil), s.history[i:]...)
+	s.compactRev = rev
+}
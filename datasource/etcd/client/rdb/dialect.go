@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rdb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	// the supported database drivers
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const (
+	DriverSQLite   = "sqlite3"
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+)
+
+// dialect describes the differences between the databases, the keys
+// must be saved in binary type to compare them byte by byte like etcd
+type dialect struct {
+	KeyType  string
+	BlobType string
+	// the placeholders are $1, $2... instead of ?
+	Numbered bool
+}
+
+var dialects = map[string]*dialect{
+	DriverSQLite:   {KeyType: "BLOB", BlobType: "BLOB"},
+	DriverMySQL:    {KeyType: "VARBINARY(1024)", BlobType: "LONGBLOB"},
+	DriverPostgres: {KeyType: "BYTEA", BlobType: "BYTEA", Numbered: true},
+}
+
+func getDialect(driver string) (*dialect, error) {
+	d, ok := dialects[driver]
+	if !ok {
+		return nil, fmt.Errorf("sql driver %s is not supported", driver)
+	}
+	return d, nil
+}
+
+// Rebind replaces the ? placeholders in query with the dialect ones
+func (d *dialect) Rebind(query string) string {
+	if !d.Numbered {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, c := range query {
+		if c != '?' {
+			b.WriteRune(c)
+			continue
+		}
+		n++
+		b.WriteByte('$')
+		b.WriteString(strconv.Itoa(n))
+	}
+	return b.String()
+}
+
+// DDL fills the column types of the statement
+func (d *dialect) DDL(stmt string) string {
+	return strings.NewReplacer("{{key}}", d.KeyType, "{{blob}}", d.BlobType).Replace(stmt)
+}
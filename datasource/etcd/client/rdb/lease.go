@@ -0,0 +1,196 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rdb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
+	errorsEx "github.com/apache/servicecomb-service-center/pkg/errors"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+)
+
+// the interval of querying the expired leases in sc_lease
+const leaseCheckInterval = 500 * time.Millisecond
+
+func expireAt(ttl int64) int64 {
+	return time.Now().Add(time.Duration(ttl) * time.Second).UnixNano()
+}
+
+func (s *Registry) LeaseGrant(ctx context.Context, TTL int64) (int64, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, errorsEx.Internal(err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	t := &txn{s: s, tx: tx, ctx: ctx}
+	var id int64
+	if _, err = t.exec("UPDATE sc_meta SET lease_id = lease_id + 1 WHERE id = 1"); err != nil {
+		return 0, errorsEx.Internal(err)
+	}
+	if err = t.queryRow("SELECT lease_id FROM sc_meta WHERE id = 1").Scan(&id); err != nil {
+		return 0, errorsEx.Internal(err)
+	}
+	if _, err = t.exec("INSERT INTO sc_lease (id, ttl, expire_at) VALUES (?, ?, ?)", id, TTL, expireAt(TTL)); err != nil {
+		return 0, errorsEx.Internal(err)
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, errorsEx.Internal(err)
+	}
+	return id, nil
+}
+
+func (s *Registry) LeaseRenew(ctx context.Context, leaseID int64) (int64, error) {
+	var ttl int64
+	err := s.DB.QueryRowContext(ctx, s.dialect.Rebind("SELECT ttl FROM sc_lease WHERE id = ?"), leaseID).Scan(&ttl)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, rpctypes.ErrLeaseNotFound
+		}
+		return 0, errorsEx.Internal(err)
+	}
+	result, err := s.DB.ExecContext(ctx, s.dialect.Rebind("UPDATE sc_lease SET expire_at = ? WHERE id = ?"),
+		expireAt(ttl), leaseID)
+	if err != nil {
+		return 0, errorsEx.Internal(err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		// revoked by others after the ttl was read
+		return 0, rpctypes.ErrLeaseNotFound
+	}
+	return ttl, nil
+}
+
+func (s *Registry) LeaseRevoke(ctx context.Context, leaseID int64) error {
+	_, err := s.update(ctx, func(t *txn) error {
+		return t.revoke(leaseID, 0)
+	})
+	return err
+}
+
+// revokeExpired revokes the lease only if it is still expired in the txn,
+// as it may be renewed after it was selected by expiredLeases
+func (s *Registry) revokeExpired(ctx context.Context, leaseID int64) error {
+	_, err := s.update(ctx, func(t *txn) error {
+		return t.revoke(leaseID, time.Now().UnixNano())
+	})
+	return err
+}
+
+// revoke removes the lease with the attached keys, the lease must expire
+// before the expireBefore time if it is greater than 0
+func (t *txn) revoke(leaseID int64, expireBefore int64) error {
+	query, args := "DELETE FROM sc_lease WHERE id = ?", []interface{}{leaseID}
+	if expireBefore > 0 {
+		query += " AND expire_at < ?"
+		args = append(args, expireBefore)
+	}
+	result, err := t.exec(query, args...)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return rpctypes.ErrLeaseNotFound
+	}
+
+	rows, err := t.query("SELECT k FROM sc_kv WHERE lease = ?", leaseID)
+	if err != nil {
+		return err
+	}
+	var keys [][]byte
+	for rows.Next() {
+		var key []byte
+		if err := rows.Scan(&key); err != nil {
+			rows.Close()
+			return err
+		}
+		keys = append(keys, key)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := t.delete(client.PluginOp{Key: key}); err != nil {
+			return err
+		}
+	}
+	t.dirty = true
+	return nil
+}
+
+func (t *txn) leaseExist(leaseID int64) (bool, error) {
+	var count int64
+	err := t.queryRow("SELECT COUNT(*) FROM sc_lease WHERE id = ?", leaseID).Scan(&count)
+	return count > 0, err
+}
+
+func (s *Registry) expireLeases(ctx context.Context) {
+	ticker := time.NewTicker(leaseCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			ids, err := s.expiredLeases(ctx)
+			if err != nil {
+				log.Error("query expired leases failed", err)
+				continue
+			}
+			for _, id := range ids {
+				// other service centers may revoke or renew the same lease
+				err := s.revokeExpired(ctx, id)
+				if err != nil && err != rpctypes.ErrLeaseNotFound {
+					log.Errorf(err, "revoke expired lease[%d] failed", id)
+				}
+			}
+		}
+	}
+}
+
+func (s *Registry) expiredLeases(ctx context.Context) ([]int64, error) {
+	rows, err := s.DB.QueryContext(ctx, s.dialect.Rebind("SELECT id FROM sc_lease WHERE expire_at < ?"),
+		time.Now().UnixNano())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,119 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rdb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/apache/servicecomb-service-center/pkg/log"
+)
+
+type migration struct {
+	Version    int64
+	Statements []string
+}
+
+// migrations are applied in order and never changed once released,
+// add a new version to change the schema
+var migrations = []migration{
+	{
+		Version: 1,
+		Statements: []string{
+			`CREATE TABLE sc_meta (
+				id INTEGER NOT NULL PRIMARY KEY,
+				revision BIGINT NOT NULL,
+				compact_revision BIGINT NOT NULL,
+				lease_id BIGINT NOT NULL)`,
+			`INSERT INTO sc_meta (id, revision, compact_revision, lease_id) VALUES (1, 0, 0, 0)`,
+			`CREATE TABLE sc_kv (
+				k {{key}} NOT NULL PRIMARY KEY,
+				v {{blob}},
+				create_revision BIGINT NOT NULL,
+				mod_revision BIGINT NOT NULL,
+				version BIGINT NOT NULL,
+				lease BIGINT NOT NULL)`,
+			`CREATE INDEX idx_sc_kv_lease ON sc_kv (lease)`,
+			`CREATE TABLE sc_lease (
+				id BIGINT NOT NULL PRIMARY KEY,
+				ttl BIGINT NOT NULL,
+				expire_at BIGINT NOT NULL)`,
+			`CREATE INDEX idx_sc_lease_expire_at ON sc_lease (expire_at)`,
+			`CREATE TABLE sc_event (
+				revision BIGINT NOT NULL,
+				seq INTEGER NOT NULL,
+				action INTEGER NOT NULL,
+				k {{key}} NOT NULL,
+				v {{blob}},
+				create_revision BIGINT NOT NULL,
+				mod_revision BIGINT NOT NULL,
+				version BIGINT NOT NULL,
+				lease BIGINT NOT NULL,
+				PRIMARY KEY (revision, seq))`,
+		},
+	},
+}
+
+func (s *Registry) migrate(ctx context.Context) error {
+	_, err := s.DB.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS sc_schema_migrations (
+		version BIGINT NOT NULL PRIMARY KEY,
+		applied_at BIGINT NOT NULL)`)
+	if err != nil {
+		return err
+	}
+
+	var current sql.NullInt64
+	err = s.DB.QueryRowContext(ctx, "SELECT MAX(version) FROM sc_schema_migrations").Scan(&current)
+	if err != nil {
+		return err
+	}
+	for _, m := range migrations {
+		if m.Version <= current.Int64 {
+			continue
+		}
+		if err := s.applyMigration(ctx, m); err != nil {
+			log.Errorf(err, "apply schema migration %d failed", m.Version)
+			return err
+		}
+		log.Infof("applied schema migration %d", m.Version)
+	}
+	return nil
+}
+
+func (s *Registry) applyMigration(ctx context.Context, m migration) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	for _, stmt := range m.Statements {
+		if _, err := tx.ExecContext(ctx, s.dialect.DDL(stmt)); err != nil {
+			return err
+		}
+	}
+	_, err = tx.ExecContext(ctx, s.dialect.Rebind(
+		"INSERT INTO sc_schema_migrations (version, applied_at) VALUES (?, ?)"),
+		m.Version, time.Now().Unix())
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
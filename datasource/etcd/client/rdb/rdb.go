@@ -0,0 +1,487 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rdb
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client/kvstore"
+	errorsEx "github.com/apache/servicecomb-service-center/pkg/errors"
+	"github.com/apache/servicecomb-service-center/pkg/gopool"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/server/config"
+)
+
+const (
+	DefaultDSN          = "data/sc.db?_busy_timeout=5000"
+	DefaultPollInterval = 500 * time.Millisecond
+	// the timeout of connecting and migrating the database
+	initTimeout = 30 * time.Second
+)
+
+func init() {
+	client.Install("sql", NewRegistry)
+}
+
+type Options struct {
+	Driver string
+	DSN    string
+	// PollInterval is the interval of fetching the events changed by
+	// other service centers, the local changes are fetched immediately
+	PollInterval time.Duration
+}
+
+// Registry is a registry client which keeps the data in a relational
+// database, all the service centers share the revision, leases and the
+// change feed of the same database, so it works like an etcd cluster
+type Registry struct {
+	DB      *sql.DB
+	Options Options
+
+	dialect   *dialect
+	err       chan error
+	ready     chan struct{}
+	closed    chan struct{}
+	closeOnce sync.Once
+	goroutine *gopool.Pool
+	changed   chan struct{}
+
+	// mu protects the watchers and the polled revision
+	mu       sync.Mutex
+	watchers map[*kvstore.Watcher]struct{}
+	polled   int64
+}
+
+func (s *Registry) Err() <-chan error {
+	return s.err
+}
+
+func (s *Registry) Ready() <-chan struct{} {
+	return s.ready
+}
+
+func (s *Registry) Close() {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+		s.goroutine.Close(true)
+		if s.DB != nil {
+			if err := s.DB.Close(); err != nil {
+				log.Error("close sql registry failed", err)
+			}
+		}
+		log.Debugf("sql registry client stopped")
+	})
+}
+
+func (s *Registry) PutNoOverride(ctx context.Context, opts ...client.PluginOpOption) (bool, error) {
+	return kvstore.PutNoOverride(ctx, s, opts...)
+}
+
+func (s *Registry) Do(ctx context.Context, opts ...client.PluginOpOption) (*client.PluginResponse, error) {
+	op := client.OptionsToOp(opts...)
+
+	if op.Action == client.ActionGet {
+		return s.get(ctx, op)
+	}
+
+	rev, err := s.update(ctx, func(t *txn) error {
+		_, err := t.Do(op)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &client.PluginResponse{
+		Revision:  rev,
+		Succeeded: true,
+	}, nil
+}
+
+func (s *Registry) Txn(ctx context.Context, opts []client.PluginOp) (*client.PluginResponse, error) {
+	resp, err := s.TxnWithCmp(ctx, opts, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &client.PluginResponse{
+		Succeeded: resp.Succeeded,
+		Revision:  resp.Revision,
+	}, nil
+}
+
+func (s *Registry) TxnWithCmp(ctx context.Context, success []client.PluginOp, cmps []client.CompareOp,
+	fail []client.PluginOp) (*client.PluginResponse, error) {
+	return kvstore.TxnWithCmp(func(fn func(t kvstore.Txn) error) (int64, error) {
+		return s.update(ctx, func(t *txn) error {
+			return fn(t)
+		})
+	}, success, cmps, fail)
+}
+
+// Compact deletes the events of the change feed, the sc_kv table never
+// saves the history revisions
+func (s *Registry) Compact(ctx context.Context, reserve int64) error {
+	_, err := s.update(ctx, func(t *txn) error {
+		var compacted int64
+		if err := t.queryRow("SELECT compact_revision FROM sc_meta WHERE id = 1").Scan(&compacted); err != nil {
+			return err
+		}
+		curRev := t.rev - 1
+		revToCompact := curRev - reserve
+		if revToCompact <= compacted {
+			log.Infof("revision is %d, <=%d, no nead to compact", curRev, reserve)
+			return nil
+		}
+		if _, err := t.exec("DELETE FROM sc_event WHERE revision <= ?", revToCompact); err != nil {
+			return err
+		}
+		if _, err := t.exec("UPDATE sc_meta SET compact_revision = ? WHERE id = 1", revToCompact); err != nil {
+			return err
+		}
+		t.dirty = true
+		log.Infof("compacted, revision is %d(current: %d, reserve %d)", revToCompact, curRev, reserve)
+		return nil
+	})
+	return err
+}
+
+func (s *Registry) get(ctx context.Context, op client.PluginOp) (*client.PluginResponse, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errorsEx.Internal(err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	t := &txn{s: s, tx: tx, ctx: ctx}
+	resp := &client.PluginResponse{Succeeded: true}
+	if err := t.queryRow("SELECT revision FROM sc_meta WHERE id = 1").Scan(&resp.Revision); err != nil {
+		return nil, errorsEx.Internal(err)
+	}
+	resp.Kvs, resp.Count, err = t.rangeKVs(op)
+	if err != nil {
+		return nil, errorsEx.Internal(err)
+	}
+	return resp, nil
+}
+
+// update runs fn in a write transaction, the transactions are serialized
+// by the lock of the sc_meta row, so the revisions are committed in order
+func (s *Registry) update(ctx context.Context, fn func(t *txn) error) (int64, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, errorsEx.Internal(err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	t := &txn{s: s, tx: tx, ctx: ctx}
+	if _, err := t.exec("UPDATE sc_meta SET revision = revision WHERE id = 1"); err != nil {
+		return 0, errorsEx.Internal(err)
+	}
+	var rev int64
+	if err := t.queryRow("SELECT revision FROM sc_meta WHERE id = 1").Scan(&rev); err != nil {
+		return 0, errorsEx.Internal(err)
+	}
+	t.rev = rev + 1
+
+	if err := fn(t); err != nil {
+		if _, ok := err.(rpctypes.EtcdError); ok {
+			return 0, err
+		}
+		return 0, errorsEx.Internal(err)
+	}
+	if t.seq > 0 {
+		if _, err := t.exec("UPDATE sc_meta SET revision = ? WHERE id = 1", t.rev); err != nil {
+			return 0, errorsEx.Internal(err)
+		}
+		rev = t.rev
+	}
+	if t.seq == 0 && !t.dirty {
+		return rev, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, errorsEx.Internal(err)
+	}
+	if t.seq > 0 {
+		s.notifyChanged()
+	}
+	return rev, nil
+}
+
+// txn is a database transaction, all the changes in it share the same revision
+type txn struct {
+	s   *Registry
+	tx  *sql.Tx
+	ctx context.Context
+	rev int64
+	// the sequence of the events in this revision
+	seq int
+	// the transaction changed something but generated no event
+	dirty bool
+}
+
+func (t *txn) exec(query string, args ...interface{}) (sql.Result, error) {
+	return t.tx.ExecContext(t.ctx, t.s.dialect.Rebind(query), args...)
+}
+
+func (t *txn) query(query string, args ...interface{}) (*sql.Rows, error) {
+	return t.tx.QueryContext(t.ctx, t.s.dialect.Rebind(query), args...)
+}
+
+func (t *txn) queryRow(query string, args ...interface{}) *sql.Row {
+	return t.tx.QueryRowContext(t.ctx, t.s.dialect.Rebind(query), args...)
+}
+
+func (t *txn) Do(op client.PluginOp) (*client.PluginResponse, error) {
+	switch op.Action {
+	case client.ActionGet:
+		kvs, count, err := t.rangeKVs(op)
+		if err != nil {
+			return nil, err
+		}
+		return &client.PluginResponse{Kvs: kvs, Count: count}, nil
+	case client.ActionPut:
+		return &client.PluginResponse{}, t.put(op)
+	case client.ActionDelete:
+		return &client.PluginResponse{}, t.delete(op)
+	}
+	return &client.PluginResponse{}, nil
+}
+
+func (t *txn) put(op client.PluginOp) error {
+	prev, err := t.Get(op.Key)
+	if err != nil {
+		return err
+	}
+	kv, err := kvstore.NextKV(prev, op, t.rev)
+	if err != nil {
+		return err
+	}
+	if !op.IgnoreLease && op.Lease > 0 {
+		ok, err := t.leaseExist(op.Lease)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return rpctypes.ErrLeaseNotFound
+		}
+	}
+
+	if prev == nil {
+		_, err = t.exec(`INSERT INTO sc_kv (k, v, create_revision, mod_revision, version, lease)
+			VALUES (?, ?, ?, ?, ?, ?)`, kv.Key, kv.Value, kv.CreateRevision, kv.ModRevision, kv.Version, kv.Lease)
+	} else {
+		_, err = t.exec(`UPDATE sc_kv SET v = ?, mod_revision = ?, version = ?, lease = ? WHERE k = ?`,
+			kv.Value, kv.ModRevision, kv.Version, kv.Lease, kv.Key)
+	}
+	if err != nil {
+		return err
+	}
+	return t.addEvent(mvccpb.PUT, kv)
+}
+
+func (t *txn) delete(op client.PluginOp) error {
+	prevs, _, err := t.rangeKVs(client.PluginOp{Key: op.Key, EndKey: op.EndKey, Prefix: op.Prefix})
+	if err != nil || len(prevs) == 0 {
+		return err
+	}
+	cond, args := rangeCond(op)
+	if _, err := t.exec("DELETE FROM sc_kv WHERE "+cond, args...); err != nil {
+		return err
+	}
+	for _, prev := range prevs {
+		if err := t.addEvent(mvccpb.DELETE, prev); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addEvent appends the change to the feed, the kv of the DELETE event is
+// the previous one
+func (t *txn) addEvent(action mvccpb.Event_EventType, kv *mvccpb.KeyValue) error {
+	_, err := t.exec(`INSERT INTO sc_event (revision, seq, action, k, v, create_revision, mod_revision, version, lease)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		t.rev, t.seq, int32(action), kv.Key, kv.Value, kv.CreateRevision, kv.ModRevision, kv.Version, kv.Lease)
+	if err != nil {
+		return err
+	}
+	t.seq++
+	return nil
+}
+
+func (t *txn) Get(key []byte) (*mvccpb.KeyValue, error) {
+	if len(key) == 0 {
+		return nil, nil
+	}
+	kvs, _, err := t.rangeKVs(client.PluginOp{Key: key})
+	if err != nil || len(kvs) == 0 {
+		return nil, err
+	}
+	return kvs[0], nil
+}
+
+// rangeKVs queries the sc_kv rows matching the range condition of op
+func (t *txn) rangeKVs(op client.PluginOp) ([]*mvccpb.KeyValue, int64, error) {
+	cond, args := rangeCond(op)
+	if op.CountOnly {
+		var count int64
+		err := t.queryRow("SELECT COUNT(*) FROM sc_kv WHERE "+cond, args...).Scan(&count)
+		return nil, count, err
+	}
+
+	orderBy := " ORDER BY k"
+	if op.OrderBy == client.OrderByCreate && op.SortOrder != client.SortNone {
+		orderBy = " ORDER BY create_revision"
+	}
+	if op.SortOrder == client.SortDescend {
+		orderBy += " DESC"
+	}
+	rows, err := t.query("SELECT k, v, create_revision, mod_revision, version, lease FROM sc_kv WHERE "+
+		cond+orderBy, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var kvs []*mvccpb.KeyValue
+	for rows.Next() {
+		kv := &mvccpb.KeyValue{}
+		if err := rows.Scan(&kv.Key, &kv.Value, &kv.CreateRevision, &kv.ModRevision, &kv.Version, &kv.Lease); err != nil {
+			return nil, 0, err
+		}
+		if op.KeyOnly {
+			kv.Value = nil
+		}
+		kvs = append(kvs, kv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return kvs, int64(len(kvs)), nil
+}
+
+func rangeCond(op client.PluginOp) (string, []interface{}) {
+	key := op.Key
+	if key == nil {
+		key = []byte{}
+	}
+	end := op.EndKey
+	if op.Prefix {
+		end = kvstore.PrefixEnd(key)
+	}
+	switch {
+	case len(end) == 0:
+		return "k = ?", []interface{}{key}
+	case len(end) == 1 && end[0] == 0:
+		// the end "\x00" means no limit
+		return "k >= ?", []interface{}{key}
+	default:
+		return "k >= ? AND k < ?", []interface{}{key, end}
+	}
+}
+
+func (s *Registry) open(ctx context.Context) error {
+	d, err := getDialect(s.Options.Driver)
+	if err != nil {
+		return err
+	}
+	s.dialect = d
+
+	if s.Options.Driver == DriverSQLite {
+		// create the directory of the database file
+		path := strings.SplitN(strings.TrimPrefix(s.Options.DSN, "file:"), "?", 2)[0]
+		if dir := filepath.Dir(path); path != ":memory:" && dir != "." {
+			if err := os.MkdirAll(dir, 0750); err != nil {
+				return err
+			}
+		}
+	}
+	db, err := sql.Open(s.Options.Driver, s.Options.DSN)
+	if err != nil {
+		return err
+	}
+	s.DB = db
+	if s.Options.Driver == DriverSQLite {
+		// sqlite allows only one writer, avoid the database is locked error
+		db.SetMaxOpenConns(1)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		return err
+	}
+	if err := s.migrate(ctx); err != nil {
+		return err
+	}
+	return db.QueryRowContext(ctx, "SELECT revision FROM sc_meta WHERE id = 1").Scan(&s.polled)
+}
+
+func NewRegistry(opts datasource.Options) client.Registry {
+	log.Warnf("enable sql registry mode")
+
+	return newRegistry(Options{
+		Driver:       config.GetString("registry.sql.driver", DriverSQLite),
+		DSN:          config.GetString("registry.sql.dsn", DefaultDSN),
+		PollInterval: config.GetDuration("registry.sql.pollInterval", DefaultPollInterval),
+	})
+}
+
+func newRegistry(opts Options) *Registry {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+	inst := &Registry{
+		Options:   opts,
+		err:       make(chan error, 1),
+		ready:     make(chan struct{}),
+		closed:    make(chan struct{}),
+		goroutine: gopool.New(context.Background()),
+		changed:   make(chan struct{}, 1),
+		watchers:  make(map[*kvstore.Watcher]struct{}),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), initTimeout)
+	defer cancel()
+	if err := inst.open(ctx); err != nil {
+		log.Errorf(err, "open sql registry[%s] failed", opts.Driver)
+		if inst.DB != nil {
+			_ = inst.DB.Close()
+			inst.DB = nil
+		}
+		inst.err <- err
+		return inst
+	}
+	log.Infof("sql registry[%s] is ready, current revision is %d", opts.Driver, inst.polled)
+
+	inst.goroutine.Do(inst.expireLeases)
+	inst.goroutine.Do(inst.poll)
+	close(inst.ready)
+	return inst
+}
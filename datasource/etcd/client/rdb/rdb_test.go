@@ -0,0 +1,412 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rdb
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
+)
+
+func newTestRegistry(t *testing.T) (*Registry, string) {
+	dir, err := ioutil.TempDir("", "sc-rdb")
+	assert.NoError(t, err)
+	path := filepath.Join(dir, "sc.db")
+	r := newRegistry(Options{Driver: DriverSQLite, DSN: path, PollInterval: 100 * time.Millisecond})
+	select {
+	case err := <-r.Err():
+		t.Fatal(err)
+	case <-r.Ready():
+	}
+	return r, path
+}
+
+func TestRegistry_Do(t *testing.T) {
+	r, path := newTestRegistry(t)
+	defer os.RemoveAll(filepath.Dir(path))
+	defer r.Close()
+	ctx := context.Background()
+
+	t.Run("put and get", func(t *testing.T) {
+		resp, err := r.Do(ctx, client.PUT, client.WithStrKey("/a/b"), client.WithStrValue("1"))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), resp.Revision)
+
+		resp, err = r.Do(ctx, client.PUT, client.WithStrKey("/a/c"), client.WithStrValue("2"))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), resp.Revision)
+
+		resp, err = r.Do(ctx, client.PUT, client.WithStrKey("/a/b"), client.WithStrValue("3"))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), resp.Revision)
+
+		resp, err = r.Do(ctx, client.GET, client.WithStrKey("/a/b"))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), resp.Revision)
+		assert.Equal(t, int64(1), resp.Count)
+		assert.Equal(t, "3", string(resp.Kvs[0].Value))
+		assert.Equal(t, int64(1), resp.Kvs[0].CreateRevision)
+		assert.Equal(t, int64(3), resp.Kvs[0].ModRevision)
+		assert.Equal(t, int64(2), resp.Kvs[0].Version)
+
+		resp, err = r.Do(ctx, client.GET, client.WithStrKey("/a/x"))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), resp.Count)
+	})
+
+	t.Run("get prefix", func(t *testing.T) {
+		_, err := r.Do(ctx, client.PUT, client.WithStrKey("/ab"), client.WithStrValue("4"))
+		assert.NoError(t, err)
+
+		resp, err := r.Do(ctx, client.GET, client.WithStrKey("/a/"), client.WithPrefix())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), resp.Count)
+		assert.Equal(t, "/a/b", string(resp.Kvs[0].Key))
+		assert.Equal(t, "/a/c", string(resp.Kvs[1].Key))
+
+		resp, err = r.Do(ctx, client.GET, client.WithStrKey("/a/"), client.WithPrefix(), client.WithCountOnly())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), resp.Count)
+		assert.Empty(t, resp.Kvs)
+
+		resp, err = r.Do(ctx, client.GET, client.WithStrKey("/a/"), client.WithPrefix(), client.WithKeyOnly())
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(resp.Kvs))
+		assert.Empty(t, resp.Kvs[0].Value)
+
+		resp, err = r.Do(ctx, client.GET, client.WithStrKey("/a/"), client.WithPrefix(), client.WithDescendOrder())
+		assert.NoError(t, err)
+		assert.Equal(t, "/a/c", string(resp.Kvs[0].Key))
+
+		resp, err = r.Do(ctx, client.GET, client.WithStrKey("/a/"), client.WithPrefix(),
+			client.WithOrderByCreate(), client.WithAscendOrder())
+		assert.NoError(t, err)
+		assert.Equal(t, "/a/b", string(resp.Kvs[0].Key))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		resp, err := r.Do(ctx, client.DEL, client.WithStrKey("/a/"), client.WithPrefix())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), resp.Revision)
+
+		resp, err = r.Do(ctx, client.GET, client.WithStrKey("/a"), client.WithPrefix())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), resp.Count)
+		assert.Equal(t, "/ab", string(resp.Kvs[0].Key))
+
+		// nothing changed, the revision is not increased
+		resp, err = r.Do(ctx, client.DEL, client.WithStrKey("/a/"), client.WithPrefix())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), resp.Revision)
+	})
+}
+
+func TestRegistry_TxnWithCmp(t *testing.T) {
+	r, path := newTestRegistry(t)
+	defer os.RemoveAll(filepath.Dir(path))
+	defer r.Close()
+	ctx := context.Background()
+
+	t.Run("put no override", func(t *testing.T) {
+		ok, err := r.PutNoOverride(ctx, client.WithStrKey("/lock"), client.WithStrValue("a"))
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = r.PutNoOverride(ctx, client.WithStrKey("/lock"), client.WithStrValue("b"))
+		assert.NoError(t, err)
+		assert.False(t, ok)
+
+		resp, err := r.Do(ctx, client.GET, client.WithStrKey("/lock"))
+		assert.NoError(t, err)
+		assert.Equal(t, "a", string(resp.Kvs[0].Value))
+	})
+
+	t.Run("compare value", func(t *testing.T) {
+		resp, err := r.TxnWithCmp(ctx,
+			[]client.PluginOp{client.OpPut(client.WithStrKey("/lock"), client.WithStrValue("c"))},
+			[]client.CompareOp{client.OpCmp(client.CmpStrVal("/lock"), client.CmpEqual, "b")},
+			[]client.PluginOp{client.OpGet(client.WithStrKey("/lock"))})
+		assert.NoError(t, err)
+		assert.False(t, resp.Succeeded)
+		assert.Equal(t, int64(1), resp.Count)
+		assert.Equal(t, "a", string(resp.Kvs[0].Value))
+
+		resp, err = r.TxnWithCmp(ctx,
+			[]client.PluginOp{client.OpPut(client.WithStrKey("/lock"), client.WithStrValue("c"))},
+			[]client.CompareOp{client.OpCmp(client.CmpStrVal("/lock"), client.CmpEqual, "a")},
+			nil)
+		assert.NoError(t, err)
+		assert.True(t, resp.Succeeded)
+
+		resp, err = r.TxnWithCmp(ctx, nil,
+			[]client.CompareOp{client.OpCmp(client.CmpStrVal("/none"), client.CmpNotEqual, "a")},
+			nil)
+		assert.NoError(t, err)
+		assert.False(t, resp.Succeeded)
+	})
+
+	t.Run("ignore lease", func(t *testing.T) {
+		resp, err := r.Txn(ctx, []client.PluginOp{
+			client.OpPut(client.WithStrKey("/none"), client.WithStrValue("a"), client.WithIgnoreLease()),
+		})
+		assert.NoError(t, err)
+		assert.False(t, resp.Succeeded)
+
+		resp, err = r.Do(ctx, client.GET, client.WithStrKey("/none"))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), resp.Count)
+	})
+}
+
+func TestRegistry_Lease(t *testing.T) {
+	r, path := newTestRegistry(t)
+	defer os.RemoveAll(filepath.Dir(path))
+	defer r.Close()
+	ctx := context.Background()
+
+	t.Run("put with lease not exist", func(t *testing.T) {
+		_, err := r.Do(ctx, client.PUT, client.WithStrKey("/inst"), client.WithLease(100))
+		assert.Equal(t, rpctypes.ErrLeaseNotFound, err)
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		id, err := r.LeaseGrant(ctx, 30)
+		assert.NoError(t, err)
+		_, err = r.Do(ctx, client.PUT, client.WithStrKey("/inst/1"), client.WithLease(id))
+		assert.NoError(t, err)
+
+		ttl, err := r.LeaseRenew(ctx, id)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(30), ttl)
+
+		// keep the lease
+		_, err = r.Do(ctx, client.PUT, client.WithStrKey("/inst/1"), client.WithStrValue("a"), client.WithIgnoreLease())
+		assert.NoError(t, err)
+
+		err = r.LeaseRevoke(ctx, id)
+		assert.NoError(t, err)
+		resp, err := r.Do(ctx, client.GET, client.WithStrKey("/inst/1"))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), resp.Count)
+
+		_, err = r.LeaseRenew(ctx, id)
+		assert.Equal(t, rpctypes.ErrLeaseNotFound, err)
+		err = r.LeaseRevoke(ctx, id)
+		assert.Equal(t, rpctypes.ErrLeaseNotFound, err)
+	})
+
+	t.Run("revoke the lease not expired as expired", func(t *testing.T) {
+		id, err := r.LeaseGrant(ctx, 30)
+		assert.NoError(t, err)
+		_, err = r.Do(ctx, client.PUT, client.WithStrKey("/inst/4"), client.WithLease(id))
+		assert.NoError(t, err)
+
+		err = r.revokeExpired(ctx, id)
+		assert.Equal(t, rpctypes.ErrLeaseNotFound, err)
+		resp, err := r.Do(ctx, client.GET, client.WithStrKey("/inst/4"))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), resp.Count)
+
+		assert.NoError(t, r.LeaseRevoke(ctx, id))
+	})
+
+	t.Run("expire", func(t *testing.T) {
+		id, err := r.LeaseGrant(ctx, 1)
+		assert.NoError(t, err)
+		_, err = r.Do(ctx, client.PUT, client.WithStrKey("/inst/2"), client.WithLease(id))
+		assert.NoError(t, err)
+		// re-attach to another lease
+		_, err = r.Do(ctx, client.PUT, client.WithStrKey("/inst/3"), client.WithLease(id))
+		assert.NoError(t, err)
+		_, err = r.Do(ctx, client.PUT, client.WithStrKey("/inst/3"))
+		assert.NoError(t, err)
+
+		time.Sleep(time.Second + 2*leaseCheckInterval)
+		resp, err := r.Do(ctx, client.GET, client.WithStrKey("/inst/"), client.WithPrefix())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), resp.Count)
+		assert.Equal(t, "/inst/3", string(resp.Kvs[0].Key))
+		_, err = r.LeaseRenew(ctx, id)
+		assert.Equal(t, rpctypes.ErrLeaseNotFound, err)
+	})
+}
+
+func TestRegistry_Watch(t *testing.T) {
+	r, path := newTestRegistry(t)
+	defer os.RemoveAll(filepath.Dir(path))
+	defer r.Close()
+	ctx := context.Background()
+
+	_, err := r.Do(ctx, client.PUT, client.WithStrKey("/a/1"), client.WithStrValue("1"))
+	assert.NoError(t, err)
+
+	watch := func(rev int64) (chan *client.PluginResponse, context.CancelFunc, chan error) {
+		ch := make(chan *client.PluginResponse, 10)
+		errCh := make(chan error, 1)
+		wCtx, cancel := context.WithCancel(ctx)
+		go func() {
+			errCh <- r.Watch(wCtx, client.WithStrKey("/a"), client.WithPrefix(), client.WithRev(rev),
+				client.WithWatchCallback(func(message string, resp *client.PluginResponse) error {
+					ch <- resp
+					return nil
+				}))
+		}()
+		return ch, cancel, errCh
+	}
+
+	t.Run("watch from now", func(t *testing.T) {
+		ch, cancel, errCh := watch(0)
+		defer cancel()
+		time.Sleep(100 * time.Millisecond)
+
+		_, err := r.Do(ctx, client.PUT, client.WithStrKey("/ab"), client.WithStrValue("x"))
+		assert.NoError(t, err)
+		_, err = r.Do(ctx, client.PUT, client.WithStrKey("/a/2"), client.WithStrValue("2"))
+		assert.NoError(t, err)
+		resp := <-ch
+		assert.Equal(t, client.ActionPut, resp.Action)
+		assert.Equal(t, int64(3), resp.Revision)
+		assert.Equal(t, "/a/2", string(resp.Kvs[0].Key))
+
+		_, err = r.Do(ctx, client.DEL, client.WithStrKey("/a/2"))
+		assert.NoError(t, err)
+		resp = <-ch
+		assert.Equal(t, client.ActionDelete, resp.Action)
+		assert.Equal(t, int64(4), resp.Revision)
+		assert.Equal(t, "2", string(resp.Kvs[0].Value))
+
+		cancel()
+		assert.NoError(t, <-errCh)
+	})
+
+	t.Run("watch from revision", func(t *testing.T) {
+		ch, cancel, _ := watch(2)
+		defer cancel()
+
+		resp := <-ch
+		assert.Equal(t, client.ActionPut, resp.Action)
+		assert.Equal(t, int64(3), resp.Revision)
+		resp = <-ch
+		assert.Equal(t, client.ActionDelete, resp.Action)
+		assert.Equal(t, int64(4), resp.Revision)
+	})
+
+	t.Run("watch compacted revision", func(t *testing.T) {
+		err := r.Compact(ctx, 1)
+		assert.NoError(t, err)
+
+		_, _, errCh := watch(2)
+		assert.Equal(t, rpctypes.ErrCompacted, <-errCh)
+	})
+}
+
+func TestRegistry_Reopen(t *testing.T) {
+	r, path := newTestRegistry(t)
+	defer os.RemoveAll(filepath.Dir(path))
+	ctx := context.Background()
+
+	id, err := r.LeaseGrant(ctx, 30)
+	assert.NoError(t, err)
+	_, err = r.Do(ctx, client.PUT, client.WithStrKey("/inst/1"), client.WithLease(id))
+	assert.NoError(t, err)
+	_, err = r.Do(ctx, client.PUT, client.WithStrKey("/svc/1"), client.WithStrValue("a"))
+	assert.NoError(t, err)
+	r.Close()
+
+	r = newRegistry(Options{Driver: DriverSQLite, DSN: path})
+	<-r.Ready()
+	defer r.Close()
+
+	resp, err := r.Do(ctx, client.GET, client.WithStrKey("/svc/1"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), resp.Revision)
+	assert.Equal(t, "a", string(resp.Kvs[0].Value))
+
+	newID, err := r.LeaseGrant(ctx, 30)
+	assert.NoError(t, err)
+	assert.True(t, newID > id)
+
+	err = r.LeaseRevoke(ctx, id)
+	assert.NoError(t, err)
+	resp, err = r.Do(ctx, client.GET, client.WithStrKey("/inst/1"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), resp.Count)
+}
+
+func TestRegistry_ChangeFeed(t *testing.T) {
+	r1, path := newTestRegistry(t)
+	defer os.RemoveAll(filepath.Dir(path))
+	defer r1.Close()
+	// another service center shares the same database
+	r2 := newRegistry(Options{Driver: DriverSQLite, DSN: path + "?_busy_timeout=5000", PollInterval: 100 * time.Millisecond})
+	<-r2.Ready()
+	defer r2.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := make(chan *client.PluginResponse, 10)
+	go func() {
+		_ = r2.Watch(ctx, client.WithStrKey("/inst"), client.WithPrefix(),
+			client.WithWatchCallback(func(message string, resp *client.PluginResponse) error {
+				ch <- resp
+				return nil
+			}))
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	id, err := r1.LeaseGrant(ctx, 30)
+	assert.NoError(t, err)
+	_, err = r1.Do(ctx, client.PUT, client.WithStrKey("/inst/1"), client.WithStrValue("a"), client.WithLease(id))
+	assert.NoError(t, err)
+	select {
+	case resp := <-ch:
+		assert.Equal(t, client.ActionPut, resp.Action)
+		assert.Equal(t, "a", string(resp.Kvs[0].Value))
+	case <-time.After(5 * time.Second):
+		t.Fatal("TestRegistry_ChangeFeed timed out")
+	}
+
+	// the lease is shared
+	err = r2.LeaseRevoke(ctx, id)
+	assert.NoError(t, err)
+	select {
+	case resp := <-ch:
+		assert.Equal(t, client.ActionDelete, resp.Action)
+		assert.Equal(t, "/inst/1", string(resp.Kvs[0].Key))
+	case <-time.After(5 * time.Second):
+		t.Fatal("TestRegistry_ChangeFeed timed out")
+	}
+}
+
+func TestDialect_Rebind(t *testing.T) {
+	query := "SELECT k FROM sc_kv WHERE k >= ? AND k < ?"
+	assert.Equal(t, query, dialects[DriverSQLite].Rebind(query))
+	assert.Equal(t, query, dialects[DriverMySQL].Rebind(query))
+	assert.Equal(t, "SELECT k FROM sc_kv WHERE k >= $1 AND k < $2", dialects[DriverPostgres].Rebind(query))
+
+	_, err := getDialect("oracle")
+	assert.Error(t, err)
+}
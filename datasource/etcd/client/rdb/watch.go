@@ -0,0 +1,157 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rdb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/coreos/etcd/etcdserver/api/v3rpc/rpctypes"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client/kvstore"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+)
+
+var ErrClosed = errors.New("sql registry is closed")
+
+func (s *Registry) Watch(ctx context.Context, opts ...client.PluginOpOption) error {
+	op := client.OpGet(opts...)
+	w, err := kvstore.NewWatcher(op)
+	if err != nil {
+		return err
+	}
+	if err := s.addWatcher(ctx, w, op.Revision); err != nil {
+		return err
+	}
+	defer s.removeWatcher(w)
+
+	return w.Watch(ctx, s.closed, ErrClosed, op.WatchCallback)
+}
+
+// addWatcher registers the watcher and replays the change feed from rev to
+// the polled revision, the later events are pushed by the poller
+func (s *Registry) addWatcher(ctx context.Context, w *kvstore.Watcher, rev int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var current, compacted int64
+	err := s.DB.QueryRowContext(ctx, "SELECT revision, compact_revision FROM sc_meta WHERE id = 1").
+		Scan(&current, &compacted)
+	if err != nil {
+		return err
+	}
+	w.Start = current + 1
+	if rev > 0 {
+		w.Start = rev
+		if rev <= compacted {
+			return rpctypes.ErrCompacted
+		}
+		if rev <= s.polled {
+			evts, err := s.events(ctx, "revision >= ? AND revision <= ?", rev, s.polled)
+			if err != nil {
+				return err
+			}
+			w.Push(evts)
+		}
+	}
+	s.watchers[w] = struct{}{}
+	return nil
+}
+
+func (s *Registry) removeWatcher(w *kvstore.Watcher) {
+	s.mu.Lock()
+	delete(s.watchers, w)
+	s.mu.Unlock()
+}
+
+func (s *Registry) notifyChanged() {
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
+
+// poll fetches the change feed periodically, or immediately after the
+// local changes committed
+func (s *Registry) poll(ctx context.Context) {
+	ticker := time.NewTicker(s.Options.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.changed:
+		}
+		if err := s.fetch(ctx); err != nil && ctx.Err() == nil {
+			log.Error("fetch the change feed failed", err)
+		}
+	}
+}
+
+func (s *Registry) fetch(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	evts, err := s.events(ctx, "revision > ?", s.polled)
+	if err != nil {
+		return err
+	}
+	if len(evts) == 0 {
+		return nil
+	}
+	for w := range s.watchers {
+		w.Push(evts)
+	}
+	s.polled = evts[len(evts)-1].Kv.ModRevision
+	return nil
+}
+
+// events returns the events matching the condition in revision order
+func (s *Registry) events(ctx context.Context, cond string, args ...interface{}) ([]mvccpb.Event, error) {
+	rows, err := s.DB.QueryContext(ctx, s.dialect.Rebind(
+		"SELECT revision, action, k, v, create_revision, mod_revision, version, lease FROM sc_event WHERE "+
+			cond+" ORDER BY revision, seq"), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var evts []mvccpb.Event
+	for rows.Next() {
+		var (
+			rev    int64
+			action int32
+		)
+		kv := &mvccpb.KeyValue{}
+		err := rows.Scan(&rev, &action, &kv.Key, &kv.Value, &kv.CreateRevision, &kv.ModRevision, &kv.Version, &kv.Lease)
+		if err != nil {
+			return nil, err
+		}
+		evt := mvccpb.Event{Type: mvccpb.Event_EventType(action), Kv: kv}
+		if evt.Type == mvccpb.DELETE {
+			evt.PrevKv = kv
+			evt.Kv = &mvccpb.KeyValue{Key: kv.Key, ModRevision: rev}
+		}
+		evts = append(evts, evt)
+	}
+	return evts, rows.Err()
+}
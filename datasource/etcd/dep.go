@@ -127,6 +127,16 @@ func (dm *DepManager) DependencyHandle(ctx context.Context) error {
 	return nil
 }
 
+func (dm *DepManager) GetConsumerDependencyRules(ctx context.Context, consumer *pb.MicroService) ([]*pb.MicroServiceKey, error) {
+	domainProject := util.ParseDomainProject(ctx)
+	key := path.GenerateConsumerDependencyRuleKey(domainProject, pb.MicroServiceToKey(domainProject, consumer))
+	dependency, err := serviceUtil.TransferToMicroServiceDependency(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return dependency.Dependency, nil
+}
+
 func (dm *DepManager) AddOrUpdateDependencies(ctx context.Context, dependencyInfos []*pb.ConsumerDependency, override bool) (*pb.Response, error) {
 	opts := make([]client.PluginOp, 0, len(dependencyInfos))
 	domainProject := util.ParseDomainProject(ctx)
@@ -38,23 +38,33 @@ func init() {
 	datasource.Install("etcd", NewDataSource)
 	datasource.Install("embeded_etcd", NewDataSource) //TODO remove misspell in future
 	datasource.Install("embedded_etcd", NewDataSource)
+	datasource.Install("local", NewDataSource)
+	datasource.Install("sql", NewDataSource)
 }
 
 type DataSource struct {
 	accountLockManager datasource.AccountLockManager
 	accountManager     datasource.AccountManager
+	tokenManager       datasource.TokenManager
 	metadataManager    datasource.MetadataManager
 	roleManager        datasource.RoleManager
 	sysManager         datasource.SystemManager
 	depManager         datasource.DependencyManager
 	scManager          datasource.SCManager
 	metricsManager     datasource.MetricsManager
+	alarmManager       datasource.AlarmManager
+	quotaManager       datasource.QuotaManager
+	brokerManager      datasource.BrokerManager
 }
 
 func (ds *DataSource) AccountLockManager() datasource.AccountLockManager {
 	return ds.accountLockManager
 }
 
+func (ds *DataSource) TokenManager() datasource.TokenManager {
+	return ds.tokenManager
+}
+
 func (ds *DataSource) SystemManager() datasource.SystemManager {
 	return ds.sysManager
 }
@@ -83,6 +93,18 @@ func (ds *DataSource) MetricsManager() datasource.MetricsManager {
 	return ds.metricsManager
 }
 
+func (ds *DataSource) AlarmManager() datasource.AlarmManager {
+	return ds.alarmManager
+}
+
+func (ds *DataSource) QuotaManager() datasource.QuotaManager {
+	return ds.quotaManager
+}
+
+func (ds *DataSource) BrokerManager() datasource.BrokerManager {
+	return ds.brokerManager
+}
+
 func NewDataSource(opts datasource.Options) (datasource.DataSource, error) {
 	// TODO: construct a reasonable DataSource instance
 	log.Warnf("data source enable etcd mode")
@@ -100,12 +122,16 @@ func NewDataSource(opts datasource.Options) (datasource.DataSource, error) {
 	}
 	inst.accountManager = &AccountManager{}
 	inst.accountLockManager = NewAccountLockManager(opts.ReleaseAccountAfter)
+	inst.tokenManager = &TokenManager{}
 	inst.roleManager = &RoleManager{}
 	inst.metadataManager = newMetadataManager(opts.SchemaNotEditable, opts.InstanceTTL)
 	inst.sysManager = newSysManager()
 	inst.depManager = &DepManager{}
 	inst.scManager = &SCManager{}
 	inst.metricsManager = &MetricsManager{}
+	inst.alarmManager = &AlarmManager{}
+	inst.quotaManager = &QuotaManager{}
+	inst.brokerManager = &BrokerManager{}
 	return inst, nil
 }
 
@@ -115,8 +141,10 @@ func (ds *DataSource) initialize(opts datasource.Options) error {
 	ds.initPlugins(opts)
 	// Add events handlers
 	event.Initialize()
+	initTombstones()
 	// Wait for kv store ready
 	ds.initKvStore()
+	beginTombstones()
 	// Compact
 	ds.autoCompact()
 	return nil
@@ -190,6 +190,8 @@ func (s *TypeStore) DependencyRule() sd.Adaptor     { return s.Adaptors(Dependen
 func (s *TypeStore) DependencyQueue() sd.Adaptor    { return s.Adaptors(DependencyQueue) }
 func (s *TypeStore) Domain() sd.Adaptor             { return s.Adaptors(DOMAIN) }
 func (s *TypeStore) Project() sd.Adaptor            { return s.Adaptors(PROJECT) }
+func (s *TypeStore) RevokedToken() sd.Adaptor       { return s.Adaptors(RevokedToken) }
+func (s *TypeStore) RevokedAccount() sd.Adaptor     { return s.Adaptors(RevokedAccount) }
 
 func Store() *TypeStore {
 	return store
@@ -48,6 +48,16 @@ var (
 	SchemaSummary   sd.Type
 	INSTANCE        sd.Type
 	LEASE           sd.Type
+	RevokedToken    sd.Type
+	RevokedAccount  sd.Type
+
+	BrokerParticipant  sd.Type
+	BrokerVersion      sd.Type
+	BrokerPact         sd.Type
+	BrokerPactVersion  sd.Type
+	BrokerPactTag      sd.Type
+	BrokerVerification sd.Type
+	BrokerLatest       sd.Type
 )
 
 func registerInnerTypes() {
@@ -94,4 +104,28 @@ func registerInnerTypes() {
 	PROJECT = Store().MustInstall(NewAddOn("PROJECT",
 		sd.Configure().WithPrefix(path.GetProjectRootKey("")).
 			WithInitSize(100).WithParser(value.StringParser)))
+	RevokedToken = Store().MustInstall(NewAddOn("REVOKED_TOKEN",
+		sd.Configure().WithPrefix(path.GenerateRevokedTokenKey("")).
+			WithInitSize(100)))
+	RevokedAccount = Store().MustInstall(NewAddOn("REVOKED_ACCOUNT",
+		sd.Configure().WithPrefix(path.GenerateRevokedAccountKey("")).
+			WithInitSize(100)))
+	registerBrokerTypes()
+}
+
+func registerBrokerTypes() {
+	BrokerParticipant = Store().MustInstall(NewAddOn("PARTICIPANT",
+		sd.Configure().WithPrefix(path.GetBrokerParticipantKey(""))))
+	BrokerVersion = Store().MustInstall(NewAddOn("VERSION",
+		sd.Configure().WithPrefix(path.GetBrokerVersionKey(""))))
+	BrokerPact = Store().MustInstall(NewAddOn("PACT",
+		sd.Configure().WithPrefix(path.GetBrokerPactKey(""))))
+	BrokerPactVersion = Store().MustInstall(NewAddOn("PACT_VERSION",
+		sd.Configure().WithPrefix(path.GetBrokerPactVersionKey(""))))
+	BrokerPactTag = Store().MustInstall(NewAddOn("PACT_TAG",
+		sd.Configure().WithPrefix(path.GetBrokerTagKey(""))))
+	BrokerVerification = Store().MustInstall(NewAddOn("VERIFICATION",
+		sd.Configure().WithPrefix(path.GetBrokerVerificationKey(""))))
+	BrokerLatest = Store().MustInstall(NewAddOn("PACT_LATEST",
+		sd.Configure().WithPrefix(path.GetBrokerLatestKey(""))))
 }
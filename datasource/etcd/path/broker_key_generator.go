@@ -15,7 +15,7 @@
  * limitations under the License.
  */
 
-package broker
+package path
 
 import (
 	"strconv"
@@ -160,42 +160,10 @@ func GenerateBrokerVerificationKey(tenant string, pactVersionID int32, number in
 	}, "/")
 }
 
-//GetBrokerLatestParticipantIDKey returns the latest participant ID
-func GetBrokerLatestParticipantIDKey() string {
+//GenerateBrokerLatestIDKey returns the latest ID key of the broker data kind
+func GenerateBrokerLatestIDKey(kind string) string {
 	return util.StringJoin([]string{
 		GetBrokerLatestKey("default"),
-		BrokerParticipantKey,
-	}, "/")
-}
-
-//GetBrokerLatestVersionIDKey returns latest version ID
-func GetBrokerLatestVersionIDKey() string {
-	return util.StringJoin([]string{
-		GetBrokerLatestKey("default"),
-		BrokerVersionKey,
-	}, "/")
-}
-
-//GetBrokerLatestPactIDKey returns latest pact ID
-func GetBrokerLatestPactIDKey() string {
-	return util.StringJoin([]string{
-		GetBrokerLatestKey("default"),
-		BrokerPactKey,
-	}, "/")
-}
-
-//GetBrokerLatestPactVersionIDKey returns lated pact version ID
-func GetBrokerLatestPactVersionIDKey() string {
-	return util.StringJoin([]string{
-		GetBrokerLatestKey("default"),
-		BrokerPactVersionKey,
-	}, "/")
-}
-
-//GetBrokerLatestVerificationIDKey returns the lastest verification ID
-func GetBrokerLatestVerificationIDKey() string {
-	return util.StringJoin([]string{
-		GetBrokerLatestKey("default"),
-		BrokerPactVerificationKey,
+		kind,
 	}, "/")
 }
@@ -18,6 +18,8 @@
 package path
 
 import (
+	"fmt"
+
 	"github.com/go-chassis/cari/discovery"
 
 	"github.com/apache/servicecomb-service-center/pkg/util"
@@ -44,6 +46,9 @@ const (
 	RegistryDepsRuleKey      = "dep-rules"
 	RegistryDepsQueueKey     = "dep-queue"
 	RegistryMetricsKey       = "metrics"
+	RegistryAlarmKey         = "alarms"
+	RegistryAlarmHistoryKey  = "alarm-histories"
+	RegistryQuotaKey         = "quotas"
 	DepsQueueUUID            = "0"
 	DepsConsumer             = "c"
 	DepsProvider             = "p"
@@ -368,6 +373,20 @@ func GenerateAccountLockKey(key string) string {
 		key,
 	}, SPLIT)
 }
+func GenerateRevokedTokenKey(tokenID string) string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		"revoked-tokens",
+		tokenID,
+	}, SPLIT)
+}
+func GenerateRevokedAccountKey(name string) string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		"revoked-accounts",
+		name,
+	}, SPLIT)
+}
 func GenerateRBACSecretKey() string {
 	return util.StringJoin([]string{
 		GetRootKey(),
@@ -396,3 +415,50 @@ func GenerateMetricsKey(name, utc, domain string) string {
 		domain,
 	}, SPLIT)
 }
+
+func GetAlarmRootKey() string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		RegistryAlarmKey,
+	}, SPLIT)
+}
+
+// GenerateAlarmKey the alarms are stored per node, so the nodes never overwrite each other
+func GenerateAlarmKey(node, id string) string {
+	return util.StringJoin([]string{
+		GetAlarmRootKey(),
+		node,
+		id,
+	}, SPLIT)
+}
+
+func GetAlarmHistoryRootKey() string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		RegistryAlarmHistoryKey,
+	}, SPLIT)
+}
+
+// GenerateAlarmHistoryKey the key is ordered by the timestamp in nanoseconds
+func GenerateAlarmHistoryKey(timestamp int64, id string) string {
+	return util.StringJoin([]string{
+		GetAlarmHistoryRootKey(),
+		fmt.Sprintf("%020d", timestamp),
+		id,
+	}, SPLIT)
+}
+
+func GetQuotaRootKey() string {
+	return util.StringJoin([]string{
+		GetRootKey(),
+		RegistryQuotaKey,
+	}, SPLIT)
+}
+
+func GenerateQuotaKey(domain, project string) string {
+	return util.StringJoin([]string{
+		GetQuotaRootKey(),
+		domain,
+		project,
+	}, SPLIT)
+}
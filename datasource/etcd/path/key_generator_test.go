@@ -41,9 +41,24 @@ func TestGenerateETCDDomainKey(t *testing.T) {
 func TestGenerateAccountKey(t *testing.T) {
 	assert.Equal(t, "/cse-sr/accounts/admin", path.GenerateAccountKey("admin"))
 }
+func TestGenerateRevokedTokenKey(t *testing.T) {
+	assert.Equal(t, "/cse-sr/revoked-tokens/1", path.GenerateRevokedTokenKey("1"))
+	assert.Equal(t, "/cse-sr/revoked-accounts/admin", path.GenerateRevokedAccountKey("admin"))
+}
 func TestGenerateAccountSecretKey(t *testing.T) {
 	assert.Equal(t, "/cse-sr/rbac/secret", path.GenerateRBACSecretKey())
 }
+func TestGenerateAlarmKey(t *testing.T) {
+	assert.Equal(t, "/cse-sr/alarms", path.GetAlarmRootKey())
+	assert.Equal(t, "/cse-sr/alarms/127.0.0.1:30100/InternalError",
+		path.GenerateAlarmKey("127.0.0.1:30100", "InternalError"))
+	assert.Equal(t, "/cse-sr/alarm-histories/00000000000000000001/InternalError",
+		path.GenerateAlarmHistoryKey(1, "InternalError"))
+}
+func TestGenerateQuotaKey(t *testing.T) {
+	assert.Equal(t, "/cse-sr/quotas", path.GetQuotaRootKey())
+	assert.Equal(t, "/cse-sr/quotas/default/default", path.GenerateQuotaKey("default", "default"))
+}
 func TestGenerateDependencyRuleKey(t *testing.T) {
 	// consumer
 	k := path.GenerateConsumerDependencyRuleKey("a", nil)
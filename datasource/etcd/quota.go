@@ -0,0 +1,87 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/path"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/quota/model"
+)
+
+type QuotaManager struct {
+}
+
+func (qm *QuotaManager) UpsertQuota(ctx context.Context, q *model.Quota) error {
+	value, err := json.Marshal(q)
+	if err != nil {
+		log.Error(fmt.Sprintf("quota[%s/%s] is invalid", q.Domain, q.Project), err)
+		return err
+	}
+	return client.PutBytes(ctx, path.GenerateQuotaKey(q.Domain, q.Project), value)
+}
+
+func (qm *QuotaManager) GetQuota(ctx context.Context, domain, project string) (*model.Quota, error) {
+	resp, err := client.Instance().Do(ctx, client.GET,
+		client.WithStrKey(path.GenerateQuotaKey(domain, project)))
+	if err != nil {
+		return nil, err
+	}
+	if resp.Count == 0 {
+		return nil, datasource.ErrQuotaNotExist
+	}
+	q := &model.Quota{}
+	err = json.Unmarshal(resp.Kvs[0].Value, q)
+	if err != nil {
+		log.Error(fmt.Sprintf("quota[%s/%s] format invalid", domain, project), err)
+		return nil, err
+	}
+	return q, nil
+}
+
+func (qm *QuotaManager) ListQuotas(ctx context.Context, domain string) ([]*model.Quota, error) {
+	prefix := path.GetQuotaRootKey() + path.SPLIT
+	if len(domain) > 0 {
+		prefix = path.GenerateQuotaKey(domain, "")
+	}
+	kvs, _, err := client.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	quotas := make([]*model.Quota, 0, len(kvs))
+	for _, kv := range kvs {
+		q := &model.Quota{}
+		if err := json.Unmarshal(kv.Value, q); err != nil {
+			log.Error(fmt.Sprintf("quota[%s] format invalid", kv.Key), err)
+			continue
+		}
+		quotas = append(quotas, q)
+	}
+	return quotas, nil
+}
+
+func (qm *QuotaManager) DeleteQuota(ctx context.Context, domain, project string) error {
+	_, err := client.Instance().Do(ctx, client.DEL,
+		client.WithStrKey(path.GenerateQuotaKey(domain, project)))
+	return err
+}
@@ -77,6 +77,40 @@ func (c *SCClientAggregate) GetScCache(ctx context.Context) (*dump.Cache, map[st
 	return caches, errs
 }
 
+// pullScCache pulls the changes after the last revision of the replicas
+// and returns the merged caches of all replicas pulled successfully
+func (c *SCClientAggregate) pullScCache(ctx context.Context, replicas map[string]*replica) (*dump.Cache, map[string]error) {
+	var caches *dump.Cache
+	errs := make(map[string]error)
+	for _, client := range *c {
+		r, ok := replicas[client.Cfg.Name]
+		if !ok {
+			r = newReplica()
+			replicas[client.Cfg.Name] = r
+		}
+		since := r.Since()
+		cache, err := client.GetScCacheSince(ctx, since)
+		if err != nil {
+			errs[client.Cfg.Name] = err
+			continue
+		}
+		if since > 0 && !cache.Incremental {
+			log.Warnf("revision %d of service center[%s] is compacted, fall back to full pull",
+				since, client.Cfg.Name)
+		}
+		r.Apply(cache)
+
+		if caches == nil {
+			caches = &dump.Cache{}
+		}
+		slices := caches.Slices()
+		for i, m := range r.slices {
+			c.cacheAppend(client.Cfg.Name, slices[i], m)
+		}
+	}
+	return caches, errs
+}
+
 func (c *SCClientAggregate) cacheAppend(name string, setter dump.Setter, getter dump.Getter) {
 	getter.ForEach(func(_ int, v *dump.KV) bool {
 		if len(v.ClusterName) == 0 || v.ClusterName == etcdclient.DefaultClusterName {
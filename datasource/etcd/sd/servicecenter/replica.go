@@ -0,0 +1,80 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package servicecenter
+
+import (
+	"github.com/apache/servicecomb-service-center/pkg/dump"
+)
+
+type kvMap map[string]*dump.KV
+
+func (m kvMap) ForEach(f func(i int, v *dump.KV) bool) {
+	i := 0
+	for _, v := range m {
+		if !f(i, v) {
+			break
+		}
+		i++
+	}
+}
+
+// replica is the local copy of a remote service center cache,
+// it merges the changes of incremental pulls
+type replica struct {
+	revision int64
+	slices   []kvMap
+}
+
+func newReplica() *replica {
+	r := &replica{}
+	r.reset()
+	return r
+}
+
+func (r *replica) reset() {
+	r.slices = make([]kvMap, len((&dump.Cache{}).Slices()))
+	for i := range r.slices {
+		r.slices[i] = make(kvMap)
+	}
+}
+
+// Since returns the revision for next pull, 0 means a full pull
+func (r *replica) Since() int64 {
+	return r.revision
+}
+
+// Apply replaces the replica with the full cache, or merges the incremental one
+func (r *replica) Apply(cache *dump.Cache) {
+	r.revision = cache.Revision
+	if !cache.Incremental {
+		r.reset()
+	}
+	// the deletions must be applied first, the kv may be re-created after deleting
+	for _, v := range cache.Deletions {
+		for _, m := range r.slices {
+			delete(m, v.Key)
+		}
+	}
+	for i, slice := range cache.Slices() {
+		m := r.slices[i]
+		slice.ForEach(func(_ int, v *dump.KV) bool {
+			m[v.Key] = v
+			return true
+		})
+	}
+}
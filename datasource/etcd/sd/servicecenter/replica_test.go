@@ -0,0 +1,53 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package servicecenter
+
+import (
+	"testing"
+
+	"github.com/apache/servicecomb-service-center/pkg/dump"
+	"github.com/go-chassis/cari/discovery"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplica_Apply(t *testing.T) {
+	r := newReplica()
+	assert.Equal(t, int64(0), r.Since())
+
+	full := &dump.Cache{Revision: 2}
+	full.Microservices.SetValue(&dump.KV{Key: "/a", Rev: 1, Value: &discovery.MicroService{ServiceId: "a"}})
+	full.Indexes.SetValue(&dump.KV{Key: "/b", Rev: 2, Value: "a"})
+	r.Apply(full)
+	assert.Equal(t, int64(2), r.Since())
+	assert.Equal(t, 1, len(r.slices[0]))
+	assert.Equal(t, 1, len(r.slices[1]))
+
+	// delete then re-create
+	incr := &dump.Cache{Revision: 5, Incremental: true}
+	incr.Microservices.SetValue(&dump.KV{Key: "/a", Rev: 4, Value: &discovery.MicroService{ServiceId: "aa"}})
+	incr.Deletions = []*dump.KV{{Key: "/a", Rev: 3}, {Key: "/b", Rev: 3}}
+	r.Apply(incr)
+	assert.Equal(t, int64(5), r.Since())
+	assert.Equal(t, int64(4), r.slices[0]["/a"].Rev)
+	assert.Equal(t, 0, len(r.slices[1]))
+
+	// compacted, replace all
+	r.Apply(&dump.Cache{Revision: 6})
+	assert.Equal(t, 0, len(r.slices[0]))
+	assert.Equal(t, int64(6), r.Since())
+}
@@ -42,16 +42,18 @@ var (
 type Syncer struct {
 	Client *SCClientAggregate
 
-	cachers map[sd.Type]*Cacher
+	cachers  map[sd.Type]*Cacher
+	replicas map[string]*replica
 }
 
 func (c *Syncer) Initialize() {
 	c.cachers = make(map[sd.Type]*Cacher)
+	c.replicas = make(map[string]*replica)
 	c.Client = GetOrCreateSCClient()
 }
 
 func (c *Syncer) Sync(ctx context.Context) {
-	cache, errs := c.Client.GetScCache(ctx)
+	cache, errs := c.Client.pullScCache(ctx, c.replicas)
 	if len(errs) > 0 {
 		err := fmt.Errorf("%v", errs)
 		log.Errorf(err, "Sync catches errors")
@@ -28,6 +28,7 @@ import (
 	"github.com/apache/servicecomb-service-center/pkg/dump"
 	"github.com/apache/servicecomb-service-center/pkg/etcdsync"
 	"github.com/apache/servicecomb-service-center/pkg/gopool"
+	"github.com/apache/servicecomb-service-center/pkg/log"
 )
 
 type SysManager struct {
@@ -42,7 +43,9 @@ func newSysManager() datasource.SystemManager {
 	return inst
 }
 func (sm *SysManager) DumpCache(ctx context.Context) *dump.Cache {
-	var cache dump.Cache
+	// the deletions after the latest revision may be missed in the scan,
+	// so the replica pulls from it next time instead of the max kv revision
+	cache := dump.Cache{Revision: tombstones.Latest()}
 	gopool.New(ctx, gopool.Configure().Workers(2)).
 		Do(func(_ context.Context) { setValue(kv.Store().Service(), &cache.Microservices) }).
 		Do(func(_ context.Context) { setValue(kv.Store().ServiceIndex(), &cache.Indexes) }).
@@ -57,8 +60,40 @@ func (sm *SysManager) DumpCache(ctx context.Context) *dump.Cache {
 	return &cache
 }
 
+func (sm *SysManager) DumpCacheSince(ctx context.Context, rev int64) *dump.Cache {
+	deletions, latest, ok := tombstones.Since(rev)
+	if !ok {
+		log.Warnf("revision %d is compacted, dump the full cache", rev)
+		return sm.DumpCache(ctx)
+	}
+	cache := dump.Cache{
+		Revision:    latest,
+		Incremental: true,
+		Deletions:   deletions,
+	}
+	gopool.New(ctx, gopool.Configure().Workers(2)).
+		Do(func(_ context.Context) { setValueSince(kv.Store().Service(), &cache.Microservices, rev) }).
+		Do(func(_ context.Context) { setValueSince(kv.Store().ServiceIndex(), &cache.Indexes, rev) }).
+		Do(func(_ context.Context) { setValueSince(kv.Store().ServiceAlias(), &cache.Aliases, rev) }).
+		Do(func(_ context.Context) { setValueSince(kv.Store().ServiceTag(), &cache.Tags, rev) }).
+		Do(func(_ context.Context) { setValueSince(kv.Store().RuleIndex(), &cache.RuleIndexes, rev) }).
+		Do(func(_ context.Context) { setValueSince(kv.Store().Rule(), &cache.Rules, rev) }).
+		Do(func(_ context.Context) { setValueSince(kv.Store().DependencyRule(), &cache.DependencyRules, rev) }).
+		Do(func(_ context.Context) { setValueSince(kv.Store().SchemaSummary(), &cache.Summaries, rev) }).
+		Do(func(_ context.Context) { setValueSince(kv.Store().Instance(), &cache.Instances, rev) }).
+		Done()
+	return &cache
+}
+
 func setValue(e sd.Adaptor, setter dump.Setter) {
+	setValueSince(e, setter, 0)
+}
+
+func setValueSince(e sd.Adaptor, setter dump.Setter, rev int64) {
 	e.Cache().ForEach(func(k string, kv *sd.KeyValue) (next bool) {
+		if rev > 0 && kv.ModRevision <= rev {
+			return true
+		}
 		setter.SetValue(&dump.KV{
 			Key:         k,
 			Rev:         kv.ModRevision,
@@ -0,0 +1,114 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/kv"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/path"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+)
+
+type TokenManager struct {
+}
+
+func (tm *TokenManager) Revoke(ctx context.Context, t *datasource.RevokedToken) error {
+	opts := []client.PluginOpOption{client.PUT, client.WithStrKey(path.GenerateRevokedTokenKey(t.ID))}
+	if t.ExpireAt > 0 {
+		ttl := t.ExpireAt - time.Now().Unix()
+		if ttl <= 0 {
+			// the token is expired, no need to revoke it
+			return nil
+		}
+		leaseID, err := client.Instance().LeaseGrant(ctx, ttl)
+		if err != nil {
+			log.Error(fmt.Sprintf("grant lease for revoked token %s failed", t.ID), err)
+			return err
+		}
+		opts = append(opts, client.WithLease(leaseID))
+	}
+	value, err := json.Marshal(t)
+	if err != nil {
+		log.Errorf(err, "revoked token is invalid")
+		return err
+	}
+	_, err = client.Instance().Do(ctx, append(opts, client.WithValue(value))...)
+	if err != nil {
+		log.Error(fmt.Sprintf("can not save revoked token %s", t.ID), err)
+		return err
+	}
+	log.Info(fmt.Sprintf("token %s of account %s is revoked", t.ID, t.Account))
+	return nil
+}
+
+// IsRevoked is called by every authenticated request, so it reads the cache only
+// unless ctx requires no cache, the cache is updated by watching the revocation list
+func (tm *TokenManager) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	resp, err := kv.Store().RevokedToken().Search(ctx,
+		client.WithStrKey(path.GenerateRevokedTokenKey(tokenID)),
+		client.WithCountOnly(), revocationCacheOption(ctx))
+	if err != nil {
+		return false, err
+	}
+	return resp.Count > 0, nil
+}
+
+func (tm *TokenManager) RevokeAccount(ctx context.Context, account string, revokeAt int64) error {
+	value, err := json.Marshal(&datasource.RevokedAccount{Account: account, RevokeAt: revokeAt})
+	if err != nil {
+		log.Errorf(err, "revoked account is invalid")
+		return err
+	}
+	err = client.PutBytes(ctx, path.GenerateRevokedAccountKey(account), value)
+	if err != nil {
+		log.Error(fmt.Sprintf("can not revoke tokens of account %s", account), err)
+		return err
+	}
+	log.Info(fmt.Sprintf("tokens of account %s issued before %d are revoked", account, revokeAt))
+	return nil
+}
+
+func (tm *TokenManager) GetAccountRevokeAt(ctx context.Context, account string) (int64, error) {
+	resp, err := kv.Store().RevokedAccount().Search(ctx,
+		client.WithStrKey(path.GenerateRevokedAccountKey(account)), revocationCacheOption(ctx))
+	if err != nil {
+		return 0, err
+	}
+	if resp.Count == 0 {
+		return 0, nil
+	}
+	revoked := &datasource.RevokedAccount{}
+	err = json.Unmarshal(resp.Kvs[0].Value.([]byte), revoked)
+	if err != nil {
+		log.Error(fmt.Sprintf("revoked account %s format invalid", account), err)
+		return 0, err
+	}
+	return revoked.RevokeAt, nil
+}
+
+func revocationCacheOption(ctx context.Context) client.PluginOpOption {
+	if util.NoCache(ctx) {
+		return client.WithNoCache()
+	}
+	return client.WithCacheOnly()
+}
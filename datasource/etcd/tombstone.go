@@ -0,0 +1,141 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcd
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"github.com/apache/servicecomb-service-center/datasource/etcd/client"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/kv"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/path"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/sd"
+	"github.com/apache/servicecomb-service-center/pkg/dump"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/go-chassis/cari/discovery"
+)
+
+// TombstoneSize is the max number of the deleted kvs kept for incremental dump
+const TombstoneSize = 10000
+
+var tombstones = newTombstoneLog(TombstoneSize)
+
+// tombstoneLog records the deleted kvs of the dumped caches, then the
+// incremental dump can tell the deletions after a revision
+type tombstoneLog struct {
+	lock sync.RWMutex
+	size int
+	// begin is the revision that the records are complete after
+	begin int64
+	// latest is the max revision of the events observed
+	latest  int64
+	records []*dump.KV
+}
+
+func newTombstoneLog(size int) *tombstoneLog {
+	return &tombstoneLog{
+		size:  size,
+		begin: math.MaxInt64,
+	}
+}
+
+func (t *tombstoneLog) OnEvent(evt sd.KvEvent) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if evt.Revision > t.latest {
+		t.latest = evt.Revision
+	}
+	if evt.Type != discovery.EVT_DELETE || evt.KV == nil {
+		return
+	}
+	t.records = append(t.records, &dump.KV{
+		Key:         util.BytesToStringWithNoCopy(evt.KV.Key),
+		Rev:         evt.Revision,
+		ClusterName: evt.KV.ClusterName,
+	})
+	if len(t.records) <= t.size {
+		return
+	}
+	// compact the oldest one
+	oldest := t.records[0]
+	t.records = t.records[1:]
+	if oldest.Rev > t.begin {
+		t.begin = oldest.Rev
+	}
+}
+
+// Begin sets the revision that the records are complete after
+func (t *tombstoneLog) Begin(rev int64) {
+	t.lock.Lock()
+	t.begin = rev
+	if rev > t.latest {
+		t.latest = rev
+	}
+	t.lock.Unlock()
+}
+
+// Since returns the deleted kvs after the revision and the latest revision,
+// ok is false if the revision is compacted
+func (t *tombstoneLog) Since(rev int64) (deletions []*dump.KV, latest int64, ok bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if rev < t.begin || rev > t.latest {
+		return nil, t.latest, false
+	}
+	for _, record := range t.records {
+		if record.Rev > rev {
+			deletions = append(deletions, record)
+		}
+	}
+	return deletions, t.latest, true
+}
+
+func (t *tombstoneLog) Latest() int64 {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.latest
+}
+
+// dumpTypes are the types of the caches dumped
+func dumpTypes() []sd.Type {
+	return []sd.Type{kv.SERVICE, kv.ServiceIndex, kv.ServiceAlias, kv.ServiceTag, kv.RuleIndex,
+		kv.RULE, kv.DependencyRule, kv.SchemaSummary, kv.INSTANCE}
+}
+
+func initTombstones() {
+	for _, t := range dumpTypes() {
+		sd.AddEventHandleFunc(t, tombstones.OnEvent)
+	}
+}
+
+// beginTombstones must be called after the kv store is ready, the deletions
+// before the current revision may be missed in records
+func beginTombstones() {
+	resp, err := client.Instance().Do(context.Background(), client.GET,
+		client.WithStrKey(path.GetRootKey()), client.WithPrefix(), client.WithCountOnly())
+	if err != nil {
+		log.Errorf(err, "get current revision failed, incremental dump is disabled")
+		return
+	}
+	tombstones.Begin(resp.Revision)
+	log.Infof("incremental dump is available after revision %d", resp.Revision)
+}
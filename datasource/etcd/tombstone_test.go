@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package etcd
+
+import (
+	"testing"
+
+	"github.com/apache/servicecomb-service-center/datasource/etcd/sd"
+	"github.com/go-chassis/cari/discovery"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTombstoneLog(t *testing.T) {
+	onEvent := func(l *tombstoneLog, action discovery.EventType, key string, rev int64) {
+		l.OnEvent(sd.NewKvEvent(action, &sd.KeyValue{Key: []byte(key), ModRevision: rev}, rev))
+	}
+
+	t.Run("not begin, should be compacted", func(t *testing.T) {
+		l := newTombstoneLog(10)
+		onEvent(l, discovery.EVT_DELETE, "/a", 2)
+		_, latest, ok := l.Since(1)
+		assert.False(t, ok)
+		assert.Equal(t, int64(2), latest)
+	})
+
+	t.Run("since revision should return the later deletions", func(t *testing.T) {
+		l := newTombstoneLog(10)
+		l.Begin(1)
+		onEvent(l, discovery.EVT_CREATE, "/a", 2)
+		onEvent(l, discovery.EVT_DELETE, "/b", 3)
+		onEvent(l, discovery.EVT_DELETE, "/c", 4)
+		onEvent(l, discovery.EVT_UPDATE, "/a", 5)
+
+		deletions, latest, ok := l.Since(3)
+		assert.True(t, ok)
+		assert.Equal(t, int64(5), latest)
+		assert.Equal(t, 1, len(deletions))
+		assert.Equal(t, "/c", deletions[0].Key)
+
+		deletions, _, ok = l.Since(1)
+		assert.True(t, ok)
+		assert.Equal(t, 2, len(deletions))
+
+		_, _, ok = l.Since(6)
+		assert.False(t, ok)
+	})
+
+	t.Run("the revision compacted should be rejected", func(t *testing.T) {
+		l := newTombstoneLog(2)
+		l.Begin(1)
+		onEvent(l, discovery.EVT_DELETE, "/a", 2)
+		onEvent(l, discovery.EVT_DELETE, "/b", 3)
+		onEvent(l, discovery.EVT_DELETE, "/c", 4)
+
+		_, _, ok := l.Since(1)
+		assert.False(t, ok)
+		deletions, _, ok := l.Since(2)
+		assert.True(t, ok)
+		assert.Equal(t, 2, len(deletions))
+	})
+}
@@ -22,8 +22,8 @@ import (
 	"strings"
 
 	"github.com/apache/servicecomb-service-center/datasource/etcd/sd"
+	"github.com/apache/servicecomb-service-center/pkg/semver"
 	"github.com/apache/servicecomb-service-center/pkg/util"
-	"github.com/apache/servicecomb-service-center/pkg/validate"
 )
 
 type VersionRule func(sorted []string, kvs map[string]*sd.KeyValue, start, end string) []string
@@ -72,9 +72,7 @@ func (sks *serviceKeySorter) Less(i, j int) bool {
 }
 
 func Larger(start, end string) bool {
-	s, _ := validate.VersionToInt64(start)
-	e, _ := validate.VersionToInt64(end)
-	return s > e
+	return semver.Less(end, start)
 }
 
 func LessEqual(start, end string) bool {
@@ -137,12 +135,24 @@ func AtLess(sorted []string, kvs map[string]*sd.KeyValue, start, end string) []s
 	return result[:]
 }
 
+// SemVerRule returns the VersionRule matches the versions by the semver rule
+func SemVerRule(rule *semver.Rule) VersionRule {
+	return func(sorted []string, kvs map[string]*sd.KeyValue, start, end string) []string {
+		result := make([]string, 0, len(sorted))
+		for _, k := range sorted {
+			if rule.Match(k) {
+				result = append(result, kvs[k].Value.(string))
+			}
+		}
+		return result
+	}
+}
+
 func ParseVersionRule(versionRule string) func(kvs []*sd.KeyValue) []string {
 	if len(versionRule) == 0 {
 		return nil
 	}
 
-	rangeIdx := strings.Index(versionRule, "-")
 	switch {
 	case versionRule == "latest":
 		return func(kvs []*sd.KeyValue) []string {
@@ -154,16 +164,23 @@ func ParseVersionRule(versionRule string) func(kvs []*sd.KeyValue) []string {
 		return func(kvs []*sd.KeyValue) []string {
 			return VersionRule(AtLess).Match(kvs, start)
 		}
-	case rangeIdx > 0:
+	case semver.IsRange(versionRule):
 		// 取版本范围集合
+		rangeIdx := strings.Index(versionRule, "-")
 		start := versionRule[:rangeIdx]
 		end := versionRule[rangeIdx+1:]
 		return func(kvs []*sd.KeyValue) []string {
 			return VersionRule(Range).Match(kvs, start, end)
 		}
 	default:
-		// 精确匹配
-		return nil
+		rule, err := semver.ParseRule(versionRule)
+		if err != nil || rule.IsExact() {
+			// 精确匹配
+			return nil
+		}
+		return func(kvs []*sd.KeyValue) []string {
+			return SemVerRule(rule).Match(kvs)
+		}
 	}
 }
 
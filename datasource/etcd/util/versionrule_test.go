@@ -163,6 +163,16 @@ func TestSorter(t *testing.T) {
 		assert.Equal(t, "1.10", kvs[1])
 	})
 
+	t.Run("pre-release", func(t *testing.T) {
+		kvs := []string{"1.0.0-rc.1", "1.0.0", "1.0.0-beta", "0.9"}
+		sort.Sort(&serviceKeySorter{
+			sortArr: kvs,
+			kvs:     make(map[string]*sd.KeyValue),
+			cmp:     Larger,
+		})
+		assert.Equal(t, []string{"1.0.0", "1.0.0-rc.1", "1.0.0-beta", "0.9"}, kvs)
+	})
+
 	log.Info("exception")
 
 	t.Run("invalid version1", func(t *testing.T) {
@@ -316,6 +326,34 @@ func TestVersionRule(t *testing.T) {
 		assert.Equal(t, "6", results[4])
 	})
 
+	t.Run("caret ver in [1.6, 2.0)", func(t *testing.T) {
+		match := ParseVersionRule("^1.6")
+		results := match(kvs[:])
+		assert.Equal(t, 5, len(results))
+		assert.Equal(t, "10", results[0])
+		assert.Equal(t, "6", results[4])
+	})
+
+	t.Run("tilde ver in [1.6, 1.7)", func(t *testing.T) {
+		match := ParseVersionRule("~1.6")
+		results := match(kvs[:])
+		assert.Equal(t, 1, len(results))
+		assert.Equal(t, "6", results[0])
+	})
+
+	t.Run("comparison ver in (1.4, 1.8]", func(t *testing.T) {
+		match := ParseVersionRule(">1.4 <=1.8")
+		results := match(kvs[:])
+		assert.Equal(t, 4, len(results))
+		assert.Equal(t, "8", results[0])
+		assert.Equal(t, "5", results[3])
+	})
+
+	t.Run("pre-release is exact", func(t *testing.T) {
+		rule := ParseVersionRule("1.0.0-rc.1")
+		assert.Equal(t, true, reflect.ValueOf(rule).IsNil())
+	})
+
 	log.Info("version match rule")
 
 	t.Run("latest", func(t *testing.T) {
@@ -330,6 +368,15 @@ func TestVersionRule(t *testing.T) {
 		assert.Equal(t, false, VersionMatchRule("1.9", "1.4-1.8"))
 	})
 
+	t.Run("semver rules", func(t *testing.T) {
+		assert.Equal(t, true, VersionMatchRule("1.9.0", "^1.2"))
+		assert.Equal(t, false, VersionMatchRule("2.0.0", "^1.2"))
+		assert.Equal(t, false, VersionMatchRule("1.3.0-rc.1", "^1.2"))
+		assert.Equal(t, true, VersionMatchRule("1.4.5", "~1.4.0"))
+		assert.Equal(t, true, VersionMatchRule("1.5.0", ">=1.0 <2.0"))
+		assert.Equal(t, true, VersionMatchRule("1.0.0-rc.1", "1.0.0-rc.1"))
+	})
+
 	t.Run("atLess ver >= 1.6", func(t *testing.T) {
 		assert.Equal(t, true, VersionMatchRule("1.6", "1.6+"))
 		assert.Equal(t, true, VersionMatchRule("1.9", "1.6+"))
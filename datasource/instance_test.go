@@ -25,6 +25,7 @@ import (
 
 	"github.com/apache/servicecomb-service-center/datasource"
 	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/selector"
 	"github.com/apache/servicecomb-service-center/pkg/util"
 	"github.com/apache/servicecomb-service-center/server/config"
 	"github.com/apache/servicecomb-service-center/server/core"
@@ -1021,6 +1022,177 @@ func TestInstance_Query(t *testing.T) {
 	})
 }
 
+func TestInstance_QueryWithSelector(t *testing.T) {
+	var (
+		serviceId   string
+		instanceId1 string
+		instanceId2 string
+	)
+
+	t.Run("register service and instances for selector query", func(t *testing.T) {
+		respCreateService, err := datasource.GetMetadataManager().RegisterService(getContext(), &pb.CreateServiceRequest{
+			Service: &pb.MicroService{
+				AppId:       "query_selector_ms",
+				ServiceName: "query_selector_service_ms",
+				Version:     "1.0.0",
+				Level:       "FRONT",
+				Status:      pb.MS_UP,
+			},
+			Tags: map[string]string{
+				"env":  "prod",
+				"zone": "az0",
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, pb.ResponseSuccess, respCreateService.Response.GetCode())
+		serviceId = respCreateService.ServiceId
+
+		respCreateInstance, err := datasource.GetMetadataManager().RegisterInstance(getContext(), &pb.RegisterInstanceRequest{
+			Instance: &pb.MicroServiceInstance{
+				ServiceId: serviceId,
+				HostName:  "UT-HOST-MS",
+				Endpoints: []string{
+					"find:127.0.0.1:8081",
+				},
+				Status: pb.MSI_UP,
+				Properties: map[string]string{
+					"zone": "az1",
+				},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, pb.ResponseSuccess, respCreateInstance.Response.GetCode())
+		instanceId1 = respCreateInstance.InstanceId
+
+		respCreateInstance, err = datasource.GetMetadataManager().RegisterInstance(getContext(), &pb.RegisterInstanceRequest{
+			Instance: &pb.MicroServiceInstance{
+				ServiceId: serviceId,
+				HostName:  "UT-HOST-MS",
+				Endpoints: []string{
+					"find:127.0.0.2:8081",
+				},
+				Status: pb.MSI_UP,
+				Properties: map[string]string{
+					"zone":   "az2",
+					"canary": "true",
+				},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, pb.ResponseSuccess, respCreateInstance.Response.GetCode())
+		instanceId2 = respCreateInstance.InstanceId
+	})
+
+	t.Run("find instances with selector", func(t *testing.T) {
+		find := func(expr string) []string {
+			s, err := selector.Parse(expr)
+			assert.NoError(t, err)
+			ctx := selector.SetContext(util.CloneContext(getContext()), s)
+			respFind, err := datasource.GetMetadataManager().FindInstances(ctx, &pb.FindInstancesRequest{
+				AppId:       "query_selector_ms",
+				ServiceName: "query_selector_service_ms",
+				VersionRule: "latest",
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, pb.ResponseSuccess, respFind.Response.GetCode())
+			var ids []string
+			for _, instance := range respFind.Instances {
+				ids = append(ids, instance.InstanceId)
+			}
+			return ids
+		}
+
+		assert.ElementsMatch(t, []string{instanceId1, instanceId2}, find(""))
+		assert.ElementsMatch(t, []string{instanceId1, instanceId2}, find("env=prod"))
+		assert.ElementsMatch(t, []string{instanceId1}, find("zone=az1"))
+		assert.ElementsMatch(t, []string{instanceId1}, find("env in (prod,test),!canary"))
+		assert.ElementsMatch(t, []string{instanceId2}, find("zone notin (az0,az1)"))
+		assert.Empty(t, find("env=test"))
+		assert.Empty(t, find("zone=az0"))
+	})
+
+	t.Run("batch find instances with selector", func(t *testing.T) {
+		s, err := selector.Parse("canary")
+		assert.NoError(t, err)
+		ctx := selector.SetContext(util.CloneContext(getContext()), s)
+		respFind, err := datasource.GetMetadataManager().BatchFind(ctx, &pb.BatchFindInstancesRequest{
+			Services: []*pb.FindService{
+				{
+					Service: &pb.MicroServiceKey{
+						AppId:       "query_selector_ms",
+						ServiceName: "query_selector_service_ms",
+						Version:     "latest",
+					},
+				},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, pb.ResponseSuccess, respFind.Response.GetCode())
+		assert.Equal(t, 1, len(respFind.Services.Updated))
+		assert.Equal(t, 1, len(respFind.Services.Updated[0].Instances))
+		assert.Equal(t, instanceId2, respFind.Services.Updated[0].Instances[0].InstanceId)
+	})
+}
+
+func TestInstance_QueryWithSemVer(t *testing.T) {
+	instances := make(map[string]string)
+
+	t.Run("register services and instances for semver query", func(t *testing.T) {
+		for _, version := range []string{"1.0.0", "1.2.0", "1.10.0", "1.11.0-rc.1", "2.0.0"} {
+			respCreateService, err := datasource.GetMetadataManager().RegisterService(getContext(), &pb.CreateServiceRequest{
+				Service: &pb.MicroService{
+					AppId:       "query_semver_ms",
+					ServiceName: "query_semver_service_ms",
+					Version:     version,
+					Level:       "FRONT",
+					Status:      pb.MS_UP,
+				},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, pb.ResponseSuccess, respCreateService.Response.GetCode())
+
+			respCreateInstance, err := datasource.GetMetadataManager().RegisterInstance(getContext(), &pb.RegisterInstanceRequest{
+				Instance: &pb.MicroServiceInstance{
+					ServiceId: respCreateService.ServiceId,
+					HostName:  "UT-HOST-MS",
+					Endpoints: []string{
+						"find:127.0.0.1:" + version,
+					},
+					Status: pb.MSI_UP,
+				},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, pb.ResponseSuccess, respCreateInstance.Response.GetCode())
+			instances[respCreateInstance.InstanceId] = version
+		}
+	})
+
+	t.Run("find instances with semver rules", func(t *testing.T) {
+		find := func(versionRule string) []string {
+			respFind, err := datasource.GetMetadataManager().FindInstances(getContext(), &pb.FindInstancesRequest{
+				AppId:       "query_semver_ms",
+				ServiceName: "query_semver_service_ms",
+				VersionRule: versionRule,
+			})
+			assert.NoError(t, err)
+			var versions []string
+			for _, instance := range respFind.Instances {
+				versions = append(versions, instances[instance.InstanceId])
+			}
+			return versions
+		}
+
+		assert.ElementsMatch(t, []string{"2.0.0"}, find("latest"))
+		assert.ElementsMatch(t, []string{"1.11.0-rc.1"}, find("1.11.0-rc.1"))
+		assert.ElementsMatch(t, []string{"1.2.0", "1.10.0", "1.11.0-rc.1"}, find("1.2-2.0"))
+		assert.ElementsMatch(t, []string{"1.10.0", "1.11.0-rc.1", "2.0.0"}, find("1.10+"))
+		assert.ElementsMatch(t, []string{"1.0.0", "1.2.0", "1.10.0"}, find("^1.0"))
+		assert.ElementsMatch(t, []string{"1.10.0"}, find("~1.10.0"))
+		assert.ElementsMatch(t, []string{"1.10.0", "1.11.0-rc.1"}, find("^1.10 || ^1.11.0-rc.0"))
+		assert.ElementsMatch(t, []string{"1.2.0", "1.10.0", "2.0.0"}, find(">1.0 <=2.0"))
+	})
+}
+
 func TestServicesStatistics_Get(t *testing.T) {
 	var ctx context.Context
 	var serviceId1 string
@@ -84,9 +84,21 @@ func GetAccountManager() AccountManager {
 func GetAccountLockManager() AccountLockManager {
 	return dataSourceInst.AccountLockManager()
 }
+func GetTokenManager() TokenManager {
+	return dataSourceInst.TokenManager()
+}
 func GetDependencyManager() DependencyManager {
 	return dataSourceInst.DependencyManager()
 }
 func GetMetricsManager() MetricsManager {
 	return dataSourceInst.MetricsManager()
 }
+func GetAlarmManager() AlarmManager {
+	return dataSourceInst.AlarmManager()
+}
+func GetQuotaManager() QuotaManager {
+	return dataSourceInst.QuotaManager()
+}
+func GetBrokerManager() BrokerManager {
+	return dataSourceInst.BrokerManager()
+}
@@ -18,6 +18,7 @@ package mongo
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/apache/servicecomb-service-center/datasource"
@@ -30,7 +31,8 @@ import (
 )
 
 type AccountLockManager struct {
-	releaseAfter time.Duration
+	// releaseAfter is time.Duration, it can be changed at runtime
+	releaseAfter int64
 }
 
 func (al *AccountLockManager) GetLock(ctx context.Context, key string) (*datasource.AccountLock, error) {
@@ -68,7 +70,7 @@ func (al *AccountLockManager) DeleteLock(ctx context.Context, key string) error
 }
 
 func (al *AccountLockManager) Ban(ctx context.Context, key string) error {
-	releaseAt := time.Now().Add(al.releaseAfter).Unix()
+	releaseAt := time.Now().Add(al.ReleaseAfter()).Unix()
 	filter := mutil.NewFilter(mutil.AccountLockKey(key))
 	updateFilter := mutil.NewFilter(mutil.Set(mutil.NewFilter(
 		mutil.AccountLockKey(key),
@@ -90,5 +92,13 @@ func (al *AccountLockManager) Ban(ctx context.Context, key string) error {
 }
 
 func NewAccountLockManager(ReleaseAfter time.Duration) datasource.AccountLockManager {
-	return &AccountLockManager{releaseAfter: ReleaseAfter}
+	return &AccountLockManager{releaseAfter: int64(ReleaseAfter)}
+}
+
+func (al *AccountLockManager) ReleaseAfter() time.Duration {
+	return time.Duration(atomic.LoadInt64(&al.releaseAfter))
+}
+
+func (al *AccountLockManager) SetReleaseAfter(d time.Duration) {
+	atomic.StoreInt64(&al.releaseAfter, int64(d))
 }
@@ -0,0 +1,154 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/datasource/mongo/client"
+	"github.com/apache/servicecomb-service-center/datasource/mongo/client/model"
+	mutil "github.com/apache/servicecomb-service-center/datasource/mongo/util"
+	amodel "github.com/apache/servicecomb-service-center/pkg/alarm/model"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+)
+
+type AlarmManager struct {
+}
+
+func (am *AlarmManager) UpsertAlarm(ctx context.Context, a *amodel.Alarm) error {
+	filter := mutil.NewFilter(mutil.Node(a.Node), mutil.ID(string(a.ID)))
+	_, err := client.GetMongoClient().Update(ctx, model.CollectionAlarm, filter, mutil.NewFilter(mutil.Set(a)),
+		options.Update().SetUpsert(true))
+	if err != nil {
+		log.Error(fmt.Sprintf("can not save alarm[%s]", a.ID), err)
+		return err
+	}
+	return nil
+}
+
+func (am *AlarmManager) GetAlarm(ctx context.Context, node string, id amodel.ID) (*amodel.Alarm, error) {
+	filter := mutil.NewFilter(mutil.Node(node), mutil.ID(string(id)))
+	result, err := client.GetMongoClient().FindOne(ctx, model.CollectionAlarm, filter)
+	if err != nil {
+		return nil, err
+	}
+	if err = result.Err(); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, datasource.ErrAlarmNotExist
+		}
+		return nil, err
+	}
+	var a amodel.Alarm
+	err = result.Decode(&a)
+	if err != nil {
+		log.Error(fmt.Sprintf("failed to decode alarm[%s]", id), err)
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (am *AlarmManager) ListAlarms(ctx context.Context, node string) ([]*amodel.Alarm, error) {
+	cursor, err := client.GetMongoClient().Find(ctx, model.CollectionAlarm, nodeFilter(node))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var alarms []*amodel.Alarm
+	for cursor.Next(ctx) {
+		var a amodel.Alarm
+		if err := cursor.Decode(&a); err != nil {
+			log.Error("failed to decode alarm", err)
+			continue
+		}
+		alarms = append(alarms, &a)
+	}
+	return alarms, nil
+}
+
+func (am *AlarmManager) DeleteAlarms(ctx context.Context, node string) error {
+	_, err := client.GetMongoClient().Delete(ctx, model.CollectionAlarm, nodeFilter(node))
+	return err
+}
+
+// nodeFilter matches the alarms of the node, or of all nodes if node is empty
+func nodeFilter(node string) bson.M {
+	if len(node) == 0 {
+		return mutil.NewFilter()
+	}
+	return mutil.NewFilter(mutil.Node(node))
+}
+
+func (am *AlarmManager) AddAlarmHistory(ctx context.Context, h *amodel.History) error {
+	_, err := client.GetMongoClient().Insert(ctx, model.CollectionAlarmHistory, h)
+	if err != nil {
+		log.Error(fmt.Sprintf("can not save alarm[%s] history", h.ID), err)
+		return err
+	}
+	return nil
+}
+
+func (am *AlarmManager) ListAlarmHistory(ctx context.Context, request *amodel.HistoryRequest) ([]*amodel.History, error) {
+	filter := mutil.NewFilter()
+	if len(request.ID) > 0 {
+		mutil.ID(string(request.ID))(filter)
+	}
+	if len(request.Status) > 0 {
+		mutil.Status(string(request.Status))(filter)
+	}
+	timeRange := bson.M{}
+	if request.Start > 0 {
+		timeRange["$gte"] = request.Start
+	}
+	if request.End > 0 {
+		timeRange["$lte"] = request.End
+	}
+	if len(timeRange) > 0 {
+		filter[model.ColumnTimestamp] = timeRange
+	}
+	opts := options.Find().SetSort(bson.M{model.ColumnTimestamp: -1})
+	if request.Limit > 0 {
+		opts.SetLimit(request.Limit)
+	}
+	cursor, err := client.GetMongoClient().Find(ctx, model.CollectionAlarmHistory, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var histories []*amodel.History
+	for cursor.Next(ctx) {
+		var h amodel.History
+		if err := cursor.Decode(&h); err != nil {
+			log.Error("failed to decode alarm history", err)
+			continue
+		}
+		histories = append(histories, &h)
+	}
+	return histories, nil
+}
+
+func (am *AlarmManager) DeleteAlarmHistory(ctx context.Context, before int64) error {
+	filter := bson.M{model.ColumnTimestamp: bson.M{"$lt": before}}
+	_, err := client.GetMongoClient().Delete(ctx, model.CollectionAlarmHistory, filter)
+	return err
+}
@@ -0,0 +1,304 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/datasource/mongo/client"
+	"github.com/apache/servicecomb-service-center/datasource/mongo/client/model"
+	mutil "github.com/apache/servicecomb-service-center/datasource/mongo/util"
+	"github.com/apache/servicecomb-service-center/pkg/broker/brokerpb"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+)
+
+// brokerCollections are the collection and column of the broker data kinds
+var brokerCollections = map[string][2]string{
+	datasource.BrokerParticipant:  {model.CollectionBrokerParticipant, model.ColumnParticipant},
+	datasource.BrokerVersion:      {model.CollectionBrokerVersion, model.ColumnVersion},
+	datasource.BrokerPact:         {model.CollectionBrokerPact, model.ColumnPact},
+	datasource.BrokerPactVersion:  {model.CollectionBrokerPactVersion, model.ColumnPactVersion},
+	datasource.BrokerVerification: {model.CollectionBrokerVerification, model.ColumnVerification},
+}
+
+type BrokerManager struct {
+}
+
+func (bm *BrokerManager) GetParticipant(ctx context.Context, domainProject, appID, serviceName string) (*brokerpb.Participant, error) {
+	filter := newBrokerFilter(domainProject, bson.M{
+		mutil.ConnectWithDot([]string{model.ColumnParticipant, model.ColumnAppID}):       appID,
+		mutil.ConnectWithDot([]string{model.ColumnParticipant, model.ColumnServiceName}): serviceName,
+	})
+	var doc model.BrokerParticipant
+	ok, err := findOneBrokerData(ctx, model.CollectionBrokerParticipant, filter, &doc)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return doc.Participant, nil
+}
+
+func (bm *BrokerManager) ListParticipants(ctx context.Context, domainProject string) ([]*brokerpb.Participant, error) {
+	var participants []*brokerpb.Participant
+	err := findBrokerData(ctx, model.CollectionBrokerParticipant, newBrokerFilter(domainProject, nil),
+		func(cursor *mongo.Cursor) error {
+			var doc model.BrokerParticipant
+			if err := cursor.Decode(&doc); err != nil {
+				return err
+			}
+			participants = append(participants, doc.Participant)
+			return nil
+		})
+	return participants, err
+}
+
+func (bm *BrokerManager) CreateParticipant(ctx context.Context, domainProject string, participant *brokerpb.Participant) error {
+	domain, project := util.FromDomainProject(domainProject)
+	return insertBrokerData(ctx, model.CollectionBrokerParticipant, model.BrokerParticipant{
+		Domain:      domain,
+		Project:     project,
+		Participant: participant,
+	})
+}
+
+func (bm *BrokerManager) GetVersion(ctx context.Context, domainProject, number string, participantID int32) (*brokerpb.Version, error) {
+	filter := newBrokerFilter(domainProject, bson.M{
+		mutil.ConnectWithDot([]string{model.ColumnVersion, model.ColumnNumber}):        number,
+		mutil.ConnectWithDot([]string{model.ColumnVersion, model.ColumnParticipantID}): participantID,
+	})
+	var doc model.BrokerVersion
+	ok, err := findOneBrokerData(ctx, model.CollectionBrokerVersion, filter, &doc)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return doc.Version, nil
+}
+
+func (bm *BrokerManager) ListVersions(ctx context.Context, domainProject string) ([]*brokerpb.Version, error) {
+	var versions []*brokerpb.Version
+	err := findBrokerData(ctx, model.CollectionBrokerVersion, newBrokerFilter(domainProject, nil),
+		func(cursor *mongo.Cursor) error {
+			var doc model.BrokerVersion
+			if err := cursor.Decode(&doc); err != nil {
+				return err
+			}
+			versions = append(versions, doc.Version)
+			return nil
+		})
+	return versions, err
+}
+
+func (bm *BrokerManager) CreateVersion(ctx context.Context, domainProject string, version *brokerpb.Version) error {
+	domain, project := util.FromDomainProject(domainProject)
+	return insertBrokerData(ctx, model.CollectionBrokerVersion, model.BrokerVersion{
+		Domain:  domain,
+		Project: project,
+		Version: version,
+	})
+}
+
+func (bm *BrokerManager) GetPact(ctx context.Context, domainProject string, consumerParticipantID, providerParticipantID int32,
+	sha []byte) (*brokerpb.Pact, error) {
+	filter := newBrokerFilter(domainProject, bson.M{
+		mutil.ConnectWithDot([]string{model.ColumnPact, model.ColumnConsumerParticipantID}): consumerParticipantID,
+		mutil.ConnectWithDot([]string{model.ColumnPact, model.ColumnProviderParticipantID}): providerParticipantID,
+		mutil.ConnectWithDot([]string{model.ColumnPact, model.ColumnSha}):                   sha,
+	})
+	var doc model.BrokerPact
+	ok, err := findOneBrokerData(ctx, model.CollectionBrokerPact, filter, &doc)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return doc.Pact, nil
+}
+
+func (bm *BrokerManager) ListPacts(ctx context.Context, domainProject string) ([]*brokerpb.Pact, error) {
+	var pacts []*brokerpb.Pact
+	err := findBrokerData(ctx, model.CollectionBrokerPact, newBrokerFilter(domainProject, nil),
+		func(cursor *mongo.Cursor) error {
+			var doc model.BrokerPact
+			if err := cursor.Decode(&doc); err != nil {
+				return err
+			}
+			pacts = append(pacts, doc.Pact)
+			return nil
+		})
+	return pacts, err
+}
+
+func (bm *BrokerManager) CreatePact(ctx context.Context, domainProject string, pact *brokerpb.Pact) error {
+	domain, project := util.FromDomainProject(domainProject)
+	return insertBrokerData(ctx, model.CollectionBrokerPact, model.BrokerPact{
+		Domain:  domain,
+		Project: project,
+		Pact:    pact,
+	})
+}
+
+func (bm *BrokerManager) GetPactVersion(ctx context.Context, domainProject string, versionID, pactID int32) (*brokerpb.PactVersion, error) {
+	filter := newBrokerFilter(domainProject, bson.M{
+		mutil.ConnectWithDot([]string{model.ColumnPactVersion, model.ColumnVersionID}): versionID,
+		mutil.ConnectWithDot([]string{model.ColumnPactVersion, model.ColumnPactID}):    pactID,
+	})
+	var doc model.BrokerPactVersion
+	ok, err := findOneBrokerData(ctx, model.CollectionBrokerPactVersion, filter, &doc)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return doc.PactVersion, nil
+}
+
+func (bm *BrokerManager) ListPactVersions(ctx context.Context, domainProject string) ([]*brokerpb.PactVersion, error) {
+	var pactVersions []*brokerpb.PactVersion
+	err := findBrokerData(ctx, model.CollectionBrokerPactVersion, newBrokerFilter(domainProject, nil),
+		func(cursor *mongo.Cursor) error {
+			var doc model.BrokerPactVersion
+			if err := cursor.Decode(&doc); err != nil {
+				return err
+			}
+			pactVersions = append(pactVersions, doc.PactVersion)
+			return nil
+		})
+	return pactVersions, err
+}
+
+func (bm *BrokerManager) CreatePactVersion(ctx context.Context, domainProject string, pactVersion *brokerpb.PactVersion) error {
+	domain, project := util.FromDomainProject(domainProject)
+	return insertBrokerData(ctx, model.CollectionBrokerPactVersion, model.BrokerPactVersion{
+		Domain:      domain,
+		Project:     project,
+		PactVersion: pactVersion,
+	})
+}
+
+func (bm *BrokerManager) ListVerifications(ctx context.Context, domainProject string, pactVersionID int32) ([]*brokerpb.Verification, error) {
+	filter := newBrokerFilter(domainProject, bson.M{
+		mutil.ConnectWithDot([]string{model.ColumnVerification, model.ColumnPactVersionID}): pactVersionID,
+	})
+	var verifications []*brokerpb.Verification
+	err := findBrokerData(ctx, model.CollectionBrokerVerification, filter,
+		func(cursor *mongo.Cursor) error {
+			var doc model.BrokerVerification
+			if err := cursor.Decode(&doc); err != nil {
+				return err
+			}
+			verifications = append(verifications, doc.Verification)
+			return nil
+		})
+	return verifications, err
+}
+
+func (bm *BrokerManager) CreateVerification(ctx context.Context, domainProject string, verification *brokerpb.Verification) error {
+	domain, project := util.FromDomainProject(domainProject)
+	return insertBrokerData(ctx, model.CollectionBrokerVerification, model.BrokerVerification{
+		Domain:       domain,
+		Project:      project,
+		Verification: verification,
+	})
+}
+
+// GetLatestID returns the max ID of the kind, the IDs are unique in all
+// the domain projects
+func (bm *BrokerManager) GetLatestID(ctx context.Context, kind string) (int32, error) {
+	col, ok := brokerCollections[kind]
+	if !ok {
+		return -1, fmt.Errorf("unknown broker data kind %s", kind)
+	}
+	column := mutil.ConnectWithDot([]string{col[1], model.ColumnID})
+	result, err := client.GetMongoClient().FindOne(ctx, col[0], mutil.NewFilter(),
+		options.FindOne().SetSort(bson.M{column: -1}).SetProjection(bson.M{"_id": 0, column: 1}))
+	if err != nil {
+		return -1, err
+	}
+	var doc map[string]struct {
+		ID int32 `bson:"id"`
+	}
+	if err := result.Decode(&doc); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return -1, nil
+		}
+		return -1, err
+	}
+	data, ok := doc[col[1]]
+	if !ok {
+		return -1, nil
+	}
+	return data.ID, nil
+}
+
+func (bm *BrokerManager) DeleteAll(ctx context.Context) error {
+	for _, col := range brokerCollections {
+		if _, err := client.GetMongoClient().Delete(ctx, col[0], mutil.NewFilter()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newBrokerFilter(domainProject string, conditions bson.M) bson.M {
+	domain, project := util.FromDomainProject(domainProject)
+	return mutil.NewDomainProjectFilter(domain, project, func(filter bson.M) {
+		for k, v := range conditions {
+			filter[k] = v
+		}
+	})
+}
+
+func findOneBrokerData(ctx context.Context, col string, filter bson.M, doc interface{}) (bool, error) {
+	result, err := client.GetMongoClient().FindOne(ctx, col, filter)
+	if err != nil {
+		return false, err
+	}
+	if err = result.Decode(doc); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return false, nil
+		}
+		log.Error(fmt.Sprintf("failed to decode broker data from %s", col), err)
+		return false, err
+	}
+	return true, nil
+}
+
+func findBrokerData(ctx context.Context, col string, filter bson.M, decode func(cursor *mongo.Cursor) error) error {
+	cursor, err := client.GetMongoClient().Find(ctx, col, filter)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		if err := decode(cursor); err != nil {
+			log.Error(fmt.Sprintf("failed to decode broker data from %s", col), err)
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func insertBrokerData(ctx context.Context, col string, doc interface{}) error {
+	_, err := client.GetMongoClient().Insert(ctx, col, doc)
+	if err != nil {
+		log.Error(fmt.Sprintf("failed to insert broker data to %s", col), err)
+	}
+	return err
+}
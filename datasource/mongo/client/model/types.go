@@ -21,19 +21,33 @@ import (
 	"time"
 
 	pb "github.com/go-chassis/cari/discovery"
+
+	"github.com/apache/servicecomb-service-center/pkg/broker/brokerpb"
 )
 
 const (
-	CollectionAccount     = "account"
-	CollectionAccountLock = "account_lock"
-	CollectionService     = "service"
-	CollectionSchema      = "schema"
-	CollectionRule        = "rule"
-	CollectionInstance    = "instance"
-	CollectionDep         = "dependency"
-	CollectionRole        = "role"
-	CollectionDomain      = "domain"
-	CollectionProject     = "project"
+	CollectionAccount      = "account"
+	CollectionAccountLock  = "account_lock"
+	CollectionService      = "service"
+	CollectionSchema       = "schema"
+	CollectionRule         = "rule"
+	CollectionInstance     = "instance"
+	CollectionDep          = "dependency"
+	CollectionRole         = "role"
+	CollectionDomain       = "domain"
+	CollectionProject      = "project"
+	CollectionAlarm        = "alarm"
+	CollectionAlarmHistory = "alarm_history"
+	CollectionQuota        = "quota"
+
+	CollectionRevokedToken   = "revoked_token"
+	CollectionRevokedAccount = "revoked_account"
+
+	CollectionBrokerParticipant  = "broker_participant"
+	CollectionBrokerVersion      = "broker_version"
+	CollectionBrokerPact         = "broker_pact"
+	CollectionBrokerPactVersion  = "broker_pact_version"
+	CollectionBrokerVerification = "broker_verification"
 )
 
 const (
@@ -81,6 +95,24 @@ const (
 	ColumnAccountLockKey       = "key"
 	ColumnAccountLockStatus    = "status"
 	ColumnAccountLockReleaseAt = "release_at"
+	ColumnAccount              = "account"
+	ColumnExpireAt             = "expire_at"
+	ColumnRevokeAt             = "revoke_at"
+	ColumnTimestamp            = "timestamp"
+	ColumnNode                 = "node"
+
+	ColumnParticipant           = "participant"
+	ColumnPact                  = "pact"
+	ColumnPactVersion           = "pact_version"
+	ColumnVerification          = "verification"
+	ColumnNumber                = "number"
+	ColumnParticipantID         = "participant_id"
+	ColumnConsumerParticipantID = "consumer_participant_id"
+	ColumnProviderParticipantID = "provider_participant_id"
+	ColumnSha                   = "sha"
+	ColumnVersionID             = "version_id"
+	ColumnPactID                = "pact_id"
+	ColumnPactVersionID         = "pact_version_id"
 )
 
 type Service struct {
@@ -106,6 +138,12 @@ type Rule struct {
 	Rule      *pb.ServiceRule `json:"rule,omitempty"`
 }
 
+type RevokedToken struct {
+	ID       string     `json:"id,omitempty"`
+	Account  string     `json:"account,omitempty"`
+	ExpireAt *time.Time `json:"expireAt,omitempty" bson:"expire_at,omitempty"`
+}
+
 type Instance struct {
 	Domain      string                   `json:"domain,omitempty"`
 	Project     string                   `json:"project,omitempty"`
@@ -142,3 +180,33 @@ type Project struct {
 	Domain  string `json:"domain,omitempty"`
 	Project string `json:"project,omitempty"`
 }
+
+type BrokerParticipant struct {
+	Domain      string                `json:"domain,omitempty"`
+	Project     string                `json:"project,omitempty"`
+	Participant *brokerpb.Participant `json:"participant,omitempty"`
+}
+
+type BrokerVersion struct {
+	Domain  string            `json:"domain,omitempty"`
+	Project string            `json:"project,omitempty"`
+	Version *brokerpb.Version `json:"version,omitempty"`
+}
+
+type BrokerPact struct {
+	Domain  string         `json:"domain,omitempty"`
+	Project string         `json:"project,omitempty"`
+	Pact    *brokerpb.Pact `json:"pact,omitempty"`
+}
+
+type BrokerPactVersion struct {
+	Domain      string                `json:"domain,omitempty"`
+	Project     string                `json:"project,omitempty"`
+	PactVersion *brokerpb.PactVersion `json:"pactVersion,omitempty" bson:"pact_version"`
+}
+
+type BrokerVerification struct {
+	Domain       string                 `json:"domain,omitempty"`
+	Project      string                 `json:"project,omitempty"`
+	Verification *brokerpb.Verification `json:"verification,omitempty"`
+}
@@ -37,6 +37,10 @@ func EnsureDB() {
 	EnsureSchema()
 	EnsureDep()
 	EnsureAccountLock()
+	EnsureRevokedToken()
+	EnsureAlarm()
+	EnsureQuota()
+	EnsureBroker()
 }
 
 func EnsureService() {
@@ -99,6 +103,60 @@ func EnsureAccountLock() {
 		mutil.BuildIndexDoc(model.ColumnAccountLockKey)})
 }
 
+func EnsureRevokedToken() {
+	tokenIDIndex := mutil.BuildIndexDoc(model.ColumnID)
+	tokenIDIndex.Options = options.Index().SetUnique(true)
+	// the revoked token record is useless after the token expired
+	expireIndex := mutil.BuildIndexDoc(model.ColumnExpireAt)
+	expireIndex.Options = options.Index().SetExpireAfterSeconds(0)
+	EnsureCollection(model.CollectionRevokedToken, []mongo.IndexModel{tokenIDIndex, expireIndex})
+
+	accountIndex := mutil.BuildIndexDoc(model.ColumnAccount)
+	accountIndex.Options = options.Index().SetUnique(true)
+	EnsureCollection(model.CollectionRevokedAccount, []mongo.IndexModel{accountIndex})
+}
+
+func EnsureAlarm() {
+	alarmIndex := mutil.BuildIndexDoc(model.ColumnNode, model.ColumnID)
+	alarmIndex.Options = options.Index().SetUnique(true)
+	EnsureCollection(model.CollectionAlarm, []mongo.IndexModel{alarmIndex})
+	EnsureCollection(model.CollectionAlarmHistory, []mongo.IndexModel{
+		mutil.BuildIndexDoc(model.ColumnTimestamp)})
+}
+
+func EnsureQuota() {
+	quotaIndex := mutil.BuildIndexDoc(model.ColumnDomain, model.ColumnProject)
+	quotaIndex.Options = options.Index().SetUnique(true)
+	EnsureCollection(model.CollectionQuota, []mongo.IndexModel{quotaIndex})
+}
+
+func EnsureBroker() {
+	EnsureCollection(model.CollectionBrokerParticipant, []mongo.IndexModel{mutil.BuildIndexDoc(
+		model.ColumnDomain,
+		model.ColumnProject,
+		mutil.ConnectWithDot([]string{model.ColumnParticipant, model.ColumnAppID}),
+		mutil.ConnectWithDot([]string{model.ColumnParticipant, model.ColumnServiceName}))})
+	EnsureCollection(model.CollectionBrokerVersion, []mongo.IndexModel{mutil.BuildIndexDoc(
+		model.ColumnDomain,
+		model.ColumnProject,
+		mutil.ConnectWithDot([]string{model.ColumnVersion, model.ColumnNumber}),
+		mutil.ConnectWithDot([]string{model.ColumnVersion, model.ColumnParticipantID}))})
+	EnsureCollection(model.CollectionBrokerPact, []mongo.IndexModel{mutil.BuildIndexDoc(
+		model.ColumnDomain,
+		model.ColumnProject,
+		mutil.ConnectWithDot([]string{model.ColumnPact, model.ColumnConsumerParticipantID}),
+		mutil.ConnectWithDot([]string{model.ColumnPact, model.ColumnProviderParticipantID}))})
+	EnsureCollection(model.CollectionBrokerPactVersion, []mongo.IndexModel{mutil.BuildIndexDoc(
+		model.ColumnDomain,
+		model.ColumnProject,
+		mutil.ConnectWithDot([]string{model.ColumnPactVersion, model.ColumnVersionID}),
+		mutil.ConnectWithDot([]string{model.ColumnPactVersion, model.ColumnPactID}))})
+	EnsureCollection(model.CollectionBrokerVerification, []mongo.IndexModel{mutil.BuildIndexDoc(
+		model.ColumnDomain,
+		model.ColumnProject,
+		mutil.ConnectWithDot([]string{model.ColumnVerification, model.ColumnPactVersionID}))})
+}
+
 func EnsureCollection(col string, indexes []mongo.IndexModel) {
 	err := client.GetMongoClient().GetDB().CreateCollection(context.Background(), col, options.CreateCollection().SetValidator(nil))
 	wrapCreateCollectionError(err)
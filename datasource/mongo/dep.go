@@ -115,6 +115,16 @@ func (ds *DepManager) SearchConsumerDependency(ctx context.Context, request *dis
 	}, nil
 }
 
+func (ds *DepManager) GetConsumerDependencyRules(ctx context.Context, consumer *discovery.MicroService) ([]*discovery.MicroServiceKey, error) {
+	domainProject := util.ParseDomainProject(ctx)
+	filter := GenerateConsumerDependencyRuleKey(domainProject, discovery.MicroServiceToKey(domainProject, consumer))
+	dependency, err := TransferToMicroServiceDependency(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return dependency.Dependency, nil
+}
+
 func (ds *DepManager) AddOrUpdateDependencies(ctx context.Context, dependencys []*discovery.ConsumerDependency, override bool) (*discovery.Response, error) {
 	domainProject := util.ParseDomainProject(ctx)
 	for _, dependency := range dependencys {
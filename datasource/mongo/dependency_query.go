@@ -34,7 +34,7 @@ import (
 	"github.com/apache/servicecomb-service-center/datasource/mongo/client/model"
 	"github.com/apache/servicecomb-service-center/datasource/mongo/util"
 	"github.com/apache/servicecomb-service-center/pkg/log"
-	"github.com/apache/servicecomb-service-center/pkg/validate"
+	"github.com/apache/servicecomb-service-center/pkg/semver"
 )
 
 type DependencyRelation struct {
@@ -212,19 +212,11 @@ func VersionMatchRule(version, versionRule string) bool {
 	if len(versionRule) == 0 {
 		return false
 	}
-	rangeIdx := strings.Index(versionRule, "-")
-	versionInt, _ := validate.VersionToInt64(version)
-	switch {
-	case versionRule[len(versionRule)-1:] == "+":
-		start, _ := validate.VersionToInt64(versionRule[:len(versionRule)-1])
-		return versionInt >= start
-	case rangeIdx > 0:
-		start, _ := validate.VersionToInt64(versionRule[:rangeIdx])
-		end, _ := validate.VersionToInt64(versionRule[rangeIdx+1:])
-		return versionInt >= start && versionInt < end
-	default:
+	rule, err := semver.ParseRule(versionRule)
+	if err != nil {
 		return version == versionRule
 	}
+	return rule.Match(version)
 }
 
 func (dr *DependencyRelation) GetServiceByMicroServiceKey(service *pb.MicroServiceKey) (*pb.MicroService, error) {
@@ -458,47 +450,39 @@ func findServiceKeysByAlias(ctx context.Context, versionRule string, key *pb.Mic
 type ServiceVersionFilter func(ctx context.Context, filter bson.D) ([]string, error)
 
 func findServiceKeys(ctx context.Context, versionRule string, filter bson.D) (filterFunc ServiceVersionFilter, newFilter bson.D) {
-	rangeIdx := strings.Index(versionRule, "-")
-	switch {
-	case versionRule == "latest":
-		return GetVersionServiceLatest, filter
-	case versionRule[len(versionRule)-1:] == "+":
-		start := versionRule[:len(versionRule)-1]
-		filter = append(filter, bson.E{Key: util.ConnectWithDot([]string{model.ColumnService, model.ColumnVersion}), Value: bson.M{"$gte": start}})
-		return GetVersionService, filter
-	case rangeIdx > 0:
-		start := versionRule[:rangeIdx]
-		end := versionRule[rangeIdx+1:]
-		filter = append(filter, bson.E{Key: util.ConnectWithDot([]string{model.ColumnService, model.ColumnVersion}), Value: bson.M{"$gte": start, "$lt": end}})
-		return GetVersionService, filter
-	default:
+	rule, err := semver.ParseRule(versionRule)
+	if err != nil || rule.IsExact() {
 		filter = append(filter, bson.E{Key: util.ConnectWithDot([]string{model.ColumnService, model.ColumnVersion}), Value: versionRule})
 		return nil, filter
 	}
+	return GetVersionRuleService(rule), filter
 }
 
-func GetVersionServiceLatest(ctx context.Context, m bson.D) (serviceIds []string, err error) {
-	findRes, err := client.GetMongoClient().Find(ctx, model.CollectionService, m,
-		&options.FindOptions{
-			Sort: bson.M{util.ConnectWithDot([]string{model.ColumnService, model.ColumnVersion}): -1}})
-	if err != nil {
-		return nil, err
-	}
-	if findRes.Err() != nil {
-		return nil, findRes.Err()
-	}
-	for findRes.Next(ctx) {
-		var service *model.Service
-		err = findRes.Decode(&service)
+// GetVersionRuleService returns the ServiceVersionFilter finds the services matched
+// the version rule, the service ids are in descending order of version
+func GetVersionRuleService(rule *semver.Rule) ServiceVersionFilter {
+	return func(ctx context.Context, m bson.D) ([]string, error) {
+		findRes, err := client.GetMongoClient().Find(ctx, model.CollectionService, m)
 		if err != nil {
-			return
+			return nil, err
 		}
-		serviceIds = append(serviceIds, service.Service.ServiceId)
-		if serviceIds != nil {
-			return
+		if findRes.Err() != nil {
+			return nil, findRes.Err()
 		}
+		var services []*model.Service
+		for findRes.Next(ctx) {
+			var service *model.Service
+			if err := findRes.Decode(&service); err != nil {
+				return nil, err
+			}
+			services = append(services, service)
+		}
+		var serviceIDs []string
+		for _, service := range filterVersionRule(rule, services) {
+			serviceIDs = append(serviceIDs, service.Service.ServiceId)
+		}
+		return serviceIDs, nil
 	}
-	return
 }
 
 func GetVersionService(ctx context.Context, m bson.D) (serviceIds []string, err error) {
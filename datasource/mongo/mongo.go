@@ -39,18 +39,26 @@ func init() {
 type DataSource struct {
 	accountLockManager datasource.AccountLockManager
 	accountManager     datasource.AccountManager
+	tokenManager       datasource.TokenManager
 	metadataManager    datasource.MetadataManager
 	roleManager        datasource.RoleManager
 	sysManager         datasource.SystemManager
 	depManager         datasource.DependencyManager
 	scManager          datasource.SCManager
 	metricsManager     datasource.MetricsManager
+	alarmManager       datasource.AlarmManager
+	quotaManager       datasource.QuotaManager
+	brokerManager      datasource.BrokerManager
 }
 
 func (ds *DataSource) AccountLockManager() datasource.AccountLockManager {
 	return ds.accountLockManager
 }
 
+func (ds *DataSource) TokenManager() datasource.TokenManager {
+	return ds.tokenManager
+}
+
 func (ds *DataSource) SystemManager() datasource.SystemManager {
 	return ds.sysManager
 }
@@ -79,6 +87,18 @@ func (ds *DataSource) MetricsManager() datasource.MetricsManager {
 	return ds.metricsManager
 }
 
+func (ds *DataSource) AlarmManager() datasource.AlarmManager {
+	return ds.alarmManager
+}
+
+func (ds *DataSource) QuotaManager() datasource.QuotaManager {
+	return ds.quotaManager
+}
+
+func (ds *DataSource) BrokerManager() datasource.BrokerManager {
+	return ds.brokerManager
+}
+
 func NewDataSource(opts datasource.Options) (datasource.DataSource, error) {
 	// TODO: construct a reasonable DataSource instance
 	inst := &DataSource{}
@@ -93,7 +113,11 @@ func NewDataSource(opts datasource.Options) (datasource.DataSource, error) {
 	inst.metadataManager = &MetadataManager{SchemaNotEditable: opts.SchemaNotEditable, InstanceTTL: opts.InstanceTTL}
 	inst.accountManager = &AccountManager{}
 	inst.accountLockManager = NewAccountLockManager(opts.ReleaseAccountAfter)
+	inst.tokenManager = &TokenManager{}
 	inst.metricsManager = &MetricsManager{}
+	inst.alarmManager = &AlarmManager{}
+	inst.quotaManager = &QuotaManager{}
+	inst.brokerManager = &BrokerManager{}
 	return inst, nil
 }
 
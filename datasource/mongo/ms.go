@@ -46,6 +46,8 @@ import (
 	mutil "github.com/apache/servicecomb-service-center/datasource/mongo/util"
 	"github.com/apache/servicecomb-service-center/pkg/gopool"
 	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/selector"
+	"github.com/apache/servicecomb-service-center/pkg/semver"
 	"github.com/apache/servicecomb-service-center/pkg/util"
 	apt "github.com/apache/servicecomb-service-center/server/core"
 	"github.com/apache/servicecomb-service-center/server/plugin/quota"
@@ -1655,6 +1657,14 @@ func (ds *MetadataManager) GetInstance(ctx context.Context, request *discovery.G
 		}
 	}
 
+	if !selector.FromContext(ctx).Match(instance.Properties, provider.Tags) {
+		mes := fmt.Errorf("%s failed, provider instance does not match the selector", findFlag())
+		log.Error("get instance failed", mes)
+		return &discovery.GetOneInstanceResponse{
+			Response: discovery.CreateResponse(discovery.ErrInstanceNotExists, mes.Error()),
+		}, nil
+	}
+
 	newRev, _ := formatRevision(request.ConsumerServiceId, instances)
 	if rev == newRev {
 		instance = nil // for gRPC
@@ -2148,6 +2158,7 @@ func (ds *MetadataManager) findSharedServiceInstance(ctx context.Context, reques
 			Response: discovery.CreateResponse(discovery.ErrInternal, err.Error()),
 		}, err
 	}
+	instances = filterSelector(ctx, services, instances)
 	newRev, _ := formatRevision(request.ConsumerServiceId, instances)
 	if rev == newRev {
 		instances = nil // for gRPC
@@ -2227,6 +2238,7 @@ func (ds *MetadataManager) findInstance(ctx context.Context, request *discovery.
 			Response: discovery.CreateResponse(discovery.ErrInternal, err.Error()),
 		}, err
 	}
+	instances = filterSelector(ctx, services, instances)
 	// add dependency queue
 	if len(request.ConsumerServiceId) > 0 &&
 		len(serviceIDs) > 0 {
@@ -2519,19 +2531,11 @@ func servicesBasicFilter(ctx context.Context, key *discovery.MicroServiceKey) ([
 		mutil.ServiceAppID(key.AppId),
 		serviceNameOption,
 	)
-	rangeIdx := strings.Index(key.Version, "-")
 	// if the version number is clear, need to add the version number to query
-	switch {
-	case key.Version == "latest":
-		return dao.GetServices(ctx, filter)
-	case len(key.Version) > 0 && key.Version[len(key.Version)-1:] == "+":
-		return dao.GetServices(ctx, filter)
-	case rangeIdx > 0:
-		return dao.GetServices(ctx, filter)
-	default:
+	if rule, err := semver.ParseRule(key.Version); err != nil || rule.IsExact() {
 		filter[mutil.ConnectWithDot([]string{model.ColumnService, model.ColumnVersion})] = key.Version
-		return dao.GetServices(ctx, filter)
 	}
+	return dao.GetServices(ctx, filter)
 }
 
 func filterServices(ctx context.Context, key *discovery.MicroServiceKey) ([]*model.Service, error) {
@@ -2539,7 +2543,6 @@ func filterServices(ctx context.Context, key *discovery.MicroServiceKey) ([]*mod
 	if len(tenant) != 2 {
 		return nil, errors.New("invalid 'domain' or 'project'")
 	}
-	rangeIdx := strings.Index(key.Version, "-")
 	serviceNameOption := mutil.ServiceServiceName(key.ServiceName)
 	if len(key.Alias) > 0 {
 		serviceNameOption = mutil.Or(serviceNameOption, mutil.ServiceAlias(key.Alias))
@@ -2549,23 +2552,34 @@ func filterServices(ctx context.Context, key *discovery.MicroServiceKey) ([]*mod
 		mutil.ServiceAppID(key.AppId),
 		serviceNameOption,
 	)
-	switch {
-	case key.Version == "latest":
-		findOption := &options.FindOptions{Sort: bson.M{mutil.ConnectWithDot([]string{model.ColumnService, model.ColumnVersion}): -1}}
-		return dao.GetServices(ctx, filter, findOption)
-	case len(key.Version) > 0 && key.Version[len(key.Version)-1:] == "+":
-		start := key.Version[:len(key.Version)-1]
-		filter[mutil.ConnectWithDot([]string{model.ColumnService, model.ColumnVersion})] = bson.M{"$gte": start}
-		return dao.GetServices(ctx, filter)
-	case rangeIdx > 0:
-		start := key.Version[:rangeIdx]
-		end := key.Version[rangeIdx+1:]
-		filter[mutil.ConnectWithDot([]string{model.ColumnService, model.ColumnVersion})] = bson.M{"$gte": start, "$lte": end}
-		return dao.GetServices(ctx, filter)
-	default:
+	rule, err := semver.ParseRule(key.Version)
+	if err != nil || rule.IsExact() {
 		filter[mutil.ConnectWithDot([]string{model.ColumnService, model.ColumnVersion})] = key.Version
 		return dao.GetServices(ctx, filter)
 	}
+	services, err := dao.GetServices(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return filterVersionRule(rule, services), nil
+}
+
+// filterVersionRule returns the services matched the version rule in descending order of version
+func filterVersionRule(rule *semver.Rule, services []*model.Service) []*model.Service {
+	versions := make([]string, 0, len(services))
+	index := make(map[string][]*model.Service, len(services))
+	for _, service := range services {
+		version := service.Service.Version
+		if _, ok := index[version]; !ok {
+			versions = append(versions, version)
+		}
+		index[version] = append(index[version], service)
+	}
+	var matched []*model.Service
+	for _, version := range rule.Filter(versions) {
+		matched = append(matched, index[version]...)
+	}
+	return matched
 }
 
 func filterServiceIDs(ctx context.Context, consumerID string, tags []string, services []*model.Service) []string {
@@ -2601,6 +2615,27 @@ func filterTags(services []*model.Service, tags []string) []*model.Service {
 	return newServices
 }
 
+// filterSelector returns the instances matched the selector in context,
+// the instance properties take precedence over the service tags
+func filterSelector(ctx context.Context, services []*model.Service,
+	instances []*discovery.MicroServiceInstance) []*discovery.MicroServiceInstance {
+	s := selector.FromContext(ctx)
+	if s.Empty() || len(instances) == 0 {
+		return instances
+	}
+	tags := make(map[string]map[string]string, len(services))
+	for _, service := range services {
+		tags[service.Service.ServiceId] = service.Tags
+	}
+	matched := make([]*discovery.MicroServiceInstance, 0, len(instances))
+	for _, instance := range instances {
+		if s.Match(instance.Properties, tags[instance.ServiceId]) {
+			matched = append(matched, instance)
+		}
+	}
+	return matched
+}
+
 func filterAccess(ctx context.Context, consumerID string, services []*model.Service) []*model.Service {
 	newServices := make([]*model.Service, 0)
 	for _, service := range services {
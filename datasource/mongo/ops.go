@@ -42,6 +42,19 @@ func (ds *MetadataManager) GetServiceCount(ctx context.Context, request *pb.GetS
 	}, nil
 }
 
+func (ds *MetadataManager) GetServicesAcrossDomainProject(ctx context.Context) (map[string][]*pb.MicroService, error) {
+	services, err := dao.GetServices(ctx, mutil.NewFilter())
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string][]*pb.MicroService)
+	for _, service := range services {
+		domainProject := service.Domain + "/" + service.Project
+		m[domainProject] = append(m[domainProject], service.Service)
+	}
+	return m, nil
+}
+
 func (ds *MetadataManager) GetInstanceCount(ctx context.Context, request *pb.GetServiceCountRequest) (
 	*pb.GetServiceCountResponse, error) {
 	inFilter, err := ds.getNotGlobalServiceFilter(ctx)
@@ -0,0 +1,96 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/datasource/mongo/client"
+	"github.com/apache/servicecomb-service-center/datasource/mongo/client/model"
+	mutil "github.com/apache/servicecomb-service-center/datasource/mongo/util"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	qmodel "github.com/apache/servicecomb-service-center/pkg/quota/model"
+)
+
+type QuotaManager struct {
+}
+
+func (qm *QuotaManager) UpsertQuota(ctx context.Context, q *qmodel.Quota) error {
+	filter := mutil.NewDomainProjectFilter(q.Domain, q.Project)
+	_, err := client.GetMongoClient().Update(ctx, model.CollectionQuota, filter, mutil.NewFilter(mutil.Set(q)),
+		options.Update().SetUpsert(true))
+	if err != nil {
+		log.Error(fmt.Sprintf("can not save quota[%s/%s]", q.Domain, q.Project), err)
+		return err
+	}
+	return nil
+}
+
+func (qm *QuotaManager) GetQuota(ctx context.Context, domain, project string) (*qmodel.Quota, error) {
+	filter := mutil.NewDomainProjectFilter(domain, project)
+	result, err := client.GetMongoClient().FindOne(ctx, model.CollectionQuota, filter)
+	if err != nil {
+		return nil, err
+	}
+	if err = result.Err(); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, datasource.ErrQuotaNotExist
+		}
+		return nil, err
+	}
+	var q qmodel.Quota
+	err = result.Decode(&q)
+	if err != nil {
+		log.Error(fmt.Sprintf("failed to decode quota[%s/%s]", domain, project), err)
+		return nil, err
+	}
+	return &q, nil
+}
+
+func (qm *QuotaManager) ListQuotas(ctx context.Context, domain string) ([]*qmodel.Quota, error) {
+	filter := mutil.NewFilter()
+	if len(domain) > 0 {
+		mutil.Domain(domain)(filter)
+	}
+	cursor, err := client.GetMongoClient().Find(ctx, model.CollectionQuota, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var quotas []*qmodel.Quota
+	for cursor.Next(ctx) {
+		var q qmodel.Quota
+		if err := cursor.Decode(&q); err != nil {
+			log.Error("failed to decode quota", err)
+			continue
+		}
+		quotas = append(quotas, &q)
+	}
+	return quotas, nil
+}
+
+func (qm *QuotaManager) DeleteQuota(ctx context.Context, domain, project string) error {
+	filter := mutil.NewDomainProjectFilter(domain, project)
+	_, err := client.GetMongoClient().Delete(ctx, model.CollectionQuota, filter)
+	return err
+}
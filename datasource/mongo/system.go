@@ -20,9 +20,8 @@ package mongo
 import (
 	"context"
 
-	"github.com/patrickmn/go-cache"
-
 	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/datasource/etcd/path"
 	"github.com/apache/servicecomb-service-center/datasource/mongo/client/model"
 	"github.com/apache/servicecomb-service-center/datasource/mongo/sd"
 	"github.com/apache/servicecomb-service-center/pkg/dump"
@@ -33,15 +32,26 @@ import (
 type SysManager struct {
 }
 
+// DumpCache returns the cached services, instances, tags, rules and dependency rules,
+// the tags, rules and dependency rules have no document of their own key, so they
+// are keyed in the etcd format
 func (ds *SysManager) DumpCache(ctx context.Context) *dump.Cache {
 	var cache dump.Cache
-	gopool.New(ctx, gopool.Configure().Workers(2)).
+	gopool.New(ctx, gopool.Configure().Workers(5)).
 		Do(func(_ context.Context) { setServiceValue(sd.Store().Service(), &cache.Microservices) }).
+		Do(func(_ context.Context) { setTagValue(sd.Store().Service(), &cache.Tags) }).
+		Do(func(_ context.Context) { setRuleValue(sd.Store().Rule(), &cache.Rules) }).
+		Do(func(_ context.Context) { setDependencyRuleValue(sd.Store().Dep(), &cache.DependencyRules) }).
 		Do(func(_ context.Context) { setInstanceValue(sd.Store().Instance(), &cache.Instances) }).
 		Done()
 	return &cache
 }
 
+// DumpCacheSince always returns the full cache, because the mongo cache has no revision
+func (ds *SysManager) DumpCacheSince(ctx context.Context, rev int64) *dump.Cache {
+	return ds.DumpCache(ctx)
+}
+
 func (ds *SysManager) DLock(ctx context.Context, request *datasource.DLockRequest) error {
 	return nil
 }
@@ -52,7 +62,10 @@ func (ds *SysManager) DUnlock(ctx context.Context, request *datasource.DUnlockRe
 
 func setServiceValue(e *sd.MongoCacher, setter dump.Setter) {
 	e.Cache().ForEach(func(k string, kv interface{}) (next bool) {
-		service := kv.(cache.Item).Object.(model.Service)
+		service, ok := kv.(model.Service)
+		if !ok {
+			return true
+		}
 		setter.SetValue(&dump.KV{
 			Key: util.StringJoin([]string{datasource.ServiceKeyPrefix, service.Domain, service.Project, k},
 				datasource.SPLIT),
@@ -64,7 +77,10 @@ func setServiceValue(e *sd.MongoCacher, setter dump.Setter) {
 
 func setInstanceValue(e *sd.MongoCacher, setter dump.Setter) {
 	e.Cache().ForEach(func(k string, kv interface{}) (next bool) {
-		instance := kv.(cache.Item).Object.(model.Instance)
+		instance, ok := kv.(model.Instance)
+		if !ok || instance.Instance == nil {
+			return true
+		}
 		setter.SetValue(&dump.KV{
 			Key: util.StringJoin([]string{datasource.InstanceKeyPrefix, instance.Domain, instance.Project,
 				instance.Instance.ServiceId, k}, datasource.SPLIT),
@@ -73,3 +89,45 @@ func setInstanceValue(e *sd.MongoCacher, setter dump.Setter) {
 		return true
 	})
 }
+
+func setTagValue(e *sd.MongoCacher, setter dump.Setter) {
+	e.Cache().ForEach(func(k string, kv interface{}) (next bool) {
+		service, ok := kv.(model.Service)
+		if !ok || service.Service == nil || len(service.Tags) == 0 {
+			return true
+		}
+		setter.SetValue(&dump.KV{
+			Key:   path.GenerateServiceTagKey(service.Domain+path.SPLIT+service.Project, service.Service.ServiceId),
+			Value: service.Tags,
+		})
+		return true
+	})
+}
+
+func setRuleValue(e *sd.MongoCacher, setter dump.Setter) {
+	e.Cache().ForEach(func(k string, kv interface{}) (next bool) {
+		rule, ok := kv.(model.Rule)
+		if !ok || rule.Rule == nil {
+			return true
+		}
+		setter.SetValue(&dump.KV{
+			Key:   path.GenerateServiceRuleKey(rule.Domain+path.SPLIT+rule.Project, rule.ServiceID, rule.Rule.RuleId),
+			Value: rule.Rule,
+		})
+		return true
+	})
+}
+
+func setDependencyRuleValue(e *sd.MongoCacher, setter dump.Setter) {
+	e.Cache().ForEach(func(k string, kv interface{}) (next bool) {
+		dep, ok := kv.(model.DependencyRule)
+		if !ok || dep.ServiceKey == nil || dep.Dep == nil {
+			return true
+		}
+		setter.SetValue(&dump.KV{
+			Key:   path.GenerateServiceDependencyRuleKey(dep.Type, dep.Domain+path.SPLIT+dep.Project, dep.ServiceKey),
+			Value: dep.Dep,
+		})
+		return true
+	})
+}
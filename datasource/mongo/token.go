@@ -0,0 +1,142 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/datasource/mongo/client"
+	"github.com/apache/servicecomb-service-center/datasource/mongo/client/model"
+	mutil "github.com/apache/servicecomb-service-center/datasource/mongo/util"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+)
+
+// revocationCacheTTL limits the time to see the revocations of the other nodes
+const revocationCacheTTL = 5 * time.Second
+
+// revocationCache caches the lookups of every authenticated request,
+// the keys are the token ids and the account names with the prefixes
+var revocationCache = cache.New(revocationCacheTTL, revocationCacheTTL)
+
+func revokedTokenCacheKey(tokenID string) string {
+	return "token/" + tokenID
+}
+
+func revokedAccountCacheKey(account string) string {
+	return "account/" + account
+}
+
+type TokenManager struct {
+}
+
+func (tm *TokenManager) Revoke(ctx context.Context, t *datasource.RevokedToken) error {
+	doc := &model.RevokedToken{
+		ID:      t.ID,
+		Account: t.Account,
+	}
+	if t.ExpireAt > 0 {
+		expireAt := time.Unix(t.ExpireAt, 0)
+		if !expireAt.After(time.Now()) {
+			// the token is expired, no need to revoke it
+			return nil
+		}
+		doc.ExpireAt = &expireAt
+	}
+	filter := bson.M{model.ColumnID: t.ID}
+	_, err := client.GetMongoClient().Update(ctx, model.CollectionRevokedToken, filter, mutil.NewFilter(mutil.Set(doc)),
+		options.Update().SetUpsert(true))
+	if err != nil {
+		log.Error(fmt.Sprintf("can not save revoked token %s", t.ID), err)
+		return err
+	}
+	revocationCache.SetDefault(revokedTokenCacheKey(t.ID), true)
+	log.Info(fmt.Sprintf("token %s of account %s is revoked", t.ID, t.Account))
+	return nil
+}
+
+func (tm *TokenManager) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	key := revokedTokenCacheKey(tokenID)
+	if v, ok := revocationCache.Get(key); ok && !util.NoCache(ctx) {
+		return v.(bool), nil
+	}
+	revoked, err := client.GetMongoClient().DocExist(ctx, model.CollectionRevokedToken, bson.M{model.ColumnID: tokenID})
+	if err != nil {
+		return false, err
+	}
+	revocationCache.SetDefault(key, revoked)
+	return revoked, nil
+}
+
+func (tm *TokenManager) RevokeAccount(ctx context.Context, account string, revokeAt int64) error {
+	filter := bson.M{model.ColumnAccount: account}
+	update := mutil.NewFilter(mutil.Set(&datasource.RevokedAccount{
+		Account:  account,
+		RevokeAt: revokeAt,
+	}))
+	_, err := client.GetMongoClient().Update(ctx, model.CollectionRevokedAccount, filter, update,
+		options.Update().SetUpsert(true))
+	if err != nil {
+		log.Error(fmt.Sprintf("can not revoke tokens of account %s", account), err)
+		return err
+	}
+	revocationCache.SetDefault(revokedAccountCacheKey(account), revokeAt)
+	log.Info(fmt.Sprintf("tokens of account %s issued before %d are revoked", account, revokeAt))
+	return nil
+}
+
+func (tm *TokenManager) GetAccountRevokeAt(ctx context.Context, account string) (int64, error) {
+	key := revokedAccountCacheKey(account)
+	if v, ok := revocationCache.Get(key); ok && !util.NoCache(ctx) {
+		return v.(int64), nil
+	}
+	revokeAt, err := getAccountRevokeAt(ctx, account)
+	if err != nil {
+		return 0, err
+	}
+	revocationCache.SetDefault(key, revokeAt)
+	return revokeAt, nil
+}
+
+func getAccountRevokeAt(ctx context.Context, account string) (int64, error) {
+	result, err := client.GetMongoClient().FindOne(ctx, model.CollectionRevokedAccount,
+		bson.M{model.ColumnAccount: account})
+	if err != nil {
+		return 0, err
+	}
+	if err = result.Err(); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return 0, nil
+		}
+		log.Error(fmt.Sprintf("failed to query revoked account %s", account), err)
+		return 0, err
+	}
+	var revoked datasource.RevokedAccount
+	err = result.Decode(&revoked)
+	if err != nil {
+		log.Error(fmt.Sprintf("failed to decode revoked account %s", account), err)
+		return 0, err
+	}
+	return revoked.RevokeAt, nil
+}
@@ -86,6 +86,12 @@ func ID(id string) Option {
 	}
 }
 
+func Node(node string) Option {
+	return func(filter bson.M) {
+		filter[model.ColumnNode] = node
+	}
+}
+
 func RoleName(name string) Option {
 	return func(filter bson.M) {
 		filter[model.ColumnRoleName] = name
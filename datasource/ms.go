@@ -57,6 +57,9 @@ type MetadataManager interface {
 		serviceRespChan chan<- *pb.DelServicesRspInfo) func(context.Context)
 	GetServiceCount(ctx context.Context,
 		request *pb.GetServiceCountRequest) (*pb.GetServiceCountResponse, error)
+	// GetServicesAcrossDomainProject returns the services of all domains and projects,
+	// the map's key is domainProject
+	GetServicesAcrossDomainProject(ctx context.Context) (map[string][]*pb.MicroService, error)
 
 	// Instance management
 	RegisterInstance(ctx context.Context, request *pb.RegisterInstanceRequest) (*pb.RegisterInstanceResponse, error)
@@ -130,7 +130,7 @@ func TestRule_Add(t *testing.T) {
 	})
 
 	t.Run("create rule out of gaugue", func(t *testing.T) {
-		size := quota.DefaultRuleQuota + 1
+		size := quota.DefaultRuleQuota() + 1
 		rules := make([]*pb.AddOrUpdateServiceRule, 0, size)
 		for i := 0; i < size; i++ {
 			rules = append(rules, &pb.AddOrUpdateServiceRule{
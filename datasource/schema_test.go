@@ -70,7 +70,7 @@ func TestSchema_Create(t *testing.T) {
 
 	t.Run("create schemas out of gauge", func(t *testing.T) {
 		log.Info("create schemas out of gauge")
-		size := quota.DefaultSchemaQuota + 1
+		size := quota.DefaultSchemaQuota() + 1
 		schemaIds := make([]string, 0, size)
 		schemas := make([]*pb.Schema, 0, size)
 		for i := 0; i < size; i++ {
@@ -95,7 +95,7 @@ func TestSchema_Create(t *testing.T) {
 		log.Info("batch modify schemas 2")
 		resp, err = datasource.GetMetadataManager().ModifySchemas(getContext(), &pb.ModifySchemasRequest{
 			ServiceId: serviceIdDev,
-			Schemas:   schemas[:quota.DefaultSchemaQuota],
+			Schemas:   schemas[:quota.DefaultSchemaQuota()],
 		})
 		assert.NoError(t, err)
 		assert.Equal(t, pb.ResponseSuccess, resp.Response.GetCode())
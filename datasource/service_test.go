@@ -34,7 +34,7 @@ import (
 
 func TestService_Register(t *testing.T) {
 	t.Run("Register service after init & install, should pass", func(t *testing.T) {
-		size := quota.DefaultSchemaQuota + 1
+		size := quota.DefaultSchemaQuota() + 1
 		paths := make([]*pb.ServicePath, 0, size)
 		properties := make(map[string]string, size)
 		for i := 0; i < size; i++ {
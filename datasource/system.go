@@ -26,6 +26,9 @@ import (
 // SystemManager contains the APIs of system management
 type SystemManager interface {
 	DumpCache(ctx context.Context) *dump.Cache
+	// DumpCacheSince returns the kvs changed and deleted after the revision,
+	// or the full cache if the revision is compacted or not supported
+	DumpCacheSince(ctx context.Context, rev int64) *dump.Cache
 	DLock(ctx context.Context, request *DLockRequest) error
 	DUnlock(ctx context.Context, request *DUnlockRequest) error
 }
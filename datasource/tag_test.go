@@ -66,7 +66,7 @@ func TestTags_Add(t *testing.T) {
 
 	t.Run("the request is valid", func(t *testing.T) {
 		log.Info("tag quota is equal to the default value and should be paas")
-		defaultQuota := quota.DefaultTagQuota
+		defaultQuota := quota.DefaultTagQuota()
 		tags := make(map[string]string, defaultQuota)
 		for i := 0; i < defaultQuota; i++ {
 			s := "tag" + strconv.Itoa(i)
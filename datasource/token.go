@@ -0,0 +1,48 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package datasource
+
+import (
+	"context"
+)
+
+// TokenManager saves the revocation list of the rbac tokens, the lookups may read
+// a cache, so the revocation takes effect after the cache synced, unless the ctx
+// is set by util.WithNoCache
+type TokenManager interface {
+	// Revoke adds the token to the revocation list, the record is kept until the token expires
+	Revoke(ctx context.Context, t *RevokedToken) error
+	IsRevoked(ctx context.Context, tokenID string) (bool, error)
+	// RevokeAccount revokes all the tokens of the account issued before revokeAt(unix milliseconds)
+	RevokeAccount(ctx context.Context, account string, revokeAt int64) error
+	// GetAccountRevokeAt returns the time before which the tokens of the account are revoked,
+	// returns 0 if the account tokens were never revoked
+	GetAccountRevokeAt(ctx context.Context, account string) (int64, error)
+}
+
+// RevokedToken is the record of the revocation list, ExpireAt is 0 if the token never expires
+type RevokedToken struct {
+	ID       string `json:"id,omitempty"`
+	Account  string `json:"account,omitempty"`
+	ExpireAt int64  `json:"expireAt,omitempty" bson:"expire_at"`
+}
+
+type RevokedAccount struct {
+	Account  string `json:"account,omitempty"`
+	RevokeAt int64  `json:"revokeAt,omitempty" bson:"revoke_at"`
+}
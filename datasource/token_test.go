@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package datasource_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+)
+
+func TestTokenRevocation(t *testing.T) {
+	t.Run("revoke a token, should be in the revocation list", func(t *testing.T) {
+		revoked, err := datasource.GetTokenManager().IsRevoked(context.Background(), "TestTokenRevocation1")
+		assert.NoError(t, err)
+		assert.False(t, revoked)
+
+		err = datasource.GetTokenManager().Revoke(context.Background(), &datasource.RevokedToken{
+			ID:       "TestTokenRevocation1",
+			Account:  "TestTokenRevocation",
+			ExpireAt: time.Now().Add(time.Minute).Unix(),
+		})
+		assert.NoError(t, err)
+
+		// the revocation list may be cached, wait for the cache synced
+		assert.Eventually(t, func() bool {
+			revoked, err = datasource.GetTokenManager().IsRevoked(context.Background(), "TestTokenRevocation1")
+			return err == nil && revoked
+		}, 3*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("revoke an expired token, should be ignored", func(t *testing.T) {
+		err := datasource.GetTokenManager().Revoke(context.Background(), &datasource.RevokedToken{
+			ID:       "TestTokenRevocation2",
+			Account:  "TestTokenRevocation",
+			ExpireAt: time.Now().Add(-time.Minute).Unix(),
+		})
+		assert.NoError(t, err)
+
+		revoked, err := datasource.GetTokenManager().IsRevoked(context.Background(), "TestTokenRevocation2")
+		assert.NoError(t, err)
+		assert.False(t, revoked)
+	})
+
+	t.Run("revoke account tokens, should refresh revokeAt", func(t *testing.T) {
+		revokeAt, err := datasource.GetTokenManager().GetAccountRevokeAt(context.Background(), "TestTokenRevocation")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), revokeAt)
+
+		err = datasource.GetTokenManager().RevokeAccount(context.Background(), "TestTokenRevocation", 1)
+		assert.NoError(t, err)
+		assert.Eventually(t, func() bool {
+			revokeAt, err = datasource.GetTokenManager().GetAccountRevokeAt(context.Background(), "TestTokenRevocation")
+			return err == nil && revokeAt == 1
+		}, 3*time.Second, 10*time.Millisecond)
+
+		err = datasource.GetTokenManager().RevokeAccount(context.Background(), "TestTokenRevocation", 2)
+		assert.NoError(t, err)
+		assert.Eventually(t, func() bool {
+			revokeAt, err = datasource.GetTokenManager().GetAccountRevokeAt(context.Background(), "TestTokenRevocation")
+			return err == nil && revokeAt == 2
+		}, 3*time.Second, 10*time.Millisecond)
+	})
+}
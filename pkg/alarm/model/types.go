@@ -0,0 +1,122 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	nf "github.com/apache/servicecomb-service-center/pkg/event"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+)
+
+type ID string
+
+type Status string
+
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+type AlarmEvent struct {
+	nf.Event `json:"-"`
+	Status   Status          `json:"status"`
+	ID       ID              `json:"id"`
+	Fields   util.JSONObject `json:"fields,omitempty"`
+}
+
+func (ae *AlarmEvent) FieldBool(key string) bool {
+	v, _ := ae.Fields[key].(bool)
+	return v
+}
+
+func (ae *AlarmEvent) FieldString(key string) string {
+	v, _ := ae.Fields[key].(string)
+	return v
+}
+
+func (ae *AlarmEvent) FieldInt64(key string) int64 {
+	v, _ := ae.Fields[key].(int64)
+	return v
+}
+
+func (ae *AlarmEvent) FieldInt(key string) int {
+	v, _ := ae.Fields[key].(int)
+	return v
+}
+
+func (ae *AlarmEvent) FieldFloat64(key string) float64 {
+	v, _ := ae.Fields[key].(float64)
+	return v
+}
+
+// Alarm is the persistent state of an alarm
+type Alarm struct {
+	// Node is the service center instance which raised the alarm
+	Node   string          `json:"node,omitempty" bson:"node"`
+	ID     ID              `json:"id" bson:"id"`
+	Status Status          `json:"status" bson:"status"`
+	Fields util.JSONObject `json:"fields,omitempty" bson:"fields,omitempty"`
+	// Count is the occurrence count since the alarm first raised
+	Count           int64 `json:"count" bson:"count"`
+	FirstRaisedTime int64 `json:"firstRaisedTime" bson:"first_raised_time"`
+	LastRaisedTime  int64 `json:"lastRaisedTime" bson:"last_raised_time"`
+	ClearedTime     int64 `json:"clearedTime,omitempty" bson:"cleared_time"`
+	// Acknowledged is reset when the alarm is activated again
+	Acknowledged     bool   `json:"acknowledged" bson:"acknowledged"`
+	AcknowledgedBy   string `json:"acknowledgedBy,omitempty" bson:"acknowledged_by"`
+	AcknowledgedTime int64  `json:"acknowledgedTime,omitempty" bson:"acknowledged_time"`
+}
+
+func (a *Alarm) FieldString(key string) string {
+	v, _ := a.Fields[key].(string)
+	return v
+}
+
+// History is the record of an alarm status transition
+type History struct {
+	Node      string          `json:"node,omitempty" bson:"node"`
+	ID        ID              `json:"id" bson:"id"`
+	Status    Status          `json:"status" bson:"status"`
+	Fields    util.JSONObject `json:"fields,omitempty" bson:"fields,omitempty"`
+	Timestamp int64           `json:"timestamp" bson:"timestamp"`
+}
+
+// HistoryRequest filters the histories, the zero value fields are ignored
+type HistoryRequest struct {
+	ID     ID
+	Status Status
+	// Start and End are the unix time range, both inclusive
+	Start int64
+	End   int64
+	// Limit the count of the latest histories
+	Limit int64
+}
+
+// Match returns true if the history satisfies the request filters
+func (r *HistoryRequest) Match(h *History) bool {
+	if len(r.ID) > 0 && r.ID != h.ID {
+		return false
+	}
+	if len(r.Status) > 0 && r.Status != h.Status {
+		return false
+	}
+	if r.Start > 0 && h.Timestamp < r.Start {
+		return false
+	}
+	if r.End > 0 && h.Timestamp > r.End {
+		return false
+	}
+	return true
+}
@@ -22,9 +22,9 @@ import (
 )
 
 type Participant struct {
-	Id          int32  `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
-	AppId       string `protobuf:"bytes,2,opt,name=appId" json:"appId,omitempty"`
-	ServiceName string `protobuf:"bytes,3,opt,name=serviceName" json:"serviceName,omitempty"`
+	Id          int32  `protobuf:"varint,1,opt,name=id" json:"id,omitempty" bson:"id"`
+	AppId       string `protobuf:"bytes,2,opt,name=appId" json:"appId,omitempty" bson:"app"`
+	ServiceName string `protobuf:"bytes,3,opt,name=serviceName" json:"serviceName,omitempty" bson:"service_name"`
 }
 
 func (m *Participant) Reset() { *m = Participant{} }
@@ -51,10 +51,10 @@ func (m *Participant) GetServiceName() string {
 }
 
 type Version struct {
-	Id            int32  `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
-	Number        string `protobuf:"bytes,2,opt,name=number" json:"number,omitempty"`
-	ParticipantId int32  `protobuf:"varint,3,opt,name=participantId" json:"participantId,omitempty"`
-	Order         int32  `protobuf:"varint,4,opt,name=order" json:"order,omitempty"`
+	Id            int32  `protobuf:"varint,1,opt,name=id" json:"id,omitempty" bson:"id"`
+	Number        string `protobuf:"bytes,2,opt,name=number" json:"number,omitempty" bson:"number"`
+	ParticipantId int32  `protobuf:"varint,3,opt,name=participantId" json:"participantId,omitempty" bson:"participant_id"`
+	Order         int32  `protobuf:"varint,4,opt,name=order" json:"order,omitempty" bson:"order"`
 }
 
 func (m *Version) Reset() { *m = Version{} }
@@ -88,11 +88,11 @@ func (m *Version) GetOrder() int32 {
 }
 
 type Pact struct {
-	Id                    int32  `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
-	ConsumerParticipantId int32  `protobuf:"varint,2,opt,name=consumerParticipantId" json:"consumerParticipantId,omitempty"`
-	ProviderParticipantId int32  `protobuf:"varint,3,opt,name=providerParticipantId" json:"providerParticipantId,omitempty"`
-	Sha                   []byte `protobuf:"bytes,4,opt,name=sha,proto3" json:"sha,omitempty"`
-	Content               []byte `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	Id                    int32  `protobuf:"varint,1,opt,name=id" json:"id,omitempty" bson:"id"`
+	ConsumerParticipantId int32  `protobuf:"varint,2,opt,name=consumerParticipantId" json:"consumerParticipantId,omitempty" bson:"consumer_participant_id"`
+	ProviderParticipantId int32  `protobuf:"varint,3,opt,name=providerParticipantId" json:"providerParticipantId,omitempty" bson:"provider_participant_id"`
+	Sha                   []byte `protobuf:"bytes,4,opt,name=sha,proto3" json:"sha,omitempty" bson:"sha"`
+	Content               []byte `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty" bson:"content"`
 }
 
 func (m *Pact) Reset() { *m = Pact{} }
@@ -133,10 +133,10 @@ func (m *Pact) GetContent() []byte {
 }
 
 type PactVersion struct {
-	Id                    int32 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
-	VersionId             int32 `protobuf:"varint,2,opt,name=versionId" json:"versionId,omitempty"`
-	PactId                int32 `protobuf:"varint,3,opt,name=pactId" json:"pactId,omitempty"`
-	ProviderParticipantId int32 `protobuf:"varint,4,opt,name=providerParticipantId" json:"providerParticipantId,omitempty"`
+	Id                    int32 `protobuf:"varint,1,opt,name=id" json:"id,omitempty" bson:"id"`
+	VersionId             int32 `protobuf:"varint,2,opt,name=versionId" json:"versionId,omitempty" bson:"version_id"`
+	PactId                int32 `protobuf:"varint,3,opt,name=pactId" json:"pactId,omitempty" bson:"pact_id"`
+	ProviderParticipantId int32 `protobuf:"varint,4,opt,name=providerParticipantId" json:"providerParticipantId,omitempty" bson:"provider_participant_id"`
 }
 
 func (m *PactVersion) Reset() { *m = PactVersion{} }
@@ -340,13 +340,13 @@ func (m *GetProviderConsumerVersionPactResponse) GetPact() []byte {
 }
 
 type Verification struct {
-	Id               int32  `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
-	Number           int32  `protobuf:"varint,2,opt,name=number" json:"number,omitempty"`
-	PactVersionId    int32  `protobuf:"varint,3,opt,name=pactVersionId" json:"pactVersionId,omitempty"`
-	Success          bool   `protobuf:"varint,4,opt,name=success" json:"success,omitempty"`
-	ProviderVersion  string `protobuf:"bytes,5,opt,name=providerVersion" json:"providerVersion,omitempty"`
-	BuildUrl         string `protobuf:"bytes,6,opt,name=buildUrl" json:"buildUrl,omitempty"`
-	VerificationDate string `protobuf:"bytes,7,opt,name=verificationDate" json:"verificationDate,omitempty"`
+	Id               int32  `protobuf:"varint,1,opt,name=id" json:"id,omitempty" bson:"id"`
+	Number           int32  `protobuf:"varint,2,opt,name=number" json:"number,omitempty" bson:"number"`
+	PactVersionId    int32  `protobuf:"varint,3,opt,name=pactVersionId" json:"pactVersionId,omitempty" bson:"pact_version_id"`
+	Success          bool   `protobuf:"varint,4,opt,name=success" json:"success,omitempty" bson:"success"`
+	ProviderVersion  string `protobuf:"bytes,5,opt,name=providerVersion" json:"providerVersion,omitempty" bson:"provider_version"`
+	BuildUrl         string `protobuf:"bytes,6,opt,name=buildUrl" json:"buildUrl,omitempty" bson:"build_url"`
+	VerificationDate string `protobuf:"bytes,7,opt,name=verificationDate" json:"verificationDate,omitempty" bson:"verification_date"`
 }
 
 func (m *Verification) Reset() { *m = Verification{} }
@@ -675,3 +675,160 @@ func (m *BrokerHomeResponse) GetCuries() []*BrokerAPIInfoEntry {
 	}
 	return nil
 }
+
+type MatrixRequest struct {
+	ConsumerId      string `protobuf:"bytes,1,opt,name=consumerId" json:"consumerId,omitempty"`
+	ConsumerVersion string `protobuf:"bytes,2,opt,name=consumerVersion" json:"consumerVersion,omitempty"`
+}
+
+func (m *MatrixRequest) Reset() { *m = MatrixRequest{} }
+
+func (m *MatrixRequest) GetConsumerId() string {
+	if m != nil {
+		return m.ConsumerId
+	}
+	return ""
+}
+
+func (m *MatrixRequest) GetConsumerVersion() string {
+	if m != nil {
+		return m.ConsumerVersion
+	}
+	return ""
+}
+
+type MatrixRow struct {
+	ProviderVersion  string `protobuf:"bytes,1,opt,name=providerVersion" json:"providerVersion,omitempty"`
+	Instances        int64  `protobuf:"varint,2,opt,name=instances" json:"instances,omitempty"`
+	Verified         bool   `protobuf:"varint,3,opt,name=verified" json:"verified,omitempty"`
+	Success          bool   `protobuf:"varint,4,opt,name=success" json:"success,omitempty"`
+	VerificationDate string `protobuf:"bytes,5,opt,name=verificationDate" json:"verificationDate,omitempty"`
+}
+
+func (m *MatrixRow) Reset() { *m = MatrixRow{} }
+
+func (m *MatrixRow) GetProviderVersion() string {
+	if m != nil {
+		return m.ProviderVersion
+	}
+	return ""
+}
+
+func (m *MatrixRow) GetInstances() int64 {
+	if m != nil {
+		return m.Instances
+	}
+	return 0
+}
+
+func (m *MatrixRow) GetVerified() bool {
+	if m != nil {
+		return m.Verified
+	}
+	return false
+}
+
+func (m *MatrixRow) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *MatrixRow) GetVerificationDate() string {
+	if m != nil {
+		return m.VerificationDate
+	}
+	return ""
+}
+
+type MatrixProvider struct {
+	AppId        string       `protobuf:"bytes,1,opt,name=appId" json:"appId,omitempty"`
+	ProviderName string       `protobuf:"bytes,2,opt,name=providerName" json:"providerName,omitempty"`
+	Deployable   bool         `protobuf:"varint,3,opt,name=deployable" json:"deployable"`
+	Reason       string       `protobuf:"bytes,4,opt,name=reason" json:"reason,omitempty"`
+	Rows         []*MatrixRow `protobuf:"bytes,5,rep,name=rows" json:"rows,omitempty"`
+}
+
+func (m *MatrixProvider) Reset() { *m = MatrixProvider{} }
+
+func (m *MatrixProvider) GetAppId() string {
+	if m != nil {
+		return m.AppId
+	}
+	return ""
+}
+
+func (m *MatrixProvider) GetProviderName() string {
+	if m != nil {
+		return m.ProviderName
+	}
+	return ""
+}
+
+func (m *MatrixProvider) GetDeployable() bool {
+	if m != nil {
+		return m.Deployable
+	}
+	return false
+}
+
+func (m *MatrixProvider) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *MatrixProvider) GetRows() []*MatrixRow {
+	if m != nil {
+		return m.Rows
+	}
+	return nil
+}
+
+type MatrixSummary struct {
+	Deployable bool   `protobuf:"varint,1,opt,name=deployable" json:"deployable"`
+	Reason     string `protobuf:"bytes,2,opt,name=reason" json:"reason,omitempty"`
+	Success    int32  `protobuf:"varint,3,opt,name=success" json:"success"`
+	Failed     int32  `protobuf:"varint,4,opt,name=failed" json:"failed"`
+	Unknown    int32  `protobuf:"varint,5,opt,name=unknown" json:"unknown"`
+}
+
+func (m *MatrixSummary) Reset() { *m = MatrixSummary{} }
+
+func (m *MatrixSummary) GetDeployable() bool {
+	if m != nil {
+		return m.Deployable
+	}
+	return false
+}
+
+func (m *MatrixSummary) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type MatrixResponse struct {
+	Response *discovery.Response `protobuf:"bytes,1,opt,name=response" json:"-"`
+	Summary  *MatrixSummary      `protobuf:"bytes,2,opt,name=summary" json:"summary,omitempty"`
+	Matrix   []*MatrixProvider   `protobuf:"bytes,3,rep,name=matrix" json:"matrix,omitempty"`
+}
+
+func (m *MatrixResponse) Reset() { *m = MatrixResponse{} }
+
+func (m *MatrixResponse) GetSummary() *MatrixSummary {
+	if m != nil {
+		return m.Summary
+	}
+	return nil
+}
+
+func (m *MatrixResponse) GetMatrix() []*MatrixProvider {
+	if m != nil {
+		return m.Matrix
+	}
+	return nil
+}
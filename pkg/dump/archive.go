@@ -0,0 +1,94 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dump
+
+import (
+	"github.com/apache/servicecomb-service-center/pkg/gov"
+	"github.com/go-chassis/cari/discovery"
+	"github.com/go-chassis/cari/rbac"
+)
+
+// ArchiveVersion is the format version of the archive written by export,
+// import rejects the archives of the newer versions
+const ArchiveVersion = 1
+
+// the resource names counted in ImportResult
+const (
+	ResourceService    = "service"
+	ResourceSchema     = "schema"
+	ResourceTag        = "tag"
+	ResourceRule       = "rule"
+	ResourceDependency = "dependency"
+	ResourceAccount    = "account"
+	ResourceRole       = "role"
+	ResourcePolicy     = "policy"
+)
+
+// Archive is the logical backup of the registry, it is independent of the
+// datasource kind, so it can be imported into any kind of datasource
+type Archive struct {
+	Version int `json:"version"`
+	// Kind is the datasource kind where the archive is exported from
+	Kind      string `json:"kind,omitempty"`
+	Timestamp string `json:"timestamp,omitempty"`
+
+	Projects []*ProjectArchive `json:"projects,omitempty"`
+	Accounts []*rbac.Account   `json:"accounts,omitempty"`
+	Roles    []*rbac.Role      `json:"roles,omitempty"`
+}
+
+// ProjectArchive holds the resources under a domain project
+type ProjectArchive struct {
+	Domain   string            `json:"domain"`
+	Project  string            `json:"project"`
+	Services []*ServiceArchive `json:"services,omitempty"`
+	// Dependencies are the consumers and the resolved versions of their providers
+	Dependencies []*discovery.ConsumerDependency `json:"dependencies,omitempty"`
+	Policies     []*gov.Policy                   `json:"policies,omitempty"`
+}
+
+// ServiceArchive holds a microservice and the resources belong to it
+type ServiceArchive struct {
+	Service *discovery.MicroService  `json:"service"`
+	Schemas []*discovery.Schema      `json:"schemas,omitempty"`
+	Tags    map[string]string        `json:"tags,omitempty"`
+	Rules   []*discovery.ServiceRule `json:"rules,omitempty"`
+}
+
+// ImportResult is the number of the imported and the skipped resources,
+// the resources already exist in the target are skipped
+type ImportResult struct {
+	Imported map[string]int `json:"imported,omitempty"`
+	Skipped  map[string]int `json:"skipped,omitempty"`
+	Errors   []string       `json:"errors,omitempty"`
+}
+
+type ExportRequest struct {
+}
+
+type ExportResponse struct {
+	Response *discovery.Response `json:"-"`
+	Archive  *Archive            `json:"archive,omitempty"`
+}
+
+type ImportRequest struct {
+	Archive *Archive `json:"archive"`
+}
+
+type ImportResponse struct {
+	Response *discovery.Response `json:"-"`
+	Result   *ImportResult       `json:"result,omitempty"`
+}
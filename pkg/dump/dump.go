@@ -159,6 +159,24 @@ type Cache struct {
 	DependencyRules MicroServiceDependencyRuleSlice `json:"dependencyRules,omitempty"`
 	Summaries       SummarySlice                    `json:"summaries,omitempty"`
 	Instances       InstanceSlice                   `json:"instances,omitempty"`
+	// Revision is the latest revision of the cache, it can be used as the
+	// 'since' revision of the next incremental dump
+	Revision int64 `json:"revision,omitempty"`
+	// Incremental is true if the cache only contains the kvs changed since
+	// the requested revision, and the deleted kvs are in Deletions
+	Incremental bool  `json:"incremental,omitempty"`
+	Deletions   []*KV `json:"deletions,omitempty"`
+}
+
+type KvSlice interface {
+	Getter
+	Setter
+}
+
+// Slices returns all kv slices of the cache
+func (c *Cache) Slices() []KvSlice {
+	return []KvSlice{&c.Microservices, &c.Indexes, &c.Aliases, &c.Tags, &c.Rules,
+		&c.RuleIndexes, &c.DependencyRules, &c.Summaries, &c.Instances}
 }
 
 type KV struct {
@@ -214,6 +232,9 @@ type Instance struct {
 
 type Request struct {
 	Options []string
+	// Since is the revision of the last dump, the cache returns the
+	// changes after it if greater than 0
+	Since int64
 }
 
 type Response struct {
@@ -227,4 +248,5 @@ type WatchInstanceChangedEvent struct {
 	Action   string        `protobuf:"bytes,1,opt,name=action" json:"action,omitempty"`
 	Service  *Microservice `protobuf:"bytes,2,opt,name=service" json:"service,omitempty"`
 	Instance *Instance     `protobuf:"bytes,3,opt,name=instance" json:"instance,omitempty"`
+	Token    string        `protobuf:"bytes,4,opt,name=token" json:"token,omitempty"`
 }
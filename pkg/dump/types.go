@@ -16,17 +16,36 @@
 package dump
 
 import (
+	"github.com/apache/servicecomb-service-center/pkg/alarm/model"
 	"github.com/apache/servicecomb-service-center/pkg/cluster"
-	"github.com/apache/servicecomb-service-center/server/alarm/model"
+	qmodel "github.com/apache/servicecomb-service-center/pkg/quota/model"
 	"github.com/go-chassis/cari/discovery"
 )
 
 type AlarmListRequest struct {
+	// Status filters the alarms, empty means all
+	Status model.Status
 }
 
 type AlarmListResponse struct {
 	Response *discovery.Response `json:"-"`
 	Alarms   []*model.AlarmEvent `json:"alarms,omitempty"`
+	// Details are the persistent states of the alarms, in the same order as Alarms
+	Details []*model.Alarm `json:"details,omitempty"`
+}
+
+type AlarmHistoryResponse struct {
+	Response  *discovery.Response `json:"-"`
+	Histories []*model.History    `json:"histories,omitempty"`
+}
+
+type AckAlarmRequest struct {
+	ID model.ID
+}
+
+type AckAlarmResponse struct {
+	Response *discovery.Response `json:"-"`
+	Alarm    *model.Alarm        `json:"alarm,omitempty"`
 }
 
 type ClustersRequest struct {
@@ -43,3 +62,50 @@ type ClearAlarmRequest struct {
 type ClearAlarmResponse struct {
 	Response *discovery.Response `json:"-"`
 }
+
+// GetQuotaRequest gets the quota of the caller's domain project if Domain and Project are empty
+type GetQuotaRequest struct {
+	Domain  string `json:"domain,omitempty"`
+	Project string `json:"project,omitempty"`
+}
+
+type PutQuotaRequest struct {
+	Domain  string `json:"domain"`
+	Project string `json:"project"`
+	// Limits is the resource name to the max num, see the quota model resources
+	Limits map[string]int64 `json:"limits"`
+}
+
+type QuotaResponse struct {
+	Response *discovery.Response `json:"-"`
+	Quota    *qmodel.Quota       `json:"quota,omitempty"`
+}
+
+type DeleteQuotaRequest struct {
+	Domain  string `json:"domain"`
+	Project string `json:"project"`
+}
+
+type DeleteQuotaResponse struct {
+	Response *discovery.Response `json:"-"`
+}
+
+type QuotaUsageRequest struct {
+	ServiceID string `json:"serviceId,omitempty"`
+}
+
+type QuotaUsageResponse struct {
+	Response *discovery.Response `json:"-"`
+	Usages   []*qmodel.Usage     `json:"usages,omitempty"`
+}
+
+type ReloadConfigRequest struct {
+}
+
+type ReloadConfigResponse struct {
+	Response *discovery.Response `json:"-"`
+	// Reloaded are the changed configurations took effect
+	Reloaded []string `json:"reloaded,omitempty"`
+	// RequireRestart are the changed configurations require restart to take effect
+	RequireRestart []string `json:"requireRestart,omitempty"`
+}
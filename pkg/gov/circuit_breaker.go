@@ -0,0 +1,43 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gov
+
+const (
+	SlidingWindowCount = "count"
+	SlidingWindowTime  = "time"
+)
+
+//CircuitBreakerSpec is the spec of circuit-breaker kind, the circuit of the
+//requests marked by match group opens when the failure rate exceeds the threshold
+type CircuitBreakerSpec struct {
+	MatchRef
+	FailureRateThreshold                  int      `json:"failureRateThreshold,omitempty" schema:"min=0,max=100" description:"the failure rate percentage to open the circuit"`
+	SlowCallRateThreshold                 int      `json:"slowCallRateThreshold,omitempty" schema:"min=0,max=100" description:"the slow call rate percentage to open the circuit"`
+	SlowCallDurationThreshold             Duration `json:"slowCallDurationThreshold,omitempty" description:"the duration which a call is slow, the number is in milliseconds"`
+	MinimumNumberOfCalls                  int      `json:"minimumNumberOfCalls,omitempty" schema:"min=0" description:"the minimum calls before the failure rate is calculated"`
+	SlidingWindowType                     string   `json:"slidingWindowType,omitempty" schema:"enum=count|time" description:"the sliding window type"`
+	SlidingWindowSize                     Duration `json:"slidingWindowSize,omitempty" description:"the calls of count window, or the duration of time window, the number is in seconds"`
+	WaitDurationInOpenState               Duration `json:"waitDurationInOpenState,omitempty" description:"the time the circuit stays open, the number is in milliseconds"`
+	PermittedNumberOfCallsInHalfOpenState int      `json:"permittedNumberOfCallsInHalfOpenState,omitempty" schema:"min=0" description:"the permitted calls when the circuit is half open"`
+}
+
+//InstanceIsolationSpec is the spec of instance-isolation kind, it is a
+//circuit breaker per provider instance
+type InstanceIsolationSpec struct {
+	CircuitBreakerSpec
+}
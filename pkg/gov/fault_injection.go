@@ -0,0 +1,54 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gov
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	FaultTypeDelay = "delay"
+	FaultTypeAbort = "abort"
+)
+
+//FaultInjectionSpec is the spec of fault-injection kind, it delays or
+//aborts a percentage of the requests marked by match group
+type FaultInjectionSpec struct {
+	MatchRef
+	Type         string   `json:"type" schema:"required,enum=delay|abort" description:"the fault type"`
+	Percentage   int      `json:"percentage,omitempty" schema:"min=0,max=100" description:"the percentage of requests to inject fault"`
+	DelayTime    Duration `json:"delayTime,omitempty" description:"the delay of delay fault, the number is in milliseconds"`
+	ErrorCode    int      `json:"errorCode,omitempty" schema:"min=0,max=599" description:"the response status code of abort fault"`
+	FallbackType string   `json:"fallbackType,omitempty" schema:"enum=ThrowException|ReturnNull" description:"the fallback of abort fault"`
+	ForceClosed  bool     `json:"forceClosed,omitempty" description:"disable the fault injection"`
+}
+
+func (s *FaultInjectionSpec) Validate() error {
+	switch s.Type {
+	case FaultTypeDelay:
+		if len(s.DelayTime) == 0 {
+			return errors.New("delayTime is required by delay fault")
+		}
+	case FaultTypeAbort:
+		if s.ErrorCode < 100 {
+			return fmt.Errorf("errorCode %d of abort fault must be a response status code", s.ErrorCode)
+		}
+	}
+	return nil
+}
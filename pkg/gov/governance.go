@@ -17,6 +17,11 @@
 
 package gov
 
+import (
+	"fmt"
+	"strings"
+)
+
 //GovernancePolicy is a unified struct
 //all governance policy must extend this struct
 //Name is the policy name, for example: "rate-limit-payment-api"
@@ -49,12 +54,35 @@ type Selector struct {
 }
 
 type LBSpec struct {
-	MarkerName string         `json:"match"`
-	RetrySame  int            `json:"retrySame,omitempty"`
-	RetryNext  int            `json:"retryNext,omitempty"`
+	MarkerName string         `json:"match" description:"the name of match group"`
+	RetrySame  int            `json:"retrySame,omitempty" schema:"min=0" description:"the retry times on the same instance"`
+	RetryNext  int            `json:"retryNext,omitempty" schema:"min=0" description:"the retry times on the next instances"`
 	Bo         *BackOffPolicy `json:"backoff,omitempty"`
 }
 type BackOffPolicy struct {
-	InitialInterval int `json:"initInterval"`
-	MaxInterval     int `json:"maxInterval"`
+	InitialInterval int `json:"initInterval" schema:"min=0" description:"the initial backoff interval in milliseconds"`
+	MaxInterval     int `json:"maxInterval" schema:"min=0" description:"the max backoff interval in milliseconds"`
+}
+
+//LoadBalancerSpec is the spec of loadbalancer kind
+type LoadBalancerSpec struct {
+	LBSpec
+	Rules *Rules `json:"rules,omitempty"`
+	Rule  string `json:"rule,omitempty" description:"the load balance rule, one of RoundRobin, Random, LeastConnection, WeightedResponse, PassThrough and SessionStickiness, case insensitive"`
+}
+
+var loadBalanceRules = map[string]bool{
+	"roundrobin":        true,
+	"random":            true,
+	"leastconnection":   true,
+	"weightedresponse":  true,
+	"passthrough":       true,
+	"sessionstickiness": true,
+}
+
+func (s *LoadBalancerSpec) Validate() error {
+	if len(s.Rule) > 0 && !loadBalanceRules[strings.ToLower(s.Rule)] {
+		return fmt.Errorf("unsupported load balance rule %s", s.Rule)
+	}
+	return nil
 }
@@ -17,6 +17,8 @@
 
 package gov
 
+import "errors"
+
 //TrafficMarker marks request, it assign a name to request in runtime
 type TrafficMarker struct {
 	*GovernancePolicy
@@ -33,3 +35,26 @@ type MatchPolicy struct {
 	APIPaths map[string]string            `json:"apiPath,omitempty"`
 	Methods  []string                     `json:"methods,omitempty"`
 }
+
+//MatchGroupSpec is the spec of match-group kind, it marks the requests
+//which the policies of the same name apply to
+type MatchGroupSpec struct {
+	Alias   string   `json:"alias,omitempty" description:"the alias of match group, it is the policy name by default"`
+	Matches []*Match `json:"matches,omitempty" description:"the request is marked if any of the matches is matched"`
+}
+
+//Match is the request match item of match group
+type Match struct {
+	Name        string               `json:"name" schema:"required" description:"the name of match item"`
+	ServiceName string               `json:"serviceName,omitempty" description:"the provider service name"`
+	APIPath     Operators            `json:"apiPath,omitempty" description:"the request path match"`
+	Headers     map[string]Operators `json:"headers,omitempty" description:"the request header matches, the key is the header name"`
+	Method      []string             `json:"method,omitempty" schema:"enum=GET|POST|PUT|DELETE|PATCH" description:"the request methods"`
+}
+
+func (m *Match) Validate() error {
+	if len(m.APIPath) == 0 && len(m.Headers) == 0 && len(m.Method) == 0 {
+		return errors.New("match must have a match item [apiPath/headers/method]")
+	}
+	return nil
+}
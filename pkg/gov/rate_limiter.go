@@ -23,7 +23,15 @@ type RateLimiter struct {
 	Spec *LimiterSpec `json:"spec,omitempty"`
 }
 type LimiterSpec struct {
-	MarkerName string `json:"match"`
-	Rate       int    `json:"rate"`
-	Burst      int    `json:"burst"`
+	MarkerName string `json:"match" description:"the name of match group"`
+	Rate       int    `json:"rate" schema:"min=1" description:"the permits in a refresh period"`
+	Burst      int    `json:"burst" schema:"min=0" description:"the max permits to accumulate"`
+}
+
+//RateLimitingSpec is the spec of rate-limiting kind
+type RateLimitingSpec struct {
+	LimiterSpec
+	Rules              *Rules   `json:"rules,omitempty"`
+	LimitRefreshPeriod Duration `json:"limitRefreshPeriod,omitempty" description:"the period to refresh the permits, the number is in milliseconds"`
+	TimeoutDuration    Duration `json:"timeoutDuration,omitempty" description:"the max time to wait for a permit, the number is in milliseconds"`
 }
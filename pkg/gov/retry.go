@@ -0,0 +1,28 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gov
+
+//RetrySpec is the spec of retry kind, it is compatible with LBSpec
+type RetrySpec struct {
+	LBSpec
+	Rules                 *Rules   `json:"rules,omitempty"`
+	MaxAttempts           int      `json:"maxAttempts,omitempty" schema:"min=0" description:"the max attempts including the first call"`
+	RetryOnSame           int      `json:"retryOnSame,omitempty" schema:"min=0" description:"the retry times on the same instance"`
+	RetryOnResponseStatus []int    `json:"retryOnResponseStatus,omitempty" schema:"min=100,max=599" description:"the response status codes to retry on"`
+	WaitDuration          Duration `json:"waitDuration,omitempty" description:"the wait time between attempts, the number is in milliseconds"`
+}
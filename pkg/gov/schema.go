@@ -0,0 +1,214 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gov
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	SchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+	durationPattern = `^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+)
+
+var (
+	durationType  = reflect.TypeOf(Duration(""))
+	operatorsType = reflect.TypeOf(Operators{})
+)
+
+//JSONSchema is the subset of JSON Schema draft-07 which describes the policy spec
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"`
+	PropertyNames        *JSONSchema            `json:"propertyNames,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Minimum              *float64               `json:"minimum,omitempty"`
+	Maximum              *float64               `json:"maximum,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	OneOf                []*JSONSchema          `json:"oneOf,omitempty"`
+}
+
+//PolicySchema returns the JSON Schema of the policy of kind
+func PolicySchema(kind string) (*JSONSchema, error) {
+	spec, err := NewSpec(kind)
+	if err != nil {
+		return nil, err
+	}
+	specSchema := schemaOf(reflect.TypeOf(spec), fieldRule{}, "the spec of "+kind)
+	return &JSONSchema{
+		Schema: SchemaDraft,
+		Title:  kind,
+		Type:   "object",
+		Properties: map[string]*JSONSchema{
+			"name":     {Type: "string", Description: "the policy name"},
+			"selector": schemaOf(reflect.TypeOf(Selector{}), fieldRule{}, "the scope which the policy applies to"),
+			"spec":     specSchema,
+		},
+		Required: []string{"spec"},
+	}, nil
+}
+
+//PolicySchemas returns the JSON Schema of all the kinds
+func PolicySchemas() map[string]*JSONSchema {
+	schemas := make(map[string]*JSONSchema, len(specs))
+	for _, kind := range Kinds() {
+		schemas[kind], _ = PolicySchema(kind)
+	}
+	return schemas
+}
+
+// fieldRule is parsed from the 'schema' tag, e.g. `schema:"required,min=0,enum=a|b"`,
+// the min, max and enum of a slice or map field apply to its elements
+type fieldRule struct {
+	required bool
+	min      *float64
+	max      *float64
+	enum     []string
+}
+
+type field struct {
+	name        string
+	index       []int
+	rule        fieldRule
+	description string
+}
+
+func parseRule(tag string) fieldRule {
+	var rule fieldRule
+	for _, item := range strings.Split(tag, ",") {
+		kv := strings.SplitN(item, "=", 2)
+		switch kv[0] {
+		case "required":
+			rule.required = true
+		case "min", "max":
+			if len(kv) < 2 {
+				continue
+			}
+			f, err := strconv.ParseFloat(kv[1], 64)
+			if err != nil {
+				continue
+			}
+			if kv[0] == "min" {
+				rule.min = &f
+			} else {
+				rule.max = &f
+			}
+		case "enum":
+			if len(kv) == 2 {
+				rule.enum = strings.Split(kv[1], "|")
+			}
+		}
+	}
+	return rule
+}
+
+// fieldsOf returns the json fields of struct, the fields of embedded struct are flattened
+func fieldsOf(t reflect.Type) []field {
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && len(name) == 0 && f.Type.Kind() == reflect.Struct {
+			for _, sub := range fieldsOf(f.Type) {
+				sub.index = append([]int{i}, sub.index...)
+				fields = append(fields, sub)
+			}
+			continue
+		}
+		if len(f.PkgPath) > 0 {
+			continue
+		}
+		if len(name) == 0 {
+			name = f.Name
+		}
+		fields = append(fields, field{
+			name:        name,
+			index:       []int{i},
+			rule:        parseRule(f.Tag.Get("schema")),
+			description: f.Tag.Get("description"),
+		})
+	}
+	return fields
+}
+
+func schemaOf(t reflect.Type, rule fieldRule, description string) *JSONSchema {
+	switch t {
+	case durationType:
+		return &JSONSchema{
+			Description: description,
+			OneOf: []*JSONSchema{
+				{Type: "number", Minimum: new(float64)},
+				{Type: "string", Pattern: durationPattern},
+			},
+		}
+	case operatorsType:
+		return &JSONSchema{
+			Description:          description,
+			Type:                 "object",
+			PropertyNames:        &JSONSchema{Enum: operators},
+			AdditionalProperties: &JSONSchema{Type: "string"},
+		}
+	}
+	s := &JSONSchema{Description: description}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaOf(t.Elem(), rule, description)
+	case reflect.Struct:
+		s.Type = "object"
+		s.Properties = make(map[string]*JSONSchema)
+		s.AdditionalProperties = false
+		for _, f := range fieldsOf(t) {
+			s.Properties[f.name] = schemaOf(t.FieldByIndex(f.index).Type, f.rule, f.description)
+			if f.rule.required {
+				s.Required = append(s.Required, f.name)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		s.Type = "array"
+		s.Items = schemaOf(t.Elem(), rule, "")
+	case reflect.Map:
+		s.Type = "object"
+		s.AdditionalProperties = schemaOf(t.Elem(), rule, "")
+	case reflect.String:
+		s.Type = "string"
+		s.Enum = rule.enum
+	case reflect.Bool:
+		s.Type = "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		s.Type = "integer"
+		s.Minimum, s.Maximum = rule.min, rule.max
+	case reflect.Float32, reflect.Float64:
+		s.Type = "number"
+		s.Minimum, s.Maximum = rule.min, rule.max
+	}
+	return s
+}
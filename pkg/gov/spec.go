@@ -0,0 +1,194 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gov
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	KindMatchGroup        = "match-group"
+	KindRetry             = "retry"
+	KindRateLimiting      = "rate-limiting"
+	KindCircuitBreaker    = "circuit-breaker"
+	KindInstanceIsolation = "instance-isolation"
+	KindBulkhead          = "bulkhead"
+	KindLoadBalancer      = "loadbalancer"
+	KindFaultInjection    = "fault-injection"
+)
+
+var ErrUnsupportedKind = errors.New("not support kind yet")
+
+// specs is the typed spec of every supported kind
+var specs = map[string]func() interface{}{
+	KindMatchGroup:        func() interface{} { return &MatchGroupSpec{} },
+	KindRetry:             func() interface{} { return &RetrySpec{} },
+	KindRateLimiting:      func() interface{} { return &RateLimitingSpec{} },
+	KindCircuitBreaker:    func() interface{} { return &CircuitBreakerSpec{} },
+	KindInstanceIsolation: func() interface{} { return &InstanceIsolationSpec{} },
+	KindBulkhead:          func() interface{} { return &BulkheadSpec{} },
+	KindLoadBalancer:      func() interface{} { return &LoadBalancerSpec{} },
+	KindFaultInjection:    func() interface{} { return &FaultInjectionSpec{} },
+}
+
+//Kinds returns the sorted kinds which have a typed spec
+func Kinds() []string {
+	kinds := make([]string, 0, len(specs))
+	for kind := range specs {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+//NewSpec returns an empty typed spec of kind
+func NewSpec(kind string) (interface{}, error) {
+	f, ok := specs[kind]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKind, kind)
+	}
+	return f(), nil
+}
+
+//DecodeSpec converts the spec to the typed spec of kind strictly,
+//the unknown fields and the illegal values are rejected
+func DecodeSpec(kind string, spec interface{}) (interface{}, error) {
+	typed, err := NewSpec(kind)
+	if err != nil {
+		return nil, err
+	}
+	if spec == nil {
+		return nil, errors.New("spec is required")
+	}
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(typed); err != nil {
+		return nil, fmt.Errorf("invalid %s spec: %v", kind, err)
+	}
+	if err := validate("spec", typed); err != nil {
+		return nil, err
+	}
+	return typed, nil
+}
+
+//Rules associates the policy with the match group, it is the legacy form of match
+type Rules struct {
+	Match string `json:"match,omitempty" schema:"required" description:"the name of match group"`
+}
+
+//MatchRef refers the match group which the policy applies to
+type MatchRef struct {
+	MarkerName string `json:"match,omitempty" description:"the name of match group"`
+	Rules      *Rules `json:"rules,omitempty"`
+}
+
+//Duration is a duration string, e.g. "1s", or a number in the unit of the field
+type Duration string
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		*d = Duration(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return fmt.Errorf("duration must be a string or a number: %s", b)
+	}
+	*d = Duration(n)
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	if _, err := strconv.ParseFloat(string(d), 64); err == nil {
+		return []byte(d), nil
+	}
+	return json.Marshal(string(d))
+}
+
+//Validate checks the duration is a non-negative number or a duration string
+func (d Duration) Validate() error {
+	if len(d) == 0 {
+		return nil
+	}
+	if f, err := strconv.ParseFloat(string(d), 64); err == nil {
+		if f < 0 {
+			return fmt.Errorf("duration %s must not be negative", d)
+		}
+		return nil
+	}
+	v, err := time.ParseDuration(string(d))
+	if err != nil {
+		return err
+	}
+	if v < 0 {
+		return fmt.Errorf("duration %s must not be negative", d)
+	}
+	return nil
+}
+
+const (
+	OperatorExact    = "exact"
+	OperatorPrefix   = "prefix"
+	OperatorSuffix   = "suffix"
+	OperatorContains = "contains"
+	OperatorRegex    = "regex"
+	OperatorCompare  = "compare"
+)
+
+var (
+	operators      = []string{OperatorExact, OperatorPrefix, OperatorSuffix, OperatorContains, OperatorRegex, OperatorCompare}
+	comparePattern = regexp.MustCompile(`^(>=|<=|!=|>|<|=)-?[0-9]+(\.[0-9]+)?$`)
+)
+
+//Operators is the string match, the key is the operator and the value is the operand
+type Operators map[string]string
+
+//Validate checks the operators are supported and the operands are legal
+func (o Operators) Validate() error {
+	for op, v := range o {
+		switch op {
+		case OperatorExact, OperatorPrefix, OperatorSuffix, OperatorContains:
+		case OperatorRegex:
+			if _, err := regexp.Compile(v); err != nil {
+				return err
+			}
+		case OperatorCompare:
+			if !comparePattern.MatchString(v) {
+				return fmt.Errorf("compare operand %q must be like '>=10'", v)
+			}
+		default:
+			return fmt.Errorf("unsupported operator %s", op)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gov_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/apache/servicecomb-service-center/pkg/gov"
+	"github.com/stretchr/testify/assert"
+)
+
+func toSpec(s string) interface{} {
+	var spec interface{}
+	if err := json.Unmarshal([]byte(s), &spec); err != nil {
+		panic(err)
+	}
+	return spec
+}
+
+func TestDecodeSpec(t *testing.T) {
+	cases := []struct {
+		name  string
+		kind  string
+		spec  string
+		valid bool
+	}{
+		{"match group", gov.KindMatchGroup, `{"alias":"a","matches":[{"name":"m","apiPath":{"prefix":"/v1"},"method":["GET"]}]}`, true},
+		{"match group without matches", gov.KindMatchGroup, `{"alias":"a"}`, true},
+		{"match group without name", gov.KindMatchGroup, `{"matches":[{"apiPath":{"exact":"/v1"}}]}`, false},
+		{"match group without match item", gov.KindMatchGroup, `{"matches":[{"name":"m"}]}`, false},
+		{"match group with illegal method", gov.KindMatchGroup, `{"matches":[{"name":"m","method":["GETS"]}]}`, false},
+		{"match group with illegal operator", gov.KindMatchGroup, `{"matches":[{"name":"m","apiPath":{"like":"/v1"}}]}`, false},
+		{"match group with illegal regex", gov.KindMatchGroup, `{"matches":[{"name":"m","headers":{"user":{"regex":"("}}}]}`, false},
+		{"match group with compare", gov.KindMatchGroup, `{"matches":[{"name":"m","headers":{"age":{"compare":">=18"}}}]}`, true},
+		{"retry", gov.KindRetry, `{"match":"g","maxAttempts":3,"retryOnResponseStatus":[502,503],"waitDuration":"10ms"}`, true},
+		{"retry compatible with lb spec", gov.KindRetry, `{"match":"","retrySame":1,"retryNext":2,"backoff":{"initInterval":1,"maxInterval":10}}`, true},
+		{"retry with rules", gov.KindRetry, `{"rules":{"match":"g"},"maxAttempts":3}`, true},
+		{"retry with empty rules match", gov.KindRetry, `{"rules":{"match":""}}`, false},
+		{"retry with illegal status", gov.KindRetry, `{"retryOnResponseStatus":[99]}`, false},
+		{"retry with negative attempts", gov.KindRetry, `{"maxAttempts":-1}`, false},
+		{"retry with unknown field", gov.KindRetry, `{"maxAttempt":3}`, false},
+		{"retry with illegal duration", gov.KindRetry, `{"waitDuration":"10 seconds"}`, false},
+		{"rate limiting", gov.KindRateLimiting, `{"match":"g","rate":10,"limitRefreshPeriod":1000}`, true},
+		{"rate limiting without rate", gov.KindRateLimiting, `{"match":"g"}`, false},
+		{"circuit breaker", gov.KindCircuitBreaker, `{"match":"g","failureRateThreshold":50,"slidingWindowType":"count","slidingWindowSize":10,"waitDurationInOpenState":"1m"}`, true},
+		{"circuit breaker with illegal threshold", gov.KindCircuitBreaker, `{"failureRateThreshold":101}`, false},
+		{"circuit breaker with illegal window type", gov.KindCircuitBreaker, `{"slidingWindowType":"size"}`, false},
+		{"instance isolation", gov.KindInstanceIsolation, `{"match":"g","minimumNumberOfCalls":5,"waitDurationInOpenState":30000}`, true},
+		{"instance isolation with negative duration", gov.KindInstanceIsolation, `{"waitDurationInOpenState":-1}`, false},
+		{"bulkhead", gov.KindBulkhead, `{"match":"g","maxConcurrentCalls":10,"maxWaitDuration":"1s"}`, true},
+		{"bulkhead without max concurrent calls", gov.KindBulkhead, `{"match":"g"}`, false},
+		{"loadbalancer", gov.KindLoadBalancer, `{"rule":"Random"}`, true},
+		{"loadbalancer with illegal rule", gov.KindLoadBalancer, `{"rule":"fastest"}`, false},
+		{"delay fault", gov.KindFaultInjection, `{"match":"g","type":"delay","percentage":10,"delayTime":"2s"}`, true},
+		{"delay fault without delay time", gov.KindFaultInjection, `{"type":"delay"}`, false},
+		{"abort fault", gov.KindFaultInjection, `{"type":"abort","percentage":100,"errorCode":503}`, true},
+		{"abort fault without error code", gov.KindFaultInjection, `{"type":"abort"}`, false},
+		{"fault without type", gov.KindFaultInjection, `{"percentage":10}`, false},
+		{"nil spec", gov.KindRetry, `null`, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := gov.DecodeSpec(c.kind, toSpec(c.spec))
+			if c.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+
+	t.Run("unsupported kind", func(t *testing.T) {
+		_, err := gov.DecodeSpec("unknown", toSpec(`{}`))
+		assert.True(t, errors.Is(err, gov.ErrUnsupportedKind))
+	})
+	t.Run("decode typed spec", func(t *testing.T) {
+		spec, err := gov.DecodeSpec(gov.KindRetry, toSpec(`{"match":"g","maxAttempts":3,"waitDuration":10}`))
+		assert.NoError(t, err)
+		retry := spec.(*gov.RetrySpec)
+		assert.Equal(t, "g", retry.MarkerName)
+		assert.Equal(t, 3, retry.MaxAttempts)
+		assert.Equal(t, gov.Duration("10"), retry.WaitDuration)
+	})
+}
+
+func TestPolicySchema(t *testing.T) {
+	schemas := gov.PolicySchemas()
+	assert.Equal(t, len(gov.Kinds()), len(schemas))
+
+	schema, err := gov.PolicySchema(gov.KindFaultInjection)
+	assert.NoError(t, err)
+	assert.Equal(t, gov.SchemaDraft, schema.Schema)
+	spec := schema.Properties["spec"]
+	assert.Equal(t, "object", spec.Type)
+	assert.Equal(t, false, spec.AdditionalProperties)
+	assert.Equal(t, []string{"type"}, spec.Required)
+	assert.Equal(t, []string{"delay", "abort"}, spec.Properties["type"].Enum)
+	assert.Equal(t, float64(100), *spec.Properties["percentage"].Maximum)
+	assert.Equal(t, 2, len(spec.Properties["delayTime"].OneOf))
+	assert.NotNil(t, spec.Properties["match"])
+
+	schema, err = gov.PolicySchema(gov.KindMatchGroup)
+	assert.NoError(t, err)
+	match := schema.Properties["spec"].Properties["matches"].Items
+	assert.Equal(t, []string{"name"}, match.Required)
+	assert.NotNil(t, match.Properties["apiPath"].PropertyNames)
+	assert.Equal(t, "object", match.Properties["headers"].AdditionalProperties.(*gov.JSONSchema).Type)
+
+	_, err = json.Marshal(schemas)
+	assert.NoError(t, err)
+
+	_, err = gov.PolicySchema("unknown")
+	assert.Error(t, err)
+}
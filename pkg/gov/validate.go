@@ -0,0 +1,124 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gov
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+//Validator is implemented by the spec which has rules beyond the 'schema' tag
+type Validator interface {
+	Validate() error
+}
+
+// validate checks the value against the 'schema' tags and the Validator recursively
+func validate(path string, v interface{}) error {
+	return validateValue(path, reflect.ValueOf(v), fieldRule{})
+}
+
+func validateValue(path string, v reflect.Value, rule fieldRule) error {
+	if !v.IsValid() {
+		if rule.required {
+			return fmt.Errorf("%s is required", path)
+		}
+		return nil
+	}
+	if rule.required && v.IsZero() {
+		return fmt.Errorf("%s is required", path)
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if err := customValidate(v); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	elemRule := rule
+	elemRule.required = false
+	switch v.Kind() {
+	case reflect.Struct:
+		for _, f := range fieldsOf(v.Type()) {
+			if err := validateValue(path+"."+f.name, v.FieldByIndex(f.index), f.rule); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := validateValue(fmt.Sprintf("%s[%d]", path, i), v.Index(i), elemRule); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, key := range keys {
+			if err := validateValue(fmt.Sprintf("%s[%s]", path, key), v.MapIndex(key), elemRule); err != nil {
+				return err
+			}
+		}
+	case reflect.String:
+		if len(rule.enum) > 0 && !v.IsZero() && !contains(rule.enum, v.String()) {
+			return fmt.Errorf("%s must be one of %v", path, rule.enum)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return validateRange(path, float64(v.Int()), rule)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return validateRange(path, float64(v.Uint()), rule)
+	case reflect.Float32, reflect.Float64:
+		return validateRange(path, v.Float(), rule)
+	}
+	return nil
+}
+
+func customValidate(v reflect.Value) error {
+	if v.CanAddr() {
+		v = v.Addr()
+	}
+	if c, ok := v.Interface().(Validator); ok {
+		return c.Validate()
+	}
+	return nil
+}
+
+func validateRange(path string, f float64, rule fieldRule) error {
+	if rule.min != nil && f < *rule.min {
+		return fmt.Errorf("%s must be greater than or equal to %s", path, formatFloat(*rule.min))
+	}
+	if rule.max != nil && f > *rule.max {
+		return fmt.Errorf("%s must be less than or equal to %s", path, formatFloat(*rule.max))
+	}
+	return nil
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
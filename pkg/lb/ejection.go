@@ -0,0 +1,109 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lb
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/apache/servicecomb-service-center/pkg/backoff"
+)
+
+type ejection struct {
+	failures int
+	until    time.Time
+}
+
+// EjectionLB wraps the balancer and passively ejects the endpoints returned
+// errors, the ejection time grows with the continuous failures by backoff.
+// If all the endpoints are ejected, the wrapped balancer result is used
+type EjectionLB struct {
+	LoadBalancer
+	size    int
+	backoff backoff.Backoff
+	lock    sync.RWMutex
+	ejected map[string]*ejection
+}
+
+func (lb *EjectionLB) Next() string {
+	if s, ok := lb.LoadBalancer.(Selector); ok {
+		if endpoint := s.Select(lb.Ejected); len(endpoint) > 0 {
+			return endpoint
+		}
+		return lb.LoadBalancer.Next()
+	}
+	// the balancer without Selector can be called Next only once if it is a Feedback
+	if _, ok := lb.LoadBalancer.(Feedback); ok {
+		return lb.LoadBalancer.Next()
+	}
+	for i := 0; i < lb.size; i++ {
+		if endpoint := lb.LoadBalancer.Next(); !lb.Ejected(endpoint) {
+			return endpoint
+		}
+	}
+	return lb.LoadBalancer.Next()
+}
+
+func (lb *EjectionLB) Done(endpoint string, err error) {
+	if fb, ok := lb.LoadBalancer.(Feedback); ok {
+		fb.Done(endpoint, err)
+	}
+	if canceled(err) {
+		// the caller gave up, it tells nothing about the endpoint
+		return
+	}
+	lb.lock.Lock()
+	defer lb.lock.Unlock()
+	if err == nil {
+		delete(lb.ejected, endpoint)
+		return
+	}
+	e, ok := lb.ejected[endpoint]
+	if !ok {
+		e = &ejection{}
+		lb.ejected[endpoint] = e
+	}
+	e.until = time.Now().Add(lb.backoff.Delay(e.failures))
+	e.failures++
+}
+
+// canceled returns true if the error is caused by the caller's context,
+// the other errors returned to the feedback are the connection errors
+func canceled(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// Ejected returns true if the endpoint is in the ejection time
+func (lb *EjectionLB) Ejected(endpoint string) bool {
+	lb.lock.RLock()
+	defer lb.lock.RUnlock()
+	e, ok := lb.ejected[endpoint]
+	return ok && time.Now().Before(e.until)
+}
+
+func NewEjectionLB(lb LoadBalancer, size int, b backoff.Backoff) *EjectionLB {
+	if b == nil {
+		b = backoff.GetBackoff()
+	}
+	return &EjectionLB{
+		LoadBalancer: lb,
+		size:         size,
+		backoff:      b,
+		ejected:      make(map[string]*ejection),
+	}
+}
@@ -0,0 +1,86 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lb
+
+import "sync"
+
+// LeastInFlightLB selects the endpoint with the least requests in flight,
+// the caller must call Done when the request finished
+type LeastInFlightLB struct {
+	lock      sync.Mutex
+	endpoints []string
+	inflight  []int
+	indexes   map[string]int
+	// offset spreads the selections when the in flight counts are equal
+	offset int
+}
+
+func (lb *LeastInFlightLB) Next() string {
+	return lb.Select(nil)
+}
+
+func (lb *LeastInFlightLB) Select(exclude func(endpoint string) bool) string {
+	lb.lock.Lock()
+	defer lb.lock.Unlock()
+	l := len(lb.endpoints)
+	best := -1
+	for i := 0; i < l; i++ {
+		idx := (lb.offset + i) % l
+		if exclude != nil && exclude(lb.endpoints[idx]) {
+			continue
+		}
+		if best < 0 || lb.inflight[idx] < lb.inflight[best] {
+			best = idx
+		}
+	}
+	if best < 0 {
+		return ""
+	}
+	lb.offset = (best + 1) % l
+	lb.inflight[best]++
+	return lb.endpoints[best]
+}
+
+func (lb *LeastInFlightLB) Done(endpoint string, _ error) {
+	lb.lock.Lock()
+	defer lb.lock.Unlock()
+	if idx, ok := lb.indexes[endpoint]; ok && lb.inflight[idx] > 0 {
+		lb.inflight[idx]--
+	}
+}
+
+// InFlight returns the in flight requests count of the endpoint
+func (lb *LeastInFlightLB) InFlight(endpoint string) int {
+	lb.lock.Lock()
+	defer lb.lock.Unlock()
+	if idx, ok := lb.indexes[endpoint]; ok {
+		return lb.inflight[idx]
+	}
+	return 0
+}
+
+func NewLeastInFlightLB(endpoints []string) *LeastInFlightLB {
+	lb := &LeastInFlightLB{
+		endpoints: make([]string, len(endpoints)),
+		inflight:  make([]int, len(endpoints)),
+		indexes:   make(map[string]int, len(endpoints)),
+	}
+	copy(lb.endpoints, endpoints)
+	for i, endpoint := range endpoints {
+		lb.indexes[endpoint] = i
+	}
+	return lb
+}
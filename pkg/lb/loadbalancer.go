@@ -15,6 +15,64 @@
 
 package lb
 
+import (
+	"fmt"
+
+	"github.com/apache/servicecomb-service-center/pkg/backoff"
+)
+
+const (
+	RoundRobin    = "roundrobin"
+	Random        = "random"
+	Weighted      = "weighted"
+	LeastInFlight = "leastinflight"
+)
+
 type LoadBalancer interface {
 	Next() string
 }
+
+// Selector selects the endpoint except the excluded ones,
+// returns empty if all the endpoints are excluded
+type Selector interface {
+	Select(exclude func(endpoint string) bool) string
+}
+
+// Feedback is implemented by the balancers which care about the result
+// of the request sent to the endpoint returned by Next, the err is the
+// connection or transport error, or the error of the caller's context
+type Feedback interface {
+	Done(endpoint string, err error)
+}
+
+type Options struct {
+	// Kind of the balancer, default is round robin
+	Kind      string
+	Endpoints []string
+	// Weights of the endpoints for the weighted balancer, default is 1
+	Weights map[string]int
+	// Ejection skips the endpoints returned errors until the backoff delay passed
+	Ejection bool
+	// Backoff of the ejection, default is backoff.GetBackoff()
+	Backoff backoff.Backoff
+}
+
+func New(opts Options) (LoadBalancer, error) {
+	var lb LoadBalancer
+	switch opts.Kind {
+	case "", RoundRobin:
+		lb = NewRoundRobinLB(opts.Endpoints)
+	case Random:
+		lb = NewRandomLB(opts.Endpoints)
+	case Weighted:
+		lb = NewWeightedLB(opts.Endpoints, opts.Weights)
+	case LeastInFlight:
+		lb = NewLeastInFlightLB(opts.Endpoints)
+	default:
+		return nil, fmt.Errorf("unsupported load balancer %s", opts.Kind)
+	}
+	if !opts.Ejection {
+		return lb, nil
+	}
+	return NewEjectionLB(lb, len(opts.Endpoints), opts.Backoff), nil
+}
@@ -16,7 +16,13 @@
 package lb
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestNewRoundRobinLB(t *testing.T) {
@@ -43,6 +49,127 @@ func TestNewRoundRobinLB(t *testing.T) {
 	}
 }
 
+func TestNew(t *testing.T) {
+	_, err := New(Options{Kind: "unknown"})
+	assert.Error(t, err)
+
+	for _, kind := range []string{"", RoundRobin, Random, Weighted, LeastInFlight} {
+		lb, err := New(Options{Kind: kind, Endpoints: []string{"1"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "1", lb.Next())
+		lb, err = New(Options{Kind: kind})
+		assert.NoError(t, err)
+		assert.Equal(t, "", lb.Next())
+	}
+
+	lb, err := New(Options{Endpoints: []string{"1"}, Ejection: true})
+	assert.NoError(t, err)
+	_, ok := lb.(*EjectionLB)
+	assert.True(t, ok)
+}
+
+func TestNewRandomLB(t *testing.T) {
+	lb := NewRandomLB([]string{"1", "2"})
+	counts := map[string]int{}
+	for i := 0; i < 100; i++ {
+		counts[lb.Next()]++
+	}
+	assert.Equal(t, 100, counts["1"]+counts["2"])
+}
+
+func TestNewWeightedLB(t *testing.T) {
+	lb := NewWeightedLB([]string{"1", "2", "3"}, map[string]int{"1": 3, "3": 0})
+	var selected []string
+	for i := 0; i < 8; i++ {
+		selected = append(selected, lb.Next())
+	}
+	// smooth weighted: 3:1, and weight 0 is never selected
+	assert.Equal(t, []string{"1", "1", "2", "1", "1", "1", "2", "1"}, selected)
+
+	lb = NewWeightedLB([]string{"1"}, map[string]int{"1": 0})
+	assert.Equal(t, "", lb.Next())
+}
+
+func TestNewLeastInFlightLB(t *testing.T) {
+	lb := NewLeastInFlightLB([]string{"1", "2"})
+	assert.Equal(t, "1", lb.Next())
+	assert.Equal(t, "2", lb.Next())
+	assert.Equal(t, "1", lb.Next())
+	assert.Equal(t, 2, lb.InFlight("1"))
+
+	lb.Done("2", nil)
+	assert.Equal(t, 0, lb.InFlight("2"))
+	assert.Equal(t, "2", lb.Next())
+	assert.Equal(t, "2", lb.Next())
+	// unknown endpoint and over done are ignored
+	lb.Done("x", nil)
+	lb.Done("1", nil)
+	lb.Done("1", nil)
+	lb.Done("1", nil)
+	assert.Equal(t, 0, lb.InFlight("1"))
+}
+
+type fixedBackoff time.Duration
+
+func (b fixedBackoff) Delay(retries int) time.Duration {
+	return time.Duration(b) * time.Duration(retries+1)
+}
+
+func TestNewEjectionLB(t *testing.T) {
+	errConn := errors.New("connection refused")
+
+	t.Run("eject the failed endpoint until backoff passed", func(t *testing.T) {
+		lb := NewEjectionLB(NewRoundRobinLB([]string{"1", "2"}), 2, fixedBackoff(50*time.Millisecond))
+		assert.Equal(t, "1", lb.Next())
+		lb.Done("1", errConn)
+		assert.True(t, lb.Ejected("1"))
+		assert.Equal(t, "2", lb.Next())
+		assert.Equal(t, "2", lb.Next())
+
+		time.Sleep(60 * time.Millisecond)
+		assert.False(t, lb.Ejected("1"))
+		assert.Equal(t, "1", lb.Next())
+
+		// the ejection time grows with the continuous failures
+		lb.Done("1", errConn)
+		time.Sleep(60 * time.Millisecond)
+		assert.True(t, lb.Ejected("1"))
+
+		lb.Done("1", nil)
+		assert.False(t, lb.Ejected("1"))
+	})
+
+	t.Run("the caller canceled, should not eject the endpoint", func(t *testing.T) {
+		lb := NewEjectionLB(NewRoundRobinLB([]string{"1", "2"}), 2, nil)
+		lb.Done("1", context.Canceled)
+		lb.Done("1", fmt.Errorf("get: %w", context.DeadlineExceeded))
+		assert.False(t, lb.Ejected("1"))
+
+		lb.Done("1", errConn)
+		lb.Done("1", context.Canceled)
+		assert.True(t, lb.Ejected("1"))
+	})
+
+	t.Run("all ejected, should fall back to the wrapped balancer", func(t *testing.T) {
+		lb := NewEjectionLB(NewRoundRobinLB([]string{"1", "2"}), 2, nil)
+		lb.Done("1", errConn)
+		lb.Done("2", errConn)
+		assert.NotEmpty(t, lb.Next())
+	})
+
+	t.Run("least in flight, should skip the ejected endpoint", func(t *testing.T) {
+		inner := NewLeastInFlightLB([]string{"1", "2"})
+		lb := NewEjectionLB(inner, 2, nil)
+		lb.Done("1", errConn)
+		assert.Equal(t, "2", lb.Next())
+		assert.Equal(t, "2", lb.Next())
+		assert.Equal(t, 0, inner.InFlight("1"))
+		assert.Equal(t, 2, inner.InFlight("2"))
+		lb.Done("2", nil)
+		assert.Equal(t, 1, inner.InFlight("2"))
+	})
+}
+
 func BenchmarkNewRoundLB(b *testing.B) {
 	lb := NewRoundRobinLB([]string{"1", "2", "3"})
 	b.RunParallel(func(pb *testing.PB) {
@@ -0,0 +1,49 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lb
+
+import "math/rand"
+
+type RandomLB struct {
+	Endpoints []string
+}
+
+func (lb *RandomLB) Next() string {
+	return lb.Select(nil)
+}
+
+func (lb *RandomLB) Select(exclude func(endpoint string) bool) string {
+	l := len(lb.Endpoints)
+	if l == 0 {
+		return ""
+	}
+	start := rand.Intn(l)
+	for i := 0; i < l; i++ {
+		endpoint := lb.Endpoints[(start+i)%l]
+		if exclude == nil || !exclude(endpoint) {
+			return endpoint
+		}
+	}
+	return ""
+}
+
+func NewRandomLB(endpoints []string) *RandomLB {
+	lb := &RandomLB{
+		Endpoints: make([]string, len(endpoints)),
+	}
+	copy(lb.Endpoints, endpoints)
+	return lb
+}
@@ -37,6 +37,16 @@ func (lb *RoundRobinLB) Next() string {
 	return lb.Endpoints[atomic.LoadInt32(&lb.index)]
 }
 
+func (lb *RoundRobinLB) Select(exclude func(endpoint string) bool) string {
+	for i := 0; i < len(lb.Endpoints); i++ {
+		endpoint := lb.Next()
+		if exclude == nil || !exclude(endpoint) {
+			return endpoint
+		}
+	}
+	return ""
+}
+
 func NewRoundRobinLB(endpoints []string) *RoundRobinLB {
 	lb := &RoundRobinLB{
 		Endpoints: make([]string, len(endpoints)),
@@ -0,0 +1,74 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lb
+
+import "sync"
+
+type weightedEndpoint struct {
+	endpoint string
+	weight   int
+	current  int
+}
+
+// WeightedLB is the smooth weighted round robin balancer,
+// the endpoint with weight 0 is never selected
+type WeightedLB struct {
+	lock      sync.Mutex
+	endpoints []*weightedEndpoint
+}
+
+func (lb *WeightedLB) Next() string {
+	return lb.Select(nil)
+}
+
+func (lb *WeightedLB) Select(exclude func(endpoint string) bool) string {
+	lb.lock.Lock()
+	defer lb.lock.Unlock()
+	var (
+		best  *weightedEndpoint
+		total int
+	)
+	for _, e := range lb.endpoints {
+		if exclude != nil && exclude(e.endpoint) {
+			continue
+		}
+		e.current += e.weight
+		total += e.weight
+		if best == nil || e.current > best.current {
+			best = e
+		}
+	}
+	if best == nil {
+		return ""
+	}
+	best.current -= total
+	return best.endpoint
+}
+
+func NewWeightedLB(endpoints []string, weights map[string]int) *WeightedLB {
+	lb := &WeightedLB{}
+	for _, endpoint := range endpoints {
+		weight, ok := weights[endpoint]
+		if !ok {
+			weight = 1
+		}
+		if weight <= 0 {
+			continue
+		}
+		lb.endpoints = append(lb.endpoints, &weightedEndpoint{endpoint: endpoint, weight: weight})
+	}
+	return lb
+}
@@ -33,3 +33,8 @@ func SetGlobal(cfg Config) {
 	// golang log
 	_ = zap.RedirectStdLog(logger.zapLogger)
 }
+
+// SetLevel changes the level of global logger at runtime
+func SetLevel(level string) {
+	logger.SetLevel(level)
+}
@@ -98,15 +98,17 @@ func Configure() Config {
 	}
 }
 
-func toZapConfig(c Config) zapcore.Core {
-	// level config
-	l, ok := zapLevelMap[strings.ToUpper(c.LoggerLevel)]
+func toZapLevel(level string) zapcore.Level {
+	l, ok := zapLevelMap[strings.ToUpper(level)]
 	if !ok {
 		l = zap.DebugLevel
 	}
-	var levelEnabler zap.LevelEnablerFunc = func(level zapcore.Level) bool {
-		return level >= l
-	}
+	return l
+}
+
+func toZapConfig(c Config, l zap.AtomicLevel) zapcore.Core {
+	// level config
+	var levelEnabler zapcore.LevelEnabler = l
 
 	// log format
 	format := zapcore.EncoderConfig{
@@ -128,7 +130,7 @@ func toZapConfig(c Config) zapcore.Core {
 	}
 	if c.NoLevel {
 		format.LevelKey = ""
-		levelEnabler = func(_ zapcore.Level) bool { return true }
+		levelEnabler = zap.LevelEnablerFunc(func(_ zapcore.Level) bool { return true })
 	}
 	if c.NoTime {
 		format.TimeKey = ""
@@ -162,6 +164,7 @@ func toZapConfig(c Config) zapcore.Core {
 type Logger struct {
 	Config Config
 
+	level     zap.AtomicLevel
 	zapLogger *zap.Logger
 	zapSugar  *zap.SugaredLogger
 }
@@ -251,6 +254,11 @@ func (l *Logger) Recover(r interface{}, callerSkip int) {
 	}
 }
 
+// SetLevel changes the level of logger at runtime, the unknown level means 'DEBUG'
+func (l *Logger) SetLevel(level string) {
+	l.level.SetLevel(toZapLevel(level))
+}
+
 func (l *Logger) Sync() {
 	err := l.zapLogger.Sync()
 	if err != nil {
@@ -272,9 +280,11 @@ func NewLogger(cfg Config) *Logger {
 	if !cfg.NoCaller {
 		opts = append(opts, zap.AddCaller(), zap.AddCallerSkip(cfg.CallerSkip))
 	}
-	l := zap.New(toZapConfig(cfg), opts...)
+	level := zap.NewAtomicLevelAt(toZapLevel(cfg.LoggerLevel))
+	l := zap.New(toZapConfig(cfg, level), opts...)
 	return &Logger{
 		Config:    cfg,
+		level:     level,
 		zapLogger: l,
 		zapSugar:  l.Sugar(),
 	}
@@ -0,0 +1,207 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openapi
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Diff returns the changes from the previous document to the current one, a nil previous
+// document means all the operations are added
+func Diff(prev, cur *Document) []*Change {
+	var changes []*Change
+	if prev == nil {
+		prev = &Document{}
+	}
+	for key, op := range cur.Operations {
+		if _, ok := prev.Operations[key]; !ok {
+			changes = append(changes, compatible(KindOperationAdded, key, "operation is added"))
+			continue
+		}
+		changes = append(changes, diffOperation(key, prev.Operations[key], op)...)
+	}
+	for key := range prev.Operations {
+		if _, ok := cur.Operations[key]; !ok {
+			changes = append(changes, breaking(KindOperationRemoved, key, "operation is removed"))
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Operation != changes[j].Operation {
+			return changes[i].Operation < changes[j].Operation
+		}
+		return changes[i].Message < changes[j].Message
+	})
+	return changes
+}
+
+// IsCompatible returns false if any breaking change exists
+func IsCompatible(changes []*Change) bool {
+	for _, c := range changes {
+		if c.Level == LevelBreaking {
+			return false
+		}
+	}
+	return true
+}
+
+func diffOperation(key string, prev, cur *Operation) []*Change {
+	var changes []*Change
+	for k, param := range cur.Parameters {
+		prevParam, ok := prev.Parameters[k]
+		switch {
+		case !ok && param.Required:
+			changes = append(changes, breaking(KindParameterAdded, key,
+				fmt.Sprintf("required parameter '%s' is added", k)))
+		case !ok:
+			changes = append(changes, compatible(KindParameterAdded, key,
+				fmt.Sprintf("optional parameter '%s' is added", k)))
+		default:
+			changes = append(changes, diffParameter(key, k, prevParam, param)...)
+		}
+	}
+	for k := range prev.Parameters {
+		if _, ok := cur.Parameters[k]; !ok {
+			changes = append(changes, breaking(KindParameterRemoved, key,
+				fmt.Sprintf("parameter '%s' is removed", k)))
+		}
+	}
+
+	switch {
+	case prev.RequestBody == nil && cur.RequestBody == nil:
+	case prev.RequestBody == nil && cur.RequestBody.Required:
+		changes = append(changes, breaking(KindRequestBodyAdded, key, "required request body is added"))
+	case prev.RequestBody == nil:
+		changes = append(changes, compatible(KindRequestBodyAdded, key, "optional request body is added"))
+	case cur.RequestBody == nil:
+		changes = append(changes, breaking(KindRequestBodyRemoved, key, "request body is removed"))
+	case !prev.RequestBody.Required && cur.RequestBody.Required:
+		changes = append(changes, breaking(KindRequestBodyRequired, key, "request body becomes required"))
+	case prev.RequestBody.Required && !cur.RequestBody.Required:
+		changes = append(changes, compatible(KindRequestBodyOptional, key, "request body becomes optional"))
+	}
+	if prev.RequestBody != nil && cur.RequestBody != nil {
+		changes = append(changes, diffSchema(KindRequestBodyChanged, key, "request body", "",
+			prev.RequestBody.Schema, cur.RequestBody.Schema, true)...)
+	}
+
+	for code, resp := range cur.Responses {
+		prevResp, ok := prev.Responses[code]
+		if !ok {
+			changes = append(changes, compatible(KindResponseAdded, key,
+				fmt.Sprintf("response '%s' is added", code)))
+			continue
+		}
+		changes = append(changes, diffSchema(KindResponseChanged, key, fmt.Sprintf("response '%s'", code), "",
+			prevResp.Schema, resp.Schema, false)...)
+	}
+	for code := range prev.Responses {
+		if _, ok := cur.Responses[code]; !ok {
+			changes = append(changes, breaking(KindResponseRemoved, key,
+				fmt.Sprintf("response '%s' is removed", code)))
+		}
+	}
+	return changes
+}
+
+func diffParameter(key, name string, prev, cur *Parameter) []*Change {
+	changes := diffSchema(KindParameterTypeChanged, key, fmt.Sprintf("parameter '%s'", name), "",
+		prev.Schema, cur.Schema, true)
+	switch {
+	case !prev.Required && cur.Required:
+		changes = append(changes, breaking(KindParameterRequired, key,
+			fmt.Sprintf("parameter '%s' becomes required", name)))
+	case prev.Required && !cur.Required:
+		changes = append(changes, compatible(KindParameterOptional, key,
+			fmt.Sprintf("parameter '%s' becomes optional", name)))
+	}
+	return changes
+}
+
+// diffSchema compares the schemas at the property path of the subject, the request schema
+// breaks the clients when it requires more and the response schema breaks them when it
+// returns less. The schema is unspecified if nil and not compared
+func diffSchema(kind, key, subject, path string, prev, cur *Schema, request bool) []*Change {
+	if prev == nil || cur == nil {
+		return nil
+	}
+	at := subject
+	if len(path) > 0 {
+		at = fmt.Sprintf("%s property '%s'", subject, path)
+	}
+	if prev.Type != cur.Type || prev.Ref != cur.Ref {
+		return []*Change{breaking(kind, key,
+			fmt.Sprintf("%s type is changed from '%s' to '%s'", at, prev, cur))}
+	}
+
+	changes := diffSchema(kind, key, subject, path+"[]", prev.Items, cur.Items, request)
+	for name, prop := range cur.Properties {
+		sub := join(path, name)
+		prevProp, ok := prev.Properties[name]
+		required, prevRequired := contains(cur.Required, name), contains(prev.Required, name)
+		switch {
+		case !ok && required:
+			changes = append(changes, classify(request, kind, key,
+				fmt.Sprintf("%s required property '%s' is added", subject, sub)))
+		case !ok:
+			changes = append(changes, compatible(kind, key,
+				fmt.Sprintf("%s optional property '%s' is added", subject, sub)))
+		case required == prevRequired:
+		case required:
+			changes = append(changes, classify(request, kind, key,
+				fmt.Sprintf("%s property '%s' becomes required", subject, sub)))
+		default:
+			changes = append(changes, classify(!request, kind, key,
+				fmt.Sprintf("%s property '%s' becomes optional", subject, sub)))
+		}
+		if ok {
+			changes = append(changes, diffSchema(kind, key, subject, sub, prevProp, prop, request)...)
+		}
+	}
+	for name := range prev.Properties {
+		if _, ok := cur.Properties[name]; ok {
+			continue
+		}
+		sub := join(path, name)
+		changes = append(changes, classify(!request, kind, key,
+			fmt.Sprintf("%s property '%s' is removed", subject, sub)))
+	}
+	return changes
+}
+
+func join(path, name string) string {
+	if len(path) == 0 {
+		return name
+	}
+	return path + "." + name
+}
+
+func breaking(kind, key, message string) *Change {
+	return &Change{Level: LevelBreaking, Kind: kind, Operation: key, Message: message}
+}
+
+func compatible(kind, key, message string) *Change {
+	return &Change{Level: LevelCompatible, Kind: kind, Operation: key, Message: message}
+}
+
+func classify(isBreaking bool, kind, key, message string) *Change {
+	if isBreaking {
+		return breaking(kind, key, message)
+	}
+	return compatible(kind, key, message)
+}
@@ -0,0 +1,225 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openapi_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/servicecomb-service-center/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func kinds(changes []*openapi.Change, level openapi.Level) []string {
+	var arr []string
+	for _, c := range changes {
+		if c.Level == level {
+			arr = append(arr, c.Kind)
+		}
+	}
+	return arr
+}
+
+func TestDiff(t *testing.T) {
+	prev, err := openapi.Parse(swagger)
+	assert.NoError(t, err)
+
+	t.Run("same document, should be no changes", func(t *testing.T) {
+		changes := openapi.Diff(prev, prev)
+		assert.Empty(t, changes)
+		assert.True(t, openapi.IsCompatible(changes))
+	})
+
+	t.Run("nil previous document, should be all added", func(t *testing.T) {
+		changes := openapi.Diff(nil, prev)
+		assert.Len(t, changes, 3)
+		assert.True(t, openapi.IsCompatible(changes))
+	})
+
+	t.Run("compatible changes, should be compatible", func(t *testing.T) {
+		cur, err := openapi.Parse(`
+swagger: "2.0"
+info:
+  title: users
+  version: 1.1.0
+basePath: /v1
+paths:
+  /users:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          type: integer
+        - name: offset
+          in: query
+          type: integer
+      responses:
+        200:
+          description: ok
+        400:
+          description: bad request
+    post:
+      parameters:
+        - name: user
+          in: body
+          required: true
+          schema:
+            $ref: '#/definitions/User'
+      responses:
+        201:
+          description: created
+  /users/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          type: string
+      responses:
+        200:
+          description: ok
+        404:
+          description: not found
+    delete:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          type: string
+      responses:
+        204:
+          description: deleted
+definitions:
+  User:
+    type: object
+    required: [name]
+    properties:
+      name:
+        type: string
+      friends:
+        type: array
+        items:
+          $ref: '#/definitions/User'
+`)
+		assert.NoError(t, err)
+		changes := openapi.Diff(prev, cur)
+		assert.True(t, openapi.IsCompatible(changes))
+		assert.Equal(t, []string{openapi.KindOperationAdded, openapi.KindParameterAdded, openapi.KindResponseAdded},
+			kinds(changes, openapi.LevelCompatible))
+	})
+
+	t.Run("breaking changes, should be incompatible", func(t *testing.T) {
+		cur, err := openapi.Parse(`
+swagger: "2.0"
+info:
+  title: users
+  version: 2.0.0
+basePath: /v1
+paths:
+  /users:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          type: string
+        - name: tenant
+          in: header
+          required: true
+          type: string
+      responses:
+        200:
+          description: ok
+  /users/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          type: string
+      responses:
+        200:
+          description: ok
+`)
+		assert.NoError(t, err)
+		changes := openapi.Diff(prev, cur)
+		assert.False(t, openapi.IsCompatible(changes))
+		assert.ElementsMatch(t, []string{openapi.KindOperationRemoved, openapi.KindParameterAdded,
+			openapi.KindParameterTypeChanged, openapi.KindResponseRemoved},
+			kinds(changes, openapi.LevelBreaking))
+	})
+
+	t.Run("request body changes, should be classified", func(t *testing.T) {
+		cur, err := openapi.Parse(openapi3)
+		assert.NoError(t, err)
+		optional, err := openapi.Parse(`{
+  "openapi": "3.0.1",
+  "info": {"title": "users", "version": "1.0.0"},
+  "paths": {"/users": {"post": {"requestBody": {"content": {}}, "responses": {"201": {}}}}}
+}`)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{openapi.KindRequestBodyOptional},
+			kinds(openapi.Diff(cur, optional), openapi.LevelCompatible))
+		assert.Equal(t, []string{openapi.KindRequestBodyRequired},
+			kinds(openapi.Diff(optional, cur), openapi.LevelBreaking))
+	})
+
+	t.Run("referenced schema changes, should be classified", func(t *testing.T) {
+		doc := func(user, body, resp string) string {
+			return `{
+  "openapi": "3.0.1",
+  "info": {"title": "users", "version": "1.0.0"},
+  "paths": {"/users/{id}": {"put": {
+    "parameters": [{"name": "id", "in": "path", "required": true, "schema": {"$ref": "#/components/schemas/ID"}}],
+    "requestBody": {"content": {"application/json": {"schema": ` + body + `}}},
+    "responses": {"200": {"content": {"application/json": {"schema": ` + resp + `}}}}
+  }}},
+  "components": {"schemas": {"ID": {"type": "string"}, "User": ` + user + `}}
+}`
+		}
+		user := `{"type": "object", "required": ["name"], "properties": {"name": {"type": "string"}, "age": {"type": "integer"}}}`
+		ref := `{"$ref": "#/components/schemas/User"}`
+		prev, err := openapi.Parse(doc(user, ref, ref))
+		assert.NoError(t, err)
+
+		same, err := openapi.Parse(doc(user, user, user))
+		assert.NoError(t, err)
+		assert.Empty(t, openapi.Diff(prev, same))
+
+		cur, err := openapi.Parse(doc(
+			`{"type": "object", "required": ["name", "email"], "properties": {"name": {"type": "string"}, "email": {"type": "string"}}}`,
+			ref, ref))
+		assert.NoError(t, err)
+		changes := openapi.Diff(prev, cur)
+		assert.ElementsMatch(t, []string{openapi.KindRequestBodyChanged, openapi.KindResponseChanged},
+			kinds(changes, openapi.LevelBreaking))
+		assert.ElementsMatch(t, []string{openapi.KindRequestBodyChanged, openapi.KindResponseChanged},
+			kinds(changes, openapi.LevelCompatible))
+
+		cur, err = openapi.Parse(doc(user, `{"type": "array", "items": `+ref+`}`, `{"type": "string"}`))
+		assert.NoError(t, err)
+		changes = openapi.Diff(prev, cur)
+		assert.Len(t, changes, 2)
+		assert.False(t, openapi.IsCompatible(changes))
+		assert.Equal(t, "request body type is changed from 'object' to 'array<object>'", changes[0].Message)
+		assert.Equal(t, "response '200' type is changed from 'object' to 'string'", changes[1].Message)
+
+		cur, err = openapi.Parse(strings.Replace(doc(user, ref, ref), `"ID": {"type": "string"}`, `"ID": {"type": "integer"}`, 1))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{openapi.KindParameterTypeChanged}, kinds(openapi.Diff(prev, cur), openapi.LevelBreaking))
+	})
+}
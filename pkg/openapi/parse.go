@@ -0,0 +1,405 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+var (
+	ErrNotObject      = errors.New("schema is not a YAML or JSON object")
+	ErrUnknownVersion = errors.New("schema is neither swagger 2.0 nor openapi 3.0")
+	ErrUndeclared     = errors.New("schema declares neither swagger nor openapi version")
+)
+
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+var paramLocations = map[string][]string{
+	Version2: {"query", "header", "path", "formData", "body"},
+	Version3: {"query", "header", "path", "cookie"},
+}
+
+type object = map[string]interface{}
+
+// Parse parses the swagger 2.0 or openapi 3.0 content in YAML or JSON,
+// returns error if the document is malformed
+func Parse(content string) (*Document, error) {
+	raw, err := yaml.YAMLToJSON([]byte(content))
+	if err != nil {
+		return nil, ErrNotObject
+	}
+	var root object
+	if err := json.Unmarshal(raw, &root); err != nil || root == nil {
+		return nil, ErrNotObject
+	}
+	p := &parser{root: root}
+	return p.parse()
+}
+
+type parser struct {
+	root object
+	doc  *Document
+}
+
+func (p *parser) parse() (*Document, error) {
+	p.doc = &Document{Operations: make(map[string]*Operation)}
+	_, hasSwagger := p.root["swagger"]
+	_, hasOpenAPI := p.root["openapi"]
+	if !hasSwagger && !hasOpenAPI {
+		return nil, ErrUndeclared
+	}
+	swagger, _ := p.root["swagger"].(string)
+	openapi, _ := p.root["openapi"].(string)
+	switch {
+	case swagger == Version2:
+		p.doc.Version = Version2
+	case strings.HasPrefix(openapi, Version3+"."):
+		p.doc.Version = Version3
+	default:
+		return nil, ErrUnknownVersion
+	}
+
+	info, ok := p.root["info"].(object)
+	if !ok {
+		return nil, errors.New("info is required")
+	}
+	p.doc.Title, _ = info["title"].(string)
+	p.doc.APIVersion, _ = info["version"].(string)
+	if len(p.doc.Title) == 0 || len(p.doc.APIVersion) == 0 {
+		return nil, errors.New("info.title and info.version are required")
+	}
+
+	paths, ok := p.root["paths"].(object)
+	if !ok {
+		return nil, errors.New("paths is required")
+	}
+	basePath := ""
+	if p.doc.Version == Version2 {
+		basePath, _ = p.root["basePath"].(string)
+		basePath = strings.TrimSuffix(basePath, "/")
+	}
+	operationIDs := make(map[string]string)
+	for path, v := range paths {
+		if strings.HasPrefix(path, "x-") {
+			continue
+		}
+		if !strings.HasPrefix(path, "/") {
+			return nil, fmt.Errorf("path '%s' must begin with '/'", path)
+		}
+		item, ok := v.(object)
+		if !ok {
+			return nil, fmt.Errorf("path '%s' is not an object", path)
+		}
+		if err := p.parsePathItem(basePath+path, item, operationIDs); err != nil {
+			return nil, err
+		}
+	}
+	return p.doc, nil
+}
+
+func (p *parser) parsePathItem(path string, item object, operationIDs map[string]string) error {
+	common, err := p.parseParameters(path, item["parameters"])
+	if err != nil {
+		return err
+	}
+	for _, method := range methods {
+		v, ok := item[method]
+		if !ok {
+			continue
+		}
+		key := strings.ToUpper(method) + " " + path
+		raw, ok := v.(object)
+		if !ok {
+			return fmt.Errorf("operation '%s' is not an object", key)
+		}
+		op, err := p.parseOperation(key, raw, common)
+		if err != nil {
+			return err
+		}
+		op.Method, op.Path = strings.ToUpper(method), path
+		if len(op.OperationID) > 0 {
+			if other, ok := operationIDs[op.OperationID]; ok {
+				return fmt.Errorf("operationId '%s' is duplicated in '%s' and '%s'", op.OperationID, other, key)
+			}
+			operationIDs[op.OperationID] = key
+		}
+		p.doc.Operations[key] = op
+	}
+	return nil
+}
+
+func (p *parser) parseOperation(key string, raw object, common map[string]*Parameter) (*Operation, error) {
+	op := &Operation{
+		Parameters: make(map[string]*Parameter, len(common)),
+		Responses:  make(map[string]*Response),
+	}
+	op.OperationID, _ = raw["operationId"].(string)
+	for k, param := range common {
+		op.Parameters[k] = param
+	}
+	params, err := p.parseParameters(key, raw["parameters"])
+	if err != nil {
+		return nil, err
+	}
+	for k, param := range params {
+		op.Parameters[k] = param
+	}
+
+	if v, ok := raw["requestBody"]; ok && p.doc.Version == Version3 {
+		body, err := p.resolve(v)
+		if err != nil {
+			return nil, fmt.Errorf("operation '%s' requestBody: %s", key, err)
+		}
+		required, _ := body["required"].(bool)
+		schema, err := p.parseContent(body["content"])
+		if err != nil {
+			return nil, fmt.Errorf("operation '%s' requestBody: %s", key, err)
+		}
+		op.RequestBody = &RequestBody{Required: required, Schema: schema}
+	}
+
+	responses, ok := raw["responses"].(object)
+	if !ok || len(responses) == 0 {
+		return nil, fmt.Errorf("operation '%s' responses is required", key)
+	}
+	for code, v := range responses {
+		if strings.HasPrefix(code, "x-") {
+			continue
+		}
+		resp, err := p.parseResponse(v)
+		if err != nil {
+			return nil, fmt.Errorf("operation '%s' response '%s': %s", key, code, err)
+		}
+		op.Responses[code] = resp
+	}
+	return op, nil
+}
+
+func (p *parser) parseResponse(v interface{}) (*Response, error) {
+	raw, err := p.resolve(v)
+	if err != nil {
+		return nil, err
+	}
+	resp := &Response{}
+	if p.doc.Version == Version3 {
+		resp.Schema, err = p.parseContent(raw["content"])
+	} else if schema, ok := raw["schema"]; ok {
+		resp.Schema, err = p.parseSchema(schema, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (p *parser) parseParameters(key string, v interface{}) (map[string]*Parameter, error) {
+	params := make(map[string]*Parameter)
+	if v == nil {
+		return params, nil
+	}
+	arr, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("'%s' parameters is not an array", key)
+	}
+	for _, item := range arr {
+		raw, err := p.resolve(item)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' parameter: %s", key, err)
+		}
+		param, err := p.parseParameter(raw)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' parameter: %s", key, err)
+		}
+		params[param.In+":"+param.Name] = param
+	}
+	return params, nil
+}
+
+func (p *parser) parseParameter(raw object) (*Parameter, error) {
+	param := &Parameter{}
+	param.Name, _ = raw["name"].(string)
+	param.In, _ = raw["in"].(string)
+	param.Required, _ = raw["required"].(bool)
+	if len(param.Name) == 0 || len(param.In) == 0 {
+		return nil, errors.New("name and in are required")
+	}
+	if !contains(paramLocations[p.doc.Version], param.In) {
+		return nil, fmt.Errorf("'%s' in '%s' is invalid", param.Name, param.In)
+	}
+	if param.In == "path" && !param.Required {
+		return nil, fmt.Errorf("path parameter '%s' must be required", param.Name)
+	}
+	var err error
+	switch {
+	case raw["schema"] != nil:
+		param.Schema, err = p.parseSchema(raw["schema"], nil)
+	case raw["content"] != nil:
+		param.Schema, err = p.parseContent(raw["content"])
+	default:
+		// the swagger 2.0 non-body parameter declares the type inline
+		param.Schema, err = p.parseSchema(raw, nil)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("'%s' %s", param.Name, err)
+	}
+	return param, nil
+}
+
+// parseContent returns the schema of the JSON media type or the first one in order
+func (p *parser) parseContent(v interface{}) (*Schema, error) {
+	content, _ := v.(object)
+	if len(content) == 0 {
+		return nil, nil
+	}
+	mediaType := "application/json"
+	if _, ok := content[mediaType]; !ok {
+		types := make([]string, 0, len(content))
+		for t := range content {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+		mediaType = types[0]
+	}
+	media, ok := content[mediaType].(object)
+	if !ok {
+		return nil, fmt.Errorf("media type '%s' is not an object", mediaType)
+	}
+	schema, ok := media["schema"]
+	if !ok {
+		return nil, nil
+	}
+	return p.parseSchema(schema, nil)
+}
+
+// parseSchema normalizes the schema and expands the references, refs are the references
+// being expanded, a recursive one is kept as is
+func (p *parser) parseSchema(v interface{}, refs []string) (*Schema, error) {
+	raw, ok := v.(object)
+	if !ok {
+		return nil, errors.New("schema is not an object")
+	}
+	if ref, ok := raw["$ref"].(string); ok {
+		if contains(refs, ref) {
+			return &Schema{Ref: ref}, nil
+		}
+		resolved, err := p.resolve(raw)
+		if err != nil {
+			return nil, err
+		}
+		return p.parseSchema(resolved, append(refs[:len(refs):len(refs)], ref))
+	}
+
+	schema := &Schema{}
+	schema.Type, _ = raw["type"].(string)
+	if items, ok := raw["items"]; ok {
+		s, err := p.parseSchema(items, refs)
+		if err != nil {
+			return nil, err
+		}
+		schema.Items = s
+	}
+	if props, ok := raw["properties"].(object); ok {
+		schema.Properties = make(map[string]*Schema, len(props))
+		for name, prop := range props {
+			s, err := p.parseSchema(prop, refs)
+			if err != nil {
+				return nil, err
+			}
+			schema.Properties[name] = s
+		}
+	}
+	if required, ok := raw["required"].([]interface{}); ok {
+		for _, name := range required {
+			if s, ok := name.(string); ok {
+				schema.Required = append(schema.Required, s)
+			}
+		}
+	}
+	allOf, _ := raw["allOf"].([]interface{})
+	for _, item := range allOf {
+		s, err := p.parseSchema(item, refs)
+		if err != nil {
+			return nil, err
+		}
+		schema.merge(s)
+	}
+	return schema, nil
+}
+
+// resolve returns the object or the local object referenced by '$ref'
+func (p *parser) resolve(v interface{}) (object, error) {
+	raw, ok := v.(object)
+	if !ok {
+		return nil, errors.New("not an object")
+	}
+	ref, ok := raw["$ref"].(string)
+	if !ok {
+		return raw, nil
+	}
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("external reference '%s' is not supported", ref)
+	}
+	var cur interface{} = p.root
+	for _, token := range strings.Split(ref[2:], "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		m, ok := cur.(object)
+		if !ok {
+			return nil, fmt.Errorf("reference '%s' not found", ref)
+		}
+		if cur, ok = m[token]; !ok {
+			return nil, fmt.Errorf("reference '%s' not found", ref)
+		}
+	}
+	resolved, ok := cur.(object)
+	if !ok {
+		return nil, fmt.Errorf("reference '%s' is not an object", ref)
+	}
+	return resolved, nil
+}
+
+// merge merges the schema of 'allOf' into s
+func (s *Schema) merge(other *Schema) {
+	if len(s.Type) == 0 {
+		s.Type = other.Type
+	}
+	if s.Items == nil {
+		s.Items = other.Items
+	}
+	for name, prop := range other.Properties {
+		if s.Properties == nil {
+			s.Properties = make(map[string]*Schema, len(other.Properties))
+		}
+		s.Properties[name] = prop
+	}
+	s.Required = append(s.Required, other.Required...)
+}
+
+func contains(arr []string, s string) bool {
+	for _, v := range arr {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
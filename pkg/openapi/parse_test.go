@@ -0,0 +1,162 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/apache/servicecomb-service-center/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+)
+
+const swagger = `
+swagger: "2.0"
+info:
+  title: users
+  version: 1.0.0
+basePath: /v1
+parameters:
+  id:
+    name: id
+    in: path
+    required: true
+    type: string
+paths:
+  /users:
+    get:
+      operationId: listUsers
+      parameters:
+        - name: limit
+          in: query
+          type: integer
+      responses:
+        200:
+          description: ok
+    post:
+      operationId: createUser
+      parameters:
+        - name: user
+          in: body
+          required: true
+          schema:
+            $ref: '#/definitions/User'
+      responses:
+        201:
+          description: created
+  /users/{id}:
+    parameters:
+      - $ref: '#/parameters/id'
+    get:
+      operationId: getUser
+      responses:
+        200:
+          description: ok
+          schema:
+            $ref: '#/definitions/User'
+        404:
+          description: not found
+definitions:
+  User:
+    type: object
+    required: [name]
+    properties:
+      name:
+        type: string
+      friends:
+        type: array
+        items:
+          $ref: '#/definitions/User'
+`
+
+const openapi3 = `{
+  "openapi": "3.0.1",
+  "info": {"title": "users", "version": "1.0.0"},
+  "paths": {
+    "/users": {
+      "post": {
+        "requestBody": {"$ref": "#/components/requestBodies/User"},
+        "responses": {"201": {"description": "created", "content": {"text/plain": {"schema": {"type": "string"}}}}}
+      }
+    }
+  },
+  "components": {
+    "requestBodies": {"User": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}}},
+    "schemas": {"User": {"type": "object"}}
+  }
+}`
+
+func TestParse(t *testing.T) {
+	t.Run("parse swagger 2.0 yaml, should be passed", func(t *testing.T) {
+		doc, err := openapi.Parse(swagger)
+		assert.NoError(t, err)
+		assert.Equal(t, openapi.Version2, doc.Version)
+		assert.Equal(t, "users", doc.Title)
+		assert.Len(t, doc.Operations, 3)
+
+		op := doc.Operations["GET /v1/users/{id}"]
+		assert.NotNil(t, op)
+		assert.Equal(t, "getUser", op.OperationID)
+		assert.Equal(t, &openapi.Parameter{Name: "id", In: "path", Required: true,
+			Schema: &openapi.Schema{Type: "string"}}, op.Parameters["path:id"])
+		assert.Len(t, op.Responses, 2)
+		assert.Equal(t, "object", op.Responses["200"].Schema.Type)
+
+		op = doc.Operations["POST /v1/users"]
+		user := op.Parameters["body:user"].Schema
+		assert.Equal(t, "object", user.Type)
+		assert.Equal(t, []string{"name"}, user.Required)
+		assert.Equal(t, "string", user.Properties["name"].Type)
+		assert.Equal(t, "array<#/definitions/User>", user.Properties["friends"].String())
+		assert.Nil(t, op.Responses["201"].Schema)
+	})
+
+	t.Run("parse openapi 3.0 json, should be passed", func(t *testing.T) {
+		doc, err := openapi.Parse(openapi3)
+		assert.NoError(t, err)
+		assert.Equal(t, openapi.Version3, doc.Version)
+		op := doc.Operations["POST /users"]
+		assert.NotNil(t, op)
+		assert.True(t, op.RequestBody.Required)
+		assert.Equal(t, "object", op.RequestBody.Schema.Type)
+		assert.Equal(t, "string", op.Responses["201"].Schema.Type)
+	})
+
+	t.Run("parse malformed documents, should be failed", func(t *testing.T) {
+		cases := map[string]string{
+			"garbage":           "create schema",
+			"list":              "- a\n- b",
+			"no version":        `{"info": {"title": "a", "version": "1"}, "paths": {}}`,
+			"unknown version":   `{"swagger": "1.2", "info": {"title": "a", "version": "1"}, "paths": {}}`,
+			"no info":           `{"swagger": "2.0", "paths": {}}`,
+			"no paths":          `{"swagger": "2.0", "info": {"title": "a", "version": "1"}}`,
+			"invalid path":      `{"swagger": "2.0", "info": {"title": "a", "version": "1"}, "paths": {"a": {}}}`,
+			"no responses":      `{"swagger": "2.0", "info": {"title": "a", "version": "1"}, "paths": {"/a": {"get": {}}}}`,
+			"invalid in":        `{"openapi": "3.0.0", "info": {"title": "a", "version": "1"}, "paths": {"/a": {"get": {"parameters": [{"name": "a", "in": "body"}], "responses": {"200": {}}}}}}`,
+			"optional path":     `{"swagger": "2.0", "info": {"title": "a", "version": "1"}, "paths": {"/a/{a}": {"get": {"parameters": [{"name": "a", "in": "path"}], "responses": {"200": {}}}}}}`,
+			"missing reference": `{"swagger": "2.0", "info": {"title": "a", "version": "1"}, "paths": {"/a": {"get": {"parameters": [{"$ref": "#/parameters/a"}], "responses": {"200": {}}}}}}`,
+			"unresolved schema": `{"swagger": "2.0", "info": {"title": "a", "version": "1"}, "paths": {"/a": {"get": {"responses": {"200": {"schema": {"$ref": "#/definitions/A"}}}}}}}`,
+			"duplicated op id":  `{"swagger": "2.0", "info": {"title": "a", "version": "1"}, "paths": {"/a": {"get": {"operationId": "a", "responses": {"200": {}}}, "put": {"operationId": "a", "responses": {"200": {}}}}}}`,
+		}
+		for name, content := range cases {
+			_, err := openapi.Parse(content)
+			assert.Error(t, err, name)
+		}
+		_, err := openapi.Parse(cases["no version"])
+		assert.Equal(t, openapi.ErrUndeclared, err)
+	})
+}
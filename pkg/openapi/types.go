@@ -0,0 +1,121 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package openapi parses the Swagger 2.0 and OpenAPI 3.0 schema documents
+// and compares the operations of two documents
+package openapi
+
+const (
+	Version2 = "2.0"
+	Version3 = "3"
+)
+
+// Document is the normalized OpenAPI document
+type Document struct {
+	// Version is Version2 or Version3
+	Version    string
+	Title      string
+	APIVersion string
+	// Operations is keyed by "METHOD path"
+	Operations map[string]*Operation
+}
+
+type Operation struct {
+	Method      string
+	Path        string
+	OperationID string
+	// Parameters is keyed by "in:name"
+	Parameters  map[string]*Parameter
+	RequestBody *RequestBody
+	// Responses is keyed by the response code
+	Responses map[string]*Response
+}
+
+type Parameter struct {
+	Name     string
+	In       string
+	Required bool
+	Schema   *Schema
+}
+
+type RequestBody struct {
+	Required bool
+	// Schema is the schema of the JSON or the first media type, nil if no content
+	Schema *Schema
+}
+
+type Response struct {
+	// Schema is nil if the response has no content
+	Schema *Schema
+}
+
+// Schema is the normalized JSON schema with the local references resolved
+type Schema struct {
+	Type       string
+	Items      *Schema
+	Properties map[string]*Schema
+	Required   []string
+	// Ref is set only if the reference is recursive and not expanded again
+	Ref string
+}
+
+// String returns the type of the schema, like 'string' or 'array<object>'
+func (s *Schema) String() string {
+	if s == nil {
+		return ""
+	}
+	if len(s.Ref) > 0 {
+		return s.Ref
+	}
+	if s.Type == "array" && s.Items != nil {
+		return "array<" + s.Items.String() + ">"
+	}
+	return s.Type
+}
+
+type Level string
+
+const (
+	LevelBreaking   Level = "breaking"
+	LevelCompatible Level = "compatible"
+)
+
+// Change is an operation difference between two documents
+type Change struct {
+	Level     Level  `json:"level"`
+	Kind      string `json:"kind"`
+	Operation string `json:"operation"`
+	Message   string `json:"message"`
+}
+
+const (
+	KindOperationAdded       = "operation-added"
+	KindOperationRemoved     = "operation-removed"
+	KindParameterAdded       = "parameter-added"
+	KindParameterRemoved     = "parameter-removed"
+	KindParameterRequired    = "parameter-required"
+	KindParameterOptional    = "parameter-optional"
+	KindParameterTypeChanged = "parameter-type-changed"
+	KindRequestBodyAdded     = "request-body-added"
+	KindRequestBodyRemoved   = "request-body-removed"
+	KindRequestBodyRequired  = "request-body-required"
+	KindRequestBodyOptional  = "request-body-optional"
+	KindResponseAdded        = "response-added"
+	KindResponseRemoved      = "response-removed"
+	KindRequestBodyChanged   = "request-body-changed"
+	KindResponseChanged      = "response-changed"
+)
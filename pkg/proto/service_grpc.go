@@ -0,0 +1,801 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package proto
+
+import (
+	"context"
+
+	"github.com/go-chassis/cari/discovery"
+	"google.golang.org/grpc"
+)
+
+// RegisterServiceCtrlServer registers the microservice APIs, the messages
+// are encoded by the codec of the content subtype, see pkg/rpc
+func RegisterServiceCtrlServer(s *grpc.Server, srv ServiceCtrlServer) {
+	s.RegisterService(&_ServiceCtrl_serviceDesc, srv)
+}
+
+func _ServiceCtrl_Exist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.GetExistenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).Exist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceCtrl/Exist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).Exist(ctx, req.(*discovery.GetExistenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.CreateServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceCtrl/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).Create(ctx, req.(*discovery.CreateServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.DeleteServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceCtrl/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).Delete(ctx, req.(*discovery.DeleteServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_GetOne_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.GetServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).GetOne(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceCtrl/GetOne",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).GetOne(ctx, req.(*discovery.GetServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_GetServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.GetServicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).GetServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceCtrl/GetServices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).GetServices(ctx, req.(*discovery.GetServicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_UpdateProperties_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.UpdateServicePropsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).UpdateProperties(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceCtrl/UpdateProperties",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).UpdateProperties(ctx, req.(*discovery.UpdateServicePropsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_AddRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.AddServiceRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).AddRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceCtrl/AddRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).AddRule(ctx, req.(*discovery.AddServiceRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_GetRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.GetServiceRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).GetRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceCtrl/GetRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).GetRule(ctx, req.(*discovery.GetServiceRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_UpdateRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.UpdateServiceRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).UpdateRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceCtrl/UpdateRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).UpdateRule(ctx, req.(*discovery.UpdateServiceRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_DeleteRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.DeleteServiceRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).DeleteRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceCtrl/DeleteRule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).DeleteRule(ctx, req.(*discovery.DeleteServiceRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_AddTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.AddServiceTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).AddTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceCtrl/AddTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).AddTags(ctx, req.(*discovery.AddServiceTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_GetTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.GetServiceTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).GetTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceCtrl/GetTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).GetTags(ctx, req.(*discovery.GetServiceTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_UpdateTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.UpdateServiceTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).UpdateTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceCtrl/UpdateTag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).UpdateTag(ctx, req.(*discovery.UpdateServiceTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_DeleteTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.DeleteServiceTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).DeleteTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceCtrl/DeleteTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).DeleteTags(ctx, req.(*discovery.DeleteServiceTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_GetSchemaInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.GetSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).GetSchemaInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceCtrl/GetSchemaInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).GetSchemaInfo(ctx, req.(*discovery.GetSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_GetAllSchemaInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.GetAllSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).GetAllSchemaInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceCtrl/GetAllSchemaInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).GetAllSchemaInfo(ctx, req.(*discovery.GetAllSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_DeleteSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.DeleteSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).DeleteSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceCtrl/DeleteSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).DeleteSchema(ctx, req.(*discovery.DeleteSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_ModifySchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.ModifySchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).ModifySchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceCtrl/ModifySchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).ModifySchema(ctx, req.(*discovery.ModifySchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_ModifySchemas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.ModifySchemasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).ModifySchemas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceCtrl/ModifySchemas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).ModifySchemas(ctx, req.(*discovery.ModifySchemasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_AddDependenciesForMicroServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.AddDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).AddDependenciesForMicroServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceCtrl/AddDependenciesForMicroServices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).AddDependenciesForMicroServices(ctx, req.(*discovery.AddDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_CreateDependenciesForMicroServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.CreateDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).CreateDependenciesForMicroServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceCtrl/CreateDependenciesForMicroServices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).CreateDependenciesForMicroServices(ctx, req.(*discovery.CreateDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_GetProviderDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.GetDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).GetProviderDependencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceCtrl/GetProviderDependencies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).GetProviderDependencies(ctx, req.(*discovery.GetDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_GetConsumerDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.GetDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).GetConsumerDependencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceCtrl/GetConsumerDependencies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).GetConsumerDependencies(ctx, req.(*discovery.GetDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceCtrl_DeleteServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.DelServicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceCtrlServer).DeleteServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceCtrl/DeleteServices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceCtrlServer).DeleteServices(ctx, req.(*discovery.DelServicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ServiceCtrl_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "servicecenter.grpc.api.ServiceCtrl",
+	HandlerType: (*ServiceCtrlServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Exist",
+			Handler:    _ServiceCtrl_Exist_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _ServiceCtrl_Create_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _ServiceCtrl_Delete_Handler,
+		},
+		{
+			MethodName: "GetOne",
+			Handler:    _ServiceCtrl_GetOne_Handler,
+		},
+		{
+			MethodName: "GetServices",
+			Handler:    _ServiceCtrl_GetServices_Handler,
+		},
+		{
+			MethodName: "UpdateProperties",
+			Handler:    _ServiceCtrl_UpdateProperties_Handler,
+		},
+		{
+			MethodName: "AddRule",
+			Handler:    _ServiceCtrl_AddRule_Handler,
+		},
+		{
+			MethodName: "GetRule",
+			Handler:    _ServiceCtrl_GetRule_Handler,
+		},
+		{
+			MethodName: "UpdateRule",
+			Handler:    _ServiceCtrl_UpdateRule_Handler,
+		},
+		{
+			MethodName: "DeleteRule",
+			Handler:    _ServiceCtrl_DeleteRule_Handler,
+		},
+		{
+			MethodName: "AddTags",
+			Handler:    _ServiceCtrl_AddTags_Handler,
+		},
+		{
+			MethodName: "GetTags",
+			Handler:    _ServiceCtrl_GetTags_Handler,
+		},
+		{
+			MethodName: "UpdateTag",
+			Handler:    _ServiceCtrl_UpdateTag_Handler,
+		},
+		{
+			MethodName: "DeleteTags",
+			Handler:    _ServiceCtrl_DeleteTags_Handler,
+		},
+		{
+			MethodName: "GetSchemaInfo",
+			Handler:    _ServiceCtrl_GetSchemaInfo_Handler,
+		},
+		{
+			MethodName: "GetAllSchemaInfo",
+			Handler:    _ServiceCtrl_GetAllSchemaInfo_Handler,
+		},
+		{
+			MethodName: "DeleteSchema",
+			Handler:    _ServiceCtrl_DeleteSchema_Handler,
+		},
+		{
+			MethodName: "ModifySchema",
+			Handler:    _ServiceCtrl_ModifySchema_Handler,
+		},
+		{
+			MethodName: "ModifySchemas",
+			Handler:    _ServiceCtrl_ModifySchemas_Handler,
+		},
+		{
+			MethodName: "AddDependenciesForMicroServices",
+			Handler:    _ServiceCtrl_AddDependenciesForMicroServices_Handler,
+		},
+		{
+			MethodName: "CreateDependenciesForMicroServices",
+			Handler:    _ServiceCtrl_CreateDependenciesForMicroServices_Handler,
+		},
+		{
+			MethodName: "GetProviderDependencies",
+			Handler:    _ServiceCtrl_GetProviderDependencies_Handler,
+		},
+		{
+			MethodName: "GetConsumerDependencies",
+			Handler:    _ServiceCtrl_GetConsumerDependencies_Handler,
+		},
+		{
+			MethodName: "DeleteServices",
+			Handler:    _ServiceCtrl_DeleteServices_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// RegisterServiceInstanceCtrlServer registers the instance APIs and the
+// watch stream
+func RegisterServiceInstanceCtrlServer(s *grpc.Server, srv ServiceInstanceCtrlServer) {
+	s.RegisterService(&_ServiceInstanceCtrl_serviceDesc, srv)
+}
+
+func _ServiceInstanceCtrl_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.RegisterInstanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceInstanceCtrlServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceInstanceCtrl/Register",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceInstanceCtrlServer).Register(ctx, req.(*discovery.RegisterInstanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceInstanceCtrl_Unregister_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.UnregisterInstanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceInstanceCtrlServer).Unregister(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceInstanceCtrl/Unregister",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceInstanceCtrlServer).Unregister(ctx, req.(*discovery.UnregisterInstanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceInstanceCtrl_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.HeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceInstanceCtrlServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceInstanceCtrl/Heartbeat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceInstanceCtrlServer).Heartbeat(ctx, req.(*discovery.HeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceInstanceCtrl_Find_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.FindInstancesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceInstanceCtrlServer).Find(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceInstanceCtrl/Find",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceInstanceCtrlServer).Find(ctx, req.(*discovery.FindInstancesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceInstanceCtrl_GetInstances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.GetInstancesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceInstanceCtrlServer).GetInstances(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceInstanceCtrl/GetInstances",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceInstanceCtrlServer).GetInstances(ctx, req.(*discovery.GetInstancesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceInstanceCtrl_GetOneInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.GetOneInstanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceInstanceCtrlServer).GetOneInstance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceInstanceCtrl/GetOneInstance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceInstanceCtrlServer).GetOneInstance(ctx, req.(*discovery.GetOneInstanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceInstanceCtrl_UpdateStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.UpdateInstanceStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceInstanceCtrlServer).UpdateStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceInstanceCtrl/UpdateStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceInstanceCtrlServer).UpdateStatus(ctx, req.(*discovery.UpdateInstanceStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceInstanceCtrl_UpdateInstanceProperties_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.UpdateInstancePropsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceInstanceCtrlServer).UpdateInstanceProperties(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceInstanceCtrl/UpdateInstanceProperties",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceInstanceCtrlServer).UpdateInstanceProperties(ctx, req.(*discovery.UpdateInstancePropsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceInstanceCtrl_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	in := new(discovery.WatchInstanceRequest)
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+	return srv.(ServiceInstanceCtrlServer).Watch(in, &serviceInstanceCtrlWatchServer{stream})
+}
+
+type serviceInstanceCtrlWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceInstanceCtrlWatchServer) Send(m *discovery.WatchInstanceResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ServiceInstanceCtrl_HeartbeatSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(discovery.HeartbeatSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceInstanceCtrlServer).HeartbeatSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/servicecenter.grpc.api.ServiceInstanceCtrl/HeartbeatSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceInstanceCtrlServer).HeartbeatSet(ctx, req.(*discovery.HeartbeatSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ServiceInstanceCtrl_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "servicecenter.grpc.api.ServiceInstanceCtrl",
+	HandlerType: (*ServiceInstanceCtrlServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Register",
+			Handler:    _ServiceInstanceCtrl_Register_Handler,
+		},
+		{
+			MethodName: "Unregister",
+			Handler:    _ServiceInstanceCtrl_Unregister_Handler,
+		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _ServiceInstanceCtrl_Heartbeat_Handler,
+		},
+		{
+			MethodName: "Find",
+			Handler:    _ServiceInstanceCtrl_Find_Handler,
+		},
+		{
+			MethodName: "GetInstances",
+			Handler:    _ServiceInstanceCtrl_GetInstances_Handler,
+		},
+		{
+			MethodName: "GetOneInstance",
+			Handler:    _ServiceInstanceCtrl_GetOneInstance_Handler,
+		},
+		{
+			MethodName: "UpdateStatus",
+			Handler:    _ServiceInstanceCtrl_UpdateStatus_Handler,
+		},
+		{
+			MethodName: "UpdateInstanceProperties",
+			Handler:    _ServiceInstanceCtrl_UpdateInstanceProperties_Handler,
+		},
+		{
+			MethodName: "HeartbeatSet",
+			Handler:    _ServiceInstanceCtrl_HeartbeatSet_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _ServiceInstanceCtrl_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+}
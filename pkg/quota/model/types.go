@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+// the resource names used in the quota limits, same as the 'quota.cap.<name>.limit' configurations
+const (
+	ResourceService  = "service"
+	ResourceInstance = "instance"
+	ResourceSchema   = "schema"
+	ResourceTag      = "tag"
+	ResourceRule     = "rule"
+	ResourceAccount  = "account"
+	ResourceRole     = "role"
+)
+
+var Resources = []string{
+	ResourceService,
+	ResourceInstance,
+	ResourceSchema,
+	ResourceTag,
+	ResourceRule,
+	ResourceAccount,
+	ResourceRole,
+}
+
+// Quota is the quota limits override of a domain project,
+// the resource not in Limits uses the global limit
+type Quota struct {
+	Domain       string           `json:"domain" bson:"domain"`
+	Project      string           `json:"project" bson:"project"`
+	Limits       map[string]int64 `json:"limits" bson:"limits"`
+	ModTimestamp string           `json:"modTimestamp,omitempty" bson:"mod_timestamp"`
+}
+
+// Usage is the quota usage of a resource, the schema, tag and rule are
+// counted per service, ServiceID is the service they counted in
+type Usage struct {
+	Resource  string `json:"resource"`
+	Limit     int64  `json:"limit"`
+	Used      int64  `json:"used"`
+	ServiceID string `json:"serviceId,omitempty"`
+}
+
+func IsResource(name string) bool {
+	for _, r := range Resources {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
@@ -19,6 +19,10 @@ package rpc
 
 import "google.golang.org/grpc"
 
+// ServerChainName is the handler chain of the gRPC server, the handlers
+// registered to it, e.g. the access log, apply to the gRPC requests
+const ServerChainName = "_grpc_server_chain"
+
 type RegisterServiceFunc func(s *grpc.Server)
 
 var registerFuncs []RegisterServiceFunc
@@ -0,0 +1,98 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package selector
+
+type token int
+
+const (
+	tokenEOF token = iota
+	tokenIdentifier
+	tokenComma
+	tokenNot
+	tokenEquals
+	tokenDoubleEquals
+	tokenNotEquals
+	tokenOpenParen
+	tokenCloseParen
+)
+
+type lexer struct {
+	s   string
+	pos int
+}
+
+// peek returns the next token without consuming it
+func (l *lexer) peek() (token, string) {
+	pos := l.pos
+	tok, lit := l.next()
+	l.pos = pos
+	return tok, lit
+}
+
+func (l *lexer) next() (token, string) {
+	for l.pos < len(l.s) && isSpace(l.s[l.pos]) {
+		l.pos++
+	}
+	if l.pos >= len(l.s) {
+		return tokenEOF, "EOF"
+	}
+
+	start := l.pos
+	switch l.s[l.pos] {
+	case ',':
+		l.pos++
+		return tokenComma, ","
+	case '(':
+		l.pos++
+		return tokenOpenParen, "("
+	case ')':
+		l.pos++
+		return tokenCloseParen, ")"
+	case '=':
+		l.pos++
+		if l.pos < len(l.s) && l.s[l.pos] == '=' {
+			l.pos++
+			return tokenDoubleEquals, "=="
+		}
+		return tokenEquals, "="
+	case '!':
+		l.pos++
+		if l.pos < len(l.s) && l.s[l.pos] == '=' {
+			l.pos++
+			return tokenNotEquals, "!="
+		}
+		return tokenNot, "!"
+	}
+
+	for l.pos < len(l.s) && !isSpecial(l.s[l.pos]) {
+		l.pos++
+	}
+	return tokenIdentifier, l.s[start:l.pos]
+}
+
+func isSpecial(c byte) bool {
+	switch c {
+	case ',', '(', ')', '=', '!':
+		return true
+	}
+	return isSpace(c)
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
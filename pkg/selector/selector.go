@@ -0,0 +1,267 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package selector implements the kubernetes style label selector
+// expressions, e.g. "zone=az1,env in (test,prod),!canary".
+package selector
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/apache/servicecomb-service-center/pkg/util"
+)
+
+const CtxSelector util.CtxKey = "selector"
+
+type Operator string
+
+const (
+	Equals       Operator = "="
+	NotEquals    Operator = "!="
+	In           Operator = "in"
+	NotIn        Operator = "notin"
+	Exists       Operator = "exists"
+	DoesNotExist Operator = "!"
+)
+
+// Requirement is a single expression of the selector
+type Requirement struct {
+	Key      string
+	Operator Operator
+	Values   []string
+}
+
+// Match checks the requirement against the labels, the value of a key
+// is looked up in the labels by order and the first one found wins.
+// As the kubernetes does, the '!=' and 'notin' match the absent key.
+func (r *Requirement) Match(labels ...map[string]string) bool {
+	value, ok := lookup(r.Key, labels)
+	switch r.Operator {
+	case Exists:
+		return ok
+	case DoesNotExist:
+		return !ok
+	case Equals, In:
+		return ok && r.hasValue(value)
+	case NotEquals, NotIn:
+		return !ok || !r.hasValue(value)
+	default:
+		return false
+	}
+}
+
+func (r *Requirement) hasValue(value string) bool {
+	for _, v := range r.Values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Requirement) String() string {
+	switch r.Operator {
+	case Exists:
+		return r.Key
+	case DoesNotExist:
+		return string(DoesNotExist) + r.Key
+	case Equals, NotEquals:
+		return r.Key + string(r.Operator) + r.Values[0]
+	default:
+		return r.Key + " " + string(r.Operator) + " (" + strings.Join(r.Values, ",") + ")"
+	}
+}
+
+// Selector is a set of requirements, it matches only when all
+// the requirements are matched
+type Selector []*Requirement
+
+func (s Selector) Empty() bool {
+	return len(s) == 0
+}
+
+func (s Selector) Match(labels ...map[string]string) bool {
+	for _, r := range s {
+		if !r.Match(labels...) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the canonical form of selector, so the equivalent
+// selectors have the same string and can be used as a cache key
+func (s Selector) String() string {
+	exps := make([]string, 0, len(s))
+	for _, r := range s {
+		exps = append(exps, r.String())
+	}
+	sort.Strings(exps)
+	return strings.Join(exps, ",")
+}
+
+func lookup(key string, labels []map[string]string) (string, bool) {
+	for _, m := range labels {
+		if v, ok := m[key]; ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// Parse parses the expression to a Selector, an empty
+// expression returns the empty Selector which matches everything.
+func Parse(expr string) (Selector, error) {
+	p := &parser{l: &lexer{s: expr}}
+	return p.parse()
+}
+
+func FromContext(ctx context.Context) Selector {
+	s, _ := ctx.Value(CtxSelector).(Selector)
+	return s
+}
+
+func SetContext(ctx context.Context, s Selector) context.Context {
+	return util.SetContext(ctx, CtxSelector, s)
+}
+
+type parser struct {
+	l *lexer
+}
+
+func (p *parser) parse() (Selector, error) {
+	var s Selector
+	tok, _ := p.l.peek()
+	if tok == tokenEOF {
+		return s, nil
+	}
+	for {
+		r, err := p.parseRequirement()
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, r)
+
+		tok, lit := p.l.next()
+		switch tok {
+		case tokenEOF:
+			return s, nil
+		case tokenComma:
+		default:
+			return nil, fmt.Errorf("expected ',' but found '%s'", lit)
+		}
+	}
+}
+
+func (p *parser) parseRequirement() (*Requirement, error) {
+	tok, lit := p.l.next()
+	if tok == tokenNot {
+		key, err := p.parseKey()
+		if err != nil {
+			return nil, err
+		}
+		return &Requirement{Key: key, Operator: DoesNotExist}, nil
+	}
+	if tok != tokenIdentifier {
+		return nil, fmt.Errorf("expected key but found '%s'", lit)
+	}
+	r := &Requirement{Key: lit, Operator: Exists}
+
+	tok, lit = p.l.peek()
+	switch {
+	case tok == tokenEOF || tok == tokenComma:
+		return r, nil
+	case tok == tokenEquals || tok == tokenDoubleEquals || tok == tokenNotEquals:
+		p.l.next()
+		r.Operator = Equals
+		if tok == tokenNotEquals {
+			r.Operator = NotEquals
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		r.Values = []string{value}
+		return r, nil
+	case tok == tokenIdentifier && (lit == string(In) || lit == string(NotIn)):
+		p.l.next()
+		r.Operator = Operator(lit)
+		values, err := p.parseValues()
+		if err != nil {
+			return nil, err
+		}
+		r.Values = values
+		return r, nil
+	default:
+		return nil, fmt.Errorf("expected operator after key '%s' but found '%s'", r.Key, lit)
+	}
+}
+
+func (p *parser) parseKey() (string, error) {
+	tok, lit := p.l.next()
+	if tok != tokenIdentifier {
+		return "", fmt.Errorf("expected key but found '%s'", lit)
+	}
+	return lit, nil
+}
+
+func (p *parser) parseValue() (string, error) {
+	tok, lit := p.l.peek()
+	switch tok {
+	case tokenIdentifier:
+		p.l.next()
+		return lit, nil
+	case tokenEOF, tokenComma:
+		// empty value is allowed
+		return "", nil
+	default:
+		return "", fmt.Errorf("expected value but found '%s'", lit)
+	}
+}
+
+func (p *parser) parseValues() ([]string, error) {
+	if tok, lit := p.l.next(); tok != tokenOpenParen {
+		return nil, fmt.Errorf("expected '(' but found '%s'", lit)
+	}
+	set := make(map[string]struct{})
+	for {
+		tok, lit := p.l.next()
+		if tok != tokenIdentifier {
+			return nil, fmt.Errorf("expected value but found '%s'", lit)
+		}
+		set[lit] = struct{}{}
+
+		tok, lit = p.l.next()
+		switch tok {
+		case tokenComma:
+			continue
+		case tokenCloseParen:
+		default:
+			return nil, fmt.Errorf("expected ',' or ')' but found '%s'", lit)
+		}
+		break
+	}
+	values := make([]string, 0, len(set))
+	for v := range set {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+	return values, nil
+}
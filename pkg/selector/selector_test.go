@@ -0,0 +1,105 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package selector_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/servicecomb-service-center/pkg/selector"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("valid expressions should return the canonical form", func(t *testing.T) {
+		cases := []struct {
+			expr     string
+			expected string
+		}{
+			{"", ""},
+			{"zone", "zone"},
+			{"!canary", "!canary"},
+			{"zone=az1", "zone=az1"},
+			{" zone == az1 ", "zone=az1"},
+			{"zone!=az1", "zone!=az1"},
+			{"zone=", "zone="},
+			{"env in (prod, test,prod)", "env in (prod,test)"},
+			{"env notin (a)", "env notin (a)"},
+			{"zone=az1,!canary,env in (b,a)", "!canary,env in (a,b),zone=az1"},
+			{"a/b.c-d=e_f:g", "a/b.c-d=e_f:g"},
+		}
+		for _, c := range cases {
+			s, err := selector.Parse(c.expr)
+			assert.NoError(t, err, c.expr)
+			assert.Equal(t, c.expected, s.String(), c.expr)
+		}
+	})
+
+	t.Run("invalid expressions should return error", func(t *testing.T) {
+		for _, expr := range []string{
+			",", "zone,", "!", "!!a", "=a", "a b", "a in", "a in ()", "a in (b", "a in (b,)",
+			"a=b=c", "a notin b", "a,,b", "(a)",
+		} {
+			_, err := selector.Parse(expr)
+			assert.Error(t, err, expr)
+		}
+	})
+}
+
+func TestSelector_Match(t *testing.T) {
+	props := map[string]string{"zone": "az1", "version": "v2"}
+	tags := map[string]string{"zone": "az2", "env": "prod"}
+	cases := []struct {
+		expr    string
+		matched bool
+	}{
+		{"", true},
+		{"zone", true},
+		{"canary", false},
+		{"!canary", true},
+		{"!env", false},
+		{"zone=az1", true},
+		{"zone=az2", false},
+		{"env=prod", true},
+		{"zone!=az2", true},
+		{"canary!=true", true},
+		{"env in (prod,test)", true},
+		{"env in (test)", false},
+		{"canary in (true)", false},
+		{"env notin (test)", true},
+		{"canary notin (true)", true},
+		{"env notin (prod)", false},
+		{"zone=az1,env=prod,!canary", true},
+		{"zone=az1,env=test", false},
+	}
+	for _, c := range cases {
+		s, err := selector.Parse(c.expr)
+		assert.NoError(t, err, c.expr)
+		assert.Equal(t, c.matched, s.Match(props, tags), c.expr)
+	}
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	assert.True(t, selector.FromContext(ctx).Empty())
+
+	s, err := selector.Parse("zone=az1")
+	assert.NoError(t, err)
+	ctx = selector.SetContext(ctx, s)
+	assert.Equal(t, "zone=az1", selector.FromContext(ctx).String())
+}
@@ -0,0 +1,314 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package semver
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	Latest = "latest"
+	// fullNumbers is the count of numbers in the full version x.y.z
+	fullNumbers = 3
+)
+
+var (
+	ErrInvalidRule = errors.New("invalid version rule")
+
+	rangeRegex = regexp.MustCompile(`^\d+(\.\d+)*-\d+(\.\d+)*$`)
+)
+
+type operator string
+
+const (
+	opEQ operator = "="
+	opGT operator = ">"
+	opGE operator = ">="
+	opLT operator = "<"
+	opLE operator = "<="
+)
+
+type comparator struct {
+	op      operator
+	version *Version
+	// bound is true if the comparator is the generated upper bound of
+	// caret or tilde, it can not make pre-release versions matched
+	bound bool
+}
+
+func (c *comparator) match(v *Version) bool {
+	r := Compare(v, c.version)
+	switch c.op {
+	case opEQ:
+		return r == 0
+	case opGT:
+		return r > 0
+	case opGE:
+		return r >= 0
+	case opLT:
+		return r < 0
+	case opLE:
+		return r <= 0
+	default:
+		return false
+	}
+}
+
+// Rule is the parsed version rule, supports:
+//  1. 'latest', the latest version
+//  2. 'x.y.z', the exact version
+//  3. 'x.y.z+', the versions greater than or equal to x.y.z
+//  4. 'x.y.z-a.b.c' or 'x.y-a.b', the versions in range [x.y.z, a.b.c), see IsRange
+//  5. '^1.2', '~1.4.0' and the comparison list like '>=1.0 <2.0', the lists
+//     can be joined by '||'. As the npm does, the pre-release versions are
+//     only matched if a comparator includes a pre-release of the same x.y.z
+type Rule struct {
+	latest bool
+	exact  string
+	legacy bool
+	sets   [][]*comparator
+}
+
+func ParseRule(rule string) (*Rule, error) {
+	rule = strings.TrimSpace(rule)
+	switch {
+	case len(rule) == 0:
+		return nil, ErrInvalidRule
+	case rule == Latest:
+		return &Rule{latest: true}, nil
+	case strings.ContainsAny(rule, "^~<>=| \t"):
+		return parseComparatorSets(rule)
+	case rule[len(rule)-1] == '+':
+		start, err := Parse(rule[:len(rule)-1])
+		if err != nil {
+			return nil, ErrInvalidRule
+		}
+		return &Rule{legacy: true, sets: [][]*comparator{{{op: opGE, version: start}}}}, nil
+	case IsRange(rule):
+		idx := strings.IndexByte(rule, '-')
+		start, err := Parse(rule[:idx])
+		if err != nil {
+			return nil, ErrInvalidRule
+		}
+		end, err := Parse(rule[idx+1:])
+		if err != nil {
+			return nil, ErrInvalidRule
+		}
+		if Compare(start, end) > 0 {
+			start, end = end, start
+		}
+		return &Rule{legacy: true, sets: [][]*comparator{{{op: opGE, version: start}, {op: opLT, version: end}}}}, nil
+	default:
+		if _, err := Parse(rule); err != nil {
+			return nil, ErrInvalidRule
+		}
+		return &Rule{exact: rule}, nil
+	}
+}
+
+// IsRange returns true if s is the range rule 'x.y.z-a.b.c', the numeric 'a-b' is
+// a range if the left side is not a full x.y.z, or both sides are full versions,
+// so '1.0-2.0' and '1.0.0-2.0.0' are ranges, but '1.0.0-1' and '1.0.0-0.3' are
+// versions with pre-release. Use '=1.0.0-0.3.7' to match such a version exactly,
+// and '>=1.0.0 <2' to specify the range unambiguously
+func IsRange(s string) bool {
+	if !rangeRegex.MatchString(s) {
+		return false
+	}
+	idx := strings.IndexByte(s, '-')
+	left, right := strings.Count(s[:idx], ".")+1, strings.Count(s[idx+1:], ".")+1
+	return left < fullNumbers || right >= fullNumbers
+}
+
+// IsLatest returns true if the rule is 'latest'
+func (r *Rule) IsLatest() bool {
+	return r.latest
+}
+
+// IsExact returns true if the rule is an exact version
+func (r *Rule) IsExact() bool {
+	return len(r.exact) > 0
+}
+
+// Match returns true if the version matches the rule,
+// any valid version matches 'latest'
+func (r *Rule) Match(version string) bool {
+	if r.IsExact() {
+		return version == r.exact
+	}
+	v, err := Parse(version)
+	if err != nil {
+		return false
+	}
+	if r.latest {
+		return true
+	}
+	return r.match(v)
+}
+
+// Filter returns the matched versions in descending order,
+// for the rule 'latest', it returns the greatest version only
+func (r *Rule) Filter(versions []string) []string {
+	type item struct {
+		raw string
+		v   *Version
+	}
+	matched := make([]item, 0, len(versions))
+	for _, version := range versions {
+		v, err := Parse(version)
+		if err != nil {
+			continue
+		}
+		if r.IsExact() && version != r.exact {
+			continue
+		}
+		if !r.IsExact() && !r.latest && !r.match(v) {
+			continue
+		}
+		matched = append(matched, item{raw: version, v: v})
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return Compare(matched[i].v, matched[j].v) > 0
+	})
+	if r.latest && len(matched) > 1 {
+		matched = matched[:1]
+	}
+	result := make([]string, 0, len(matched))
+	for _, m := range matched {
+		result = append(result, m.raw)
+	}
+	return result
+}
+
+func (r *Rule) match(v *Version) bool {
+	for _, set := range r.sets {
+		if r.matchSet(set, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) matchSet(set []*comparator, v *Version) bool {
+	for _, c := range set {
+		if !c.match(v) {
+			return false
+		}
+	}
+	if r.legacy || !v.IsPreRelease() {
+		return true
+	}
+	for _, c := range set {
+		if !c.bound && c.version.IsPreRelease() && c.version.sameNumbers(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseComparatorSets(rule string) (*Rule, error) {
+	r := &Rule{}
+	for _, s := range strings.Split(rule, "||") {
+		set, err := parseComparatorSet(s)
+		if err != nil {
+			return nil, err
+		}
+		r.sets = append(r.sets, set)
+	}
+	return r, nil
+}
+
+func parseComparatorSet(s string) ([]*comparator, error) {
+	var set []*comparator
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil, ErrInvalidRule
+	}
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		// the operator may be separated from the version, e.g. '>= 1.0'
+		if strings.Trim(f, "^~<>=") == "" && i+1 < len(fields) {
+			i++
+			f += fields[i]
+		}
+		cs, err := parseComparator(f)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, cs...)
+	}
+	return set, nil
+}
+
+func parseComparator(s string) ([]*comparator, error) {
+	var op string
+	for _, prefix := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(s, prefix) {
+			op = prefix
+			break
+		}
+	}
+	v, err := Parse(s[len(op):])
+	if err != nil {
+		return nil, ErrInvalidRule
+	}
+	switch op {
+	case "^":
+		return []*comparator{{op: opGE, version: v}, {op: opLT, version: caretBound(v), bound: true}}, nil
+	case "~":
+		return []*comparator{{op: opGE, version: v}, {op: opLT, version: tildeBound(v), bound: true}}, nil
+	case "":
+		return []*comparator{{op: opEQ, version: v}}, nil
+	default:
+		return []*comparator{{op: operator(op), version: v}}, nil
+	}
+}
+
+// caretBound returns the upper bound of '^', it increases the left-most
+// non-zero number, e.g. ^1.2.3 := <2.0.0-0, ^0.2.3 := <0.3.0-0, ^0.0.3 := <0.0.4-0
+func caretBound(v *Version) *Version {
+	idx := len(v.Numbers) - 1
+	for i, n := range v.Numbers {
+		if n != 0 {
+			idx = i
+			break
+		}
+	}
+	return bump(v, idx)
+}
+
+// tildeBound returns the upper bound of '~', it increases the minor number
+// if specified, e.g. ~1.2.3 := <1.3.0-0, ~1 := <2.0.0-0
+func tildeBound(v *Version) *Version {
+	if len(v.Numbers) > 1 {
+		return bump(v, 1)
+	}
+	return bump(v, 0)
+}
+
+// bump increases the number at idx and resets the rest, the bound
+// is the least pre-release version so it excludes the pre-releases
+func bump(v *Version, idx int) *Version {
+	numbers := make([]int64, idx+1)
+	copy(numbers, v.Numbers[:idx+1])
+	numbers[idx]++
+	return &Version{Numbers: numbers, PreRelease: []string{"0"}}
+}
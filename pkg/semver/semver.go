@@ -0,0 +1,202 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package semver implements the SemVer 2.0 versions and the npm style
+// version rules, it is compatible with the legacy x[.y[.z[.w]]] versions.
+package semver
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MaxNumber is the max value of the version number, it keeps
+	// compatible with the versions converted to int64
+	MaxNumber = 32767
+	// MaxNumbers is the max count of the version numbers
+	MaxNumbers = 4
+)
+
+var ErrInvalidVersion = errors.New("invalid version")
+
+// Version is the parsed version, the build metadata is ignored when compare
+type Version struct {
+	Numbers    []int64
+	PreRelease []string
+	Build      string
+}
+
+func Parse(s string) (*Version, error) {
+	v := &Version{}
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		v.Build = s[i+1:]
+		if !validIdentifiers(v.Build, false) {
+			return nil, ErrInvalidVersion
+		}
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		pre := s[i+1:]
+		if !validIdentifiers(pre, true) {
+			return nil, ErrInvalidVersion
+		}
+		v.PreRelease = strings.Split(pre, ".")
+		s = s[:i]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > MaxNumbers {
+		return nil, ErrInvalidVersion
+	}
+	v.Numbers = make([]int64, len(parts))
+	for i, part := range parts {
+		if !isNumeric(part) {
+			return nil, ErrInvalidVersion
+		}
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || n > MaxNumber {
+			return nil, ErrInvalidVersion
+		}
+		v.Numbers[i] = n
+	}
+	return v, nil
+}
+
+// Compare returns 0 if a == b, -1 if a < b, and +1 if a > b
+func Compare(a, b *Version) int {
+	for i := 0; i < MaxNumbers; i++ {
+		if c := compareInt(a.number(i), b.number(i)); c != 0 {
+			return c
+		}
+	}
+	return comparePreRelease(a.PreRelease, b.PreRelease)
+}
+
+// Less compares the version strings, the invalid version
+// is less than any valid one
+func Less(a, b string) bool {
+	va, errA := Parse(a)
+	vb, errB := Parse(b)
+	switch {
+	case errA != nil && errB != nil:
+		return a < b
+	case errA != nil:
+		return true
+	case errB != nil:
+		return false
+	default:
+		return Compare(va, vb) < 0
+	}
+}
+
+func (v *Version) number(i int) int64 {
+	if i < len(v.Numbers) {
+		return v.Numbers[i]
+	}
+	return 0
+}
+
+func (v *Version) IsPreRelease() bool {
+	return len(v.PreRelease) > 0
+}
+
+// sameNumbers returns true if the x.y.z of the versions are the same
+func (v *Version) sameNumbers(o *Version) bool {
+	for i := 0; i < MaxNumbers; i++ {
+		if v.number(i) != o.number(i) {
+			return false
+		}
+	}
+	return true
+}
+
+func comparePreRelease(a, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := compareIdentifier(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInt(int64(len(a)), int64(len(b)))
+}
+
+func compareIdentifier(a, b string) int {
+	na, nb := isNumeric(a), isNumeric(b)
+	switch {
+	case na && nb:
+		if c := compareInt(int64(len(a)), int64(len(b))); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	case na:
+		return -1
+	case nb:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func validIdentifiers(s string, pre bool) bool {
+	for _, id := range strings.Split(s, ".") {
+		if len(id) == 0 {
+			return false
+		}
+		for _, c := range id {
+			if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '-') {
+				return false
+			}
+		}
+		// numeric identifiers of pre-release must not include leading zeroes
+		if pre && len(id) > 1 && id[0] == '0' && isNumeric(id) {
+			return false
+		}
+	}
+	return true
+}
+
+func isNumeric(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,160 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package semver_test
+
+import (
+	"testing"
+
+	"github.com/apache/servicecomb-service-center/pkg/semver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("valid versions should return no error", func(t *testing.T) {
+		for _, s := range []string{
+			"1", "1.0", "1.0.0", "1.0.0.0", "32767.0.0", "1.0.0-rc.1", "1.0.0-0.3.7", "1.0.0-x-y.7.z",
+			"1.0.0+build.1", "1.0.0-rc.1+20210101", "01.2",
+		} {
+			_, err := semver.Parse(s)
+			assert.NoError(t, err, s)
+		}
+	})
+
+	t.Run("invalid versions should return error", func(t *testing.T) {
+		for _, s := range []string{
+			"", "a", ".", "1.", ".1", "1.0.0.0.0", "32768", "60000", "-1", "1.0-", "1.0+",
+			"1.0.0-01", "1.0.0-rc..1", "1.0.0+b..1", "1.0.0-rc_1", "v1.0.0",
+		} {
+			_, err := semver.Parse(s)
+			assert.Error(t, err, s)
+		}
+	})
+}
+
+func TestCompare(t *testing.T) {
+	// ascending order of the SemVer 2.0 spec
+	ordered := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2",
+		"1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.2", "1.10.0", "2.0.0.1",
+	}
+	for i := 0; i < len(ordered)-1; i++ {
+		assert.True(t, semver.Less(ordered[i], ordered[i+1]), "%s < %s", ordered[i], ordered[i+1])
+		assert.False(t, semver.Less(ordered[i+1], ordered[i]), "%s > %s", ordered[i+1], ordered[i])
+	}
+
+	a, _ := semver.Parse("1.0")
+	b, _ := semver.Parse("1.0.0+build.1")
+	assert.Equal(t, 0, semver.Compare(a, b))
+
+	assert.True(t, semver.Less("invalid", "1.0"))
+	assert.False(t, semver.Less("1.0", "invalid"))
+}
+
+func TestParseRule(t *testing.T) {
+	t.Run("valid rules should return no error", func(t *testing.T) {
+		for _, s := range []string{
+			"latest", "1.0", "1.0.0-rc.1", "1.0+", "1.0-2.0", "^1.2", "~1.4.0", ">=1.0 <2.0", ">= 1.0",
+			"=1.0.0-1", "1.0 || ^2.0", "<1.0.0-rc.1",
+		} {
+			_, err := semver.ParseRule(s)
+			assert.NoError(t, err, s)
+		}
+	})
+
+	t.Run("invalid rules should return error", func(t *testing.T) {
+		for _, s := range []string{
+			"", "abc", "+", "1.a+", "60000-1", "1.1.1.1.1-2.2.2.2", "^", "^a", ">=", "1.0 ||", ">>1.0", "1.0-",
+		} {
+			_, err := semver.ParseRule(s)
+			assert.Error(t, err, s)
+		}
+	})
+}
+
+func TestRule_Match(t *testing.T) {
+	cases := []struct {
+		rule    string
+		version string
+		matched bool
+	}{
+		{"latest", "1.0.0", true},
+		{"1.0", "1.0", true},
+		{"1.0", "1.0.0", false},
+		{"1.0.0-rc.1", "1.0.0-rc.1", true},
+		{"1.6+", "1.6", true},
+		{"1.6+", "2.0.0-rc.1", true},
+		{"1.6+", "1.5.9", false},
+		{"1.4-1.8", "1.4", true},
+		{"1.4-1.8", "1.8", false},
+		{"1.8-1.4", "1.6", true},
+		{"^1.2", "1.2.0", true},
+		{"^1.2", "1.9.9", true},
+		{"^1.2", "2.0.0", false},
+		{"^1.2", "2.0.0-rc.1", false},
+		{"^1.2", "1.3.0-rc.1", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.3", true},
+		{"^0.0.3", "0.0.4", false},
+		{"^1.2.0-rc.1", "1.2.0-rc.2", true},
+		{"^1.2.0-rc.1", "1.2.0", true},
+		{"^1.2.0-rc.1", "1.3.0-rc.1", false},
+		{"~1.4.0", "1.4.9", true},
+		{"~1.4.0", "1.5.0", false},
+		{"~1", "1.9.0", true},
+		{"~1", "2.0.0", false},
+		{">=1.0 <2.0", "1.0.0", true},
+		{">=1.0 <2.0", "1.9.9", true},
+		{">=1.0 <2.0", "2.0", false},
+		{">=1.0 <2.0", "0.9", false},
+		{">1.0", "1.0.0+build", false},
+		{"<=1.0", "1.0.0+build", true},
+		{"=1.0.0-1", "1.0.0-1", true},
+		{"1.0.0-1", "1.0.0-1", true},
+		{"1.0.0-1", "1.0.0", false},
+		{"1.0.0-0.3", "1.0.0-0.3", true},
+		{"1.0.0-1.0.1", "1.0.0", true},
+		{"1.0.0-1.0.1", "1.0.1", false},
+		{"1.0-2.0", "1.5.0", true},
+		{"1.0 || ^2.0", "1.0.0", true},
+		{"1.0 || ^2.0", "2.5.0", true},
+		{"1.0 || ^2.0", "1.5.0", false},
+		{"^1.0", "invalid", false},
+	}
+	for _, c := range cases {
+		r, err := semver.ParseRule(c.rule)
+		assert.NoError(t, err, c.rule)
+		assert.Equal(t, c.matched, r.Match(c.version), "%s match %s", c.rule, c.version)
+	}
+}
+
+func TestRule_Filter(t *testing.T) {
+	versions := []string{"1.0.0", "1.10.0", "1.2.0", "2.0.0-rc.1", "invalid", "1.9"}
+
+	r, _ := semver.ParseRule("latest")
+	assert.Equal(t, []string{"2.0.0-rc.1"}, r.Filter(versions))
+
+	r, _ = semver.ParseRule("^1.0")
+	assert.Equal(t, []string{"1.10.0", "1.9", "1.2.0", "1.0.0"}, r.Filter(versions))
+
+	r, _ = semver.ParseRule("1.2.0")
+	assert.Equal(t, []string{"1.2.0"}, r.Filter(versions))
+
+	r, _ = semver.ParseRule(">=3.0")
+	assert.Empty(t, r.Filter(versions))
+}
@@ -20,10 +20,60 @@ package util
 import (
 	"regexp"
 	"strings"
+	"sync"
+)
+
+// RegexPrefix marks the pattern as a regular expression, e.g. "regex:^svc-[0-9]+$"
+const RegexPrefix = "regex:"
+
+// the compiled patterns cache, key is the pattern string
+var (
+	wildcards sync.Map
+	regexes   sync.Map
 )
 
 func WildcardMatch(pattern, dist string) bool {
-	regx := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), "\\*", ".*") + "$"
-	matched, _ := regexp.MatchString(regx, dist)
-	return matched
+	if !strings.Contains(pattern, "*") {
+		return pattern == dist
+	}
+	return compileWildcard(pattern).MatchString(dist)
+}
+
+// PatternMatch returns true if the value matches the pattern, the pattern can be
+// a wildcard pattern like "svc-*", or a regular expression with the RegexPrefix,
+// an invalid regular expression matches nothing
+func PatternMatch(pattern, value string) bool {
+	if !strings.HasPrefix(pattern, RegexPrefix) {
+		return WildcardMatch(pattern, value)
+	}
+	re, err := CompilePattern(pattern)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(value)
+}
+
+// CompilePattern compiles the wildcard or regex pattern, it is used to check the pattern
+func CompilePattern(pattern string) (*regexp.Regexp, error) {
+	if !strings.HasPrefix(pattern, RegexPrefix) {
+		return compileWildcard(pattern), nil
+	}
+	if re, ok := regexes.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(strings.TrimPrefix(pattern, RegexPrefix))
+	if err != nil {
+		return nil, err
+	}
+	regexes.Store(pattern, re)
+	return re, nil
+}
+
+func compileWildcard(pattern string) *regexp.Regexp {
+	if re, ok := wildcards.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+	re := regexp.MustCompile("^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), "\\*", ".*") + "$")
+	wildcards.Store(pattern, re)
+	return re
 }
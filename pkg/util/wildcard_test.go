@@ -56,3 +56,36 @@ func TestWildcardMatch(t *testing.T) {
 		assert.True(t, util.WildcardMatch("^(Test)?[a-z]*\\w+$", "^(Test)?[a-z]A\\w+$"))
 	})
 }
+
+func TestPatternMatch(t *testing.T) {
+	cases := []struct {
+		name    string
+		pattern string
+		value   string
+		match   bool
+	}{
+		{"exact", "svc-a", "svc-a", true},
+		{"exact not match", "svc-a", "svc-b", false},
+		{"wildcard", "svc-*", "svc-a", true},
+		{"wildcard all", "*", "", true},
+		{"wildcard not match", "svc-*", "order", false},
+		{"regex", "regex:^svc-[0-9]+$", "svc-1", true},
+		{"regex not match", "regex:^svc-[0-9]+$", "svc-a", false},
+		{"regex is not a wildcard", "regex:svc-*", "svc", true},
+		{"invalid regex matches nothing", "regex:svc-(", "svc-(", false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.match, util.PatternMatch(c.pattern, c.value))
+		})
+	}
+}
+
+func TestCompilePattern(t *testing.T) {
+	_, err := util.CompilePattern("svc-*")
+	assert.NoError(t, err)
+	_, err = util.CompilePattern("regex:^svc-[0-9]+$")
+	assert.NoError(t, err)
+	_, err = util.CompilePattern("regex:svc-(")
+	assert.Error(t, err)
+}
@@ -26,10 +26,18 @@ import (
 )
 
 type Rule struct {
-	Min    int
-	Max    int
-	Regexp Method
-	Hide   bool // if true, do not print the value when return invalid result
+	Min     int
+	Max     int
+	MaxFunc func() int // if set, returns the Max when matching, e.g. the reloadable limit
+	Regexp  Method
+	Hide    bool // if true, do not print the value when return invalid result
+}
+
+func (v *Rule) max() int {
+	if v.MaxFunc != nil {
+		return v.MaxFunc()
+	}
+	return v.Max
 }
 
 func (v *Rule) String() string {
@@ -38,8 +46,8 @@ func (v *Rule) String() string {
 	if v.Min != 0 {
 		s = append(s, fmt.Sprintf("Min: %d", v.Min))
 	}
-	if v.Max != 0 {
-		s = append(s, fmt.Sprintf("Max: %d", v.Max))
+	if max := v.max(); max != 0 {
+		s = append(s, fmt.Sprintf("Max: %d", max))
 	}
 	if v.Regexp != nil {
 		s = append(s, fmt.Sprintf("Regexp: %s", v.Regexp))
@@ -70,18 +78,18 @@ func (v *Rule) Match(s interface{}) (ok bool, invalidValue interface{}) {
 			invalid = false
 		}
 	}
-	if v.Max > 0 && !invalid {
+	if max := v.max(); max > 0 && !invalid {
 		switch k {
 		case reflect.String:
-			invalid = utf8.RuneCountInString(sv.String()) > v.Max
+			invalid = utf8.RuneCountInString(sv.String()) > max
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			invalid = sv.Int() > int64(v.Max)
+			invalid = sv.Int() > int64(max)
 		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-			invalid = sv.Uint() > uint64(v.Max)
+			invalid = sv.Uint() > uint64(max)
 		case reflect.Float32, reflect.Float64:
-			invalid = sv.Float() > float64(v.Max)
+			invalid = sv.Float() > float64(max)
 		case reflect.Slice, reflect.Map, reflect.Array:
-			invalid = sv.Len() > v.Max
+			invalid = sv.Len() > max
 		default:
 			invalid = false
 		}
@@ -22,6 +22,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/apache/servicecomb-service-center/pkg/semver"
 	"github.com/apache/servicecomb-service-center/pkg/util"
 )
 
@@ -39,9 +40,10 @@ func (vr *VersionRegexp) MatchString(s string) bool {
 
 func (vr *VersionRegexp) String() string {
 	if vr.Fuzzy {
-		return "the form x[.y[.z]] or x[.y[.z]]+ or x[.y[.z]]-x[.y[.z]] or 'latest' where x y and z are 0-32767 range"
+		return "the SemVer 2.0 form x[.y[.z]][-prerelease][+build] or x[.y[.z]]+ or x[.y[.z]]-x[.y[.z]] or 'latest' " +
+			"or the npm style rules like ^x[.y[.z]], ~x[.y[.z]] and '>=x[.y[.z]] <x[.y[.z]]' where x y and z are 0-32767 range"
 	}
-	return "the form x[.y[.z]] where x y and z are 0-32767 range"
+	return "the SemVer 2.0 form x[.y[.z]][-prerelease][+build] where x y and z are 0-32767 range"
 }
 
 func (vr *VersionRegexp) validateVersionRule(versionRule string) (err error) {
@@ -50,39 +52,23 @@ func (vr *VersionRegexp) validateVersionRule(versionRule string) (err error) {
 	}
 
 	if !vr.Fuzzy {
-		_, err = VersionToInt64(versionRule)
-		return
-	}
-
-	rangeIdx := strings.Index(versionRule, "-")
-	switch {
-	case versionRule == "latest":
-		return
-	case versionRule[len(versionRule)-1:] == "+":
-		// 取最低版本及高版本集合
-		start := versionRule[:len(versionRule)-1]
-		_, err = VersionToInt64(start)
-	case rangeIdx > 0:
-		// 取版本范围集合
-		start := versionRule[:rangeIdx]
-		end := versionRule[rangeIdx+1:]
-		if _, err = VersionToInt64(start); err == nil {
-			_, err = VersionToInt64(end)
+		if semver.IsRange(versionRule) {
+			return semver.ErrInvalidVersion
 		}
-	default:
-		// 精确匹配
-		_, err = VersionToInt64(versionRule)
+		_, err = semver.Parse(versionRule)
+		return
 	}
+	_, err = semver.ParseRule(versionRule)
 	return
 }
 
 func NewVersionRegexp(fuzzy bool) (vr *VersionRegexp) {
 	vr = &VersionRegexp{Fuzzy: fuzzy}
 	if fuzzy {
-		vr.Regex, _ = regexp.Compile(`^\d+(\.\d+){0,3}\+?$|^\d+(\.\d+){0,3}-\d+(\.\d+){0,3}$|^latest$`)
+		vr.Regex, _ = regexp.Compile(`^[0-9A-Za-z.+\-^~<>=| ]+$`)
 		return
 	}
-	vr.Regex, _ = regexp.Compile(`^\d+(\.\d+){0,3}$`)
+	vr.Regex, _ = regexp.Compile(`^[0-9A-Za-z.+\-]+$`)
 	return
 }
 
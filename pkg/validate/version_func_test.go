@@ -88,6 +88,24 @@ func TestNewVersionRegexp(t *testing.T) {
 		assert.Equal(t, false, vr.MatchString("1.4.0.0.0"))
 	})
 
+	t.Run("semver", func(t *testing.T) {
+		vr := validate.NewVersionRegexp(false)
+		assert.Equal(t, true, vr.MatchString("1.2.0-rc.1"))
+		assert.Equal(t, true, vr.MatchString("1.2.0-rc.1+build.5"))
+		assert.Equal(t, true, vr.MatchString("1.2.0-1"))
+		assert.Equal(t, false, vr.MatchString("1.2.0-1.3.0"))
+		assert.Equal(t, false, vr.MatchString("^1.2"))
+		vr = validate.NewVersionRegexp(true)
+		assert.Equal(t, true, vr.MatchString("1.2.0-rc.1"))
+		assert.Equal(t, true, vr.MatchString("^1.2"))
+		assert.Equal(t, true, vr.MatchString("~1.4.0"))
+		assert.Equal(t, true, vr.MatchString(">=1.0 <2.0"))
+		assert.Equal(t, true, vr.MatchString("1.0 || ^2.0.0-rc.1"))
+		assert.Equal(t, false, vr.MatchString("^"))
+		assert.Equal(t, false, vr.MatchString(">=1.0 <"))
+		assert.Equal(t, false, vr.MatchString("^60000"))
+	})
+
 	log.Info("exception")
 
 	t.Run("MatchString & String", func(t *testing.T) {
@@ -29,4 +29,6 @@ import (
 	_ "github.com/apache/servicecomb-service-center/scctl/pkg/plugin/get/cluster"
 
 	_ "github.com/apache/servicecomb-service-center/scctl/pkg/plugin/health"
+
+	_ "github.com/apache/servicecomb-service-center/scctl/pkg/plugin/archive"
 )
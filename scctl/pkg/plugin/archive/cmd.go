@@ -0,0 +1,136 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/apache/servicecomb-service-center/client"
+	"github.com/apache/servicecomb-service-center/pkg/dump"
+	"github.com/apache/servicecomb-service-center/scctl/pkg/cmd"
+	"github.com/spf13/cobra"
+)
+
+var (
+	File string
+)
+
+func init() {
+	NewExportCommand(cmd.RootCmd())
+	NewImportCommand(cmd.RootCmd())
+}
+
+func NewExportCommand(parent *cobra.Command) *cobra.Command {
+	c := &cobra.Command{
+		Use:     "export [options]",
+		Short:   "Export the registry data of service center to an archive",
+		Run:     ExportCommandFunc,
+		Example: parent.CommandPath() + ` export --addr "http://127.0.0.1:30100" -f registry.json;`,
+	}
+
+	c.Flags().StringVarP(&File, "file", "f", "", "the archive file to write, print to stdout if empty")
+
+	parent.AddCommand(c)
+	return c
+}
+
+func NewImportCommand(parent *cobra.Command) *cobra.Command {
+	c := &cobra.Command{
+		Use:     "import [options]",
+		Short:   "Import an archive into service center, the existing resources are skipped",
+		Run:     ImportCommandFunc,
+		Example: parent.CommandPath() + ` import --addr "http://127.0.0.1:30100" -f registry.json;`,
+	}
+
+	c.Flags().StringVarP(&File, "file", "f", "", "the archive file to import")
+
+	parent.AddCommand(c)
+	return c
+}
+
+func ExportCommandFunc(_ *cobra.Command, args []string) {
+	scClient, err := client.NewSCClient(cmd.ScClientConfig)
+	if err != nil {
+		cmd.StopAndExit(cmd.ExitError, err)
+	}
+	archive, scErr := scClient.Export(context.Background())
+	if scErr != nil {
+		cmd.StopAndExit(cmd.ExitError, scErr)
+	}
+	b, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		cmd.StopAndExit(cmd.ExitError, err)
+	}
+	if len(File) == 0 {
+		fmt.Fprintln(os.Stdout, string(b))
+		return
+	}
+	if err := ioutil.WriteFile(File, b, 0600); err != nil {
+		cmd.StopAndExit(cmd.ExitError, err)
+	}
+}
+
+func ImportCommandFunc(_ *cobra.Command, args []string) {
+	if len(File) == 0 {
+		cmd.StopAndExit(cmd.ExitError, errors.New("the archive file is required"))
+	}
+	b, err := ioutil.ReadFile(File)
+	if err != nil {
+		cmd.StopAndExit(cmd.ExitError, err)
+	}
+	archive := &dump.Archive{}
+	if err := json.Unmarshal(b, archive); err != nil {
+		cmd.StopAndExit(cmd.ExitError, err)
+	}
+	scClient, err := client.NewSCClient(cmd.ScClientConfig)
+	if err != nil {
+		cmd.StopAndExit(cmd.ExitError, err)
+	}
+	result, scErr := scClient.Import(context.Background(), archive)
+	if scErr != nil {
+		cmd.StopAndExit(cmd.ExitError, scErr)
+	}
+	printResult(result)
+	if len(result.Errors) > 0 {
+		cmd.StopAndExit(cmd.ExitError)
+	}
+}
+
+func printResult(result *dump.ImportResult) {
+	resources := make([]string, 0, len(result.Imported)+len(result.Skipped))
+	for resource := range result.Imported {
+		resources = append(resources, resource)
+	}
+	for resource := range result.Skipped {
+		if _, ok := result.Imported[resource]; !ok {
+			resources = append(resources, resource)
+		}
+	}
+	sort.Strings(resources)
+	for _, resource := range resources {
+		fmt.Fprintf(os.Stdout, "%s: %d imported, %d skipped\n",
+			resource, result.Imported[resource], result.Skipped[resource])
+	}
+	for _, e := range result.Errors {
+		fmt.Fprintln(os.Stderr, e)
+	}
+}
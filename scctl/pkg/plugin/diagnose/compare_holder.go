@@ -17,13 +17,17 @@ package diagnose
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
+	"github.com/apache/servicecomb-service-center/datasource/etcd/path"
 	"github.com/apache/servicecomb-service-center/datasource/etcd/value"
 	"github.com/apache/servicecomb-service-center/pkg/dump"
 	"github.com/apache/servicecomb-service-center/pkg/gopool"
 	"github.com/coreos/etcd/mvcc/mvccpb"
 	pb "github.com/go-chassis/cari/discovery"
+	"github.com/go-chassis/cari/rbac"
 )
 
 type CompareHolder interface {
@@ -47,6 +51,32 @@ func (d *DataStore) ForEach(f func(i int, v *dump.KV) bool) {
 type CompareResult struct {
 	Name    string
 	Results map[int][]string
+	// Keys are the backend keys of the Results in the same order
+	Keys map[int][]string
+}
+
+func newCompareResult(name string) *CompareResult {
+	return &CompareResult{
+		Name:    name,
+		Results: make(map[int][]string),
+		Keys:    make(map[int][]string),
+	}
+}
+
+func (r *CompareResult) add(t int, name, key string) {
+	r.Results[t] = append(r.Results[t], name)
+	r.Keys[t] = append(r.Keys[t], key)
+}
+
+// KVSlice is the dump.Getter of the key-values derived from other resources
+type KVSlice []*dump.KV
+
+func (s KVSlice) ForEach(f func(i int, v *dump.KV) bool) {
+	for i, kv := range s {
+		if !f(i, kv) {
+			return
+		}
+	}
 }
 
 type abstractCompareHolder struct {
@@ -65,18 +95,14 @@ func (h *abstractCompareHolder) toMap(getter dump.Getter) map[string]*dump.KV {
 }
 
 func (h *abstractCompareHolder) Compare() *CompareResult {
-	result := &CompareResult{
-		Results: make(map[int][]string),
-	}
+	result := newCompareResult("")
 	leftCh := make(chan map[string]*dump.KV, 2)
 	rightCh := make(chan map[string]*dump.KV, 2)
-
 	var (
-		add    []string
-		update []string
-		del    []string
+		add    []*dump.KV
+		update []*dump.KV
+		del    []*dump.KV
 	)
-
 	gopool.New(context.Background(), gopool.Configure().Workers(3)).
 		Do(func(_ context.Context) {
 			left := h.toMap(h.Cache)
@@ -95,11 +121,11 @@ func (h *abstractCompareHolder) Compare() *CompareResult {
 			for lk, lkv := range left {
 				rkv, ok := right[lk]
 				if !ok {
-					add = append(add, h.MismatchFunc(lkv))
+					add = append(add, lkv)
 					continue
 				}
 				if rkv.Rev != lkv.Rev {
-					update = append(update, h.MismatchFunc(lkv))
+					update = append(update, lkv)
 				}
 			}
 		}).
@@ -109,20 +135,16 @@ func (h *abstractCompareHolder) Compare() *CompareResult {
 			// delete
 			for rk, rkv := range right {
 				if _, ok := left[rk]; !ok {
-					del = append(del, h.MismatchFunc(rkv))
+					del = append(del, rkv)
 				}
 			}
 		}).
 		Done()
 
-	if len(add) > 0 {
-		result.Results[greater] = add
-	}
-	if len(update) > 0 {
-		result.Results[mismatch] = update
-	}
-	if len(del) > 0 {
-		result.Results[less] = del
+	for t, kvs := range map[int][]*dump.KV{greater: add, mismatch: update, less: del} {
+		for _, kv := range kvs {
+			result.add(t, h.MismatchFunc(kv), kv.Key)
+		}
 	}
 	return result
 }
@@ -170,3 +192,198 @@ func (h *InstanceCompareHolder) toName(kv *dump.KV) string {
 	}
 	return fmt.Sprintf("%v(%s/%s)", s.Endpoints, s.ServiceId, s.InstanceId)
 }
+
+type TagCompareHolder struct {
+	*abstractCompareHolder
+	Cache dump.TagSlice
+	Kvs   []*mvccpb.KeyValue
+}
+
+func (h *TagCompareHolder) Compare() *CompareResult {
+	h.abstractCompareHolder = &abstractCompareHolder{
+		Cache: &h.Cache, DataStore: &DataStore{Data: h.Kvs, DataParser: value.MapParser}, MismatchFunc: h.toName,
+	}
+	r := h.abstractCompareHolder.Compare()
+	r.Name = tag
+	return r
+}
+
+func (h *TagCompareHolder) toName(kv *dump.KV) string {
+	tags, ok := kv.Value.(map[string]string)
+	if !ok {
+		return "unknown"
+	}
+	return fmt.Sprintf("%v(%s)", tags, baseName(kv.Key))
+}
+
+type RuleCompareHolder struct {
+	*abstractCompareHolder
+	Cache dump.MicroServiceRuleSlice
+	Kvs   []*mvccpb.KeyValue
+}
+
+func (h *RuleCompareHolder) Compare() *CompareResult {
+	h.abstractCompareHolder = &abstractCompareHolder{
+		Cache: &h.Cache, DataStore: &DataStore{Data: h.Kvs, DataParser: value.RuleParser}, MismatchFunc: h.toName,
+	}
+	r := h.abstractCompareHolder.Compare()
+	r.Name = rule
+	return r
+}
+
+func (h *RuleCompareHolder) toName(kv *dump.KV) string {
+	r, ok := kv.Value.(*pb.ServiceRule)
+	if !ok {
+		return "unknown"
+	}
+	return fmt.Sprintf("%s %s:%s(%s)", r.RuleType, r.Attribute, r.Pattern, r.RuleId)
+}
+
+type DependencyCompareHolder struct {
+	*abstractCompareHolder
+	Cache dump.MicroServiceDependencyRuleSlice
+	Kvs   []*mvccpb.KeyValue
+}
+
+func (h *DependencyCompareHolder) Compare() *CompareResult {
+	h.abstractCompareHolder = &abstractCompareHolder{
+		Cache: &h.Cache, DataStore: &DataStore{Data: h.Kvs, DataParser: value.DependencyRuleParser}, MismatchFunc: h.toName,
+	}
+	r := h.abstractCompareHolder.Compare()
+	r.Name = dependency
+	return r
+}
+
+func (h *DependencyCompareHolder) toName(kv *dump.KV) string {
+	// the key format is {prefix}/{domain}/{project}/{type}/{env}/{app}/{name}/{version}
+	arr := strings.Split(strings.TrimPrefix(kv.Key, typeMap[dependency]), "/")
+	if len(arr) < 3 {
+		return "unknown"
+	}
+	return strings.Join(arr[2:], "/")
+}
+
+// SchemaCompareHolder finds the schemas in backend which are not declared by
+// any service in cache, the declared schemas may not be uploaded, so they are
+// not compared
+type SchemaCompareHolder struct {
+	*abstractCompareHolder
+	Cache dump.MicroserviceSlice
+	Kvs   []*mvccpb.KeyValue
+}
+
+func (h *SchemaCompareHolder) Compare() *CompareResult {
+	h.abstractCompareHolder = &abstractCompareHolder{
+		Cache: h.declared(), DataStore: &DataStore{Data: h.Kvs, DataParser: value.BytesParser}, MismatchFunc: h.toName,
+	}
+	r := h.abstractCompareHolder.Compare()
+	r.Name = schema
+	delete(r.Results, greater)
+	delete(r.Keys, greater)
+	delete(r.Results, mismatch)
+	delete(r.Keys, mismatch)
+	return r
+}
+
+func (h *SchemaCompareHolder) declared() KVSlice {
+	var kvs KVSlice
+	h.Cache.ForEach(func(_ int, kv *dump.KV) bool {
+		s, ok := kv.Value.(*pb.MicroService)
+		if !ok {
+			return true
+		}
+		domainProject := splitDomainProject(kv.Key, typeMap[service])
+		for _, schemaID := range s.Schemas {
+			kvs = append(kvs, &dump.KV{Key: path.GenerateServiceSchemaKey(domainProject, s.ServiceId, schemaID)})
+		}
+		return true
+	})
+	return kvs
+}
+
+func (h *SchemaCompareHolder) toName(kv *dump.KV) string {
+	// the key format is {prefix}/{domain}/{project}/{serviceId}/{schemaId}
+	arr := strings.Split(strings.TrimPrefix(kv.Key, typeMap[schema]), "/")
+	if len(arr) != 4 {
+		return "unknown"
+	}
+	return fmt.Sprintf("%s(%s)", arr[3], arr[2])
+}
+
+// AccountCompareHolder checks the accounts in backend, the account which
+// binds a not exist role is mismatch, and if CheckIndex is true, the
+// role-account indexes are compared with the bindings of the accounts
+type AccountCompareHolder struct {
+	Kvs        []*mvccpb.KeyValue
+	RoleKvs    []*mvccpb.KeyValue
+	IndexKvs   []*mvccpb.KeyValue
+	CheckIndex bool
+}
+
+func (h *AccountCompareHolder) Compare() *CompareResult {
+	result := newCompareResult(account)
+
+	roles := make(map[string]struct{}, len(h.RoleKvs))
+	for _, kv := range h.RoleKvs {
+		roles[baseName(string(kv.Key))] = struct{}{}
+	}
+	bindings := make(map[string]string)
+	for _, kv := range h.Kvs {
+		a := &rbac.Account{}
+		if err := json.Unmarshal(kv.Value, a); err != nil {
+			result.add(mismatch, "unknown", string(kv.Key))
+			continue
+		}
+		for _, r := range a.Roles {
+			if _, ok := roles[r]; !ok {
+				result.add(mismatch, bindingName(a.Name, r), string(kv.Key))
+			}
+			bindings[path.GenRoleAccountIdxKey(r, a.Name)] = bindingName(a.Name, r)
+		}
+	}
+	if !h.CheckIndex {
+		return result
+	}
+
+	indexes := make(map[string]struct{}, len(h.IndexKvs))
+	for _, kv := range h.IndexKvs {
+		key := string(kv.Key)
+		indexes[key] = struct{}{}
+		if _, ok := bindings[key]; !ok {
+			result.add(less, indexName(key), key)
+		}
+	}
+	for key, name := range bindings {
+		if _, ok := indexes[key]; !ok {
+			result.add(greater, name, key)
+		}
+	}
+	return result
+}
+
+func bindingName(account, role string) string {
+	return fmt.Sprintf("%s(%s)", account, role)
+}
+
+func indexName(key string) string {
+	// the key format is {prefix}/{role}/{account}
+	arr := strings.Split(strings.TrimPrefix(key, typeMap[roleIndex]), "/")
+	if len(arr) != 2 {
+		return "unknown"
+	}
+	return bindingName(arr[1], arr[0])
+}
+
+// splitDomainProject returns the {domain}/{project} of the key
+// formatted as {prefix}/{domain}/{project}/...
+func splitDomainProject(key, prefix string) string {
+	arr := strings.SplitN(strings.TrimPrefix(key, prefix), "/", 3)
+	if len(arr) < 2 {
+		return ""
+	}
+	return arr[0] + "/" + arr[1]
+}
+
+func baseName(key string) string {
+	return key[strings.LastIndex(key, "/")+1:]
+}
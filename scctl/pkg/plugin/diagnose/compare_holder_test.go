@@ -21,6 +21,7 @@ import (
 	"github.com/apache/servicecomb-service-center/pkg/dump"
 	"github.com/coreos/etcd/mvcc/mvccpb"
 	"github.com/go-chassis/cari/discovery"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAbstractCompareHolder_Compare(t *testing.T) {
@@ -50,3 +51,91 @@ func TestAbstractCompareHolder_Compare(t *testing.T) {
 		t.Fatalf("TestAbstractCompareHolder_Compare failed, %v", rs)
 	}
 }
+
+func TestSchemaCompareHolder_Compare(t *testing.T) {
+	services := dump.MicroserviceSlice{
+		dump.NewMicroservice(&dump.KV{Key: "/cse-sr/ms/files/default/default/1", Rev: 1,
+			Value: &discovery.MicroService{ServiceId: "1", Schemas: []string{"a", "b"}}}),
+	}
+	kvs := []*mvccpb.KeyValue{
+		{Key: []byte("/cse-sr/ms/schemas/default/default/1/a"), ModRevision: 2}, // pass
+		{Key: []byte("/cse-sr/ms/schemas/default/default/1/c"), ModRevision: 2}, // less
+		{Key: []byte("/cse-sr/ms/schemas/default/default/2/a"), ModRevision: 2}, // less
+	}
+	h := SchemaCompareHolder{Cache: services, Kvs: kvs}
+	rs := h.Compare()
+	assert.Equal(t, schema, rs.Name)
+	assert.Equal(t, 1, len(rs.Results))
+	assert.ElementsMatch(t, []string{"c(1)", "a(2)"}, rs.Results[less])
+	assert.ElementsMatch(t, []string{"/cse-sr/ms/schemas/default/default/1/c",
+		"/cse-sr/ms/schemas/default/default/2/a"}, rs.Keys[less])
+}
+
+func TestTagCompareHolder_Compare(t *testing.T) {
+	tags := dump.TagSlice{
+		dump.NewTag(&dump.KV{Key: "/cse-sr/ms/tags/default/default/1", Rev: 1, Value: map[string]string{"a": "b"}}),
+	}
+	kvs := []*mvccpb.KeyValue{
+		{Key: []byte("/cse-sr/ms/tags/default/default/1"), ModRevision: 2, Value: []byte(`{"a":"c"}`)},
+	}
+	h := TagCompareHolder{Cache: tags, Kvs: kvs}
+	rs := h.Compare()
+	assert.Equal(t, []string{"map[a:b](1)"}, rs.Results[mismatch])
+	assert.Equal(t, []string{"/cse-sr/ms/tags/default/default/1"}, rs.Keys[mismatch])
+}
+
+func TestRuleCompareHolder_Compare(t *testing.T) {
+	kvs := []*mvccpb.KeyValue{
+		{Key: []byte("/cse-sr/ms/rules/default/default/1/r1"), ModRevision: 1,
+			Value: []byte(`{"ruleId":"r1","ruleType":"WHITE","attribute":"tag_a","pattern":"b"}`)},
+	}
+	h := RuleCompareHolder{Kvs: kvs}
+	rs := h.Compare()
+	assert.Equal(t, []string{"WHITE tag_a:b(r1)"}, rs.Results[less])
+}
+
+func TestDependencyCompareHolder_Compare(t *testing.T) {
+	key := "/cse-sr/ms/dep-rules/default/default/c/env/app/name/1.0.0"
+	deps := dump.MicroServiceDependencyRuleSlice{
+		dump.NewMicroServiceDependencyRule(&dump.KV{Key: key, Rev: 1, Value: &discovery.MicroServiceDependency{}}),
+	}
+	h := DependencyCompareHolder{Cache: deps}
+	rs := h.Compare()
+	assert.Equal(t, []string{"c/env/app/name/1.0.0"}, rs.Results[greater])
+	assert.Equal(t, []string{key}, rs.Keys[greater])
+}
+
+func TestAccountCompareHolder_Compare(t *testing.T) {
+	accounts := []*mvccpb.KeyValue{
+		{Key: []byte("/cse-sr/accounts/a"), Value: []byte(`{"name":"a","roles":["admin","not-exist"]}`)},
+		{Key: []byte("/cse-sr/accounts/b"), Value: []byte(`{"name":"b","roles":["developer"]}`)},
+	}
+	roles := []*mvccpb.KeyValue{
+		{Key: []byte("/cse-sr/roles/admin")},
+		{Key: []byte("/cse-sr/roles/developer")},
+	}
+	indexes := []*mvccpb.KeyValue{
+		{Key: []byte("/cse-sr/idx-role-account/admin/a")},
+		{Key: []byte("/cse-sr/idx-role-account/admin/b")}, // less
+	}
+
+	t.Run("check index", func(t *testing.T) {
+		h := AccountCompareHolder{Kvs: accounts, RoleKvs: roles, IndexKvs: indexes, CheckIndex: true}
+		rs := h.Compare()
+		assert.Equal(t, account, rs.Name)
+		assert.Equal(t, []string{"a(not-exist)"}, rs.Results[mismatch])
+		assert.Equal(t, []string{"/cse-sr/accounts/a"}, rs.Keys[mismatch])
+		assert.ElementsMatch(t, []string{"a(not-exist)", "b(developer)"}, rs.Results[greater])
+		assert.ElementsMatch(t, []string{"/cse-sr/idx-role-account/not-exist/a",
+			"/cse-sr/idx-role-account/developer/b"}, rs.Keys[greater])
+		assert.Equal(t, []string{"b(admin)"}, rs.Results[less])
+		assert.Equal(t, []string{"/cse-sr/idx-role-account/admin/b"}, rs.Keys[less])
+	})
+
+	t.Run("no index", func(t *testing.T) {
+		h := AccountCompareHolder{Kvs: accounts, RoleKvs: roles}
+		rs := h.Compare()
+		assert.Equal(t, 1, len(rs.Results))
+		assert.Equal(t, []string{"a(not-exist)"}, rs.Results[mismatch])
+	})
+}
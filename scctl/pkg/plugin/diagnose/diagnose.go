@@ -18,9 +18,11 @@ package diagnose
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/apache/servicecomb-service-center/client"
+	mongo "github.com/apache/servicecomb-service-center/datasource/mongo/client"
 	"github.com/apache/servicecomb-service-center/pkg/dump"
 	"github.com/apache/servicecomb-service-center/scctl/etcd"
 	"github.com/apache/servicecomb-service-center/scctl/pkg/cmd"
@@ -30,8 +32,21 @@ import (
 )
 
 const (
-	service  = "service"
-	instance = "instance"
+	service    = "service"
+	instance   = "instance"
+	schema     = "schema"
+	tag        = "tag"
+	rule       = "rule"
+	dependency = "dependency"
+	account    = "account"
+	role       = "role"
+	roleIndex  = "roleIndex"
+)
+
+const (
+	etcdBackend  = "etcd"
+	mongoBackend = "mongo"
+	outputJSON   = "json"
 )
 
 const (
@@ -41,26 +56,31 @@ const (
 )
 
 var typeMap = map[string]string{
-	service:  "/cse-sr/ms/files/",
-	instance: "/cse-sr/inst/files/",
+	service:    "/cse-sr/ms/files/",
+	instance:   "/cse-sr/inst/files/",
+	schema:     "/cse-sr/ms/schemas/",
+	tag:        "/cse-sr/ms/tags/",
+	rule:       "/cse-sr/ms/rules/",
+	dependency: "/cse-sr/ms/dep-rules/",
+	account:    "/cse-sr/accounts/",
+	role:       "/cse-sr/roles/",
+	roleIndex:  "/cse-sr/idx-role-account/",
 }
 
+// etcdResponse is the backend data grouped by the resource type, the mongo
+// documents are also converted to the etcd key-values, only the types in it
+// are compared
 type etcdResponse map[string][]*mvccpb.KeyValue
 
 func DiagnoseCommandFunc(_ *cobra.Command, args []string) {
-	// initialize sc/etcd clients
+	// initialize sc client
 	scClient, err := client.NewSCClient(cmd.ScClientConfig)
 	if err != nil {
 		cmd.StopAndExit(cmd.ExitError, err)
 	}
-	etcdClient, err := etcd.NewEtcdClient(EtcdClientConfig)
-	if err != nil {
-		cmd.StopAndExit(cmd.ExitError, err)
-	}
-	defer etcdClient.Close()
 
-	// query etcd
-	etcdResp, err := getEtcdResponse(context.Background(), etcdClient)
+	// query backend
+	storeResp, err := getBackendResponse(context.Background())
 	if err != nil {
 		cmd.StopAndExit(cmd.ExitError, err)
 	}
@@ -70,15 +90,52 @@ func DiagnoseCommandFunc(_ *cobra.Command, args []string) {
 	if scErr != nil {
 		cmd.StopAndExit(cmd.ExitError, scErr)
 	}
+	if Backend == mongoBackend {
+		normalizeMongoCache(cache)
+	}
 
 	// diagnose go...
-	err, details := diagnose(cache, etcdResp)
+	rss := compare(cache, storeResp)
+	if Output == outputJSON {
+		r := newReport(rss)
+		b, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			cmd.StopAndExit(cmd.ExitError, err)
+		}
+		fmt.Println(string(b)) // stdout
+		if len(r.Diffs) > 0 {
+			cmd.StopAndExit(cmd.ExitError)
+		}
+		return
+	}
+	err, details := writeReport(rss)
 	if err != nil {
 		fmt.Println(details)                // stdout
 		cmd.StopAndExit(cmd.ExitError, err) // stderr
 	}
 }
 
+func getBackendResponse(ctx context.Context) (etcdResponse, error) {
+	switch Backend {
+	case etcdBackend:
+		etcdClient, err := etcd.NewEtcdClient(EtcdClientConfig)
+		if err != nil {
+			return nil, err
+		}
+		defer etcdClient.Close()
+		return getEtcdResponse(ctx, etcdClient)
+	case mongoBackend:
+		mongoClient, err := newMongoClient(ctx, MongoURI)
+		if err != nil {
+			return nil, err
+		}
+		defer mongoClient.Disconnect(ctx)
+		return getMongoResponse(ctx, mongoClient.Database(mongo.MongoDB))
+	default:
+		return nil, fmt.Errorf("unsupported backend '%s'", Backend)
+	}
+}
+
 func getEtcdResponse(ctx context.Context, etcdClient *clientv3.Client) (etcdResponse, error) {
 	etcdResp := make(etcdResponse)
 	for t, prefix := range typeMap {
@@ -99,20 +156,54 @@ func setResponse(ctx context.Context, etcdClient *clientv3.Client, key, prefix s
 }
 
 func diagnose(cache *dump.Cache, etcdResp etcdResponse) (err error, details string) {
-	var (
-		service  = ServiceCompareHolder{Cache: cache.Microservices, Kvs: etcdResp[service]}
-		instance = InstanceCompareHolder{Cache: cache.Instances, Kvs: etcdResp[instance]}
-	)
+	return writeReport(compare(cache, etcdResp))
+}
 
-	sr := service.Compare()
-	ir := instance.Compare()
+func compare(cache *dump.Cache, etcdResp etcdResponse) []*CompareResult {
+	var holders []CompareHolder
+	if kvs, ok := etcdResp[service]; ok {
+		holders = append(holders, &ServiceCompareHolder{Cache: cache.Microservices, Kvs: kvs})
+	}
+	if kvs, ok := etcdResp[instance]; ok {
+		holders = append(holders, &InstanceCompareHolder{Cache: cache.Instances, Kvs: kvs})
+	}
+	if kvs, ok := etcdResp[schema]; ok {
+		holders = append(holders, &SchemaCompareHolder{Cache: cache.Microservices, Kvs: kvs})
+	}
+	if kvs, ok := etcdResp[tag]; ok {
+		holders = append(holders, &TagCompareHolder{Cache: cache.Tags, Kvs: kvs})
+	}
+	if kvs, ok := etcdResp[rule]; ok {
+		holders = append(holders, &RuleCompareHolder{Cache: cache.Rules, Kvs: kvs})
+	}
+	if kvs, ok := etcdResp[dependency]; ok {
+		holders = append(holders, &DependencyCompareHolder{Cache: cache.DependencyRules, Kvs: kvs})
+	}
+	if kvs, ok := etcdResp[account]; ok {
+		indexKvs, checkIndex := etcdResp[roleIndex]
+		holders = append(holders, &AccountCompareHolder{
+			Kvs: kvs, RoleKvs: etcdResp[role], IndexKvs: indexKvs, CheckIndex: checkIndex,
+		})
+	}
 
+	rss := make([]*CompareResult, 0, len(holders))
+	for _, h := range holders {
+		rss = append(rss, h.Compare())
+	}
+	return rss
+}
+
+func writeReport(rss []*CompareResult) (err error, details string) {
 	var (
 		b    bytes.Buffer
 		full bytes.Buffer
 	)
-	writeResult(&b, &full, sr, ir)
+	writeResult(&b, &full, rss...)
 	if b.Len() > 0 {
+		if Repair {
+			full.WriteRune('\n')
+			writeRepairPlan(&full, rss)
+		}
 		return fmt.Errorf("error: %s", b.String()), full.String()
 	}
 	return nil, ""
@@ -136,21 +227,21 @@ func writeResult(b *bytes.Buffer, full *bytes.Buffer, rss ...*CompareResult) {
 	i := 0
 	if s := len(g); s > 0 {
 		i++
-		header := fmt.Sprintf("%d. found in cache but not in etcd ", i)
+		header := fmt.Sprintf("%d. found in cache but not in %s ", i, Backend)
 		b.WriteString(header)
 		full.WriteString(header)
 		writeBody(full, g)
 	}
 	if s := len(m); s > 0 {
 		i++
-		header := fmt.Sprintf("%d. found different between cache and etcd ", i)
+		header := fmt.Sprintf("%d. found different between cache and %s ", i, Backend)
 		b.WriteString(header)
 		full.WriteString(header)
 		writeBody(full, m)
 	}
 	if s := len(l); s > 0 {
 		i++
-		header := fmt.Sprintf("%d. found in etcd but not in cache ", i)
+		header := fmt.Sprintf("%d. found in %s but not in cache ", i, Backend)
 		b.WriteString(header)
 		full.WriteString(header)
 		writeBody(full, l)
@@ -22,6 +22,7 @@ import (
 	"github.com/apache/servicecomb-service-center/pkg/dump"
 	"github.com/coreos/etcd/mvcc/mvccpb"
 	"github.com/go-chassis/cari/discovery"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestNewDiagnoseCommand(t *testing.T) {
@@ -65,3 +66,58 @@ func TestNewDiagnoseCommand(t *testing.T) {
 	fmt.Println(details)
 	//}
 }
+
+func TestCompare(t *testing.T) {
+	cache := &dump.Cache{
+		Microservices: dump.MicroserviceSlice{
+			dump.NewMicroservice(&dump.KV{Key: "/cse-sr/ms/files/default/default/1", Rev: 1,
+				Value: &discovery.MicroService{ServiceId: "1", Schemas: []string{"a"}}}),
+		},
+	}
+	resp := etcdResponse{
+		service: {{Key: []byte("/cse-sr/ms/files/default/default/1"), ModRevision: 1, Value: []byte(`{"serviceId":"1"}`)}},
+		schema: {
+			{Key: []byte("/cse-sr/ms/schemas/default/default/1/a"), ModRevision: 1},
+			{Key: []byte("/cse-sr/ms/schemas/default/default/1/b"), ModRevision: 1}, // less
+		},
+		account: {{Key: []byte("/cse-sr/accounts/a"), Value: []byte(`{"name":"a","roles":["admin"]}`)}},
+		role:    {{Key: []byte("/cse-sr/roles/admin")}},
+		// greater
+		roleIndex: nil,
+	}
+
+	rss := compare(cache, resp)
+	assert.Equal(t, 3, len(rss))
+
+	defer func(old bool) { Repair = old }(Repair)
+	Repair = true
+
+	t.Run("json report should contain the diffs and repair plan", func(t *testing.T) {
+		r := newReport(rss)
+		assert.Equal(t, etcdBackend, r.Backend)
+		assert.Equal(t, []*Diff{
+			{Type: account, Result: "greater", Name: "a(admin)", Key: "/cse-sr/idx-role-account/admin/a"},
+			{Type: schema, Result: "less", Name: "b(1)", Key: "/cse-sr/ms/schemas/default/default/1/b"},
+		}, r.Diffs)
+		assert.Equal(t, []*RepairItem{
+			{Type: account, Action: RepairPut, Key: "/cse-sr/idx-role-account/admin/a"},
+			{Type: schema, Action: RepairDelete, Key: "/cse-sr/ms/schemas/default/default/1/b"},
+		}, r.Repair)
+	})
+
+	t.Run("text report should contain the repair plan", func(t *testing.T) {
+		err, details := writeReport(rss)
+		assert.Error(t, err)
+		assert.Contains(t, details, "repair plan:\n")
+		assert.Contains(t, details, "  delete schema /cse-sr/ms/schemas/default/default/1/b")
+		assert.Contains(t, details, "  put account /cse-sr/idx-role-account/admin/a")
+	})
+
+	t.Run("no diff should pass", func(t *testing.T) {
+		rss := compare(cache, etcdResponse{service: resp[service]})
+		err, details := writeReport(rss)
+		assert.NoError(t, err)
+		assert.Empty(t, details)
+		assert.Empty(t, newReport(rss).Diffs)
+	})
+}
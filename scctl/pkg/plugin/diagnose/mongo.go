@@ -0,0 +1,195 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnose
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"github.com/go-chassis/cari/rbac"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/apache/servicecomb-service-center/datasource/etcd/path"
+	"github.com/apache/servicecomb-service-center/datasource/mongo/client/model"
+	"github.com/apache/servicecomb-service-center/pkg/dump"
+)
+
+func newMongoClient(ctx context.Context, uri string) (*mongo.Client, error) {
+	c, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Ping(ctx, nil); err != nil {
+		c.Disconnect(ctx)
+		return nil, err
+	}
+	return c, nil
+}
+
+// getMongoResponse reads the collections of datasource/mongo, the documents
+// are converted to the key-values in the etcd format, and the documents have
+// no revision, so only the existence is compared
+func getMongoResponse(ctx context.Context, db *mongo.Database) (etcdResponse, error) {
+	var (
+		services  []*model.Service
+		instances []*model.Instance
+		schemas   []*model.Schema
+		rules     []*model.Rule
+		deps      []*model.DependencyRule
+		accounts  []*rbac.Account
+		roles     []*rbac.Role
+	)
+	for collection, results := range map[string]interface{}{
+		model.CollectionService:  &services,
+		model.CollectionInstance: &instances,
+		model.CollectionSchema:   &schemas,
+		model.CollectionRule:     &rules,
+		model.CollectionDep:      &deps,
+		model.CollectionAccount:  &accounts,
+		model.CollectionRole:     &roles,
+	} {
+		cursor, err := db.Collection(collection).Find(ctx, bson.M{})
+		if err != nil {
+			return nil, err
+		}
+		if err := cursor.All(ctx, results); err != nil {
+			return nil, err
+		}
+	}
+	return etcdResponse{
+		service:    mongoServiceKvs(services),
+		instance:   mongoInstanceKvs(instances),
+		schema:     mongoSchemaKvs(schemas),
+		tag:        mongoTagKvs(services),
+		rule:       mongoRuleKvs(rules),
+		dependency: mongoDependencyKvs(deps),
+		account:    mongoAccountKvs(accounts),
+		role:       mongoRoleKvs(roles),
+	}, nil
+}
+
+func mongoServiceKvs(services []*model.Service) []*mvccpb.KeyValue {
+	kvs := make([]*mvccpb.KeyValue, 0, len(services))
+	for _, s := range services {
+		if s.Service == nil {
+			continue
+		}
+		key := path.GenerateServiceKey(s.Domain+"/"+s.Project, s.Service.ServiceId)
+		kvs = append(kvs, toKeyValue(key, s.Service))
+	}
+	return kvs
+}
+
+func mongoInstanceKvs(instances []*model.Instance) []*mvccpb.KeyValue {
+	kvs := make([]*mvccpb.KeyValue, 0, len(instances))
+	for _, inst := range instances {
+		if inst.Instance == nil {
+			continue
+		}
+		key := path.GenerateInstanceKey(inst.Domain+"/"+inst.Project, inst.Instance.ServiceId, inst.Instance.InstanceId)
+		kvs = append(kvs, toKeyValue(key, inst.Instance))
+	}
+	return kvs
+}
+
+func mongoSchemaKvs(schemas []*model.Schema) []*mvccpb.KeyValue {
+	kvs := make([]*mvccpb.KeyValue, 0, len(schemas))
+	for _, s := range schemas {
+		key := path.GenerateServiceSchemaKey(s.Domain+"/"+s.Project, s.ServiceID, s.SchemaID)
+		kvs = append(kvs, &mvccpb.KeyValue{Key: []byte(key), Value: []byte(s.Schema)})
+	}
+	return kvs
+}
+
+// mongoTagKvs returns the tags of the services, the service without tags has no tag key
+func mongoTagKvs(services []*model.Service) []*mvccpb.KeyValue {
+	kvs := make([]*mvccpb.KeyValue, 0, len(services))
+	for _, s := range services {
+		if s.Service == nil || len(s.Tags) == 0 {
+			continue
+		}
+		key := path.GenerateServiceTagKey(s.Domain+"/"+s.Project, s.Service.ServiceId)
+		kvs = append(kvs, toKeyValue(key, s.Tags))
+	}
+	return kvs
+}
+
+func mongoRuleKvs(rules []*model.Rule) []*mvccpb.KeyValue {
+	kvs := make([]*mvccpb.KeyValue, 0, len(rules))
+	for _, r := range rules {
+		if r.Rule == nil {
+			continue
+		}
+		key := path.GenerateServiceRuleKey(r.Domain+"/"+r.Project, r.ServiceID, r.Rule.RuleId)
+		kvs = append(kvs, toKeyValue(key, r.Rule))
+	}
+	return kvs
+}
+
+func mongoDependencyKvs(deps []*model.DependencyRule) []*mvccpb.KeyValue {
+	kvs := make([]*mvccpb.KeyValue, 0, len(deps))
+	for _, d := range deps {
+		if d.ServiceKey == nil || d.Dep == nil {
+			continue
+		}
+		key := path.GenerateServiceDependencyRuleKey(d.Type, d.Domain+"/"+d.Project, d.ServiceKey)
+		kvs = append(kvs, toKeyValue(key, d.Dep))
+	}
+	return kvs
+}
+
+func mongoAccountKvs(accounts []*rbac.Account) []*mvccpb.KeyValue {
+	kvs := make([]*mvccpb.KeyValue, 0, len(accounts))
+	for _, a := range accounts {
+		kvs = append(kvs, toKeyValue(path.GenerateAccountKey(a.Name), a))
+	}
+	return kvs
+}
+
+func mongoRoleKvs(roles []*rbac.Role) []*mvccpb.KeyValue {
+	kvs := make([]*mvccpb.KeyValue, 0, len(roles))
+	for _, r := range roles {
+		kvs = append(kvs, toKeyValue(path.GenerateRBACRoleKey(r.Name), r))
+	}
+	return kvs
+}
+
+func toKeyValue(key string, v interface{}) *mvccpb.KeyValue {
+	b, _ := json.Marshal(v)
+	return &mvccpb.KeyValue{Key: []byte(key), Value: b}
+}
+
+// normalizeMongoCache rewrites the keys of the SC cache, the cache of mongo
+// is keyed by the document id, but the backend keys are built by the
+// resource ids
+func normalizeMongoCache(cache *dump.Cache) {
+	for _, s := range cache.Microservices {
+		if s.KV == nil || s.Value == nil {
+			continue
+		}
+		s.KV.Key = path.GenerateServiceKey(splitDomainProject(s.KV.Key, typeMap[service]), s.Value.ServiceId)
+	}
+	for _, inst := range cache.Instances {
+		if inst.KV == nil || inst.Value == nil {
+			continue
+		}
+		inst.KV.Key = path.GenerateInstanceKey(splitDomainProject(inst.KV.Key, typeMap[instance]),
+			inst.Value.ServiceId, inst.Value.InstanceId)
+	}
+}
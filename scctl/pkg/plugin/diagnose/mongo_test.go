@@ -0,0 +1,92 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnose
+
+import (
+	"testing"
+
+	"github.com/go-chassis/cari/discovery"
+	"github.com/go-chassis/cari/rbac"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/servicecomb-service-center/datasource/mongo/client/model"
+	"github.com/apache/servicecomb-service-center/pkg/dump"
+)
+
+func TestMongoKvs(t *testing.T) {
+	services := mongoServiceKvs([]*model.Service{
+		{Domain: "d", Project: "p", Service: &discovery.MicroService{ServiceId: "1"}},
+		{Domain: "d", Project: "p"},
+	})
+	assert.Equal(t, 1, len(services))
+	assert.Equal(t, "/cse-sr/ms/files/d/p/1", string(services[0].Key))
+	assert.JSONEq(t, `{"serviceId":"1"}`, string(services[0].Value))
+
+	instances := mongoInstanceKvs([]*model.Instance{
+		{Domain: "d", Project: "p", Instance: &discovery.MicroServiceInstance{ServiceId: "1", InstanceId: "2"}},
+	})
+	assert.Equal(t, "/cse-sr/inst/files/d/p/1/2", string(instances[0].Key))
+
+	schemas := mongoSchemaKvs([]*model.Schema{{Domain: "d", Project: "p", ServiceID: "1", SchemaID: "a", Schema: "x"}})
+	assert.Equal(t, "/cse-sr/ms/schemas/d/p/1/a", string(schemas[0].Key))
+	assert.Equal(t, "x", string(schemas[0].Value))
+
+	tags := mongoTagKvs([]*model.Service{
+		{Domain: "d", Project: "p", Tags: map[string]string{"a": "b"}, Service: &discovery.MicroService{ServiceId: "1"}},
+		{Domain: "d", Project: "p", Service: &discovery.MicroService{ServiceId: "2"}},
+	})
+	assert.Equal(t, 1, len(tags))
+	assert.Equal(t, "/cse-sr/ms/tags/d/p/1", string(tags[0].Key))
+	assert.JSONEq(t, `{"a":"b"}`, string(tags[0].Value))
+
+	rules := mongoRuleKvs([]*model.Rule{
+		{Domain: "d", Project: "p", ServiceID: "1", Rule: &discovery.ServiceRule{RuleId: "r"}},
+		{Domain: "d", Project: "p", ServiceID: "1"},
+	})
+	assert.Equal(t, 1, len(rules))
+	assert.Equal(t, "/cse-sr/ms/rules/d/p/1/r", string(rules[0].Key))
+
+	deps := mongoDependencyKvs([]*model.DependencyRule{{
+		Type: "c", Domain: "d", Project: "p",
+		ServiceKey: &discovery.MicroServiceKey{Environment: "e", AppId: "a", ServiceName: "s", Version: "1.0.0"},
+		Dep:        &discovery.MicroServiceDependency{},
+	}})
+	assert.Equal(t, 1, len(deps))
+	assert.Equal(t, "/cse-sr/ms/dep-rules/d/p/c/e/a/s/1.0.0", string(deps[0].Key))
+
+	accounts := mongoAccountKvs([]*rbac.Account{{Name: "a", Roles: []string{"admin"}}})
+	assert.Equal(t, "/cse-sr/accounts/a", string(accounts[0].Key))
+	roles := mongoRoleKvs([]*rbac.Role{{Name: "admin"}})
+	assert.Equal(t, "/cse-sr/roles/admin", string(roles[0].Key))
+
+	rs := (&AccountCompareHolder{Kvs: accounts, RoleKvs: roles}).Compare()
+	assert.Empty(t, rs.Results)
+}
+
+func TestNormalizeMongoCache(t *testing.T) {
+	cache := &dump.Cache{
+		Microservices: dump.MicroserviceSlice{
+			dump.NewMicroservice(&dump.KV{Key: "/cse-sr/ms/files/d/p/60b0c5a3", Value: &discovery.MicroService{ServiceId: "1"}}),
+		},
+		Instances: dump.InstanceSlice{
+			dump.NewInstance(&dump.KV{Key: "/cse-sr/inst/files/d/p/1/60b0c5a4",
+				Value: &discovery.MicroServiceInstance{ServiceId: "1", InstanceId: "2"}}),
+		},
+	}
+	normalizeMongoCache(cache)
+	assert.Equal(t, "/cse-sr/ms/files/d/p/1", cache.Microservices[0].Key)
+	assert.Equal(t, "/cse-sr/inst/files/d/p/1/2", cache.Instances[0].Key)
+}
@@ -0,0 +1,139 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnose
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+const (
+	// RepairReload means the SC cache of the key is out of date, it should
+	// be reloaded from the backend
+	RepairReload = "reload"
+	// RepairPut means the key is missing in the backend
+	RepairPut = "put"
+	// RepairUpdate means the value of the key refers to something not exist
+	RepairUpdate = "update"
+	// RepairDelete means the key is an orphan in the backend
+	RepairDelete = "delete"
+)
+
+var resultNames = map[int]string{
+	greater:  "greater",
+	mismatch: "mismatch",
+	less:     "less",
+}
+
+// Report is the machine-readable diagnose report
+type Report struct {
+	Backend string        `json:"backend"`
+	Diffs   []*Diff       `json:"diffs"`
+	Repair  []*RepairItem `json:"repair,omitempty"`
+}
+
+// Diff is one inconsistent resource, the Result is 'greater' if found in
+// cache but not in backend, 'mismatch' if they are different and 'less' if
+// found in backend but not in cache
+type Diff struct {
+	Type   string `json:"type"`
+	Result string `json:"result"`
+	Name   string `json:"name"`
+	Key    string `json:"key"`
+}
+
+type RepairItem struct {
+	Type   string `json:"type"`
+	Action string `json:"action"`
+	Key    string `json:"key"`
+}
+
+func newReport(rss []*CompareResult) *Report {
+	r := &Report{Backend: Backend, Diffs: []*Diff{}}
+	for _, rs := range rss {
+		for t, names := range rs.Results {
+			for i, name := range names {
+				r.Diffs = append(r.Diffs, &Diff{Type: rs.Name, Result: resultNames[t], Name: name, Key: rs.Keys[t][i]})
+			}
+		}
+	}
+	sort.Slice(r.Diffs, func(i, j int) bool {
+		a, b := r.Diffs[i], r.Diffs[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.Result != b.Result {
+			return a.Result < b.Result
+		}
+		return a.Key < b.Key
+	})
+	if Repair {
+		r.Repair = repairPlan(rss)
+	}
+	return r
+}
+
+// repairPlan lists the keys to fix, the cache diffs are fixed by reloading
+// the SC cache, and the backend diffs are fixed by writing the backend
+func repairPlan(rss []*CompareResult) []*RepairItem {
+	var items []*RepairItem
+	for _, rs := range rss {
+		for t, keys := range rs.Keys {
+			action := repairAction(rs.Name, t)
+			for _, key := range keys {
+				items = append(items, &RepairItem{Type: rs.Name, Action: action, Key: key})
+			}
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Type != items[j].Type {
+			return items[i].Type < items[j].Type
+		}
+		return items[i].Key < items[j].Key
+	})
+	return items
+}
+
+func repairAction(t string, result int) string {
+	switch t {
+	case schema:
+		return RepairDelete
+	case account:
+		switch result {
+		case greater:
+			return RepairPut
+		case mismatch:
+			return RepairUpdate
+		default:
+			return RepairDelete
+		}
+	default:
+		return RepairReload
+	}
+}
+
+func writeRepairPlan(b *bytes.Buffer, rss []*CompareResult) {
+	items := repairPlan(rss)
+	if len(items) == 0 {
+		return
+	}
+	b.WriteString("repair plan:\n")
+	for _, item := range items {
+		b.WriteString(fmt.Sprintf("  %s %s %s\n", item.Action, item.Type, item.Key))
+	}
+	b.Truncate(b.Len() - 1)
+}
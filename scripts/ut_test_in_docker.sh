@@ -51,6 +51,8 @@ elif [ ${db_name} == "mongo" ];then
     sleep 1
   done
   echo "${green}mongodb is running......${reset}"
+elif [ ${db_name} == "local" ] || [ ${db_name} == "sql" ];then
+  echo "${green}${db_name} registry needs no external services${reset}"
 else
   echo "${db_name} non-existent"
 	exit 1
@@ -73,6 +75,15 @@ elif [ ${db_name} == "mongo" ];then
   [ $? == 0 ] && ut_for_dir datasource/mongo
   [ $? == 0 ] && ut_for_dir syncer
   [ $? == 0 ] && ut_for_dir server
+elif [ ${db_name} == "local" ];then
+  export TEST_MODE=local
+  [ $? == 0 ] && ut_for_file datasource
+  [ $? == 0 ] && ut_for_dir datasource/etcd/client/local
+elif [ ${db_name} == "sql" ];then
+  export TEST_MODE=sql
+  export CGO_ENABLED=1
+  [ $? == 0 ] && ut_for_file datasource
+  [ $? == 0 ] && ut_for_dir datasource/etcd/client/rdb
 else
   echo "${db_name} non-existent"
 	exit 1
@@ -16,10 +16,18 @@
 package alarm
 
 import (
+	"context"
 	"fmt"
+	"time"
 
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/alarm/model"
+	"github.com/apache/servicecomb-service-center/pkg/backoff"
 	"github.com/apache/servicecomb-service-center/pkg/event"
-	"github.com/apache/servicecomb-service-center/server/alarm/model"
+	"github.com/apache/servicecomb-service-center/pkg/gopool"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/metrics"
+	"github.com/apache/servicecomb-service-center/server/config"
 )
 
 const (
@@ -28,10 +36,11 @@ const (
 )
 
 const (
-	IDBackendConnectionRefuse model.ID = "BackendConnectionRefuse"
-	IDInternalError           model.ID = "InternalError"
-	IDIncrementPullError      model.ID = "IncrementPullError"
-	IDWebsocketOfScSyncerLost model.ID = "WebsocketOfScSyncerLost"
+	IDBackendConnectionRefuse    model.ID = "BackendConnectionRefuse"
+	IDInternalError              model.ID = "InternalError"
+	IDIncrementPullError         model.ID = "IncrementPullError"
+	IDWebsocketOfScSyncerLost    model.ID = "WebsocketOfScSyncerLost"
+	IDGovernanceDistributorDrift model.ID = "GovernanceDistributorDrift"
 )
 
 const (
@@ -69,10 +78,53 @@ func AdditionalContext(format string, args ...interface{}) model.Field {
 	return FieldString(FieldAdditionalContext, fmt.Sprintf(format, args...))
 }
 
-func ListAll() []*model.AlarmEvent {
+// Init enables the notifiers and the persistence of alarms,
+// it should be called after the datasource is initialized
+func Init() error {
+	opts := config.App.Alarm
+	if opts == nil {
+		opts = &config.Alarm{}
+	}
+	notifiers, err := newNotifiers(opts.Notifiers)
+	if err != nil {
+		return err
+	}
+	// the metrics instance name is unique per service center, the host name is the fallback
+	if node := metrics.InstanceName(); len(node) > 0 {
+		Center().SetNode(node)
+	}
+	Center().SetNotifiers(notifiers)
+	Center().SetHistoryTTL(historyTTL(opts.HistoryTTL))
+	gopool.Go(func(ctx context.Context) {
+		load(ctx, datasource.GetAlarmManager())
+	})
+	return nil
+}
+
+// load retries until the persisted alarms are loaded
+func load(ctx context.Context, store datasource.AlarmManager) {
+	for i := 0; ; i++ {
+		err := Center().Load(ctx, store)
+		if err == nil {
+			return
+		}
+		log.Errorf(err, "load the persisted alarms failed")
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff.GetBackoff().Delay(i)):
+		}
+	}
+}
+
+func ListAll() []*model.Alarm {
 	return Center().ListAll()
 }
 
+func ListHistory(ctx context.Context, request *model.HistoryRequest) ([]*model.History, error) {
+	return Center().ListHistory(ctx, request)
+}
+
 func Raise(id model.ID, fields ...model.Field) error {
 	return Center().Raise(id, fields...)
 }
@@ -81,6 +133,14 @@ func Clear(id model.ID) error {
 	return Center().Clear(id)
 }
 
-func ClearAll() {
-	Center().ClearAll()
+func Acknowledge(ctx context.Context, id model.ID, user string) (*model.Alarm, error) {
+	return Center().Acknowledge(ctx, id, user)
+}
+
+func ClearAll(ctx context.Context) error {
+	return Center().ClearAll(ctx)
+}
+
+func OnLoaded(f func(persisted []*model.Alarm)) {
+	Center().OnLoaded(f)
 }
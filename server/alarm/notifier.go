@@ -0,0 +1,115 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alarm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/apache/servicecomb-service-center/pkg/alarm/model"
+	"github.com/apache/servicecomb-service-center/pkg/backoff"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/queue"
+	"github.com/apache/servicecomb-service-center/server/config"
+)
+
+const notifyRetryTimes = 3
+
+var ErrNotLoaded = errors.New("alarm histories are not loaded")
+
+// Notifier sends the alarm to external systems when it is ACTIVATED or CLEARED
+type Notifier interface {
+	Notify(ctx context.Context, a *model.Alarm) error
+}
+
+type NewNotifierFunc func(opts config.NotifierOptions) (Notifier, error)
+
+var notifierPlugins = make(map[string]NewNotifierFunc)
+
+// InstallNotifier registers the notifier type
+func InstallNotifier(t string, f NewNotifierFunc) {
+	notifierPlugins[t] = f
+}
+
+// notifier wraps the Notifier with the alarm ID filters, the alarms are
+// sent one by one in a queue, so the receiver gets the transitions in order
+type notifier struct {
+	Notifier
+	name   string
+	alarms map[model.ID]struct{}
+	queue  *queue.TaskQueue
+}
+
+func (n *notifier) Accept(id model.ID) bool {
+	if len(n.alarms) == 0 {
+		return true
+	}
+	_, ok := n.alarms[id]
+	return ok
+}
+
+// Send queues the alarm, it is sent after the previous ones
+func (n *notifier) Send(a *model.Alarm) {
+	n.queue.Add(queue.Task{Payload: a})
+}
+
+func (n *notifier) Handle(ctx context.Context, obj interface{}) {
+	a := obj.(*model.Alarm)
+	err := backoff.DelayIn(notifyRetryTimes, func() error {
+		return n.Notify(ctx, a)
+	})
+	if err != nil {
+		log.Errorf(err, "notifier[%s] send alarm[%s] %s failed", n.name, a.ID, a.Status)
+	}
+}
+
+func newNotifiers(opts []config.NotifierOptions) ([]*notifier, error) {
+	notifiers := make([]*notifier, 0, len(opts))
+	for _, o := range opts {
+		f, ok := notifierPlugins[o.Type]
+		if !ok {
+			return nil, fmt.Errorf("unsupported alarm notifier type %s", o.Type)
+		}
+		n, err := f(o)
+		if err != nil {
+			return nil, err
+		}
+		filters := make(map[model.ID]struct{}, len(o.Alarms))
+		for _, id := range o.Alarms {
+			filters[model.ID(id)] = struct{}{}
+		}
+		nf := &notifier{Notifier: n, name: o.Name, alarms: filters, queue: queue.NewTaskQueue(0)}
+		nf.queue.AddWorker(nf)
+		nf.queue.Run()
+		notifiers = append(notifiers, nf)
+		log.Infof("alarm notifier[%s] type %s enabled", o.Name, o.Type)
+	}
+	return notifiers, nil
+}
+
+func historyTTL(s string) time.Duration {
+	if len(s) == 0 {
+		return defaultHistoryTTL
+	}
+	ttl, err := time.ParseDuration(s)
+	if err != nil || ttl <= 0 {
+		log.Warnf("invalid alarm historyTTL %s, use default %s", s, defaultHistoryTTL)
+		return defaultHistoryTTL
+	}
+	return ttl
+}
@@ -16,23 +16,59 @@
 package alarm
 
 import (
+	"context"
+	"sort"
 	"sync"
+	"time"
 
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/alarm/model"
 	nf "github.com/apache/servicecomb-service-center/pkg/event"
 	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/queue"
 	"github.com/apache/servicecomb-service-center/pkg/util"
-	"github.com/apache/servicecomb-service-center/server/alarm/model"
 	"github.com/apache/servicecomb-service-center/server/event"
 )
 
+const defaultHistoryTTL = 7 * 24 * time.Hour
+
 var (
 	service *Service
 	once    sync.Once
 )
 
+// change is the alarm snapshot to persist and notify
+type change struct {
+	alarm *model.Alarm
+	// transition is true when the status is changed to ACTIVATED or CLEARED
+	transition bool
+	timestamp  int64
+}
+
+// clearAll deletes the persisted alarms of the node, then records and
+// notifies the alarms cleared, the result receives the deletion error
+type clearAll struct {
+	node      string
+	cleared   []*model.Alarm
+	timestamp int64
+	result    chan error
+}
+
 type Service struct {
 	nf.Subscriber
-	alarms util.ConcurrentMap
+	lock sync.RWMutex
+	// node identifies this service center in the store
+	node   string
+	alarms map[model.ID]*model.Alarm
+	// store is nil until the persisted alarms are loaded
+	store      datasource.AlarmManager
+	dirty      map[model.ID]struct{}
+	notifiers  []*notifier
+	historyTTL time.Duration
+	queue      *queue.TaskQueue
+	// loaded is the snapshot of the persisted alarms, onLoaded are called with it after loading
+	loaded   []*model.Alarm
+	onLoaded []func(persisted []*model.Alarm)
 }
 
 func (ac *Service) Raise(id model.ID, fields ...model.Field) error {
@@ -57,38 +93,309 @@ func (ac *Service) Clear(id model.ID) error {
 	return event.Center().Fire(ae)
 }
 
-func (ac *Service) ListAll() (ls []*model.AlarmEvent) {
-	ac.alarms.ForEach(func(item util.MapItem) (next bool) {
-		ls = append(ls, item.Value.(*model.AlarmEvent))
-		return true
-	})
-	return
+func (ac *Service) ListAll() []*model.Alarm {
+	ac.lock.RLock()
+	ls := make([]*model.Alarm, 0, len(ac.alarms))
+	for _, a := range ac.alarms {
+		snapshot := *a
+		ls = append(ls, &snapshot)
+	}
+	ac.lock.RUnlock()
+	sort.Slice(ls, func(i, j int) bool { return ls[i].ID < ls[j].ID })
+	return ls
+}
+
+// ListHistory returns the latest transitions first
+func (ac *Service) ListHistory(ctx context.Context, request *model.HistoryRequest) ([]*model.History, error) {
+	store := ac.getStore()
+	if store == nil {
+		return nil, ErrNotLoaded
+	}
+	return store.ListAlarmHistory(ctx, request)
 }
 
-func (ac *Service) ClearAll() {
-	ac.alarms = util.ConcurrentMap{}
+func (ac *Service) Acknowledge(ctx context.Context, id model.ID, user string) (*model.Alarm, error) {
+	ac.lock.Lock()
+	a, ok := ac.alarms[id]
+	if !ok {
+		ac.lock.Unlock()
+		return nil, datasource.ErrAlarmNotExist
+	}
+	a.Acknowledged = true
+	a.AcknowledgedBy = user
+	a.AcknowledgedTime = time.Now().Unix()
+	snapshot := *a
+	ac.lock.Unlock()
+
+	ac.queue.Add(queue.Task{Payload: &change{alarm: &snapshot, timestamp: snapshot.AcknowledgedTime}})
+	log.Infof("alarm[%s] is acknowledged by %s", id, user)
+	return &snapshot, nil
+}
+
+// ClearAll removes all the alarms in memory at once, the persisted ones are
+// deleted in the queue after the previous changes, so they are not re-created
+func (ac *Service) ClearAll(ctx context.Context) error {
+	now := time.Now().Unix()
+	ac.lock.Lock()
+	c := &clearAll{node: ac.node, timestamp: now, result: make(chan error, 1)}
+	for _, a := range ac.alarms {
+		if a.Status == Cleared {
+			continue
+		}
+		snapshot := *a
+		snapshot.Status = Cleared
+		snapshot.ClearedTime = now
+		c.cleared = append(c.cleared, &snapshot)
+	}
+	ac.alarms = make(map[model.ID]*model.Alarm)
+	ac.dirty = make(map[model.ID]struct{})
+	ac.lock.Unlock()
+
+	ac.queue.Add(queue.Task{Payload: c})
+	select {
+	case err := <-c.result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Load merges the persisted alarms into memory and enables the persistence,
+// the alarms raised before loading are flushed to the store
+func (ac *Service) Load(ctx context.Context, store datasource.AlarmManager) error {
+	persisted, err := store.ListAlarms(ctx, ac.getNode())
+	if err != nil {
+		return err
+	}
+	loaded := make([]*model.Alarm, 0, len(persisted))
+	for _, p := range persisted {
+		snapshot := *p
+		loaded = append(loaded, &snapshot)
+	}
+	ac.lock.Lock()
+	for id := range ac.alarms {
+		ac.dirty[id] = struct{}{}
+	}
+	for _, p := range persisted {
+		exist, ok := ac.alarms[p.ID]
+		if !ok {
+			ac.alarms[p.ID] = p
+			continue
+		}
+		exist.Count += p.Count
+		exist.FirstRaisedTime = p.FirstRaisedTime
+	}
+	ac.store = store
+	ac.loaded = loaded
+	hooks := ac.onLoaded
+	ac.onLoaded = nil
+	ac.lock.Unlock()
+
+	// flush in the queue to keep the writes ordered
+	ac.queue.Add(queue.Task{Payload: &change{}})
+	log.Infof("%d persisted alarms are loaded", len(persisted))
+	for _, f := range hooks {
+		f(loaded)
+	}
+	return nil
+}
+
+// OnLoaded calls f with the persisted alarms after loading, or at once if loaded already,
+// so the raiser keeping its own state can rebuild it after restarting
+func (ac *Service) OnLoaded(f func(persisted []*model.Alarm)) {
+	ac.lock.Lock()
+	if ac.store == nil {
+		ac.onLoaded = append(ac.onLoaded, f)
+		ac.lock.Unlock()
+		return
+	}
+	loaded := ac.loaded
+	ac.lock.Unlock()
+	f(loaded)
+}
+
+func (ac *Service) SetNotifiers(notifiers []*notifier) {
+	ac.lock.Lock()
+	ac.notifiers = notifiers
+	ac.lock.Unlock()
+}
+
+// SetNode changes the node of the alarms raised afterwards, it should be called before loading
+func (ac *Service) SetNode(node string) {
+	ac.lock.Lock()
+	ac.node = node
+	ac.lock.Unlock()
+}
+
+func (ac *Service) SetHistoryTTL(ttl time.Duration) {
+	ac.lock.Lock()
+	ac.historyTTL = ttl
+	ac.lock.Unlock()
 }
 
 func (ac *Service) OnMessage(evt nf.Event) {
-	alarm := evt.(*model.AlarmEvent)
-	switch alarm.Status {
+	ae := evt.(*model.AlarmEvent)
+	c := ac.apply(ae, time.Now().Unix())
+	if c == nil {
+		return
+	}
+	log.Debugf("alarm[%s] %s, %v", ae.ID, ae.Status, ae.Fields)
+	ac.queue.Add(queue.Task{Payload: c})
+}
+
+// apply updates the alarm in memory, returns nil if nothing changed
+func (ac *Service) apply(ae *model.AlarmEvent, now int64) *change {
+	ac.lock.Lock()
+	defer ac.lock.Unlock()
+	exist, ok := ac.alarms[ae.ID]
+	transition := false
+	switch ae.Status {
 	case Cleared:
-		if itf, ok := ac.alarms.Get(alarm.ID); ok {
-			if exist := itf.(*model.AlarmEvent); exist.Status != Cleared {
-				exist.Status = Cleared
-				alarm = exist
-			}
+		if !ok || exist.Status == Cleared {
+			return nil
 		}
+		exist.Status = Cleared
+		exist.ClearedTime = now
+		transition = true
 	default:
-		ac.alarms.Put(alarm.ID, alarm)
+		if !ok {
+			exist = &model.Alarm{Node: ac.node, ID: ae.ID, FirstRaisedTime: now}
+			ac.alarms[ae.ID] = exist
+		}
+		transition = exist.Status != Activated
+		exist.Status = Activated
+		exist.Fields = ae.Fields
+		exist.Count++
+		exist.LastRaisedTime = now
+		if transition {
+			exist.ClearedTime = 0
+			exist.Acknowledged = false
+			exist.AcknowledgedBy = ""
+			exist.AcknowledgedTime = 0
+		}
+	}
+	snapshot := *exist
+	return &change{alarm: &snapshot, transition: transition, timestamp: now}
+}
+
+// Handle persists and notifies the changes in order
+func (ac *Service) Handle(ctx context.Context, obj interface{}) {
+	if c, ok := obj.(*clearAll); ok {
+		ac.clearAll(ctx, c)
+		return
+	}
+	c := obj.(*change)
+	ac.persist(ctx, c)
+	if c.transition {
+		ac.notify(c.alarm)
+	}
+}
+
+func (ac *Service) clearAll(ctx context.Context, c *clearAll) {
+	ac.lock.RLock()
+	store, ttl := ac.store, ac.historyTTL
+	ac.lock.RUnlock()
+	if store != nil {
+		if err := store.DeleteAlarms(ctx, c.node); err != nil {
+			log.Errorf(err, "delete the alarms of node[%s] failed", c.node)
+			c.result <- err
+			return
+		}
+	}
+	c.result <- nil
+	for _, a := range c.cleared {
+		if store != nil {
+			addHistory(ctx, store, ttl, &change{alarm: a, transition: true, timestamp: c.timestamp})
+		}
+		ac.notify(a)
+	}
+}
+
+func (ac *Service) persist(ctx context.Context, c *change) {
+	ac.lock.Lock()
+	store, ttl := ac.store, ac.historyTTL
+	if c.alarm != nil {
+		ac.dirty[c.alarm.ID] = struct{}{}
+	}
+	var pending []*model.Alarm
+	for id := range ac.dirty {
+		if a, ok := ac.alarms[id]; ok {
+			snapshot := *a
+			pending = append(pending, &snapshot)
+		}
+	}
+	ac.lock.Unlock()
+	if store == nil {
+		return
 	}
-	log.Debugf("alarm[%s] %s, %v", alarm.ID, alarm.Status, alarm.Fields)
+
+	for _, a := range pending {
+		if err := store.UpsertAlarm(ctx, a); err != nil {
+			log.Errorf(err, "persist alarm[%s] failed", a.ID)
+			continue
+		}
+		ac.lock.Lock()
+		delete(ac.dirty, a.ID)
+		ac.lock.Unlock()
+	}
+
+	if c.transition {
+		addHistory(ctx, store, ttl, c)
+	}
+}
+
+// addHistory records the transition and deletes the expired histories
+func addHistory(ctx context.Context, store datasource.AlarmManager, ttl time.Duration, c *change) {
+	err := store.AddAlarmHistory(ctx, &model.History{
+		Node:      c.alarm.Node,
+		ID:        c.alarm.ID,
+		Status:    c.alarm.Status,
+		Fields:    c.alarm.Fields,
+		Timestamp: c.timestamp,
+	})
+	if err != nil {
+		log.Errorf(err, "add alarm[%s] history failed", c.alarm.ID)
+		return
+	}
+	if err := store.DeleteAlarmHistory(ctx, c.timestamp-int64(ttl/time.Second)); err != nil {
+		log.Errorf(err, "delete the expired alarm histories failed")
+	}
+}
+
+func (ac *Service) notify(a *model.Alarm) {
+	ac.lock.RLock()
+	notifiers := ac.notifiers
+	ac.lock.RUnlock()
+	for _, n := range notifiers {
+		if n.Accept(a.ID) {
+			n.Send(a)
+		}
+	}
+}
+
+func (ac *Service) getNode() string {
+	ac.lock.RLock()
+	defer ac.lock.RUnlock()
+	return ac.node
+}
+
+func (ac *Service) getStore() datasource.AlarmManager {
+	ac.lock.RLock()
+	defer ac.lock.RUnlock()
+	return ac.store
 }
 
 func NewAlarmService() *Service {
 	c := &Service{
 		Subscriber: nf.NewSubscriber(ALARM, Subject, Group),
+		node:       util.HostName(),
+		alarms:     make(map[model.ID]*model.Alarm),
+		dirty:      make(map[model.ID]struct{}),
+		historyTTL: defaultHistoryTTL,
+		queue:      queue.NewTaskQueue(0),
 	}
+	c.queue.AddWorker(c)
+	c.queue.Run()
 	err := event.Center().AddSubscriber(c)
 	if err != nil {
 		log.Error("", err)
@@ -0,0 +1,276 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alarm
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/alarm/model"
+	nf "github.com/apache/servicecomb-service-center/pkg/event"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/config"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockStore struct {
+	lock sync.Mutex
+	// alarms are indexed by the node
+	alarms    map[string]map[model.ID]*model.Alarm
+	histories []*model.History
+}
+
+func (m *mockStore) UpsertAlarm(ctx context.Context, a *model.Alarm) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, ok := m.alarms[a.Node]; !ok {
+		m.alarms[a.Node] = make(map[model.ID]*model.Alarm)
+	}
+	m.alarms[a.Node][a.ID] = a
+	return nil
+}
+
+func (m *mockStore) GetAlarm(ctx context.Context, node string, id model.ID) (*model.Alarm, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	a, ok := m.alarms[node][id]
+	if !ok {
+		return nil, datasource.ErrAlarmNotExist
+	}
+	return a, nil
+}
+
+func (m *mockStore) ListAlarms(ctx context.Context, node string) ([]*model.Alarm, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var ls []*model.Alarm
+	for n, alarms := range m.alarms {
+		if len(node) > 0 && n != node {
+			continue
+		}
+		for _, a := range alarms {
+			ls = append(ls, a)
+		}
+	}
+	return ls, nil
+}
+
+func (m *mockStore) DeleteAlarms(ctx context.Context, node string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if len(node) == 0 {
+		m.alarms = make(map[string]map[model.ID]*model.Alarm)
+		return nil
+	}
+	delete(m.alarms, node)
+	return nil
+}
+
+func (m *mockStore) AddAlarmHistory(ctx context.Context, h *model.History) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.histories = append(m.histories, h)
+	return nil
+}
+
+func (m *mockStore) ListAlarmHistory(ctx context.Context, request *model.HistoryRequest) ([]*model.History, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var ls []*model.History
+	for i := len(m.histories) - 1; i >= 0; i-- {
+		if request.Match(m.histories[i]) {
+			ls = append(ls, m.histories[i])
+		}
+	}
+	return ls, nil
+}
+
+func (m *mockStore) DeleteAlarmHistory(ctx context.Context, before int64) error {
+	return nil
+}
+
+func (m *mockStore) historyCount() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return len(m.histories)
+}
+
+type mockNotifier struct {
+	ch chan *model.Alarm
+}
+
+func (m *mockNotifier) Notify(ctx context.Context, a *model.Alarm) error {
+	m.ch <- a
+	return nil
+}
+
+func newEvent(status model.Status, id model.ID) *model.AlarmEvent {
+	return &model.AlarmEvent{
+		Event:  nf.NewEvent(ALARM, Subject, ""),
+		Status: status,
+		ID:     id,
+		Fields: util.JSONObject{FieldAdditionalContext: string(status)},
+	}
+}
+
+func TestService(t *testing.T) {
+	received := &mockNotifier{ch: make(chan *model.Alarm, 10)}
+	InstallNotifier("mock", func(opts config.NotifierOptions) (Notifier, error) {
+		return received, nil
+	})
+	notifiers, err := newNotifiers([]config.NotifierOptions{
+		{Name: "test", Type: "mock", Alarms: []string{string(IDBackendConnectionRefuse)}},
+	})
+	assert.NoError(t, err)
+
+	svc := NewAlarmService()
+	svc.SetNode("node1")
+	svc.SetNotifiers(notifiers)
+	store := &mockStore{alarms: map[string]map[model.ID]*model.Alarm{
+		"node1": {
+			IDInternalError: {Node: "node1", ID: IDInternalError, Status: Cleared, Count: 3, FirstRaisedTime: 1},
+		},
+		"node2": {
+			IDInternalError:           {Node: "node2", ID: IDInternalError, Status: Activated, Count: 1, FirstRaisedTime: 1},
+			IDBackendConnectionRefuse: {Node: "node2", ID: IDBackendConnectionRefuse, Status: Activated, Count: 7},
+		},
+	}}
+	ctx := context.Background()
+
+	t.Run("raise before loaded, should notify and keep in memory", func(t *testing.T) {
+		svc.OnMessage(newEvent(Activated, IDBackendConnectionRefuse))
+		a := <-received.ch
+		assert.Equal(t, IDBackendConnectionRefuse, a.ID)
+		assert.Equal(t, Activated, a.Status)
+		assert.Equal(t, int64(1), a.Count)
+
+		_, err := svc.ListHistory(ctx, &model.HistoryRequest{})
+		assert.Equal(t, ErrNotLoaded, err)
+	})
+
+	t.Run("load, should merge the persisted alarms", func(t *testing.T) {
+		var loaded []*model.Alarm
+		svc.OnLoaded(func(persisted []*model.Alarm) { loaded = persisted })
+		assert.NoError(t, svc.Load(ctx, store))
+		assert.Equal(t, 1, len(loaded))
+		assert.Equal(t, IDInternalError, loaded[0].ID)
+		loaded = nil
+		svc.OnLoaded(func(persisted []*model.Alarm) { loaded = persisted })
+		assert.Equal(t, 1, len(loaded))
+
+		assert.Eventually(t, func() bool {
+			a, err := store.GetAlarm(ctx, "node1", IDBackendConnectionRefuse)
+			return err == nil && a.Count == 1
+		}, time.Second, 10*time.Millisecond)
+		alarms := svc.ListAll()
+		assert.Equal(t, 2, len(alarms))
+		assert.Equal(t, IDBackendConnectionRefuse, alarms[0].ID)
+		assert.Equal(t, IDInternalError, alarms[1].ID)
+		assert.Equal(t, Cleared, alarms[1].Status)
+
+		// the alarms of the other nodes are not overwritten
+		a, err := store.GetAlarm(ctx, "node2", IDBackendConnectionRefuse)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), a.Count)
+	})
+
+	t.Run("raise again, should count without transition", func(t *testing.T) {
+		svc.OnMessage(newEvent(Activated, IDBackendConnectionRefuse))
+		assert.Eventually(t, func() bool {
+			a, err := store.GetAlarm(ctx, "node1", IDBackendConnectionRefuse)
+			return err == nil && a.Count == 2
+		}, time.Second, 10*time.Millisecond)
+		assert.Equal(t, 0, len(received.ch))
+	})
+
+	t.Run("acknowledge, should be persisted", func(t *testing.T) {
+		_, err := svc.Acknowledge(ctx, "not-exist", "admin")
+		assert.Equal(t, datasource.ErrAlarmNotExist, err)
+
+		a, err := svc.Acknowledge(ctx, IDBackendConnectionRefuse, "admin")
+		assert.NoError(t, err)
+		assert.True(t, a.Acknowledged)
+		assert.Eventually(t, func() bool {
+			a, err := store.GetAlarm(ctx, "node1", IDBackendConnectionRefuse)
+			return err == nil && a.AcknowledgedBy == "admin"
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("clear, should notify and record history", func(t *testing.T) {
+		svc.OnMessage(newEvent(Cleared, IDBackendConnectionRefuse))
+		a := <-received.ch
+		assert.Equal(t, Cleared, a.Status)
+		assert.NotZero(t, a.ClearedTime)
+		assert.True(t, a.Acknowledged)
+
+		// clear twice is ignored
+		svc.OnMessage(newEvent(Cleared, IDBackendConnectionRefuse))
+		assert.Eventually(t, func() bool {
+			return store.historyCount() == 1
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("reactivate, should reset the acknowledgement", func(t *testing.T) {
+		svc.OnMessage(newEvent(Activated, IDBackendConnectionRefuse))
+		a := <-received.ch
+		assert.Equal(t, Activated, a.Status)
+		assert.Equal(t, int64(3), a.Count)
+		assert.False(t, a.Acknowledged)
+		assert.Zero(t, a.ClearedTime)
+
+		// not in the notifier filters
+		svc.OnMessage(newEvent(Activated, IDInternalError))
+		assert.Eventually(t, func() bool {
+			return store.historyCount() == 3
+		}, time.Second, 10*time.Millisecond)
+		assert.Equal(t, 0, len(received.ch))
+
+		histories, err := svc.ListHistory(ctx, &model.HistoryRequest{ID: IDBackendConnectionRefuse})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(histories))
+		assert.Equal(t, Activated, histories[0].Status)
+		assert.Equal(t, Cleared, histories[1].Status)
+	})
+
+	t.Run("clear all, should delete the persisted alarms and notify", func(t *testing.T) {
+		assert.NoError(t, svc.ClearAll(ctx))
+		assert.Equal(t, 0, len(svc.ListAll()))
+		a := <-received.ch
+		assert.Equal(t, IDBackendConnectionRefuse, a.ID)
+		assert.Equal(t, Cleared, a.Status)
+		assert.Eventually(t, func() bool {
+			return store.historyCount() == 5
+		}, time.Second, 10*time.Millisecond)
+		alarms, err := store.ListAlarms(ctx, "node1")
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(alarms))
+		alarms, err = store.ListAlarms(ctx, "")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(alarms))
+	})
+}
+
+func TestNewNotifiers(t *testing.T) {
+	_, err := newNotifiers([]config.NotifierOptions{{Name: "x", Type: "unknown"}})
+	assert.Error(t, err)
+
+	assert.Equal(t, defaultHistoryTTL, historyTTL(""))
+	assert.Equal(t, defaultHistoryTTL, historyTTL("x"))
+	assert.Equal(t, time.Hour, historyTTL("1h"))
+}
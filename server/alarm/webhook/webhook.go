@@ -0,0 +1,99 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package webhook posts the alarm transitions to a HTTP endpoint
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/apache/servicecomb-service-center/pkg/alarm/model"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/alarm"
+	"github.com/apache/servicecomb-service-center/server/config"
+)
+
+const (
+	TypeWebhook    = "webhook"
+	defaultTimeout = 5 * time.Second
+)
+
+func init() {
+	alarm.InstallNotifier(TypeWebhook, New)
+}
+
+// Payload is the request body of the webhook
+type Payload struct {
+	// Source is the host name of service center
+	Source string       `json:"source"`
+	Alarm  *model.Alarm `json:"alarm"`
+}
+
+type Notifier struct {
+	endpoint string
+	headers  map[string]string
+	client   *http.Client
+}
+
+func (n *Notifier) Notify(ctx context.Context, a *model.Alarm) error {
+	body, err := json.Marshal(&Payload{Source: util.HostName(), Alarm: a})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range n.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook %s responses %s", n.endpoint, resp.Status)
+	}
+	return nil
+}
+
+func New(opts config.NotifierOptions) (alarm.Notifier, error) {
+	if len(opts.Endpoint) == 0 {
+		return nil, errors.New("webhook endpoint is required")
+	}
+	timeout := defaultTimeout
+	if len(opts.Timeout) > 0 {
+		d, err := time.ParseDuration(opts.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook timeout %s", opts.Timeout)
+		}
+		timeout = d
+	}
+	return &Notifier{
+		endpoint: opts.Endpoint,
+		headers:  opts.Headers,
+		client:   &http.Client{Timeout: timeout},
+	}, nil
+}
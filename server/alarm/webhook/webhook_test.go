@@ -0,0 +1,77 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/servicecomb-service-center/pkg/alarm/model"
+	"github.com/apache/servicecomb-service-center/server/alarm"
+	"github.com/apache/servicecomb-service-center/server/alarm/webhook"
+	"github.com/apache/servicecomb-service-center/server/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifier_Notify(t *testing.T) {
+	var received webhook.Payload
+	var token string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if received.Alarm.ID == alarm.IDInternalError {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	_, err := webhook.New(config.NotifierOptions{})
+	assert.Error(t, err)
+	_, err = webhook.New(config.NotifierOptions{Endpoint: server.URL, Timeout: "x"})
+	assert.Error(t, err)
+
+	n, err := webhook.New(config.NotifierOptions{
+		Endpoint: server.URL,
+		Headers:  map[string]string{"Authorization": "Bearer x"},
+	})
+	assert.NoError(t, err)
+
+	t.Run("post alarm, should be received", func(t *testing.T) {
+		err := n.Notify(context.Background(), &model.Alarm{
+			ID:     alarm.IDBackendConnectionRefuse,
+			Status: alarm.Activated,
+			Count:  1,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer x", token)
+		assert.NotEmpty(t, received.Source)
+		assert.Equal(t, alarm.IDBackendConnectionRefuse, received.Alarm.ID)
+		assert.Equal(t, alarm.Activated, received.Alarm.Status)
+	})
+
+	t.Run("endpoint responses error, should return error", func(t *testing.T) {
+		err := n.Notify(context.Background(), &model.Alarm{ID: alarm.IDInternalError})
+		assert.Error(t, err)
+	})
+}
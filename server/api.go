@@ -30,11 +30,15 @@ import (
 	"github.com/apache/servicecomb-service-center/pkg/gopool"
 	"github.com/apache/servicecomb-service-center/pkg/grace"
 	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/proto"
 	"github.com/apache/servicecomb-service-center/pkg/rest"
+	"github.com/apache/servicecomb-service-center/pkg/rpc"
 	"github.com/apache/servicecomb-service-center/server/config"
 	"github.com/apache/servicecomb-service-center/server/core"
 	"github.com/apache/servicecomb-service-center/server/metrics"
 	rs "github.com/apache/servicecomb-service-center/server/rest"
+	grpcsrv "github.com/apache/servicecomb-service-center/server/rpc"
+	"google.golang.org/grpc"
 )
 
 var apiServer *APIServer
@@ -51,6 +55,10 @@ func init() {
 
 func InitAPI() {
 	core.ServiceAPI = disco.AssembleResources()
+	rpc.RegisterService(func(s *grpc.Server) {
+		proto.RegisterServiceCtrlServer(s, core.ServiceAPI)
+		proto.RegisterServiceInstanceCtrlServer(s, disco.NewInstanceService())
+	})
 }
 
 type APIType int64
@@ -70,6 +78,7 @@ type APIServer struct {
 	Listeners map[APIType]string
 
 	restSrv   *rest.Server
+	rpcSrv    *grpcsrv.Server
 	isClose   bool
 	forked    bool
 	err       chan error
@@ -142,6 +151,30 @@ func (s *APIServer) startRESTServer() (err error) {
 	return
 }
 
+func (s *APIServer) startRPCServer() (err error) {
+	addr, ok := s.Listeners[RPC]
+	if !ok {
+		return
+	}
+	s.rpcSrv, err = grpcsrv.NewServer(addr)
+	if err != nil {
+		return
+	}
+	log.Infof("listen address: %s://%s", RPC, s.rpcSrv.Listener.Addr().String())
+
+	s.populateEndpoint(RPC, s.rpcSrv.Listener.Addr().String())
+
+	s.goroutine.Do(func(_ context.Context) {
+		err := s.rpcSrv.Serve()
+		if s.isClose {
+			return
+		}
+		log.Errorf(err, "error to start RPC API server %s", addr)
+		s.err <- err
+	})
+	return
+}
+
 func (s *APIServer) Start() {
 	if !s.isClose {
 		return
@@ -156,6 +189,12 @@ func (s *APIServer) Start() {
 		return
 	}
 
+	err = s.startRPCServer()
+	if err != nil {
+		s.err <- err
+		return
+	}
+
 	s.graceDone()
 
 	defer log.Info("api server is ready")
@@ -183,6 +222,10 @@ func (s *APIServer) Stop() {
 		s.restSrv.Shutdown()
 	}
 
+	if s.rpcSrv != nil {
+		s.rpcSrv.Shutdown()
+	}
+
 	close(s.err)
 
 	s.goroutine.Close(true)
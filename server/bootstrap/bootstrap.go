@@ -46,6 +46,9 @@ import (
 	//tracing
 	_ "github.com/apache/servicecomb-service-center/server/plugin/tracing/pzipkin"
 
+	//auditlog
+	_ "github.com/apache/servicecomb-service-center/server/plugin/auditlog/buildin"
+
 	//tlsconf
 	_ "github.com/apache/servicecomb-service-center/server/plugin/security/tlsconf/buildin"
 
@@ -59,14 +62,19 @@ import (
 	_ "github.com/apache/servicecomb-service-center/server/rest/syncer"
 
 	//governance
+	_ "github.com/apache/servicecomb-service-center/server/service/gov/istio"
 	_ "github.com/apache/servicecomb-service-center/server/service/gov/kie"
 
 	//metrics
 	_ "github.com/apache/servicecomb-service-center/server/rest/metrics"
 
+	//alarm notifiers
+	_ "github.com/apache/servicecomb-service-center/server/alarm/webhook"
+
 	"github.com/apache/servicecomb-service-center/pkg/log"
 	"github.com/apache/servicecomb-service-center/server/broker"
 	"github.com/apache/servicecomb-service-center/server/handler/accesslog"
+	"github.com/apache/servicecomb-service-center/server/handler/auditlog"
 	"github.com/apache/servicecomb-service-center/server/handler/auth"
 	"github.com/apache/servicecomb-service-center/server/handler/context"
 	"github.com/apache/servicecomb-service-center/server/handler/exception"
@@ -90,6 +98,7 @@ func init() {
 	exception.RegisterHandlers()
 	context.RegisterHandlers()
 	accesslog.RegisterHandlers()
+	auditlog.RegisterHandlers()
 	maxbody.RegisterHandlers()
 	auth.RegisterHandlers()
 	metrics.RegisterHandlers()
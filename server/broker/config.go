@@ -20,8 +20,6 @@ package broker
 import (
 	"path/filepath"
 
-	"github.com/apache/servicecomb-service-center/datasource/etcd/kv"
-	"github.com/apache/servicecomb-service-center/datasource/etcd/sd"
 	"github.com/apache/servicecomb-service-center/pkg/log"
 	"github.com/apache/servicecomb-service-center/server/config"
 )
@@ -39,18 +37,4 @@ func Init() {
 		LogRotateSize:  int(config.GetLog().LogRotateSize),
 		LogBackupCount: int(config.GetLog().LogBackupCount),
 	})
-	PARTICIPANT = kv.Store().MustInstall(kv.NewAddOn("PARTICIPANT",
-		sd.Configure().WithPrefix(GetBrokerParticipantKey(""))))
-	VERSION = kv.Store().MustInstall(kv.NewAddOn("VERSION",
-		sd.Configure().WithPrefix(GetBrokerVersionKey(""))))
-	PACT = kv.Store().MustInstall(kv.NewAddOn("PACT",
-		sd.Configure().WithPrefix(GetBrokerPactKey(""))))
-	PactVersion = kv.Store().MustInstall(kv.NewAddOn("PACT_VERSION",
-		sd.Configure().WithPrefix(GetBrokerPactVersionKey(""))))
-	PactTag = kv.Store().MustInstall(kv.NewAddOn("PACT_TAG",
-		sd.Configure().WithPrefix(GetBrokerTagKey(""))))
-	VERIFICATION = kv.Store().MustInstall(kv.NewAddOn("VERIFICATION",
-		sd.Configure().WithPrefix(GetBrokerVerificationKey(""))))
-	PactLatest = kv.Store().MustInstall(kv.NewAddOn("PACT_LATEST",
-		sd.Configure().WithPrefix(GetBrokerLatestKey(""))))
 }
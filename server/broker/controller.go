@@ -25,8 +25,8 @@ import (
 
 	"github.com/apache/servicecomb-service-center/pkg/log"
 
+	"github.com/apache/servicecomb-service-center/pkg/broker/brokerpb"
 	"github.com/apache/servicecomb-service-center/pkg/rest"
-	"github.com/apache/servicecomb-service-center/server/broker/brokerpb"
 	pb "github.com/go-chassis/cari/discovery"
 )
 
@@ -59,6 +59,9 @@ func (brokerService *Controller) URLPatterns() []rest.Route {
 		{Method: http.MethodGet,
 			Path: "/verification-results/consumer/:consumerId/version/:consumerVersion/latest",
 			Func: brokerService.RetrieveVerificationResults},
+		{Method: http.MethodGet,
+			Path: MatrixURL,
+			Func: brokerService.CanIDeploy},
 	}
 }
 
@@ -192,6 +195,17 @@ func (*Controller) RetrieveVerificationResults(w http.ResponseWriter, r *http.Re
 	rest.WriteResponse(w, r, resp.Response, resp)
 }
 
+func (*Controller) CanIDeploy(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := &brokerpb.MatrixRequest{
+		ConsumerId:      query.Get(":consumerId"),
+		ConsumerVersion: query.Get(":consumerVersion"),
+	}
+	PactLogger.Infof("Can i deploy: %s, %s\n", request.ConsumerId, request.ConsumerVersion)
+	resp, _ := ServiceAPI.CanIDeploy(r.Context(), request)
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
 func getScheme(r *http.Request) string {
 	if len(r.URL.Scheme) < 1 {
 		return DefaultScheme
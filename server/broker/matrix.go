@@ -0,0 +1,258 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/broker/brokerpb"
+	pb "github.com/go-chassis/cari/discovery"
+)
+
+const (
+	reasonNoInstances  = "no provider instances are registered"
+	reasonUnverified   = "pact is not verified against some registered provider versions"
+	reasonFailed       = "pact verification failed against some registered provider versions"
+	reasonAllVerified  = "pact is verified against all registered provider versions"
+	reasonNoPacts      = "no pacts are published by the consumer version"
+	reasonAllProviders = "all providers are verified"
+	reasonSomeProvider = "some providers are not verified"
+)
+
+// CanIDeploy combines the verification results of the pacts published by a
+// consumer version with the provider versions which currently have instances,
+// and decides per provider whether the consumer version can be deployed
+func (*Service) CanIDeploy(ctx context.Context, in *brokerpb.MatrixRequest) (*brokerpb.MatrixResponse, error) {
+	if in == nil || len(in.ConsumerId) == 0 || len(in.ConsumerVersion) == 0 {
+		PactLogger.Errorf(nil, "can-i-deploy request failed: invalid params.")
+		return &brokerpb.MatrixResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, "Request format invalid."),
+		}, nil
+	}
+	tenant := GetDefaultTenantProject()
+	consumer, err := GetService(ctx, in.ConsumerId)
+	if err != nil {
+		if errors.Is(err, datasource.ErrNoData) {
+			PactLogger.Debug(fmt.Sprintf("can-i-deploy request failed, consumerID is %s: consumer not exist.", in.ConsumerId))
+			return &brokerpb.MatrixResponse{
+				Response: pb.CreateResponse(pb.ErrInvalidParams, "Consumer does not exist."),
+			}, nil
+		}
+		PactLogger.Error(fmt.Sprintf("can-i-deploy request failed, consumerID is %s: query consumer failed.", in.ConsumerId), err)
+		return &brokerpb.MatrixResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, "Query consumer failed."),
+		}, err
+	}
+	consumerParticipant, err := GetParticipant(ctx, tenant, consumer.AppId, consumer.ServiceName)
+	if err != nil || consumerParticipant == nil {
+		PactLogger.Errorf(err, "can-i-deploy request failed, consumer participant cannot be searched.")
+		return &brokerpb.MatrixResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, "consumer participant cannot be searched."),
+		}, err
+	}
+	version, err := GetVersion(ctx, tenant, in.ConsumerVersion, consumerParticipant.Id)
+	if err != nil || version == nil {
+		PactLogger.Errorf(err, "can-i-deploy request failed, version cannot be searched.")
+		return &brokerpb.MatrixResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, "version cannot be searched."),
+		}, err
+	}
+	pactVersions, err := listPactVersionsOf(ctx, tenant, version.Id)
+	if err != nil {
+		PactLogger.Errorf(err, "can-i-deploy request failed, pact version cannot be searched.")
+		return &brokerpb.MatrixResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, "pact version cannot be searched."),
+		}, err
+	}
+	participants, err := datasource.GetBrokerManager().ListParticipants(ctx, tenant)
+	if err != nil {
+		PactLogger.Errorf(err, "can-i-deploy request failed, provider participant cannot be searched.")
+		return &brokerpb.MatrixResponse{
+			Response: pb.CreateResponse(pb.ErrInternal, "provider participant cannot be searched."),
+		}, err
+	}
+	participantMap := make(map[int32]*brokerpb.Participant, len(participants))
+	for _, participant := range participants {
+		participantMap[participant.Id] = participant
+	}
+
+	matrix := make([]*brokerpb.MatrixProvider, 0, len(pactVersions))
+	for _, pactVersion := range latestPactVersionPerProvider(pactVersions) {
+		provider, ok := participantMap[pactVersion.ProviderParticipantId]
+		if !ok {
+			PactLogger.Warnf("can-i-deploy skip pact version %d, provider participant %d does not exist",
+				pactVersion.Id, pactVersion.ProviderParticipantId)
+			continue
+		}
+		verifications, err := datasource.GetBrokerManager().ListVerifications(ctx, tenant, pactVersion.Id)
+		if err != nil {
+			PactLogger.Errorf(err, "can-i-deploy request failed, verification results cannot be searched.")
+			return &brokerpb.MatrixResponse{
+				Response: pb.CreateResponse(pb.ErrInternal, "verification results cannot be searched."),
+			}, err
+		}
+		liveVersions, err := getLiveVersions(ctx, provider.AppId, provider.ServiceName)
+		if err != nil {
+			PactLogger.Errorf(err, "can-i-deploy request failed, provider instances cannot be searched.")
+			return &brokerpb.MatrixResponse{
+				Response: pb.CreateResponse(pb.ErrInternal, "provider instances cannot be searched."),
+			}, err
+		}
+		matrix = append(matrix, BuildMatrixProvider(provider, liveVersions, verifications))
+	}
+	PactLogger.Infof("can-i-deploy matrix of consumer (%s, %s) calculated, %d providers",
+		in.ConsumerId, in.ConsumerVersion, len(matrix))
+	return &brokerpb.MatrixResponse{
+		Response: pb.CreateResponse(pb.ResponseSuccess, "Matrix retrieved successfully."),
+		Summary:  SummarizeMatrix(matrix),
+		Matrix:   matrix,
+	}, nil
+}
+
+// latestPactVersionPerProvider keeps the latest published pact of every
+// provider, the older ones are superseded and their verifications are stale
+func latestPactVersionPerProvider(pactVersions []*brokerpb.PactVersion) []*brokerpb.PactVersion {
+	latest := make(map[int32]*brokerpb.PactVersion, len(pactVersions))
+	for _, pactVersion := range pactVersions {
+		if last, ok := latest[pactVersion.ProviderParticipantId]; !ok || pactVersion.Id > last.Id {
+			latest[pactVersion.ProviderParticipantId] = pactVersion
+		}
+	}
+	result := make([]*brokerpb.PactVersion, 0, len(latest))
+	for _, pactVersion := range latest {
+		result = append(result, pactVersion)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ProviderParticipantId < result[j].ProviderParticipantId
+	})
+	return result
+}
+
+// getLiveVersions returns the instance count of the provider versions which
+// have instances registered
+func getLiveVersions(ctx context.Context, appID, serviceName string) (map[string]int64, error) {
+	resp, err := datasource.GetMetadataManager().GetServicesInfo(tenantContext(ctx), &pb.GetServicesInfoRequest{
+		Options:     []string{"instances"},
+		AppId:       appID,
+		ServiceName: serviceName,
+		CountOnly:   true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	versions := make(map[string]int64, len(resp.AllServicesDetail))
+	for _, detail := range resp.AllServicesDetail {
+		if detail.MicroService == nil || detail.Statics == nil || detail.Statics.Instances == nil {
+			continue
+		}
+		if count := detail.Statics.Instances.Count; count > 0 {
+			versions[detail.MicroService.Version] += count
+		}
+	}
+	return versions, nil
+}
+
+// BuildMatrixProvider matches the latest verification result of every live
+// provider version, the provider is deployable only when all of them succeed
+func BuildMatrixProvider(provider *brokerpb.Participant, liveVersions map[string]int64,
+	verifications []*brokerpb.Verification) *brokerpb.MatrixProvider {
+	latest := make(map[string]*brokerpb.Verification, len(verifications))
+	for _, verification := range verifications {
+		last, ok := latest[verification.ProviderVersion]
+		if !ok || verification.Number > last.Number ||
+			(verification.Number == last.Number && verification.Id > last.Id) {
+			latest[verification.ProviderVersion] = verification
+		}
+	}
+
+	versions := make([]string, 0, len(liveVersions))
+	for version := range liveVersions {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+
+	result := &brokerpb.MatrixProvider{
+		AppId:        provider.AppId,
+		ProviderName: provider.ServiceName,
+		Rows:         make([]*brokerpb.MatrixRow, 0, len(versions)),
+	}
+	if len(versions) == 0 {
+		result.Reason = reasonNoInstances
+		return result
+	}
+	unverified, failed := false, false
+	for _, version := range versions {
+		row := &brokerpb.MatrixRow{
+			ProviderVersion: version,
+			Instances:       liveVersions[version],
+		}
+		if verification, ok := latest[version]; ok {
+			row.Verified = true
+			row.Success = verification.Success
+			row.VerificationDate = verification.VerificationDate
+		}
+		switch {
+		case !row.Verified:
+			unverified = true
+		case !row.Success:
+			failed = true
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	switch {
+	case failed:
+		result.Reason = reasonFailed
+	case unverified:
+		result.Reason = reasonUnverified
+	default:
+		result.Deployable = true
+		result.Reason = reasonAllVerified
+	}
+	return result
+}
+
+// SummarizeMatrix counts the providers by result, a consumer version without
+// pacts has nothing to verify and is deployable
+func SummarizeMatrix(matrix []*brokerpb.MatrixProvider) *brokerpb.MatrixSummary {
+	summary := &brokerpb.MatrixSummary{}
+	for _, provider := range matrix {
+		switch {
+		case provider.Deployable:
+			summary.Success++
+		case provider.Reason == reasonFailed:
+			summary.Failed++
+		default:
+			summary.Unknown++
+		}
+	}
+	switch {
+	case len(matrix) == 0:
+		summary.Deployable = true
+		summary.Reason = reasonNoPacts
+	case summary.Failed == 0 && summary.Unknown == 0:
+		summary.Deployable = true
+		summary.Reason = reasonAllProviders
+	default:
+		summary.Reason = reasonSomeProvider
+	}
+	return summary
+}
@@ -0,0 +1,87 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package broker_test
+
+import (
+	"testing"
+
+	"github.com/apache/servicecomb-service-center/pkg/broker/brokerpb"
+	"github.com/apache/servicecomb-service-center/server/broker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildMatrixProvider(t *testing.T) {
+	provider := &brokerpb.Participant{Id: 1, AppId: "app", ServiceName: "provider"}
+	verifications := []*brokerpb.Verification{
+		{Id: 1, Number: 0, ProviderVersion: "1.0.0", Success: false},
+		{Id: 2, Number: 1, ProviderVersion: "1.0.0", Success: true},
+		{Id: 3, Number: 2, ProviderVersion: "2.0.0", Success: false},
+	}
+
+	t.Run("no instances should not be deployable", func(t *testing.T) {
+		result := broker.BuildMatrixProvider(provider, nil, verifications)
+		assert.False(t, result.Deployable)
+		assert.Empty(t, result.Rows)
+	})
+
+	t.Run("latest verification succeeded should be deployable", func(t *testing.T) {
+		result := broker.BuildMatrixProvider(provider, map[string]int64{"1.0.0": 2}, verifications)
+		assert.True(t, result.Deployable)
+		assert.Equal(t, 1, len(result.Rows))
+		assert.Equal(t, int64(2), result.Rows[0].Instances)
+		assert.True(t, result.Rows[0].Verified)
+		assert.True(t, result.Rows[0].Success)
+	})
+
+	t.Run("any live version failed should not be deployable", func(t *testing.T) {
+		result := broker.BuildMatrixProvider(provider, map[string]int64{"1.0.0": 1, "2.0.0": 1}, verifications)
+		assert.False(t, result.Deployable)
+		assert.Equal(t, 2, len(result.Rows))
+		assert.Equal(t, "1.0.0", result.Rows[0].ProviderVersion)
+		assert.Equal(t, "2.0.0", result.Rows[1].ProviderVersion)
+		assert.False(t, result.Rows[1].Success)
+	})
+
+	t.Run("live version not verified should not be deployable", func(t *testing.T) {
+		result := broker.BuildMatrixProvider(provider, map[string]int64{"1.0.0": 1, "3.0.0": 1}, verifications)
+		assert.False(t, result.Deployable)
+		assert.False(t, result.Rows[1].Verified)
+	})
+}
+
+func TestSummarizeMatrix(t *testing.T) {
+	provider := &brokerpb.Participant{Id: 1, AppId: "app", ServiceName: "provider"}
+	passed := broker.BuildMatrixProvider(provider, map[string]int64{"1.0.0": 1},
+		[]*brokerpb.Verification{{Id: 1, ProviderVersion: "1.0.0", Success: true}})
+	failed := broker.BuildMatrixProvider(provider, map[string]int64{"1.0.0": 1},
+		[]*brokerpb.Verification{{Id: 1, ProviderVersion: "1.0.0", Success: false}})
+	unknown := broker.BuildMatrixProvider(provider, map[string]int64{"1.0.0": 1}, nil)
+
+	summary := broker.SummarizeMatrix(nil)
+	assert.True(t, summary.Deployable)
+
+	summary = broker.SummarizeMatrix([]*brokerpb.MatrixProvider{passed})
+	assert.True(t, summary.Deployable)
+	assert.Equal(t, int32(1), summary.Success)
+
+	summary = broker.SummarizeMatrix([]*brokerpb.MatrixProvider{passed, failed, unknown})
+	assert.False(t, summary.Deployable)
+	assert.Equal(t, int32(1), summary.Success)
+	assert.Equal(t, int32(1), summary.Failed)
+	assert.Equal(t, int32(1), summary.Unknown)
+}
@@ -20,10 +20,12 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/broker/brokerpb"
 	"github.com/apache/servicecomb-service-center/pkg/util"
 	"github.com/apache/servicecomb-service-center/server/broker"
-	"github.com/apache/servicecomb-service-center/server/broker/brokerpb"
 	"github.com/apache/servicecomb-service-center/server/core"
+	discosvc "github.com/apache/servicecomb-service-center/server/service/disco"
 	pb "github.com/go-chassis/cari/discovery"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -189,13 +191,13 @@ var _ = Describe("Controller", func() {
 			It("PublishVerificationResults", func() {
 				fmt.Println("UT===========PublishVerificationResults")
 
-				id, err := broker.GetData(context.Background(), broker.GetBrokerLatestPactIDKey())
+				id, err := broker.GetLatestID(context.Background(), datasource.BrokerPact)
 				Expect(err).To(BeNil())
 				respResults, err := brokerResource.PublishVerificationResults(getContext(),
 					&brokerpb.PublishVerificationRequest{
 						ProviderId:                 providerServiceId,
 						ConsumerId:                 consumerServiceId,
-						PactId:                     int32(id),
+						PactId:                     id,
 						ProviderApplicationVersion: TEST_BROKER_PROVIDER_VERSION,
 					})
 
@@ -216,6 +218,61 @@ var _ = Describe("Controller", func() {
 				Expect(respVerification.Response.GetCode()).To(Equal(pb.ResponseSuccess))
 			})
 
+			It("CanIDeploy", func() {
+				fmt.Println("UT===========CanIDeploy")
+
+				respMatrix, err := brokerResource.CanIDeploy(getContext(),
+					&brokerpb.MatrixRequest{
+						ConsumerId:      consumerServiceId,
+						ConsumerVersion: TEST_BROKER_CONSUMER_VERSION,
+					})
+				Expect(err).To(BeNil())
+				Expect(respMatrix.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+				Expect(len(respMatrix.Matrix)).To(Equal(1))
+				Expect(respMatrix.Matrix[0].Deployable).To(BeFalse())
+				Expect(len(respMatrix.Matrix[0].Rows)).To(Equal(0))
+				Expect(respMatrix.Summary.Deployable).To(BeFalse())
+
+				respInstance, err := discosvc.RegisterInstance(getContext(), &pb.RegisterInstanceRequest{
+					Instance: &pb.MicroServiceInstance{
+						ServiceId: providerServiceId,
+						Endpoints: []string{
+							"broker:127.0.0.1:8080",
+						},
+						HostName: "UT-HOST",
+						Status:   pb.MSI_UP,
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(respInstance.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+
+				respMatrix, err = brokerResource.CanIDeploy(getContext(),
+					&brokerpb.MatrixRequest{
+						ConsumerId:      consumerServiceId,
+						ConsumerVersion: TEST_BROKER_CONSUMER_VERSION,
+					})
+				Expect(err).To(BeNil())
+				Expect(respMatrix.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+				Expect(len(respMatrix.Matrix)).To(Equal(1))
+				Expect(len(respMatrix.Matrix[0].Rows)).To(Equal(1))
+				Expect(respMatrix.Matrix[0].Rows[0].ProviderVersion).To(Equal(TEST_BROKER_PROVIDER_VERSION))
+				Expect(respMatrix.Matrix[0].Rows[0].Verified).To(BeTrue())
+				Expect(respMatrix.Matrix[0].Rows[0].Success).To(BeFalse())
+				Expect(respMatrix.Summary.Failed).To(Equal(int32(1)))
+				Expect(respMatrix.Summary.Deployable).To(BeFalse())
+			})
+
+			It("CanIDeploy-noConsumerVersion", func() {
+				fmt.Println("UT===========CanIDeploy, no consumer version")
+
+				respMatrix, _ := brokerResource.CanIDeploy(getContext(),
+					&brokerpb.MatrixRequest{
+						ConsumerId:      consumerServiceId,
+						ConsumerVersion: TEST_BROKER_NO_VERSION,
+					})
+				Expect(respMatrix.Response.GetCode()).ToNot(Equal(pb.ResponseSuccess))
+			})
+
 			It("RetrieveProviderPacts", func() {
 				fmt.Println("UT===========RetrieveProviderPacts")
 
@@ -87,7 +87,7 @@ func Init() {
 	setCPUs()
 
 	err := archaius.Init(archaius.WithMemorySource(), archaius.WithENVSource(),
-		archaius.WithOptionalFiles([]string{filepath.Join(util.GetAppRoot(), "conf", "app.yaml")}))
+		archaius.WithOptionalFiles([]string{ConfigFile()}))
 	if err != nil {
 		log.Fatal("can not init archaius", err)
 	}
@@ -97,11 +97,18 @@ func Init() {
 		log.Fatal("reload configs failed", err)
 	}
 
+	watchConfigFile()
+
 	plugin.RegisterConfigurator(App)
 
 	version.Ver().Log()
 }
 
+//ConfigFile return the path of configuration file
+func ConfigFile() string {
+	return filepath.Join(util.GetAppRoot(), "conf", "app.yaml")
+}
+
 //Reload reload the all configurations
 func Reload() error {
 	err := archaius.UnmarshalConfig(App)
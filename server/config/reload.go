@@ -0,0 +1,228 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chassis/go-archaius"
+	"github.com/go-chassis/go-archaius/event"
+	filesource "github.com/go-chassis/go-archaius/source/file"
+	archaiusutil "github.com/go-chassis/go-archaius/source/util"
+
+	"github.com/apache/servicecomb-service-center/pkg/log"
+)
+
+// reloadDelay merges the file changes in a short time into one reload
+const reloadDelay = time.Second
+
+// hotKeys are the prefixes of the configurations which can be reloaded
+// at runtime, the others require restart to take effect
+var hotKeys = []string{
+	"log.level",
+	"log.accessEnable",
+	"quota.cap.",
+	"heartbeat.websocket.pingInterval",
+	"rbac.releaseLockAfter",
+}
+
+var (
+	reloadLock  sync.Mutex
+	reloadFuncs []func()
+	// fileConfigs is the snapshot of the configuration file last loaded
+	fileConfigs map[string]interface{}
+	// overrides are the keys set to memory source by ReloadFile, they
+	// are released after the file source catches up the changes, archaius
+	// ignores the file events of the keys overridden by memory source
+	overrides = make(map[string]struct{})
+	// pending are the keys changed by file watcher but not reloaded
+	pending = make(map[string]struct{})
+)
+
+// ReloadResult lists the changed configurations
+type ReloadResult struct {
+	Reloaded       []string
+	RequireRestart []string
+}
+
+// RegisterReloadFunc registers the function to apply the configurations
+// after reloading at runtime
+func RegisterReloadFunc(f func()) {
+	reloadLock.Lock()
+	reloadFuncs = append(reloadFuncs, f)
+	reloadLock.Unlock()
+}
+
+// IsHotReloadable returns true if the configuration can be reloaded at runtime
+func IsHotReloadable(key string) bool {
+	for _, hot := range hotKeys {
+		if key == hot || (strings.HasSuffix(hot, ".") && strings.HasPrefix(key, hot)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReloadFile re-reads the configuration file, then reloads the hot
+// reloadable changes and reports the changes require restart
+func ReloadFile() (*ReloadResult, error) {
+	configs, err := readConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+
+	var keys []string
+	for key, value := range configs {
+		if old, ok := fileConfigs[key]; !ok || !reflect.DeepEqual(old, value) {
+			keys = append(keys, key)
+		}
+	}
+	for key := range fileConfigs {
+		if _, ok := configs[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	result := classify(keys)
+	if len(overrides) == 0 && len(result.Reloaded) > 0 {
+		time.AfterFunc(reloadDelay, releaseOverrides)
+	}
+	for _, key := range result.Reloaded {
+		value, ok := configs[key]
+		if !ok {
+			// removed, the file source keeps the old one until the watcher catches up
+			continue
+		}
+		if err := archaius.Set(key, value); err != nil {
+			return nil, err
+		}
+		overrides[key] = struct{}{}
+	}
+	fileConfigs = configs
+	apply()
+	return result, nil
+}
+
+func readConfigFile() (map[string]interface{}, error) {
+	file := ConfigFile()
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read config file failed, %w", err)
+	}
+	return archaiusutil.Convert2JavaProps(file, content)
+}
+
+func classify(keys []string) *ReloadResult {
+	result := &ReloadResult{}
+	for _, key := range keys {
+		if IsHotReloadable(key) {
+			result.Reloaded = append(result.Reloaded, key)
+			continue
+		}
+		result.RequireRestart = append(result.RequireRestart, key)
+	}
+	sort.Strings(result.Reloaded)
+	sort.Strings(result.RequireRestart)
+	return result
+}
+
+// apply must be called with reloadLock held, Server.Config keeps the
+// values loaded at startup, the reload functions read the hot keys
+// from archaius and store them safely for concurrent use
+func apply() {
+	for _, f := range reloadFuncs {
+		f()
+	}
+}
+
+// fileListener receives the changes of configuration file from archaius
+type fileListener struct {
+}
+
+func (l *fileListener) Event(evt *event.Event) {
+	if evt.EventSource != filesource.FileConfigSourceConst {
+		return
+	}
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+	if len(pending) == 0 {
+		time.AfterFunc(reloadDelay, reloadPending)
+	}
+	pending[evt.Key] = struct{}{}
+}
+
+func reloadPending() {
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+
+	keys := make([]string, 0, len(pending))
+	for key := range pending {
+		keys = append(keys, key)
+	}
+	pending = make(map[string]struct{})
+
+	if configs, err := readConfigFile(); err == nil {
+		fileConfigs = configs
+	}
+	result := classify(keys)
+	apply()
+	log.Infof("config file changed, reloaded %v", result.Reloaded)
+	if len(result.RequireRestart) > 0 {
+		log.Warnf("config file changed, %v require restart to take effect", result.RequireRestart)
+	}
+}
+
+// releaseOverrides removes the overrides of memory source, the values of
+// file source take effect again
+func releaseOverrides() {
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+
+	for key := range overrides {
+		if err := archaius.Delete(key); err != nil {
+			log.Errorf(err, "release the reloaded config[%s] failed", key)
+		}
+	}
+	overrides = make(map[string]struct{})
+	apply()
+}
+
+// watchConfigFile listens the changes of configuration file, archaius
+// file source watches the file and dispatches the changes
+func watchConfigFile() {
+	configs, err := readConfigFile()
+	if err != nil {
+		log.Warnf("skip watching config file, %s", err.Error())
+		return
+	}
+	reloadLock.Lock()
+	fileConfigs = configs
+	reloadLock.Unlock()
+
+	if err := archaius.RegisterListener(&fileListener{}, ".*"); err != nil {
+		log.Errorf(err, "watch config file failed")
+	}
+}
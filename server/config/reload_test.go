@@ -0,0 +1,86 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/config"
+	"github.com/go-chassis/go-archaius"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsHotReloadable(t *testing.T) {
+	assert.True(t, config.IsHotReloadable("log.level"))
+	assert.True(t, config.IsHotReloadable("quota.cap.service.limit"))
+	assert.False(t, config.IsHotReloadable("server.limit.connections"))
+	assert.False(t, config.IsHotReloadable("log.levels"))
+	assert.False(t, config.IsHotReloadable("quota.cap"))
+	assert.False(t, config.IsHotReloadable("server.port"))
+}
+
+func TestReloadFile(t *testing.T) {
+	defer archaius.Clean()
+	dir := filepath.Join(util.GetAppRoot(), "conf")
+	defer os.Remove(dir)
+	os.Mkdir(dir, 0750)
+	file := filepath.Join(dir, "app.yaml")
+	defer os.Remove(file)
+
+	err := ioutil.WriteFile(file, []byte(`
+server:
+  port: 30100
+log:
+  level: INFO
+`), 0640)
+	assert.NoError(t, err)
+	config.Init()
+	assert.Equal(t, "INFO", config.GetLog().LogLevel)
+
+	var reloaded bool
+	config.RegisterReloadFunc(func() {
+		reloaded = true
+	})
+
+	err = ioutil.WriteFile(file, []byte(`
+server:
+  port: 30101
+  limit:
+    connections: 100
+log:
+  level: ERROR
+`), 0640)
+	assert.NoError(t, err)
+	result, err := config.ReloadFile()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"log.level"}, result.Reloaded)
+	assert.Equal(t, []string{"server.limit.connections", "server.port"}, result.RequireRestart)
+	assert.Equal(t, "ERROR", config.GetString("log.level", ""))
+	assert.True(t, reloaded)
+
+	t.Run("reload again, should be no changes", func(t *testing.T) {
+		result, err := config.ReloadFile()
+		assert.NoError(t, err)
+		assert.Empty(t, result.Reloaded)
+		assert.Empty(t, result.RequireRestart)
+	})
+}
@@ -30,6 +30,7 @@ const (
 type AppConfig struct {
 	Gov    *Gov          `yaml:"gov"`
 	Server *ServerConfig `yaml:"server"`
+	Alarm  *Alarm        `yaml:"alarm"`
 }
 type Gov struct {
 	DistOptions []DistributorOptions `yaml:"plugins"`
@@ -38,6 +39,23 @@ type DistributorOptions struct {
 	Name     string `yaml:"name"`
 	Type     string `yaml:"type"`
 	Endpoint string `yaml:"endpoint"`
+	// Primary distributor serves the reads, if no one is primary, use the first one
+	Primary bool `yaml:"primary"`
+}
+
+type Alarm struct {
+	// HistoryTTL is the retention of the alarm histories, e.g. 168h
+	HistoryTTL string            `yaml:"historyTTL"`
+	Notifiers  []NotifierOptions `yaml:"notifiers"`
+}
+type NotifierOptions struct {
+	Name     string            `yaml:"name"`
+	Type     string            `yaml:"type"`
+	Endpoint string            `yaml:"endpoint"`
+	Headers  map[string]string `yaml:"headers"`
+	Timeout  string            `yaml:"timeout"`
+	// Alarms filters the alarm IDs to notify, empty means all
+	Alarms []string `yaml:"alarms"`
 }
 
 // GetImplName return the impl name
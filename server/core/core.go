@@ -35,6 +35,11 @@ func Initialize() {
 	RegisterGlobalServices()
 	// Logging
 	initLogger()
+	config.RegisterReloadFunc(reloadLogger)
+}
+
+func reloadLogger() {
+	log.SetLevel(config.GetString("log.level", "", config.WithStandby("loglevel")))
 }
 
 func initLogger() {
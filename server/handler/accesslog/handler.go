@@ -21,6 +21,8 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/apache/servicecomb-service-center/server/config"
@@ -28,14 +30,19 @@ import (
 	"github.com/apache/servicecomb-service-center/pkg/chain"
 	"github.com/apache/servicecomb-service-center/pkg/log"
 	"github.com/apache/servicecomb-service-center/pkg/rest"
+	"github.com/apache/servicecomb-service-center/pkg/rpc"
 	"github.com/apache/servicecomb-service-center/pkg/util"
 )
 
+// enabled is 1 if access log is enabled, it can be reloaded at runtime
+var enabled int32
+
 // Handler implements chain.Handler
 // Handler records access log.
 // Make sure to complete the initialization before handling the request.
 type Handler struct {
 	logger        *log.Logger
+	loggerOnce    sync.Once
 	whiteListAPIs map[string]struct{} // not record access log
 }
 
@@ -56,11 +63,17 @@ func (h *Handler) ShouldIgnoreAPI(api string) bool {
 
 // Handle handles the request
 func (h *Handler) Handle(i *chain.Invocation) {
+	// access log can be enabled or disabled at runtime
+	if atomic.LoadInt32(&enabled) == 0 {
+		i.Next()
+		return
+	}
 	matchPattern := i.Context().Value(rest.CtxMatchPattern).(string)
 	if h.ShouldIgnoreAPI(matchPattern) {
 		i.Next()
 		return
 	}
+	logger := h.getLogger()
 	startTimeStr := "unknown"
 	start, ok := i.Context().Value(rest.CtxStartTimestamp).(time.Time)
 	if ok {
@@ -75,7 +88,7 @@ func (h *Handler) Handle(i *chain.Invocation) {
 		statusCode := i.Context().Value(rest.CtxResponseStatus).(int)
 		// format:  remoteIp requestReceiveTime "method requestUri proto" statusCode requestBodySize delay(ms)
 		// example: 127.0.0.1 2006-01-02T15:04:05.000Z07:00 "GET /v4/default/registry/microservices HTTP/1.1" 200 0 0
-		h.logger.Infof("%s %s \"%s %s %s\" %d %d %s",
+		logger.Infof("%s %s \"%s %s %s\" %d %d %s",
 			util.GetIPFromContext(i.Context()),
 			startTimeStr,
 			r.Method,
@@ -94,21 +107,31 @@ func NewAccessLogHandler(l *log.Logger) *Handler {
 		whiteListAPIs: make(map[string]struct{})}
 }
 
-// RegisterHandlers registers an access log handler to the handler chain
-func RegisterHandlers() {
-	if !config.GetLog().EnableAccessLog {
-		return
-	}
-	logger := log.NewLogger(log.Config{
-		LoggerFile:     os.ExpandEnv(config.GetLog().AccessLogFile),
-		LogFormatText:  true,
-		LogRotateSize:  int(config.GetLog().LogRotateSize),
-		LogBackupCount: int(config.GetLog().LogBackupCount),
-		NoCaller:       true,
-		NoTime:         true,
-		NoLevel:        true,
+// getLogger creates the access log file when the first access log is recording
+func (h *Handler) getLogger() *log.Logger {
+	h.loggerOnce.Do(func() {
+		if h.logger != nil {
+			return
+		}
+		h.logger = log.NewLogger(log.Config{
+			LoggerFile:     os.ExpandEnv(config.GetLog().AccessLogFile),
+			LogFormatText:  true,
+			LogRotateSize:  int(config.GetLog().LogRotateSize),
+			LogBackupCount: int(config.GetLog().LogBackupCount),
+			NoCaller:       true,
+			NoTime:         true,
+			NoLevel:        true,
+		})
 	})
-	h := NewAccessLogHandler(logger)
+	return h.logger
+}
+
+// RegisterHandlers registers an access log handler to the handler chain,
+// the handler records nothing if access log is disabled
+func RegisterHandlers() {
+	loadEnabled()
+	config.RegisterReloadFunc(loadEnabled)
+	h := NewAccessLogHandler(nil)
 	// no access log for heartbeat
 	h.AddWhiteListAPIs(
 		"/v4/:project/registry/microservices/:serviceId/instances/:instanceId/heartbeat",
@@ -117,4 +140,13 @@ func RegisterHandlers() {
 		"/registry/v3/heartbeats",
 		"")
 	chain.RegisterHandler(rest.ServerChainName, h)
+	chain.RegisterHandler(rpc.ServerChainName, h)
+}
+
+func loadEnabled() {
+	var v int32
+	if config.GetBool("log.accessEnable", false, config.WithStandby("enable_access_log")) {
+		v = 1
+	}
+	atomic.StoreInt32(&enabled, v)
 }
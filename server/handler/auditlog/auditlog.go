@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auditlog
+
+import (
+	"net/http"
+
+	"github.com/apache/servicecomb-service-center/pkg/chain"
+	"github.com/apache/servicecomb-service-center/pkg/rest"
+	"github.com/apache/servicecomb-service-center/pkg/rpc"
+	"github.com/apache/servicecomb-service-center/server/config"
+	"github.com/apache/servicecomb-service-center/server/plugin/auditlog"
+	rbacsvc "github.com/apache/servicecomb-service-center/server/service/rbac"
+)
+
+// no audit log for heartbeat
+var whiteListAPIs = map[string]struct{}{
+	"/v4/:project/registry/microservices/:serviceId/instances/:instanceId/heartbeat": {},
+	"/v4/:project/registry/heartbeats":                                               {},
+	"/registry/v3/microservices/:serviceId/instances/:instanceId/heartbeat":          {},
+	"/registry/v3/heartbeats":                                                        {},
+}
+
+// Handler implements chain.Handler
+// Handler records the audit log of the mutating requests.
+type Handler struct {
+}
+
+func (h *Handler) Handle(i *chain.Invocation) {
+	r, pattern := i.Context().Value(rest.CtxRequest).(*http.Request),
+		i.Context().Value(rest.CtxMatchPattern).(string)
+	if _, ok := whiteListAPIs[pattern]; ok || !IsMutating(r.Method) {
+		i.Next()
+		return
+	}
+	i.Next(chain.WithAsyncFunc(func(_ chain.Result) {
+		w := i.Context().Value(rest.CtxResponse).(http.ResponseWriter)
+		auditlog.Record(r, w.Header())
+	}))
+}
+
+// IsMutating returns true if the request with the method changes any resources
+func IsMutating(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
+
+// RegisterHandlers registers an audit log handler to the handler chain
+func RegisterHandlers() {
+	if !config.GetBool("auditlog.enable", false) {
+		return
+	}
+	// the audit log records the resource type of request even if rbac is disabled
+	rbacsvc.InitResourceMap()
+	chain.RegisterHandler(rest.ServerChainName, &Handler{})
+	chain.RegisterHandler(rpc.ServerChainName, &Handler{})
+}
@@ -26,8 +26,9 @@ import (
 )
 
 const (
-	instanceSize   = 5 * 1024 // 5KB
-	propertiesSize = 3 * 1024 // 3KB
+	instanceSize   = 5 * 1024         // 5KB
+	propertiesSize = 3 * 1024         // 3KB
+	archiveSize    = 64 * 1024 * 1024 // 64MB
 )
 
 var resourcesMap = map[string]int64{
@@ -39,6 +40,8 @@ var resourcesMap = map[string]int64{
 
 	"/registry/v3/microservices/:serviceId/instances/:instanceId/properties":          propertiesSize,
 	"/v4/:project/registry/microservices/:serviceId/instances/:instanceId/properties": propertiesSize,
+
+	"/v4/:project/admin/import": archiveSize,
 }
 
 type Handler struct {
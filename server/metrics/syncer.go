@@ -0,0 +1,62 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"github.com/apache/servicecomb-service-center/pkg/metrics"
+	helper "github.com/apache/servicecomb-service-center/pkg/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	syncerWatcherGauge = helper.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metrics.FamilyName,
+			Subsystem: "syncer",
+			Name:      "watcher_total",
+			Help:      "Gauge of syncer watchers",
+		}, []string{"instance"})
+
+	syncerDroppedCounter = helper.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metrics.FamilyName,
+			Subsystem: "syncer",
+			Name:      "dropped_total",
+			Help:      "Counter of instance events dropped because the watcher queue is full",
+		}, []string{"instance"})
+
+	syncerOverflowCounter = helper.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metrics.FamilyName,
+			Subsystem: "syncer",
+			Name:      "resume_overflow_total",
+			Help:      "Counter of resumed watches whose token is out of the event history",
+		}, []string{"instance"})
+)
+
+func ReportSyncerWatcher(n float64) {
+	syncerWatcherGauge.WithLabelValues(metrics.InstanceName()).Add(n)
+}
+
+func ReportSyncerEventDropped() {
+	syncerDroppedCounter.WithLabelValues(metrics.InstanceName()).Inc()
+}
+
+func ReportSyncerResumeOverflow() {
+	syncerOverflowCounter.WithLabelValues(metrics.InstanceName()).Inc()
+}
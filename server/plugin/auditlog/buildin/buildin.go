@@ -0,0 +1,168 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package buildin
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	rbacmodel "github.com/go-chassis/cari/rbac"
+
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/plugin"
+	"github.com/apache/servicecomb-service-center/pkg/rest"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/config"
+	"github.com/apache/servicecomb-service-center/server/plugin/auditlog"
+	rbacsvc "github.com/apache/servicecomb-service-center/server/service/rbac"
+)
+
+const (
+	OutputFile   = "file"
+	OutputStdout = "stdout"
+
+	defaultFile  = "./audit.log"
+	paramPrefix  = ":"
+	paramProject = ":project"
+)
+
+func init() {
+	plugin.RegisterPlugin(plugin.Plugin{Kind: auditlog.AUDITLOG, Name: "buildin", New: New})
+}
+
+func New() plugin.Instance {
+	output := config.GetString("auditlog.type", OutputFile, config.WithENV("AUDIT_LOG_TYPE"))
+	if output == OutputStdout {
+		return &Logger{w: NewWriter(os.Stdout)}
+	}
+
+	path := os.ExpandEnv(config.GetString("auditlog.file", defaultFile, config.WithENV("AUDIT_LOG_FILE")))
+	w, err := NewFileWriter(path)
+	if err != nil {
+		log.Errorf(err, "open audit log file %s failed, use stdout instead", path)
+		return &Logger{w: NewWriter(os.Stdout)}
+	}
+	return &Logger{w: w}
+}
+
+// Entry is the audit log record of one request
+type Entry struct {
+	Time       string            `json:"time"`
+	Account    string            `json:"account,omitempty"`
+	RemoteIP   string            `json:"remoteIP,omitempty"`
+	Domain     string            `json:"domain,omitempty"`
+	Project    string            `json:"project,omitempty"`
+	Method     string            `json:"method"`
+	Route      string            `json:"route"`
+	URI        string            `json:"uri"`
+	Resource   string            `json:"resource,omitempty"`
+	StatusCode int               `json:"statusCode"`
+	Latency    int64             `json:"latency"` // ms
+	EntityIDs  map[string]string `json:"entityIds,omitempty"`
+}
+
+// Logger writes the JSON format audit log records
+type Logger struct {
+	w *Writer
+}
+
+func (l *Logger) Record(r *http.Request, responseHeaders http.Header) {
+	df, ok := plugin.DynamicPluginFunc(auditlog.AUDITLOG, "Record").(func(*http.Request, http.Header))
+	if ok {
+		df(r, responseHeaders)
+		return
+	}
+
+	b, err := json.Marshal(NewEntry(r))
+	if err != nil {
+		log.Error("marshal audit log entry failed", err)
+		return
+	}
+	if err := l.w.WriteLine(b); err != nil {
+		log.Error("write audit log failed", err)
+	}
+}
+
+// NewEntry parses the audit log entry from the finished request
+func NewEntry(r *http.Request) *Entry {
+	ctx := r.Context()
+	route, _ := ctx.Value(rest.CtxMatchPattern).(string)
+	statusCode, _ := ctx.Value(rest.CtxResponseStatus).(int)
+	entry := &Entry{
+		Time:       time.Now().Format("2006-01-02T15:04:05.000Z07:00"),
+		Account:    rbacsvc.UserFromContext(ctx),
+		RemoteIP:   util.GetIPFromContext(ctx),
+		Domain:     util.ParseDomain(ctx),
+		Project:    util.ParseProject(ctx),
+		Method:     r.Method,
+		Route:      route,
+		URI:        r.URL.Path,
+		Resource:   rbacmodel.GetResource(route),
+		StatusCode: statusCode,
+		EntityIDs:  entityIDs(r),
+	}
+	if start, ok := ctx.Value(rest.CtxStartTimestamp).(time.Time); ok {
+		entry.Latency = int64(time.Since(start) / time.Millisecond)
+	}
+	return entry
+}
+
+// entityIDs returns the IDs of the changed entities, which come from the
+// path parameters, e.g. ':serviceId', and the IDs in the response object
+// of the create requests, e.g. 'instanceId' of the register instance response
+func entityIDs(r *http.Request) map[string]string {
+	ids := make(map[string]string)
+	for key, values := range r.URL.Query() {
+		if !strings.HasPrefix(key, paramPrefix) || key == paramProject || len(values) == 0 {
+			continue
+		}
+		ids[key[len(paramPrefix):]] = values[0]
+	}
+
+	switch obj := r.Context().Value(rest.CtxResponseObject).(type) {
+	case nil, string, []byte:
+	default:
+		b, err := json.Marshal(obj)
+		if err != nil {
+			break
+		}
+		m := make(map[string]interface{})
+		if json.Unmarshal(b, &m) != nil {
+			break
+		}
+		for key, value := range m {
+			id, ok := value.(string)
+			if !ok || len(id) == 0 || !isIDKey(key) {
+				continue
+			}
+			ids[key] = id
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+	return ids
+}
+
+func isIDKey(key string) bool {
+	return key == "id" || strings.HasSuffix(key, "Id") || strings.HasSuffix(key, "ID")
+}
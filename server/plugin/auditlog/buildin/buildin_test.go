@@ -0,0 +1,92 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package buildin_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-chassis/cari/discovery"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/servicecomb-service-center/pkg/rest"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/plugin/auditlog/buildin"
+	rbacsvc "github.com/apache/servicecomb-service-center/server/service/rbac"
+)
+
+func newRequest() *http.Request {
+	r, _ := http.NewRequest(http.MethodPost,
+		"http://127.0.0.1:30100/v4/default/registry/microservices/s1/instances?:project=default&:serviceId=s1", nil)
+	ctx := util.NewStringContext(context.Background())
+	ctx.SetKV(rest.CtxMatchPattern, "/v4/:project/registry/microservices/:serviceId/instances")
+	ctx.SetKV(rest.CtxResponseStatus, http.StatusOK)
+	ctx.SetKV(rest.CtxStartTimestamp, time.Now().Add(-time.Second))
+	ctx.SetKV(rest.CtxResponseObject, &discovery.RegisterInstanceResponse{InstanceId: "i1"})
+	ctx.SetKV(rbacsvc.CtxRequestClaims, map[string]interface{}{"account": "root"})
+	ctx.SetKV(util.CtxDomain, "default")
+	ctx.SetKV(util.CtxProject, "default")
+	return r.WithContext(ctx)
+}
+
+func TestNewEntry(t *testing.T) {
+	entry := buildin.NewEntry(newRequest())
+	assert.Equal(t, "root", entry.Account)
+	assert.Equal(t, "default", entry.Domain)
+	assert.Equal(t, "default", entry.Project)
+	assert.Equal(t, http.MethodPost, entry.Method)
+	assert.Equal(t, "/v4/:project/registry/microservices/:serviceId/instances", entry.Route)
+	assert.Equal(t, http.StatusOK, entry.StatusCode)
+	assert.True(t, entry.Latency >= 1000)
+	assert.Equal(t, map[string]string{"serviceId": "s1", "instanceId": "i1"}, entry.EntityIDs)
+}
+
+func TestWriter_WriteLine(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auditlog")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "audit.log")
+	w, err := buildin.NewFileWriter(path)
+	assert.NoError(t, err)
+	defer w.Close()
+
+	assert.NoError(t, w.WriteLine([]byte(`{"a":1}`)))
+	// re-create the file if removed
+	assert.NoError(t, os.Remove(path))
+	b, err := json.Marshal(buildin.NewEntry(newRequest()))
+	assert.NoError(t, err)
+	assert.NoError(t, w.WriteLine(b))
+
+	content, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	lines := bytes.Split(bytes.TrimSpace(content), []byte("\n"))
+	assert.Equal(t, 1, len(lines))
+
+	var entry buildin.Entry
+	assert.NoError(t, json.Unmarshal(lines[0], &entry))
+	assert.Equal(t, "root", entry.Account)
+	assert.Equal(t, "i1", entry.EntityIDs["instanceId"])
+}
@@ -0,0 +1,132 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package buildin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/servicecomb-service-center/pkg/gopool"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/config"
+)
+
+const rotateInterval = 100 * time.Second
+
+// Writer writes the lines to the underlying io.Writer, and if it is a file,
+// rotates it like the other log files of service center.
+type Writer struct {
+	w         io.Writer
+	fd        *os.File
+	lock      sync.Mutex
+	goroutine *gopool.Pool
+}
+
+func (w *Writer) WriteLine(b []byte) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.fd != nil {
+		if err := w.checkFile(); err != nil {
+			return err
+		}
+	}
+	_, err := w.w.Write(append(b, '\n'))
+	return err
+}
+
+func (w *Writer) Close() error {
+	if w.goroutine != nil {
+		w.goroutine.Close(true)
+	}
+	if w.fd == nil {
+		return nil
+	}
+	return w.fd.Close()
+}
+
+// checkFile re-creates the audit log file if it was removed
+func (w *Writer) checkFile() error {
+	name := w.fd.Name()
+	if util.PathExist(name) || strings.Index(name, "/dev/") == 0 {
+		return nil
+	}
+
+	stat, err := w.fd.Stat()
+	if err != nil {
+		return fmt.Errorf("stat %s: %s", name, err)
+	}
+
+	log.Warnf("audit log file %s does not exist, re-create one", name)
+	fd, err := os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_RDWR, stat.Mode())
+	if err != nil {
+		return fmt.Errorf("open %s: %s", name, err)
+	}
+
+	var old *os.File
+	w.fd, old = fd, w.fd
+	w.w = fd
+	if err := old.Close(); err != nil {
+		log.Errorf(err, "close %s", name)
+	}
+	return nil
+}
+
+func (w *Writer) rotate() {
+	w.goroutine.Do(func(ctx context.Context) {
+		t := time.NewTicker(rotateInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				w.lock.Lock()
+				log.RotateFile(w.fd.Name(),
+					int(config.GetLog().LogRotateSize),
+					int(config.GetLog().LogBackupCount),
+				)
+				w.lock.Unlock()
+			}
+		}
+	})
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+func NewFileWriter(path string) (*Writer, error) {
+	fd, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	w := &Writer{
+		w:         fd,
+		fd:        fd,
+		goroutine: gopool.New(context.Background()),
+	}
+	w.rotate()
+	return w, nil
+}
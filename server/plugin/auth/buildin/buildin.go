@@ -144,7 +144,6 @@ func checkPerm(roleList []string, project string, req *http.Request, apiPattern,
 	if !ok || targetResource == nil {
 		return false, nil, errors.New("no valid resouce scope")
 	}
-	//TODO add project
 	return rbacsvc.Allow(req.Context(), project, normalRoles, targetResource)
 }
 
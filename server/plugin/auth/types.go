@@ -26,3 +26,26 @@ type ResourceScope struct {
 	// Verb is the apply resource action, e.g. "get", "create"
 	Verb string
 }
+
+// LabelDeny is the reserved label key marks the label set denied, the resources
+// matched by a denied label set are removed from the results even if allowed
+const LabelDeny = "!"
+
+// SplitLabels separates the denied label sets from the allowed ones,
+// the LabelDeny key is removed from the denied label sets
+func SplitLabels(labelsList []map[string]string) (allows, denies []map[string]string) {
+	for _, labels := range labelsList {
+		if _, ok := labels[LabelDeny]; !ok {
+			allows = append(allows, labels)
+			continue
+		}
+		deny := make(map[string]string, len(labels)-1)
+		for k, v := range labels {
+			if k != LabelDeny {
+				deny[k] = v
+			}
+		}
+		denies = append(denies, deny)
+	}
+	return
+}
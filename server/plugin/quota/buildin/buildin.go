@@ -22,7 +22,10 @@ import (
 
 	"github.com/apache/servicecomb-service-center/pkg/log"
 	"github.com/apache/servicecomb-service-center/pkg/plugin"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/config"
 	"github.com/apache/servicecomb-service-center/server/plugin/quota"
+	quotasvc "github.com/apache/servicecomb-service-center/server/service/quota"
 )
 
 func init() {
@@ -31,38 +34,54 @@ func init() {
 
 func New() plugin.Instance {
 	quota.Init()
+	config.RegisterReloadFunc(quota.Init)
 	log.Infof("quota init, service: %d, instance: %d, schema: %d/service, tag: %d/service, rule: %d/service"+
 		", account: %d, role: %d",
-		quota.DefaultServiceQuota, quota.DefaultInstanceQuota,
-		quota.DefaultSchemaQuota, quota.DefaultTagQuota, quota.DefaultRuleQuota,
-		quota.DefaultAccountQuota, quota.DefaultRoleQuota)
+		quota.DefaultServiceQuota(), quota.DefaultInstanceQuota(),
+		quota.DefaultSchemaQuota(), quota.DefaultTagQuota(), quota.DefaultRuleQuota(),
+		quota.DefaultAccountQuota(), quota.DefaultRoleQuota())
 	return &Quota{}
 }
 
 type Quota struct {
 }
 
+// GetQuota returns the limit override of the domain project if it was set,
+// otherwise returns the global limit
 func (q *Quota) GetQuota(ctx context.Context, t quota.ResourceType) int64 {
+	if limit, ok := getOverride(ctx, t); ok {
+		return limit
+	}
 	switch t {
 	case quota.TypeInstance:
-		return int64(quota.DefaultInstanceQuota)
+		return int64(quota.DefaultInstanceQuota())
 	case quota.TypeService:
-		return int64(quota.DefaultServiceQuota)
+		return int64(quota.DefaultServiceQuota())
 	case quota.TypeRule:
-		return int64(quota.DefaultRuleQuota)
+		return int64(quota.DefaultRuleQuota())
 	case quota.TypeSchema:
-		return int64(quota.DefaultSchemaQuota)
+		return int64(quota.DefaultSchemaQuota())
 	case quota.TypeTag:
-		return int64(quota.DefaultTagQuota)
+		return int64(quota.DefaultTagQuota())
 	case quota.TypeAccount:
-		return int64(quota.DefaultAccountQuota)
+		return int64(quota.DefaultAccountQuota())
 	case quota.TypeRole:
-		return int64(quota.DefaultRoleQuota)
+		return int64(quota.DefaultRoleQuota())
 	default:
 		return 0
 	}
 }
 
+func getOverride(ctx context.Context, t quota.ResourceType) (int64, bool) {
+	override, err := quotasvc.GetCachedLimits(ctx)
+	if err != nil {
+		log.Errorf(err, "get quota of domain project[%s] failed, use the global limit", util.ParseDomainProject(ctx))
+		return 0, false
+	}
+	limit, ok := override.Limits[t.Name()]
+	return limit, ok
+}
+
 //向配额中心上报配额使用量
 func (q *Quota) RemandQuotas(ctx context.Context, quotaType quota.ResourceType) {
 	df, ok := plugin.DynamicPluginFunc(quota.QUOTA, "RemandQuotas").(func(context.Context, quota.ResourceType))
@@ -29,6 +29,8 @@ import (
 
 	_ "github.com/apache/servicecomb-service-center/server/bootstrap"
 	"github.com/apache/servicecomb-service-center/server/plugin/quota"
+	"github.com/apache/servicecomb-service-center/server/plugin/quota/buildin"
+	quotasvc "github.com/apache/servicecomb-service-center/server/service/quota"
 	pb "github.com/go-chassis/cari/discovery"
 	"github.com/go-chassis/go-archaius"
 	"github.com/stretchr/testify/assert"
@@ -84,3 +86,33 @@ func TestGetResourceLimit(t *testing.T) {
 	})
 
 }
+
+func TestGetQuota(t *testing.T) {
+	ctx := util.SetDomainProject(context.TODO(), "quota_override", "quota_override")
+	q := &buildin.Quota{}
+	t.Run("without override, should return the global limit", func(t *testing.T) {
+		assert.Equal(t, int64(quota.DefaultInstanceQuota()), q.GetQuota(ctx, quota.TypeInstance))
+	})
+	t.Run("with override, should return the domain project limit", func(t *testing.T) {
+		_, err := quotasvc.PutLimits(ctx, "quota_override", "quota_override", map[string]int64{"instance": 1})
+		assert.NoError(t, err)
+		defer quotasvc.DeleteLimits(ctx, "quota_override", "quota_override")
+
+		assert.Equal(t, int64(1), q.GetQuota(ctx, quota.TypeInstance))
+		assert.Equal(t, int64(quota.DefaultServiceQuota()), q.GetQuota(ctx, quota.TypeService))
+		assert.Equal(t, int64(quota.DefaultInstanceQuota()),
+			q.GetQuota(util.SetDomainProject(context.TODO(), "quota_override", "other"), quota.TypeInstance))
+
+		resp, err := discosvc.RegisterService(ctx, &pb.CreateServiceRequest{
+			Service: &pb.MicroService{
+				ServiceName: "quota_override",
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, pb.ResponseSuccess, resp.Response.GetCode())
+
+		res := quota.NewApplyQuotaResource(quota.TypeInstance, "quota_override/quota_override", resp.ServiceId, 2)
+		err = quota.Apply(ctx, res)
+		assert.NotNil(t, err)
+	})
+}
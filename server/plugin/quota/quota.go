@@ -22,9 +22,11 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"sync/atomic"
 
 	"github.com/apache/servicecomb-service-center/pkg/log"
 	"github.com/apache/servicecomb-service-center/pkg/plugin"
+	"github.com/apache/servicecomb-service-center/pkg/quota/model"
 	"github.com/apache/servicecomb-service-center/pkg/util"
 	"github.com/apache/servicecomb-service-center/server/config"
 	"github.com/apache/servicecomb-service-center/server/service/quota"
@@ -54,24 +56,57 @@ const (
 	TypeRole
 )
 
+// the global limits can be reloaded at runtime, read them by the Default*Quota functions
 var (
-	DefaultServiceQuota  = defaultServiceLimit
-	DefaultInstanceQuota = defaultInstanceLimit
-	DefaultSchemaQuota   = defaultSchemaLimit
-	DefaultTagQuota      = defaultTagLimit
-	DefaultRuleQuota     = defaultRuleLimit
-	DefaultAccountQuota  = defaultAccountLimit
-	DefaultRoleQuota     = defaultRoleLimit
+	serviceQuota  int64 = defaultServiceLimit
+	instanceQuota int64 = defaultInstanceLimit
+	schemaQuota   int64 = defaultSchemaLimit
+	tagQuota      int64 = defaultTagLimit
+	ruleQuota     int64 = defaultRuleLimit
+	accountQuota  int64 = defaultAccountLimit
+	roleQuota     int64 = defaultRoleLimit
 )
 
 func Init() {
-	DefaultServiceQuota = config.GetInt("quota.cap.service.limit", defaultServiceLimit, config.WithENV("QUOTA_SERVICE"))
-	DefaultInstanceQuota = config.GetInt("quota.cap.instance.limit", defaultInstanceLimit, config.WithENV("QUOTA_INSTANCE"))
-	DefaultSchemaQuota = config.GetInt("quota.cap.schema.limit", defaultSchemaLimit, config.WithENV("QUOTA_SCHEMA"))
-	DefaultTagQuota = config.GetInt("quota.cap.tag.limit", defaultTagLimit, config.WithENV("QUOTA_TAG"))
-	DefaultRuleQuota = config.GetInt("quota.cap.rule.limit", defaultRuleLimit, config.WithENV("QUOTA_RULE"))
-	DefaultAccountQuota = config.GetInt("quota.cap.account.limit", defaultAccountLimit, config.WithENV("QUOTA_ACCOUNT"))
-	DefaultRoleQuota = config.GetInt("quota.cap.role.limit", defaultRoleLimit, config.WithENV("QUOTA_ROLE"))
+	setQuota(&serviceQuota, config.GetInt("quota.cap.service.limit", defaultServiceLimit, config.WithENV("QUOTA_SERVICE")))
+	setQuota(&instanceQuota, config.GetInt("quota.cap.instance.limit", defaultInstanceLimit, config.WithENV("QUOTA_INSTANCE")))
+	setQuota(&schemaQuota, config.GetInt("quota.cap.schema.limit", defaultSchemaLimit, config.WithENV("QUOTA_SCHEMA")))
+	setQuota(&tagQuota, config.GetInt("quota.cap.tag.limit", defaultTagLimit, config.WithENV("QUOTA_TAG")))
+	setQuota(&ruleQuota, config.GetInt("quota.cap.rule.limit", defaultRuleLimit, config.WithENV("QUOTA_RULE")))
+	setQuota(&accountQuota, config.GetInt("quota.cap.account.limit", defaultAccountLimit, config.WithENV("QUOTA_ACCOUNT")))
+	setQuota(&roleQuota, config.GetInt("quota.cap.role.limit", defaultRoleLimit, config.WithENV("QUOTA_ROLE")))
+}
+
+func setQuota(addr *int64, limit int) {
+	atomic.StoreInt64(addr, int64(limit))
+}
+
+func DefaultServiceQuota() int {
+	return int(atomic.LoadInt64(&serviceQuota))
+}
+
+func DefaultInstanceQuota() int {
+	return int(atomic.LoadInt64(&instanceQuota))
+}
+
+func DefaultSchemaQuota() int {
+	return int(atomic.LoadInt64(&schemaQuota))
+}
+
+func DefaultTagQuota() int {
+	return int(atomic.LoadInt64(&tagQuota))
+}
+
+func DefaultRuleQuota() int {
+	return int(atomic.LoadInt64(&ruleQuota))
+}
+
+func DefaultAccountQuota() int {
+	return int(atomic.LoadInt64(&accountQuota))
+}
+
+func DefaultRoleQuota() int {
+	return int(atomic.LoadInt64(&roleQuota))
 }
 
 type ApplyQuotaResource struct {
@@ -118,6 +153,33 @@ func (r ResourceType) String() string {
 	}
 }
 
+// Name returns the resource name used in the quota limits override
+func (r ResourceType) Name() string {
+	switch r {
+	case TypeRule:
+		return model.ResourceRule
+	case TypeSchema:
+		return model.ResourceSchema
+	case TypeTag:
+		return model.ResourceTag
+	case TypeService:
+		return model.ResourceService
+	case TypeInstance:
+		return model.ResourceInstance
+	case TypeAccount:
+		return model.ResourceAccount
+	case TypeRole:
+		return model.ResourceRole
+	default:
+		return ""
+	}
+}
+
+// PerService returns true if the resource is counted per service
+func (r ResourceType) PerService() bool {
+	return r == TypeSchema || r == TypeTag || r == TypeRule
+}
+
 //申请配额sourceType serviceinstance servicetype
 func Apply(ctx context.Context, res *ApplyQuotaResource) *errsvc.Error {
 	if res == nil {
@@ -172,3 +234,40 @@ func GetResourceUsage(ctx context.Context, res *ApplyQuotaResource) (int64, erro
 		return 0, fmt.Errorf("not define quota type '%s'", res.QuotaType)
 	}
 }
+
+// ListUsage returns the limits and usages of all the resources of the domain project,
+// the schema, tag and rule are counted in the service of serviceID,
+// their usages are 0 if serviceID is empty
+func ListUsage(ctx context.Context, serviceID string) ([]*model.Usage, error) {
+	manager := plugin.Plugins().Instance(QUOTA).(Manager)
+	types := []ResourceType{TypeService, TypeInstance, TypeAccount, TypeRole, TypeSchema, TypeTag, TypeRule}
+	usages := make([]*model.Usage, 0, len(types))
+	for _, t := range types {
+		usage := &model.Usage{
+			Resource: t.Name(),
+			Limit:    manager.GetQuota(ctx, t),
+		}
+		usages = append(usages, usage)
+		if t.PerService() {
+			if len(serviceID) == 0 {
+				continue
+			}
+			usage.ServiceID = serviceID
+		}
+		used, err := getUsage(ctx, t, serviceID)
+		if err != nil {
+			log.Errorf(err, "get %s quota usage failed", t)
+			return nil, err
+		}
+		usage.Used = used
+	}
+	return usages, nil
+}
+
+func getUsage(ctx context.Context, t ResourceType, serviceID string) (int64, error) {
+	if t == TypeTag {
+		// GetResourceUsage does not count the tags, see the comment in it
+		return quota.TagUsage(ctx, serviceID)
+	}
+	return GetResourceUsage(ctx, &ApplyQuotaResource{QuotaType: t, ServiceID: serviceID})
+}
@@ -32,6 +32,12 @@ import (
 type Governance struct {
 }
 
+//DistributeResponse reports the results of secondary distributors
+type DistributeResponse struct {
+	*model.Policy
+	Distributors []*gov.Result `json:"distributors,omitempty"`
+}
+
 const (
 	AppKey         = "app"
 	EnvironmentKey = "environment"
@@ -39,6 +45,8 @@ const (
 	ProjectKey     = ":project"
 	IDKey          = ":id"
 	DisplayKey     = "display"
+	SchemaKey      = "schema"
+	QueryKindKey   = "kind"
 )
 
 //Create gov config
@@ -52,7 +60,7 @@ func (t *Governance) Create(w http.ResponseWriter, r *http.Request) {
 		rest.WriteError(w, discovery.ErrInternal, err.Error())
 		return
 	}
-	id, err := gov.Create(kind, project, body)
+	id, results, err := gov.Create(kind, project, body)
 	if err != nil {
 		if _, ok := err.(*kie.ErrIllegalItem); ok {
 			log.Error("", err)
@@ -63,7 +71,10 @@ func (t *Governance) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	rest.WriteResponse(w, r, nil, &model.Policy{GovernancePolicy: &model.GovernancePolicy{ID: string(id)}})
+	rest.WriteResponse(w, r, nil, &DistributeResponse{
+		Policy:       &model.Policy{GovernancePolicy: &model.GovernancePolicy{ID: string(id)}},
+		Distributors: results,
+	})
 }
 
 //Put gov config
@@ -77,7 +88,7 @@ func (t *Governance) Put(w http.ResponseWriter, r *http.Request) {
 		processError(w, err, "read body err")
 		return
 	}
-	err = gov.Update(kind, id, project, body)
+	results, err := gov.Update(kind, id, project, body)
 	if err != nil {
 		if _, ok := err.(*kie.ErrIllegalItem); ok {
 			log.Error("", err)
@@ -87,7 +98,7 @@ func (t *Governance) Put(w http.ResponseWriter, r *http.Request) {
 		processError(w, err, "put gov err")
 		return
 	}
-	rest.WriteResponse(w, r, nil, nil)
+	writeResults(w, r, results)
 }
 
 //ListOrDisPlay return all gov config
@@ -131,12 +142,36 @@ func (t *Governance) Delete(w http.ResponseWriter, r *http.Request) {
 	kind := query.Get(KindKey)
 	id := query.Get(IDKey)
 	project := query.Get(ProjectKey)
-	err := gov.Delete(kind, id, project)
+	results, err := gov.Delete(kind, id, project)
 	if err != nil {
 		processError(w, err, "delete gov err")
 		return
 	}
-	rest.WriteResponse(w, r, nil, nil)
+	writeResults(w, r, results)
+}
+
+//Schema return the JSON Schema of all kinds, or the one of kind in query
+func (t *Governance) Schema(w http.ResponseWriter, r *http.Request) {
+	kind := r.URL.Query().Get(QueryKindKey)
+	if len(kind) == 0 {
+		rest.WriteResponse(w, r, nil, model.PolicySchemas())
+		return
+	}
+	schema, err := model.PolicySchema(kind)
+	if err != nil {
+		log.Error("", err)
+		rest.WriteError(w, discovery.ErrInvalidParams, err.Error())
+		return
+	}
+	rest.WriteResponse(w, r, nil, schema)
+}
+
+func writeResults(w http.ResponseWriter, r *http.Request, results []*gov.Result) {
+	if len(results) == 0 {
+		rest.WriteResponse(w, r, nil, nil)
+		return
+	}
+	rest.WriteResponse(w, r, nil, &DistributeResponse{Distributors: results})
 }
 
 func processError(w http.ResponseWriter, err error, msg string) {
@@ -149,6 +184,7 @@ func (t *Governance) URLPatterns() []rest.Route {
 		//servicecomb.marker.{name}
 		//servicecomb.rateLimiter.{name}
 		//....
+		{Method: http.MethodGet, Path: "/v1/:project/gov/" + SchemaKey, Func: t.Schema},
 		{Method: http.MethodPost, Path: "/v1/:project/gov/" + KindKey, Func: t.Create},
 		{Method: http.MethodGet, Path: "/v1/:project/gov/" + KindKey, Func: t.ListOrDisPlay},
 		{Method: http.MethodGet, Path: "/v1/:project/gov/" + KindKey + "/" + IDKey, Func: t.Get},
@@ -71,4 +71,27 @@ func TestAuthResource_Login(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 	})
 
+	t.Run("get schema", func(t *testing.T) {
+		governance := &v1.Governance{}
+		r, _ := http.NewRequest(http.MethodGet, "/v1/default/gov/schema", nil)
+		w := httptest.NewRecorder()
+		governance.Schema(w, r)
+		assert.Equal(t, http.StatusOK, w.Code)
+		schemas := make(map[string]*gov.JSONSchema)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &schemas))
+		assert.NotNil(t, schemas[gov.KindCircuitBreaker])
+
+		r, _ = http.NewRequest(http.MethodGet, "/v1/default/gov/schema?kind="+gov.KindBulkhead, nil)
+		w = httptest.NewRecorder()
+		governance.Schema(w, r)
+		assert.Equal(t, http.StatusOK, w.Code)
+		schema := &gov.JSONSchema{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), schema))
+		assert.Equal(t, gov.KindBulkhead, schema.Title)
+
+		r, _ = http.NewRequest(http.MethodGet, "/v1/default/gov/schema?kind=unknown", nil)
+		w = httptest.NewRecorder()
+		governance.Schema(w, r)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
 }
@@ -21,11 +21,13 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"strings"
 
 	"github.com/go-chassis/cari/discovery"
 	"github.com/go-chassis/cari/pkg/errsvc"
 	"github.com/go-chassis/cari/rbac"
 	"github.com/go-chassis/go-chassis/v2/security/authr"
+	"github.com/go-chassis/go-chassis/v2/server/restful"
 
 	errorsEx "github.com/apache/servicecomb-service-center/pkg/errors"
 	"github.com/apache/servicecomb-service-center/pkg/log"
@@ -43,6 +45,8 @@ type AuthResource struct {
 func (ar *AuthResource) URLPatterns() []rest.Route {
 	return []rest.Route{
 		{Method: http.MethodPost, Path: "/v4/token", Func: ar.Login},
+		{Method: http.MethodDelete, Path: "/v4/token", Func: ar.Logout},
+		{Method: http.MethodPost, Path: "/v4/token/refresh", Func: ar.RefreshToken},
 		{Method: http.MethodPost, Path: "/v4/accounts", Func: ar.CreateAccount},
 		{Method: http.MethodGet, Path: "/v4/accounts", Func: ar.ListAccount},
 		{Method: http.MethodGet, Path: "/v4/accounts/:name", Func: ar.GetAccount},
@@ -185,7 +189,68 @@ func (ar *AuthResource) Login(w http.ResponseWriter, r *http.Request) {
 		writeErrsvcOrInternalErr(w, err)
 		return
 	}
-	rest.WriteResponse(w, r, nil, &rbac.Token{TokenStr: t})
+	rt, err := rbacsvc.IssueRefreshToken(r.Context(), a.Name)
+	if err != nil {
+		log.Error("can not issue refresh token", err)
+		writeErrsvcOrInternalErr(w, err)
+		return
+	}
+	rest.WriteResponse(w, r, nil, &rbacsvc.Token{Token: rbac.Token{TokenStr: t}, RefreshTokenStr: rt})
+}
+
+func (ar *AuthResource) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		log.Error("read body err", err)
+		rest.WriteError(w, discovery.ErrInternal, err.Error())
+		return
+	}
+	req := &rbacsvc.RefreshTokenRequest{}
+	if err = json.Unmarshal(body, req); err != nil {
+		log.Error("json err", err)
+		rest.WriteError(w, discovery.ErrInvalidParams, errorsEx.MsgJSON)
+		return
+	}
+	if req.TokenExpirationTime == "" {
+		req.TokenExpirationTime = DefaultTokenExpirationDuration
+	}
+	t, err := rbacsvc.RefreshToken(r.Context(), req)
+	if err != nil {
+		log.Error("refresh token failed", err)
+		writeErrsvcOrInternalErr(w, err)
+		return
+	}
+	rest.WriteResponse(w, r, nil, t)
+}
+
+// Logout revokes the token in the Authorization header, and the refresh token in body if exists
+func (ar *AuthResource) Logout(w http.ResponseWriter, r *http.Request) {
+	v := strings.Split(r.Header.Get(restful.HeaderAuth), " ")
+	if len(v) != 2 {
+		rest.WriteErrsvcError(w, rbac.NewError(rbac.ErrNoAuthHeader, ""))
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		log.Error("read body err", err)
+		rest.WriteError(w, discovery.ErrInternal, err.Error())
+		return
+	}
+	req := &rbacsvc.RefreshTokenRequest{}
+	if len(body) > 0 {
+		if err = json.Unmarshal(body, req); err != nil {
+			log.Error("json err", err)
+			rest.WriteError(w, discovery.ErrInvalidParams, errorsEx.MsgJSON)
+			return
+		}
+	}
+	err = rbacsvc.Logout(r.Context(), v[1], req.RefreshTokenStr)
+	if err != nil {
+		log.Error("logout failed", err)
+		writeErrsvcOrInternalErr(w, err)
+		return
+	}
+	rest.WriteSuccess(w, r)
 }
 
 func MakeBanKey(name, ip string) string {
@@ -367,6 +367,62 @@ func TestAuthResource_Login2(t *testing.T) {
 	})
 }
 
+func TestAuthResource_Token(t *testing.T) {
+	to := &rbacsvc.Token{}
+	t.Run("root login, should return a refresh token", func(t *testing.T) {
+		b, _ := json.Marshal(&rbacmodel.Account{Name: "root", Password: pwd})
+		r, _ := http.NewRequest(http.MethodPost, "/v4/token", bytes.NewBuffer(b))
+		w := httptest.NewRecorder()
+		rest.GetRouter().ServeHTTP(w, r)
+		assert.Equal(t, http.StatusOK, w.Code)
+		json.Unmarshal(w.Body.Bytes(), to)
+		assert.NotEmpty(t, to.TokenStr)
+		assert.NotEmpty(t, to.RefreshTokenStr)
+	})
+	t.Run("use refresh token to access apis, should fail", func(t *testing.T) {
+		r, _ := http.NewRequest(http.MethodGet, "/v4/accounts", nil)
+		r.Header.Set(restful.HeaderAuth, "Bearer "+to.RefreshTokenStr)
+		w := httptest.NewRecorder()
+		rest.GetRouter().ServeHTTP(w, r)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+	refreshed := &rbacsvc.Token{}
+	t.Run("refresh token, should return a new token pair", func(t *testing.T) {
+		b, _ := json.Marshal(&rbacsvc.RefreshTokenRequest{RefreshTokenStr: to.RefreshTokenStr})
+		r, _ := http.NewRequest(http.MethodPost, "/v4/token/refresh", bytes.NewBuffer(b))
+		w := httptest.NewRecorder()
+		rest.GetRouter().ServeHTTP(w, r)
+		assert.Equal(t, http.StatusOK, w.Code)
+		json.Unmarshal(w.Body.Bytes(), refreshed)
+		assert.NotEmpty(t, refreshed.TokenStr)
+		assert.NotEmpty(t, refreshed.RefreshTokenStr)
+
+		r2, _ := http.NewRequest(http.MethodPost, "/v4/token/refresh", bytes.NewBuffer(b))
+		w2 := httptest.NewRecorder()
+		rest.GetRouter().ServeHTTP(w2, r2)
+		assert.Equal(t, http.StatusUnauthorized, w2.Code)
+	})
+	t.Run("logout, should revoke the token", func(t *testing.T) {
+		r, _ := http.NewRequest(http.MethodDelete, "/v4/token", nil)
+		r.Header.Set(restful.HeaderAuth, "Bearer "+refreshed.TokenStr)
+		w := httptest.NewRecorder()
+		rest.GetRouter().ServeHTTP(w, r)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		r2, _ := http.NewRequest(http.MethodGet, "/v4/accounts", nil)
+		r2.Header.Set(restful.HeaderAuth, "Bearer "+refreshed.TokenStr)
+		w2 := httptest.NewRecorder()
+		rest.GetRouter().ServeHTTP(w2, r2)
+		assert.Equal(t, http.StatusUnauthorized, w2.Code)
+
+		r3, _ := http.NewRequest(http.MethodGet, "/v4/accounts", nil)
+		r3.Header.Set(restful.HeaderAuth, "Bearer "+to.TokenStr)
+		w3 := httptest.NewRecorder()
+		rest.GetRouter().ServeHTTP(w3, r3)
+		assert.Equal(t, http.StatusOK, w3.Code)
+	})
+}
+
 func BenchmarkAuthResource_LoginP(b *testing.B) {
 	body, _ := json.Marshal(&rbacmodel.Account{Name: "root", Password: pwd})
 	b.RunParallel(func(pb *testing.PB) {
@@ -21,6 +21,7 @@ import (
 	"github.com/go-chassis/cari/discovery"
 
 	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/plugin/auth"
 )
 
 func init() {
@@ -42,27 +43,40 @@ func MicroserviceListFilter(obj interface{}, labels []map[string]string) interfa
 }
 
 func matchOne(service *discovery.MicroService, labels map[string]string) bool {
-	if env, ok := labels["environment"]; ok && service.Environment != env {
+	if env, ok := labels["environment"]; ok && !util.PatternMatch(env, service.Environment) {
 		return false
 	}
-	if app, ok := labels["appId"]; ok && service.AppId != app {
+	if app, ok := labels["appId"]; ok && !util.PatternMatch(app, service.AppId) {
 		return false
 	}
-	if name, ok := labels["serviceName"]; ok && !util.WildcardMatch(name, service.ServiceName) {
+	if name, ok := labels["serviceName"]; ok && !util.PatternMatch(name, service.ServiceName) {
 		return false
 	}
 	return true
 }
 
+// matchLabels returns true if the service matches one of the allowed labels
+// and none of the denied labels
+func matchLabels(service *discovery.MicroService, allows, denies []map[string]string) bool {
+	for _, labels := range denies {
+		if matchOne(service, labels) {
+			return false
+		}
+	}
+	for _, labels := range allows {
+		if matchOne(service, labels) {
+			return true
+		}
+	}
+	return false
+}
+
 func filterMicroservices(sources []*discovery.MicroService, labelsList []map[string]string) []*discovery.MicroService {
+	allows, denies := auth.SplitLabels(labelsList)
 	var services []*discovery.MicroService
 	for _, service := range sources {
-		for _, labels := range labelsList {
-			if !matchOne(service, labels) {
-				continue
-			}
+		if matchLabels(service, allows, denies) {
 			services = append(services, service)
-			break
 		}
 	}
 	return services
@@ -91,14 +105,11 @@ func MicroServiceInfoListFilter(obj interface{}, labelsList []map[string]string)
 	if !ok {
 		return obj
 	}
+	allows, denies := auth.SplitLabels(labelsList)
 	var services []*discovery.ServiceDetail
 	for _, service := range servicesResponse.AllServicesDetail {
-		for _, labels := range labelsList {
-			if !matchOne(service.MicroService, labels) {
-				continue
-			}
+		if matchLabels(service.MicroService, allows, denies) {
 			services = append(services, service)
-			break
 		}
 	}
 	servicesResponse.AllServicesDetail = services
@@ -110,10 +121,14 @@ func AppIDListFilter(obj interface{}, labelsList []map[string]string) interface{
 	if !ok {
 		return obj
 	}
+	allows, denies := auth.SplitLabels(labelsList)
 	var apps []string
 	for _, appID := range appsResponse.AppIds {
-		for _, labels := range labelsList {
-			if app, ok := labels["appId"]; ok && appID != app {
+		if appDenied(appID, denies) {
+			continue
+		}
+		for _, labels := range allows {
+			if app, ok := labels["appId"]; ok && !util.PatternMatch(app, appID) {
 				continue
 			}
 			apps = append(apps, appID)
@@ -123,3 +138,15 @@ func AppIDListFilter(obj interface{}, labelsList []map[string]string) interface{
 	appsResponse.AppIds = apps
 	return appsResponse
 }
+
+// appDenied returns true if a denied label denies the whole app,
+// the denied labels with other keys only deny some services of the app
+func appDenied(appID string, denies []map[string]string) bool {
+	for _, labels := range denies {
+		app, ok := labels["appId"]
+		if ok && len(labels) == 1 && util.PatternMatch(app, appID) {
+			return true
+		}
+	}
+	return false
+}
@@ -20,6 +20,7 @@ package response_test
 import (
 	"testing"
 
+	"github.com/apache/servicecomb-service-center/server/plugin/auth"
 	"github.com/apache/servicecomb-service-center/server/response"
 	"github.com/go-chassis/cari/discovery"
 	"github.com/stretchr/testify/assert"
@@ -147,4 +148,51 @@ func TestMicroserviceListFilter(t *testing.T) {
 		assert.Equal(t, 1, len(mss))
 		assert.Equal(t, "TestA", mss[0].ServiceName)
 	})
+	t.Run("regex match appId and wildcard match environment, should return A", func(t *testing.T) {
+		rs := response.MicroserviceListFilter(&discovery.GetServicesResponse{
+			Services: []*discovery.MicroService{
+				{AppId: "app-1", ServiceName: "A", Environment: "production"},
+				{AppId: "app-x", ServiceName: "B", Environment: "production"},
+				{AppId: "app-2", ServiceName: "C", Environment: "development"},
+			},
+		}, []map[string]string{{"appId": "regex:^app-[0-9]+$", "environment": "prod*"}})
+		mss := rs.(*discovery.GetServicesResponse).Services
+		assert.Equal(t, 1, len(mss))
+		assert.Equal(t, "A", mss[0].ServiceName)
+	})
+	t.Run("allow all but deny appId, should not return the denied services", func(t *testing.T) {
+		rs := response.MicroserviceListFilter(&discovery.GetServicesResponse{
+			Services: []*discovery.MicroService{
+				{AppId: "default", ServiceName: "A"},
+				{AppId: "secret", ServiceName: "B"},
+			},
+		}, []map[string]string{{}, {auth.LabelDeny: "", "appId": "secret"}})
+		mss := rs.(*discovery.GetServicesResponse).Services
+		assert.Equal(t, 1, len(mss))
+		assert.Equal(t, "A", mss[0].ServiceName)
+	})
+	t.Run("deny overrides allow, should not return the denied services", func(t *testing.T) {
+		rs := response.MicroserviceListFilter(&discovery.GetServicesResponse{
+			Services: []*discovery.MicroService{
+				{AppId: "secret", ServiceName: "A"},
+				{AppId: "secret", ServiceName: "B"},
+			},
+		}, []map[string]string{{"appId": "secret"}, {auth.LabelDeny: "", "serviceName": "B"}})
+		mss := rs.(*discovery.GetServicesResponse).Services
+		assert.Equal(t, 1, len(mss))
+		assert.Equal(t, "A", mss[0].ServiceName)
+	})
+}
+
+func TestAppIDListFilter(t *testing.T) {
+	rs := response.AppIDListFilter(&discovery.GetAppsResponse{
+		AppIds: []string{"app-1", "app-x", "default"},
+	}, []map[string]string{{"appId": "regex:^app-[0-9]+$"}, {"appId": "default"}})
+	assert.Equal(t, []string{"app-1", "default"}, rs.(*discovery.GetAppsResponse).AppIds)
+
+	rs = response.AppIDListFilter(&discovery.GetAppsResponse{
+		AppIds: []string{"app-1", "secret", "default"},
+	}, []map[string]string{{}, {auth.LabelDeny: "", "appId": "secret"},
+		{auth.LabelDeny: "", "appId": "default", "serviceName": "a"}})
+	assert.Equal(t, []string{"app-1", "default"}, rs.(*discovery.GetAppsResponse).AppIds)
 }
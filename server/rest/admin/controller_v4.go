@@ -18,9 +18,16 @@
 package admin
 
 import (
+	"encoding/json"
+	"io/ioutil"
 	"net/http"
+	"strconv"
 
+	"github.com/apache/servicecomb-service-center/pkg/alarm/model"
 	"github.com/apache/servicecomb-service-center/pkg/dump"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/go-chassis/cari/discovery"
 
 	"strings"
 
@@ -36,7 +43,16 @@ func (ctrl *ControllerV4) URLPatterns() []rest.Route {
 	return []rest.Route{
 		{Method: http.MethodGet, Path: "/v4/:project/admin/alarms", Func: ctrl.AlarmList},
 		{Method: http.MethodDelete, Path: "/v4/:project/admin/alarms", Func: ctrl.ClearAlarm},
+		{Method: http.MethodGet, Path: "/v4/:project/admin/alarms/history", Func: ctrl.AlarmHistory},
+		{Method: http.MethodPut, Path: "/v4/:project/admin/alarms/:id/acknowledge", Func: ctrl.AcknowledgeAlarm},
+		{Method: http.MethodGet, Path: "/v4/:project/admin/quotas", Func: ctrl.GetQuota},
+		{Method: http.MethodPut, Path: "/v4/:project/admin/quotas", Func: ctrl.PutQuota},
+		{Method: http.MethodDelete, Path: "/v4/:project/admin/quotas", Func: ctrl.DeleteQuota},
+		{Method: http.MethodGet, Path: "/v4/:project/admin/quotas/usage", Func: ctrl.QuotaUsage},
+		{Method: http.MethodPost, Path: "/v4/:project/admin/config/reload", Func: ctrl.ReloadConfig},
 		{Method: http.MethodGet, Path: "/v4/:project/admin/dump", Func: ctrl.Dump},
+		{Method: http.MethodGet, Path: "/v4/:project/admin/export", Func: ctrl.Export},
+		{Method: http.MethodPost, Path: "/v4/:project/admin/import", Func: ctrl.Import},
 		{Method: http.MethodGet, Path: "/v4/:project/admin/clusters", Func: ctrl.Clusters},
 	}
 }
@@ -47,14 +63,55 @@ func (ctrl *ControllerV4) Dump(w http.ResponseWriter, r *http.Request) {
 	if s := strings.TrimSpace(query.Get("options")); len(s) > 0 {
 		options = strings.Split(s, ",")
 	}
+	var since int64
+	if s := query.Get("since"); len(s) > 0 {
+		rev, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || rev < 0 {
+			rest.WriteError(w, discovery.ErrInvalidParams, "invalid since revision")
+			return
+		}
+		since = rev
+	}
 	request := &dump.Request{
 		Options: options,
+		Since:   since,
 	}
 	ctx := r.Context()
 	resp, _ := AdminServiceAPI.Dump(ctx, request)
 	rest.WriteResponse(w, r, resp.Response, resp)
 }
 
+func (ctrl *ControllerV4) Export(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	resp, _ := AdminServiceAPI.Export(ctx, &dump.ExportRequest{})
+	rest.WriteResponse(w, r, resp.Response, resp.Archive)
+}
+
+func (ctrl *ControllerV4) Import(w http.ResponseWriter, r *http.Request) {
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		log.Error("read body failed", err)
+		rest.WriteError(w, discovery.ErrInvalidParams, err.Error())
+		return
+	}
+	request := &dump.ImportRequest{Archive: &dump.Archive{}}
+	err = json.Unmarshal(message, request.Archive)
+	if err != nil {
+		log.Errorf(err, "invalid json: %s", util.BytesToStringWithNoCopy(message))
+		rest.WriteError(w, discovery.ErrInvalidParams, "Unmarshal error")
+		return
+	}
+	ctx := r.Context()
+	resp, _ := AdminServiceAPI.Import(ctx, request)
+	rest.WriteResponse(w, r, resp.Response, resp.Result)
+}
+
+func (ctrl *ControllerV4) ReloadConfig(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	resp, _ := AdminServiceAPI.ReloadConfig(ctx, &dump.ReloadConfigRequest{})
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
 func (ctrl *ControllerV4) Clusters(w http.ResponseWriter, r *http.Request) {
 	request := &dump.ClustersRequest{}
 	ctx := r.Context()
@@ -63,7 +120,9 @@ func (ctrl *ControllerV4) Clusters(w http.ResponseWriter, r *http.Request) {
 }
 
 func (ctrl *ControllerV4) AlarmList(w http.ResponseWriter, r *http.Request) {
-	request := &dump.AlarmListRequest{}
+	request := &dump.AlarmListRequest{
+		Status: model.Status(r.URL.Query().Get("status")),
+	}
 	ctx := r.Context()
 	resp, _ := AdminServiceAPI.AlarmList(ctx, request)
 	rest.WriteResponse(w, r, resp.Response, resp)
@@ -75,3 +134,84 @@ func (ctrl *ControllerV4) ClearAlarm(w http.ResponseWriter, r *http.Request) {
 	resp, _ := AdminServiceAPI.ClearAlarm(ctx, request)
 	rest.WriteResponse(w, r, resp.Response, nil)
 }
+
+func (ctrl *ControllerV4) AlarmHistory(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := &model.HistoryRequest{
+		ID:     model.ID(query.Get("id")),
+		Status: model.Status(query.Get("status")),
+	}
+	for key, v := range map[string]*int64{"start": &request.Start, "end": &request.End, "limit": &request.Limit} {
+		s := query.Get(key)
+		if len(s) == 0 {
+			continue
+		}
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || i < 0 {
+			rest.WriteError(w, discovery.ErrInvalidParams, "invalid query parameter "+key)
+			return
+		}
+		*v = i
+	}
+	ctx := r.Context()
+	resp, _ := AdminServiceAPI.AlarmHistory(ctx, request)
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
+
+func (ctrl *ControllerV4) AcknowledgeAlarm(w http.ResponseWriter, r *http.Request) {
+	request := &dump.AckAlarmRequest{
+		ID: model.ID(r.URL.Query().Get(":id")),
+	}
+	ctx := r.Context()
+	resp, _ := AdminServiceAPI.AcknowledgeAlarm(ctx, request)
+	rest.WriteResponse(w, r, resp.Response, resp.Alarm)
+}
+
+func (ctrl *ControllerV4) GetQuota(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+	resp, _ := AdminServiceAPI.GetQuota(ctx, &dump.GetQuotaRequest{
+		Domain:  query.Get("domain"),
+		Project: query.Get("project"),
+	})
+	rest.WriteResponse(w, r, resp.Response, resp.Quota)
+}
+
+func (ctrl *ControllerV4) PutQuota(w http.ResponseWriter, r *http.Request) {
+	message, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		log.Error("read body failed", err)
+		rest.WriteError(w, discovery.ErrInvalidParams, err.Error())
+		return
+	}
+	request := &dump.PutQuotaRequest{}
+	err = json.Unmarshal(message, request)
+	if err != nil {
+		log.Errorf(err, "invalid json: %s", util.BytesToStringWithNoCopy(message))
+		rest.WriteError(w, discovery.ErrInvalidParams, "Unmarshal error")
+		return
+	}
+	query := r.URL.Query()
+	request.Domain, request.Project = query.Get("domain"), query.Get("project")
+	ctx := r.Context()
+	resp, _ := AdminServiceAPI.PutQuota(ctx, request)
+	rest.WriteResponse(w, r, resp.Response, resp.Quota)
+}
+
+func (ctrl *ControllerV4) DeleteQuota(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+	resp, _ := AdminServiceAPI.DeleteQuota(ctx, &dump.DeleteQuotaRequest{
+		Domain:  query.Get("domain"),
+		Project: query.Get("project"),
+	})
+	rest.WriteResponse(w, r, resp.Response, nil)
+}
+
+func (ctrl *ControllerV4) QuotaUsage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	resp, _ := AdminServiceAPI.QuotaUsage(ctx, &dump.QuotaUsageRequest{
+		ServiceID: r.URL.Query().Get("serviceId"),
+	})
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
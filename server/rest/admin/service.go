@@ -21,10 +21,16 @@ import (
 	"context"
 
 	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/alarm/model"
 	"github.com/apache/servicecomb-service-center/pkg/dump"
 	"github.com/apache/servicecomb-service-center/pkg/log"
 	"github.com/apache/servicecomb-service-center/pkg/util"
 	"github.com/apache/servicecomb-service-center/server/alarm"
+	"github.com/apache/servicecomb-service-center/server/config"
+	quotaplugin "github.com/apache/servicecomb-service-center/server/plugin/quota"
+	"github.com/apache/servicecomb-service-center/server/service/archive"
+	"github.com/apache/servicecomb-service-center/server/service/quota"
+	rbacsvc "github.com/apache/servicecomb-service-center/server/service/rbac"
 	"github.com/apache/servicecomb-service-center/version"
 	"github.com/go-chassis/cari/discovery"
 	"github.com/go-chassis/go-archaius"
@@ -51,39 +57,117 @@ func (service *Service) Dump(ctx context.Context, in *dump.Request) (*dump.Respo
 	}
 
 	if len(in.Options) == 0 {
-		service.dump(ctx, "cache", resp)
+		service.dump(ctx, "cache", in.Since, resp)
 		return resp, nil
 	}
 
 	options := make(map[string]struct{}, len(in.Options))
 	for _, option := range in.Options {
 		if option == "all" {
-			service.dump(ctx, "all", resp)
+			service.dump(ctx, "all", in.Since, resp)
 			return resp, nil
 		}
 		options[option] = struct{}{}
 	}
 	for option := range options {
-		service.dump(ctx, option, resp)
+		service.dump(ctx, option, in.Since, resp)
 	}
 	return resp, nil
 }
 
-func (service *Service) dump(ctx context.Context, option string, resp *dump.Response) {
+func (service *Service) dump(ctx context.Context, option string, since int64, resp *dump.Response) {
 	switch option {
 	case "info":
 		resp.Info = version.Ver()
 	case "config":
 		resp.AppConfig = archaius.GetConfigs()
 	case "cache":
+		if since > 0 {
+			resp.Cache = datasource.GetSystemManager().DumpCacheSince(ctx, since)
+			break
+		}
 		resp.Cache = datasource.GetSystemManager().DumpCache(ctx)
 	case "all":
-		service.dump(ctx, "info", resp)
-		service.dump(ctx, "config", resp)
-		service.dump(ctx, "cache", resp)
+		service.dump(ctx, "info", since, resp)
+		service.dump(ctx, "config", since, resp)
+		service.dump(ctx, "cache", since, resp)
 	}
 }
 
+func (service *Service) Export(ctx context.Context, in *dump.ExportRequest) (*dump.ExportResponse, error) {
+	domainProject := util.ParseDomainProject(ctx)
+
+	if !datasource.IsDefaultDomainProject(domainProject) {
+		return &dump.ExportResponse{
+			Response: discovery.CreateResponse(discovery.ErrForbidden, "Required admin permission"),
+		}, nil
+	}
+
+	a, err := archive.Export(ctx)
+	if err != nil {
+		log.Errorf(err, "export registry failed")
+		return &dump.ExportResponse{
+			Response: discovery.CreateResponse(discovery.ErrInternal, err.Error()),
+		}, nil
+	}
+	return &dump.ExportResponse{
+		Response: discovery.CreateResponse(discovery.ResponseSuccess, "Export successfully"),
+		Archive:  a,
+	}, nil
+}
+
+func (service *Service) Import(ctx context.Context, in *dump.ImportRequest) (*dump.ImportResponse, error) {
+	domainProject := util.ParseDomainProject(ctx)
+
+	if !datasource.IsDefaultDomainProject(domainProject) {
+		return &dump.ImportResponse{
+			Response: discovery.CreateResponse(discovery.ErrForbidden, "Required admin permission"),
+		}, nil
+	}
+
+	result, err := archive.Import(ctx, in.Archive)
+	if err != nil {
+		log.Errorf(err, "import registry failed")
+		code := discovery.ErrInternal
+		if err == archive.ErrUnsupportedVersion {
+			code = discovery.ErrInvalidParams
+		}
+		return &dump.ImportResponse{
+			Response: discovery.CreateResponse(code, err.Error()),
+		}, nil
+	}
+	return &dump.ImportResponse{
+		Response: discovery.CreateResponse(discovery.ResponseSuccess, "Import successfully"),
+		Result:   result,
+	}, nil
+}
+
+func (service *Service) ReloadConfig(ctx context.Context, in *dump.ReloadConfigRequest) (*dump.ReloadConfigResponse, error) {
+	domainProject := util.ParseDomainProject(ctx)
+
+	if !datasource.IsDefaultDomainProject(domainProject) {
+		return &dump.ReloadConfigResponse{
+			Response: discovery.CreateResponse(discovery.ErrForbidden, "Required admin permission"),
+		}, nil
+	}
+
+	result, err := config.ReloadFile()
+	if err != nil {
+		log.Errorf(err, "reload config failed")
+		return &dump.ReloadConfigResponse{
+			Response: discovery.CreateResponse(discovery.ErrInternal, err.Error()),
+		}, nil
+	}
+	if len(result.RequireRestart) > 0 {
+		log.Warnf("%v require restart to take effect", result.RequireRestart)
+	}
+	return &dump.ReloadConfigResponse{
+		Response:       discovery.CreateResponse(discovery.ResponseSuccess, "Reload config successfully"),
+		Reloaded:       result.Reloaded,
+		RequireRestart: result.RequireRestart,
+	}, nil
+}
+
 func (service *Service) Clusters(ctx context.Context, in *dump.ClustersRequest) (*dump.ClustersResponse, error) {
 	clusters, err := datasource.GetSCManager().GetClusters(ctx)
 	if err != nil {
@@ -95,13 +179,157 @@ func (service *Service) Clusters(ctx context.Context, in *dump.ClustersRequest)
 }
 
 func (service *Service) AlarmList(ctx context.Context, in *dump.AlarmListRequest) (*dump.AlarmListResponse, error) {
+	alarms := alarm.ListAll()
+	if len(in.Status) > 0 {
+		filtered := alarms[:0]
+		for _, a := range alarms {
+			if a.Status == in.Status {
+				filtered = append(filtered, a)
+			}
+		}
+		alarms = filtered
+	}
+	events := make([]*model.AlarmEvent, 0, len(alarms))
+	for _, a := range alarms {
+		events = append(events, &model.AlarmEvent{
+			Status: a.Status,
+			ID:     a.ID,
+			Fields: a.Fields,
+		})
+	}
 	return &dump.AlarmListResponse{
-		Alarms: alarm.ListAll(),
+		Response: discovery.CreateResponse(discovery.ResponseSuccess, "List alarms successfully"),
+		Alarms:   events,
+		Details:  alarms,
+	}, nil
+}
+
+func (service *Service) AlarmHistory(ctx context.Context, in *model.HistoryRequest) (*dump.AlarmHistoryResponse, error) {
+	histories, err := alarm.ListHistory(ctx, in)
+	if err != nil {
+		log.Errorf(err, "list alarm histories failed")
+		code := discovery.ErrInternal
+		if err == alarm.ErrNotLoaded {
+			code = discovery.ErrUnavailableBackend
+		}
+		return &dump.AlarmHistoryResponse{
+			Response: discovery.CreateResponse(code, err.Error()),
+		}, nil
+	}
+	return &dump.AlarmHistoryResponse{
+		Response:  discovery.CreateResponse(discovery.ResponseSuccess, "List alarm histories successfully"),
+		Histories: histories,
+	}, nil
+}
+
+func (service *Service) AcknowledgeAlarm(ctx context.Context, in *dump.AckAlarmRequest) (*dump.AckAlarmResponse, error) {
+	a, err := alarm.Acknowledge(ctx, in.ID, rbacsvc.UserFromContext(ctx))
+	if err != nil {
+		return &dump.AckAlarmResponse{
+			Response: discovery.CreateResponse(discovery.ErrInvalidParams, err.Error()),
+		}, nil
+	}
+	return &dump.AckAlarmResponse{
+		Response: discovery.CreateResponse(discovery.ResponseSuccess, "Acknowledge alarm successfully"),
+		Alarm:    a,
 	}, nil
 }
 
 func (service *Service) ClearAlarm(ctx context.Context, in *dump.ClearAlarmRequest) (*dump.ClearAlarmResponse, error) {
-	alarm.ClearAll()
+	if err := alarm.ClearAll(ctx); err != nil {
+		log.Errorf(err, "clear service center alarms failed")
+		return &dump.ClearAlarmResponse{
+			Response: discovery.CreateResponse(discovery.ErrInternal, err.Error()),
+		}, nil
+	}
 	log.Infof("service center alarms are cleared")
 	return &dump.ClearAlarmResponse{}, nil
 }
+
+func (service *Service) GetQuota(ctx context.Context, in *dump.GetQuotaRequest) (*dump.QuotaResponse, error) {
+	domain, project := in.Domain, in.Project
+	if len(domain) == 0 && len(project) == 0 {
+		domain, project = util.ParseDomain(ctx), util.ParseProject(ctx)
+	} else if !datasource.IsDefaultDomainProject(util.ParseDomainProject(ctx)) {
+		return &dump.QuotaResponse{
+			Response: discovery.CreateResponse(discovery.ErrForbidden, "Required admin permission"),
+		}, nil
+	}
+	q, err := quota.GetLimits(ctx, domain, project)
+	if err != nil {
+		log.Errorf(err, "get quota of domain project[%s/%s] failed", domain, project)
+		return &dump.QuotaResponse{
+			Response: discovery.CreateResponse(discovery.ErrInternal, err.Error()),
+		}, nil
+	}
+	return &dump.QuotaResponse{
+		Response: discovery.CreateResponse(discovery.ResponseSuccess, "Get quota successfully"),
+		Quota:    q,
+	}, nil
+}
+
+func (service *Service) PutQuota(ctx context.Context, in *dump.PutQuotaRequest) (*dump.QuotaResponse, error) {
+	if !datasource.IsDefaultDomainProject(util.ParseDomainProject(ctx)) {
+		return &dump.QuotaResponse{
+			Response: discovery.CreateResponse(discovery.ErrForbidden, "Required admin permission"),
+		}, nil
+	}
+	if len(in.Domain) == 0 || len(in.Project) == 0 {
+		return &dump.QuotaResponse{
+			Response: discovery.CreateResponse(discovery.ErrInvalidParams, "domain and project are required"),
+		}, nil
+	}
+	if err := quota.ValidateLimits(in.Limits); err != nil {
+		return &dump.QuotaResponse{
+			Response: discovery.CreateResponse(discovery.ErrInvalidParams, err.Error()),
+		}, nil
+	}
+	q, err := quota.PutLimits(ctx, in.Domain, in.Project, in.Limits)
+	if err != nil {
+		log.Errorf(err, "put quota of domain project[%s/%s] failed", in.Domain, in.Project)
+		return &dump.QuotaResponse{
+			Response: discovery.CreateResponse(discovery.ErrInternal, err.Error()),
+		}, nil
+	}
+	log.Infof("quota of domain project[%s/%s] is set to %v", in.Domain, in.Project, in.Limits)
+	return &dump.QuotaResponse{
+		Response: discovery.CreateResponse(discovery.ResponseSuccess, "Put quota successfully"),
+		Quota:    q,
+	}, nil
+}
+
+func (service *Service) DeleteQuota(ctx context.Context, in *dump.DeleteQuotaRequest) (*dump.DeleteQuotaResponse, error) {
+	if !datasource.IsDefaultDomainProject(util.ParseDomainProject(ctx)) {
+		return &dump.DeleteQuotaResponse{
+			Response: discovery.CreateResponse(discovery.ErrForbidden, "Required admin permission"),
+		}, nil
+	}
+	if len(in.Domain) == 0 || len(in.Project) == 0 {
+		return &dump.DeleteQuotaResponse{
+			Response: discovery.CreateResponse(discovery.ErrInvalidParams, "domain and project are required"),
+		}, nil
+	}
+	if err := quota.DeleteLimits(ctx, in.Domain, in.Project); err != nil {
+		log.Errorf(err, "delete quota of domain project[%s/%s] failed", in.Domain, in.Project)
+		return &dump.DeleteQuotaResponse{
+			Response: discovery.CreateResponse(discovery.ErrInternal, err.Error()),
+		}, nil
+	}
+	log.Infof("quota of domain project[%s/%s] is reset to the global limits", in.Domain, in.Project)
+	return &dump.DeleteQuotaResponse{
+		Response: discovery.CreateResponse(discovery.ResponseSuccess, "Delete quota successfully"),
+	}, nil
+}
+
+func (service *Service) QuotaUsage(ctx context.Context, in *dump.QuotaUsageRequest) (*dump.QuotaUsageResponse, error) {
+	usages, err := quotaplugin.ListUsage(ctx, in.ServiceID)
+	if err != nil {
+		return &dump.QuotaUsageResponse{
+			Response: discovery.CreateResponse(discovery.ErrInternal, err.Error()),
+		}, nil
+	}
+	return &dump.QuotaUsageResponse{
+		Response: discovery.CreateResponse(discovery.ResponseSuccess, "Get quota usage successfully"),
+		Usages:   usages,
+	}, nil
+}
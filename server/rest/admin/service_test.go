@@ -21,9 +21,11 @@ import (
 	"context"
 	"testing"
 
+	"github.com/apache/servicecomb-service-center/datasource"
 	"github.com/apache/servicecomb-service-center/pkg/dump"
 	"github.com/apache/servicecomb-service-center/pkg/util"
 	"github.com/apache/servicecomb-service-center/server/rest/admin"
+	"github.com/apache/servicecomb-service-center/server/service/disco"
 	_ "github.com/apache/servicecomb-service-center/test"
 	"github.com/astaxie/beego"
 	"github.com/go-chassis/cari/discovery"
@@ -44,8 +46,129 @@ func TestAdminService_Dump(t *testing.T) {
 		&dump.Request{})
 	assert.NoError(t, err)
 	assert.Equal(t, discovery.ErrForbidden, resp.Response.GetCode())
+
+	t.Log("execute 'dump' operation,when get since the latest revision,should return the incremental cache")
+	resp, err = admin.AdminServiceAPI.Dump(getContext(), &dump.Request{})
+	assert.NoError(t, err)
+	rev := resp.Cache.Revision
+	resp, err = admin.AdminServiceAPI.Dump(getContext(), &dump.Request{Since: rev})
+	assert.NoError(t, err)
+	assert.Equal(t, discovery.ResponseSuccess, resp.Response.GetCode())
+	assert.True(t, resp.Cache.Incremental)
 }
 
 func getContext() context.Context {
 	return util.WithNoCache(util.SetDomainProject(context.Background(), "default", "default"))
 }
+
+func TestAdminService_Quota(t *testing.T) {
+	ctx := util.SetDomainProject(context.Background(), "admin_quota", "admin_quota")
+
+	resp, err := admin.AdminServiceAPI.PutQuota(ctx, &dump.PutQuotaRequest{
+		Domain: "admin_quota", Project: "admin_quota", Limits: map[string]int64{"service": 100}})
+	assert.NoError(t, err)
+	assert.Equal(t, discovery.ErrForbidden, resp.Response.GetCode())
+
+	delResp, err := admin.AdminServiceAPI.DeleteQuota(ctx, &dump.DeleteQuotaRequest{Domain: "admin_quota", Project: "admin_quota"})
+	assert.NoError(t, err)
+	assert.Equal(t, discovery.ErrForbidden, delResp.Response.GetCode())
+
+	resp, err = admin.AdminServiceAPI.GetQuota(ctx, &dump.GetQuotaRequest{Domain: "default", Project: "default"})
+	assert.NoError(t, err)
+	assert.Equal(t, discovery.ErrForbidden, resp.Response.GetCode())
+
+	resp, err = admin.AdminServiceAPI.PutQuota(getContext(), &dump.PutQuotaRequest{Limits: map[string]int64{"service": 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, discovery.ErrInvalidParams, resp.Response.GetCode())
+
+	resp, err = admin.AdminServiceAPI.PutQuota(getContext(), &dump.PutQuotaRequest{
+		Domain: "admin_quota", Project: "admin_quota", Limits: map[string]int64{"unknown": 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, discovery.ErrInvalidParams, resp.Response.GetCode())
+
+	resp, err = admin.AdminServiceAPI.PutQuota(getContext(), &dump.PutQuotaRequest{
+		Domain: "admin_quota", Project: "admin_quota", Limits: map[string]int64{"service": 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, discovery.ResponseSuccess, resp.Response.GetCode())
+
+	resp, err = admin.AdminServiceAPI.GetQuota(ctx, &dump.GetQuotaRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), resp.Quota.Limits["service"])
+	resp, err = admin.AdminServiceAPI.GetQuota(getContext(), &dump.GetQuotaRequest{Domain: "admin_quota", Project: "admin_quota"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), resp.Quota.Limits["service"])
+
+	usageResp, err := admin.AdminServiceAPI.QuotaUsage(ctx, &dump.QuotaUsageRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, discovery.ResponseSuccess, usageResp.Response.GetCode())
+	assert.Equal(t, 7, len(usageResp.Usages))
+	for _, usage := range usageResp.Usages {
+		if usage.Resource == "service" {
+			assert.Equal(t, int64(1), usage.Limit)
+			assert.Equal(t, int64(0), usage.Used)
+		}
+	}
+
+	t.Run("list the usage of service, should count the per service resources", func(t *testing.T) {
+		service, err := disco.RegisterService(ctx, &discovery.CreateServiceRequest{
+			Service: &discovery.MicroService{ServiceName: "admin_quota"},
+		})
+		assert.NoError(t, err)
+		defer disco.UnregisterService(ctx, &discovery.DeleteServiceRequest{ServiceId: service.ServiceId, Force: true})
+		_, err = datasource.GetMetadataManager().AddTags(ctx, &discovery.AddServiceTagsRequest{
+			ServiceId: service.ServiceId,
+			Tags:      map[string]string{"a": "1", "b": "2"},
+		})
+		assert.NoError(t, err)
+
+		usageResp, err := admin.AdminServiceAPI.QuotaUsage(util.WithNoCache(ctx),
+			&dump.QuotaUsageRequest{ServiceID: service.ServiceId})
+		assert.NoError(t, err)
+		assert.Equal(t, discovery.ResponseSuccess, usageResp.Response.GetCode())
+		for _, usage := range usageResp.Usages {
+			switch usage.Resource {
+			case "service":
+				assert.Equal(t, int64(1), usage.Used)
+				assert.Empty(t, usage.ServiceID)
+			case "tag":
+				assert.Equal(t, int64(2), usage.Used)
+				assert.Equal(t, service.ServiceId, usage.ServiceID)
+			case "schema", "rule":
+				assert.Equal(t, int64(0), usage.Used)
+				assert.Equal(t, service.ServiceId, usage.ServiceID)
+			}
+		}
+	})
+
+	delResp, err = admin.AdminServiceAPI.DeleteQuota(getContext(), &dump.DeleteQuotaRequest{Domain: "admin_quota", Project: "admin_quota"})
+	assert.NoError(t, err)
+	assert.Equal(t, discovery.ResponseSuccess, delResp.Response.GetCode())
+}
+
+func TestAdminService_ReloadConfig(t *testing.T) {
+	resp, err := admin.AdminServiceAPI.ReloadConfig(util.SetDomainProject(context.Background(), "x", "x"),
+		&dump.ReloadConfigRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, discovery.ErrForbidden, resp.Response.GetCode())
+}
+
+func TestAdminService_ExportImport(t *testing.T) {
+	exportResp, err := admin.AdminServiceAPI.Export(util.SetDomainProject(context.Background(), "x", "x"),
+		&dump.ExportRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, discovery.ErrForbidden, exportResp.Response.GetCode())
+
+	exportResp, err = admin.AdminServiceAPI.Export(getContext(), &dump.ExportRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, discovery.ResponseSuccess, exportResp.Response.GetCode())
+	assert.Equal(t, dump.ArchiveVersion, exportResp.Archive.Version)
+
+	importResp, err := admin.AdminServiceAPI.Import(getContext(), &dump.ImportRequest{Archive: exportResp.Archive})
+	assert.NoError(t, err)
+	assert.Equal(t, discovery.ResponseSuccess, importResp.Response.GetCode())
+	assert.Equal(t, 0, importResp.Result.Imported[dump.ResourceService])
+
+	importResp, err = admin.AdminServiceAPI.Import(getContext(), &dump.ImportRequest{Archive: &dump.Archive{}})
+	assert.NoError(t, err)
+	assert.Equal(t, discovery.ErrInvalidParams, importResp.Response.GetCode())
+}
@@ -18,6 +18,7 @@
 package v4
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -28,6 +29,7 @@ import (
 
 	"github.com/apache/servicecomb-service-center/pkg/log"
 	"github.com/apache/servicecomb-service-center/pkg/rest"
+	"github.com/apache/servicecomb-service-center/pkg/selector"
 	"github.com/apache/servicecomb-service-center/pkg/util"
 	pb "github.com/go-chassis/cari/discovery"
 )
@@ -136,7 +138,13 @@ func (s *MicroServiceInstanceService) FindInstances(w http.ResponseWriter, r *ht
 		Tags:              ids,
 	}
 
-	ctx := util.SetTargetDomainProject(r.Context(), r.Header.Get("X-Domain-Name"), query.Get(":project"))
+	ctx, err := withSelector(r.Context(), query.Get("selector"))
+	if err != nil {
+		log.Errorf(err, "invalid selector")
+		rest.WriteError(w, pb.ErrInvalidParams, err.Error())
+		return
+	}
+	ctx = util.SetTargetDomainProject(ctx, r.Header.Get("X-Domain-Name"), query.Get(":project"))
 
 	resp, _ := discosvc.FindInstances(ctx, request)
 	respInternal := resp.Response
@@ -171,7 +179,13 @@ func (s *MicroServiceInstanceService) InstancesAction(w http.ResponseWriter, r *
 			return
 		}
 		request.ConsumerServiceId = r.Header.Get("X-ConsumerId")
-		ctx := util.SetTargetDomainProject(r.Context(), r.Header.Get("X-Domain-Name"), r.URL.Query().Get(":project"))
+		ctx, err := withSelector(r.Context(), query.Get("selector"))
+		if err != nil {
+			log.Errorf(err, "invalid selector")
+			rest.WriteError(w, pb.ErrInvalidParams, err.Error())
+			return
+		}
+		ctx = util.SetTargetDomainProject(ctx, r.Header.Get("X-Domain-Name"), query.Get(":project"))
 		resp, _ := discosvc.BatchFindInstances(ctx, request)
 		rest.WriteResponse(w, r, resp.Response, resp)
 	default:
@@ -272,3 +286,14 @@ func (s *MicroServiceInstanceService) UpdateMetadata(w http.ResponseWriter, r *h
 	}
 	rest.WriteResponse(w, r, resp.Response, nil)
 }
+
+func withSelector(ctx context.Context, expr string) (context.Context, error) {
+	if len(expr) == 0 {
+		return ctx, nil
+	}
+	s, err := selector.Parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector '%s': %s", expr, err.Error())
+	}
+	return selector.SetContext(ctx, s), nil
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/apache/servicecomb-service-center/pkg/util"
 	"github.com/apache/servicecomb-service-center/server/config"
 	"github.com/apache/servicecomb-service-center/server/core"
+	"github.com/apache/servicecomb-service-center/server/service/disco"
 	pb "github.com/go-chassis/cari/discovery"
 )
 
@@ -44,6 +45,7 @@ func (s *SchemaService) URLPatterns() []rest.Route {
 		{Method: http.MethodDelete, Path: "/v4/:project/registry/microservices/:serviceId/schemas/:schemaId", Func: s.DeleteSchemas},
 		{Method: http.MethodPost, Path: "/v4/:project/registry/microservices/:serviceId/schemas", Func: s.ModifySchemas},
 		{Method: http.MethodGet, Path: "/v4/:project/registry/microservices/:serviceId/schemas", Func: s.GetAllSchemas},
+		{Method: http.MethodGet, Path: "/v4/:project/registry/microservices/:serviceId/schemas/:schemaId/diff", Func: s.DiffSchema},
 	}
 
 	if !config.GetRegistry().SchemaDisable {
@@ -148,3 +150,13 @@ func (s *SchemaService) GetAllSchemas(w http.ResponseWriter, r *http.Request) {
 	resp, _ := core.ServiceAPI.GetAllSchemaInfo(r.Context(), request)
 	rest.WriteResponse(w, r, resp.Response, resp)
 }
+
+func (s *SchemaService) DiffSchema(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := &disco.DiffSchemaRequest{
+		ServiceId: query.Get(":serviceId"),
+		SchemaId:  query.Get(":schemaId"),
+	}
+	resp, _ := disco.DiffSchema(r.Context(), request)
+	rest.WriteResponse(w, r, resp.Response, resp)
+}
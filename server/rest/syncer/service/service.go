@@ -22,7 +22,9 @@ import (
 	"net/http"
 
 	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/rest"
 	"github.com/apache/servicecomb-service-center/server/syncernotify"
+	"github.com/go-chassis/cari/discovery"
 	"github.com/gorilla/websocket"
 )
 
@@ -34,6 +36,19 @@ type Service struct {
 }
 
 func (service *Service) WatchInstance(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	token := query.Get("resumeToken")
+	if len(token) > 0 {
+		if _, _, err := syncernotify.ParseToken(token); err != nil {
+			rest.WriteError(w, discovery.ErrInvalidParams, err.Error())
+			return
+		}
+	}
+	filter := syncernotify.WatchFilter{
+		Domain:  query.Get("domain"),
+		Project: query.Get("project"),
+	}
+
 	var upgrader = websocket.Upgrader{
 		CheckOrigin: func(r *http.Request) bool {
 			return true
@@ -46,5 +61,6 @@ func (service *Service) WatchInstance(w http.ResponseWriter, r *http.Request) {
 	}
 	defer conn.Close()
 
-	syncernotify.DoWebSocketWatch(context.Background(), conn)
+	syncernotify.DoWebSocketWatch(context.Background(), conn,
+		syncernotify.WithFilter(filter), syncernotify.WithResumeToken(token))
 }
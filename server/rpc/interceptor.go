@@ -0,0 +1,316 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-chassis/cari/discovery"
+	"github.com/go-chassis/cari/pkg/errsvc"
+	"github.com/go-chassis/cari/rbac"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/chain"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/rest"
+	pkgrpc "github.com/apache/servicecomb-service-center/pkg/rpc"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	authHandler "github.com/apache/servicecomb-service-center/server/handler/auth"
+	"github.com/apache/servicecomb-service-center/server/plugin/auth"
+	"github.com/apache/servicecomb-service-center/server/response"
+)
+
+// MetaAuthorization is the metadata key of the token, the value format is
+// the same as the REST header 'Authorization: Bearer <token>'
+const MetaAuthorization = "authorization"
+
+// UnaryServerInterceptor writes the domain and project into the context, then
+// runs the gRPC server chain, e.g. the access log and audit log handlers, and
+// authenticates the request like the REST handler chain, finally converts the
+// failed response to the gRPC status error
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	r, ok := routes[info.FullMethod]
+	if !ok {
+		return handler(ctx, req)
+	}
+	httpReq, err := toHTTPRequest(ctx, r, req)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	util.SetRequestContext(httpReq, rest.CtxStartTimestamp, time.Now())
+
+	h := &unaryHandler{route: r, req: req, handler: handler}
+	handlers := chain.Handlers(pkgrpc.ServerChainName)
+	// copy the registered handlers, appending may modify the shared array
+	handlers = append(append(make([]chain.Handler, 0, len(handlers)+1), handlers...), h)
+	inv := chain.NewInvocation(httpReq.Context(), chain.NewChain(pkgrpc.ServerChainName, handlers))
+	inv.WithContext(rest.CtxRequest, httpReq).
+		WithContext(rest.CtxResponse, &responseWriter{header: make(http.Header)}).
+		WithContext(rest.CtxMatchPattern, r.Pattern).
+		WithContext(rest.CtxResponseStatus, http.StatusOK)
+
+	var resp interface{}
+	inv.Invoke(func(ret chain.Result) {
+		if !ret.OK {
+			err = toStatusError(ret.Err)
+			return
+		}
+		resp = h.resp
+	})
+	return resp, err
+}
+
+// unaryHandler is the last handler of the gRPC server chain, it authenticates
+// the request and calls the gRPC method
+type unaryHandler struct {
+	route   route
+	req     interface{}
+	handler grpc.UnaryHandler
+	resp    interface{}
+}
+
+func (h *unaryHandler) Handle(i *chain.Invocation) {
+	r := i.Context().Value(rest.CtxRequest).(*http.Request)
+	labels, err := identify(r)
+	if err != nil {
+		h.fail(i, err)
+		return
+	}
+	resp, err := h.handler(r.Context(), h.req)
+	if err != nil {
+		h.fail(i, err)
+		return
+	}
+	if scErr := responseError(resp); scErr != nil {
+		h.fail(i, scErr)
+		return
+	}
+	i.WithContext(rest.CtxResponseObject, resp)
+	if len(labels) > 0 {
+		resp = response.Filter(h.route.Pattern, resp, labels)
+	}
+	h.resp = resp
+	i.Success()
+}
+
+// fail records the status code like the REST response for the log handlers
+func (h *unaryHandler) fail(i *chain.Invocation, err error) {
+	statusCode := http.StatusInternalServerError
+	if scErr, ok := err.(*errsvc.Error); ok {
+		statusCode = scErr.StatusCode()
+	}
+	i.WithContext(rest.CtxResponseStatus, statusCode)
+	i.Fail(err)
+}
+
+// responseWriter is the placeholder of the REST response for the chain
+// handlers, the gRPC response is returned by the interceptor
+type responseWriter struct {
+	header http.Header
+}
+
+func (w *responseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+func (w *responseWriter) WriteHeader(int) {
+}
+
+// StreamServerInterceptor authenticates the stream by the first received
+// request, then the stream handler gets the context with domain and project
+func StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	r, ok := routes[info.FullMethod]
+	if !ok {
+		return handler(srv, ss)
+	}
+	err := handler(srv, &serverStream{ServerStream: ss, ctx: ss.Context(), route: r})
+	if err != nil {
+		return toStatusError(err)
+	}
+	return nil
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx           context.Context
+	route         route
+	authenticated bool
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.authenticated {
+		return nil
+	}
+	ctx, _, err := authenticate(s.ctx, s.route, m)
+	if err != nil {
+		return err
+	}
+	s.ctx, s.authenticated = ctx, true
+	return nil
+}
+
+// authenticate converts the gRPC request to the equivalent REST request,
+// then applies the auth plugin to it
+func authenticate(ctx context.Context, r route, req interface{}) (context.Context, []map[string]string, error) {
+	httpReq, err := toHTTPRequest(ctx, r, req)
+	if err != nil {
+		return nil, nil, status.Error(codes.Internal, err.Error())
+	}
+	labels, err := identify(httpReq)
+	if err != nil {
+		return nil, nil, toStatusError(err)
+	}
+	return httpReq.Context(), labels, nil
+}
+
+// identify applies the auth plugin to the request, returns the labels
+// of the resources which the account is allowed to access
+func identify(httpReq *http.Request) ([]map[string]string, error) {
+	util.SetRequestContext(httpReq, authHandler.CtxResourceScopes, auth.ResourceScopes(httpReq))
+	if err := auth.Identify(httpReq); err != nil {
+		log.Errorf(err, "authenticate request failed, %s %s", httpReq.Method, httpReq.RequestURI)
+		if e, ok := err.(*errsvc.Error); ok {
+			return nil, e
+		}
+		return nil, discovery.NewError(rbac.ErrUnauthorized, err.Error())
+	}
+	labels, _ := httpReq.Context().Value(authHandler.CtxResourceLabels).([]map[string]string)
+	return labels, nil
+}
+
+func toHTTPRequest(ctx context.Context, r route, req interface{}) (*http.Request, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	domain, project := firstValue(md, string(util.CtxDomain)), firstValue(md, string(util.CtxProject))
+	if len(domain) == 0 {
+		domain = datasource.RegistryDomain
+	}
+	if len(project) == 0 {
+		project = datasource.RegistryProject
+	}
+	ctx = util.SetDomainProject(ctx, domain, project)
+	ctx = util.SetTargetDomainProject(ctx, domain, project)
+	ctx = util.SetContext(ctx, rest.CtxMatchPattern, r.Pattern)
+	if p, ok := peer.FromContext(ctx); ok {
+		ctx = util.SetContext(ctx, util.CtxRemoteIP, util.ParseIPPort(p.Addr.String()).IP)
+	}
+
+	query := url.Values{}
+	query.Set(":project", project)
+	path := strings.Replace(r.Pattern, ":project", url.PathEscape(project), 1)
+	for key, field := range r.Params {
+		value := fieldString(req, field)
+		query.Set(key, value)
+		if strings.HasPrefix(key, ":") {
+			path = strings.Replace(path, key, url.PathEscape(value), 1)
+		}
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequest(r.Method, path+"?"+query.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.RequestURI = path
+	httpReq.Header.Set("X-Domain-Name", domain)
+	if token := firstValue(md, MetaAuthorization); len(token) > 0 {
+		httpReq.Header.Set("Authorization", token)
+	}
+	return httpReq.WithContext(ctx), nil
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// responseError returns the error of the 'Response' field in the gRPC
+// response, nil if the response succeeded
+func responseError(resp interface{}) *errsvc.Error {
+	rv := reflect.ValueOf(resp)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	field := rv.Elem().FieldByName("Response")
+	if !field.IsValid() {
+		return nil
+	}
+	r, ok := field.Interface().(*discovery.Response)
+	if !ok || r.GetCode() == discovery.ResponseSuccess {
+		return nil
+	}
+	return discovery.NewError(r.GetCode(), r.GetMessage())
+}
+
+// toStatusError converts the error to the gRPC status error, the status
+// message is the JSON encoded errsvc.Error like the REST error body
+func toStatusError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	scErr, ok := err.(*errsvc.Error)
+	if !ok {
+		scErr = discovery.NewError(discovery.ErrInternal, err.Error())
+	}
+	code := codes.Unknown
+	switch sc := scErr.StatusCode(); {
+	case sc == http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case sc == http.StatusForbidden:
+		code = codes.PermissionDenied
+	case sc == http.StatusNotFound:
+		code = codes.NotFound
+	case sc == http.StatusTooManyRequests:
+		code = codes.ResourceExhausted
+	case sc == http.StatusServiceUnavailable:
+		code = codes.Unavailable
+	case sc >= http.StatusInternalServerError:
+		code = codes.Internal
+	case sc >= http.StatusBadRequest:
+		code = codes.InvalidArgument
+	}
+	b, _ := json.Marshal(scErr)
+	return status.Error(code, string(b))
+}
@@ -0,0 +1,114 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rpc
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+const (
+	servicePrefix  = "/servicecenter.grpc.api.ServiceCtrl/"
+	instancePrefix = "/servicecenter.grpc.api.ServiceInstanceCtrl/"
+)
+
+// route is the equivalent REST API of the gRPC method, the REST auth and
+// resource parsers apply to the gRPC requests by the route
+type route struct {
+	Method  string
+	Pattern string
+	// Params maps the path parameters of the Pattern and the queries to the
+	// field paths of the gRPC request
+	Params map[string]string
+}
+
+var (
+	byServiceID  = map[string]string{":serviceId": "ServiceId"}
+	byProviderID = map[string]string{":serviceId": "ProviderServiceId"}
+	byInstanceID = map[string]string{":serviceId": "ServiceId", ":instanceId": "InstanceId"}
+	byServiceKey = map[string]string{"env": "Environment", "appId": "AppId", "serviceName": "ServiceName"}
+
+	routes = map[string]route{
+		servicePrefix + "Exist": {http.MethodGet, "/v4/:project/registry/existence",
+			map[string]string{"env": "Environment", "appId": "AppId", "serviceName": "ServiceName", "serviceId": "ServiceId"}},
+		servicePrefix + "Create":                             {http.MethodPost, "/v4/:project/registry/microservices", nil},
+		servicePrefix + "Delete":                             {http.MethodDelete, "/v4/:project/registry/microservices/:serviceId", byServiceID},
+		servicePrefix + "GetOne":                             {http.MethodGet, "/v4/:project/registry/microservices/:serviceId", byServiceID},
+		servicePrefix + "GetServices":                        {http.MethodGet, "/v4/:project/registry/microservices", nil},
+		servicePrefix + "UpdateProperties":                   {http.MethodPut, "/v4/:project/registry/microservices/:serviceId/properties", byServiceID},
+		servicePrefix + "AddRule":                            {http.MethodPost, "/v4/:project/registry/microservices/:serviceId/rules", byServiceID},
+		servicePrefix + "GetRule":                            {http.MethodGet, "/v4/:project/registry/microservices/:serviceId/rules", byServiceID},
+		servicePrefix + "UpdateRule":                         {http.MethodPut, "/v4/:project/registry/microservices/:serviceId/rules/:rule_id", byServiceID},
+		servicePrefix + "DeleteRule":                         {http.MethodDelete, "/v4/:project/registry/microservices/:serviceId/rules/:rule_id", byServiceID},
+		servicePrefix + "AddTags":                            {http.MethodPost, "/v4/:project/registry/microservices/:serviceId/tags", byServiceID},
+		servicePrefix + "GetTags":                            {http.MethodGet, "/v4/:project/registry/microservices/:serviceId/tags", byServiceID},
+		servicePrefix + "UpdateTag":                          {http.MethodPut, "/v4/:project/registry/microservices/:serviceId/tags/:key", byServiceID},
+		servicePrefix + "DeleteTags":                         {http.MethodDelete, "/v4/:project/registry/microservices/:serviceId/tags/:key", byServiceID},
+		servicePrefix + "GetSchemaInfo":                      {http.MethodGet, "/v4/:project/registry/microservices/:serviceId/schemas/:schemaId", byServiceID},
+		servicePrefix + "GetAllSchemaInfo":                   {http.MethodGet, "/v4/:project/registry/microservices/:serviceId/schemas", byServiceID},
+		servicePrefix + "DeleteSchema":                       {http.MethodDelete, "/v4/:project/registry/microservices/:serviceId/schemas/:schemaId", byServiceID},
+		servicePrefix + "ModifySchema":                       {http.MethodPut, "/v4/:project/registry/microservices/:serviceId/schemas/:schemaId", byServiceID},
+		servicePrefix + "ModifySchemas":                      {http.MethodPost, "/v4/:project/registry/microservices/:serviceId/schemas", byServiceID},
+		servicePrefix + "AddDependenciesForMicroServices":    {http.MethodPost, "/v4/:project/registry/dependencies", nil},
+		servicePrefix + "CreateDependenciesForMicroServices": {http.MethodPut, "/v4/:project/registry/dependencies", nil},
+		servicePrefix + "GetProviderDependencies": {http.MethodGet, "/v4/:project/registry/microservices/:providerId/consumers",
+			map[string]string{":providerId": "ServiceId"}},
+		servicePrefix + "GetConsumerDependencies": {http.MethodGet, "/v4/:project/registry/microservices/:consumerId/providers",
+			map[string]string{":consumerId": "ServiceId"}},
+		servicePrefix + "DeleteServices": {http.MethodDelete, "/v4/:project/registry/microservices", nil},
+
+		instancePrefix + "Register": {http.MethodPost, "/v4/:project/registry/microservices/:serviceId/instances",
+			map[string]string{":serviceId": "Instance.ServiceId"}},
+		instancePrefix + "Unregister":               {http.MethodDelete, "/v4/:project/registry/microservices/:serviceId/instances/:instanceId", byInstanceID},
+		instancePrefix + "Heartbeat":                {http.MethodPut, "/v4/:project/registry/microservices/:serviceId/instances/:instanceId/heartbeat", byInstanceID},
+		instancePrefix + "Find":                     {http.MethodGet, "/v4/:project/registry/instances", byServiceKey},
+		instancePrefix + "GetInstances":             {http.MethodGet, "/v4/:project/registry/microservices/:serviceId/instances", byProviderID},
+		instancePrefix + "GetOneInstance":           {http.MethodGet, "/v4/:project/registry/microservices/:serviceId/instances/:instanceId", map[string]string{":serviceId": "ProviderServiceId", ":instanceId": "ProviderInstanceId"}},
+		instancePrefix + "UpdateStatus":             {http.MethodPut, "/v4/:project/registry/microservices/:serviceId/instances/:instanceId/status", byInstanceID},
+		instancePrefix + "UpdateInstanceProperties": {http.MethodPut, "/v4/:project/registry/microservices/:serviceId/instances/:instanceId/properties", byInstanceID},
+		instancePrefix + "Watch": {http.MethodGet, "/v4/:project/registry/microservices/:serviceId/watcher",
+			map[string]string{":serviceId": "SelfServiceId"}},
+		instancePrefix + "HeartbeatSet": {http.MethodPut, "/v4/:project/registry/heartbeats", nil},
+	}
+)
+
+// fieldString returns the string field of the struct pointer v by the field
+// path, e.g. "Instance.ServiceId", empty if the field does not exist
+func fieldString(v interface{}, path string) string {
+	rv := reflect.ValueOf(v)
+	for _, name := range strings.Split(path, ".") {
+		for rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				return ""
+			}
+			rv = rv.Elem()
+		}
+		if rv.Kind() != reflect.Struct {
+			return ""
+		}
+		rv = rv.FieldByName(name)
+		if !rv.IsValid() {
+			return ""
+		}
+	}
+	if rv.Kind() != reflect.String {
+		return ""
+	}
+	return rv.String()
+}
@@ -0,0 +1,77 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package rpc serves the discovery API and the instance watch stream in gRPC,
+// the requests are authenticated like the REST API
+package rpc
+
+import (
+	"crypto/tls"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	pkgrpc "github.com/apache/servicecomb-service-center/pkg/rpc"
+	"github.com/apache/servicecomb-service-center/server/config"
+	"github.com/apache/servicecomb-service-center/server/plugin/security/tlsconf"
+)
+
+type Server struct {
+	*grpc.Server
+	Listener net.Listener
+}
+
+// Serve blocks until the server stops
+func (s *Server) Serve() error {
+	return s.Server.Serve(s.Listener)
+}
+
+func (s *Server) Shutdown() {
+	s.Server.GracefulStop()
+}
+
+// NewServer listens the address, the TLS is enabled by the ssl config
+func NewServer(ipAddr string) (*Server, error) {
+	var tlsConfig *tls.Config
+	if config.GetSSL().SslEnabled {
+		var err error
+		tlsConfig, err = tlsconf.ServerConfig()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return newServer(ipAddr, tlsConfig, grpc.MaxRecvMsgSize(int(config.GetServer().MaxBodyBytes)))
+}
+
+func newServer(ipAddr string, tlsConfig *tls.Config, opts ...grpc.ServerOption) (*Server, error) {
+	opts = append(opts,
+		grpc.UnaryInterceptor(UnaryServerInterceptor),
+		grpc.StreamInterceptor(StreamServerInterceptor),
+	)
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	srv := grpc.NewServer(opts...)
+	pkgrpc.RegisterGRpcServer(srv)
+
+	ls, err := net.Listen("tcp", ipAddr)
+	if err != nil {
+		return nil, err
+	}
+	return &Server{Server: srv, Listener: ls}, nil
+}
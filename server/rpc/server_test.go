@@ -0,0 +1,275 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/go-chassis/cari/discovery"
+	"github.com/go-chassis/cari/pkg/errsvc"
+	"github.com/go-chassis/cari/rbac"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/apache/servicecomb-service-center/pkg/chain"
+	"github.com/apache/servicecomb-service-center/pkg/plugin"
+	"github.com/apache/servicecomb-service-center/pkg/proto"
+	"github.com/apache/servicecomb-service-center/pkg/rest"
+	pkgrpc "github.com/apache/servicecomb-service-center/pkg/rpc"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/plugin/auth"
+)
+
+const testToken = "Bearer test"
+
+type identified struct {
+	Method  string
+	Path    string
+	Query   string
+	Domain  string
+	Project string
+}
+
+// fakeAuth accepts the testToken only, and records the identified requests
+type fakeAuth struct {
+	lock     sync.Mutex
+	requests []identified
+}
+
+func (a *fakeAuth) Identify(r *http.Request) error {
+	a.lock.Lock()
+	a.requests = append(a.requests, identified{
+		Method:  r.Method,
+		Path:    r.URL.Path,
+		Query:   r.URL.RawQuery,
+		Domain:  r.Header.Get("X-Domain-Name"),
+		Project: r.URL.Query().Get(":project"),
+	})
+	a.lock.Unlock()
+	if r.Header.Get("Authorization") != testToken {
+		return discovery.NewError(rbac.ErrUnauthorized, "invalid token")
+	}
+	return nil
+}
+
+func (a *fakeAuth) ResourceScopes(r *http.Request) *auth.ResourceScope {
+	return &auth.ResourceScope{Type: "service", Verb: "get"}
+}
+
+func (a *fakeAuth) last() identified {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.requests[len(a.requests)-1]
+}
+
+// fakeInstanceCtrl responds the domain and project of the context
+type fakeInstanceCtrl struct {
+	proto.ServiceInstanceCtrlServer
+}
+
+func (c *fakeInstanceCtrl) Find(ctx context.Context, in *discovery.FindInstancesRequest) (*discovery.FindInstancesResponse, error) {
+	if in.ServiceName == "not-exist" {
+		return &discovery.FindInstancesResponse{
+			Response: discovery.CreateResponse(discovery.ErrServiceNotExists, "service does not exist"),
+		}, nil
+	}
+	return &discovery.FindInstancesResponse{
+		Response: discovery.CreateResponse(discovery.ResponseSuccess, "ok"),
+		Instances: []*discovery.MicroServiceInstance{
+			{InstanceId: util.ParseDomain(ctx) + "/" + util.ParseProject(ctx)},
+		},
+	}, nil
+}
+
+func (c *fakeInstanceCtrl) Watch(in *discovery.WatchInstanceRequest, stream proto.ServiceInstanceCtrlWatchServer) error {
+	ctx := stream.Context()
+	return stream.Send(&discovery.WatchInstanceResponse{
+		Action: string(discovery.EVT_CREATE),
+		Key:    &discovery.MicroServiceKey{Tenant: util.ParseDomainProject(ctx)},
+		Instance: &discovery.MicroServiceInstance{
+			ServiceId: in.SelfServiceId,
+		},
+	})
+}
+
+// chainRecorder records the route and status code of the server chain
+type chainRecorder struct {
+	lock     sync.Mutex
+	patterns []string
+	statuses []int
+}
+
+func (h *chainRecorder) Handle(i *chain.Invocation) {
+	i.Next(chain.WithFunc(func(_ chain.Result) {
+		h.lock.Lock()
+		h.patterns = append(h.patterns, i.Context().Value(rest.CtxMatchPattern).(string))
+		h.statuses = append(h.statuses, i.Context().Value(rest.CtxResponseStatus).(int))
+		h.lock.Unlock()
+	}))
+}
+
+func (h *chainRecorder) last() (string, int) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return h.patterns[len(h.patterns)-1], h.statuses[len(h.statuses)-1]
+}
+
+var (
+	testAuth  = &fakeAuth{}
+	testChain = &chainRecorder{}
+)
+
+func init() {
+	plugin.RegisterPlugin(plugin.Plugin{Kind: auth.AUTH, Name: "buildin", New: func() plugin.Instance {
+		return testAuth
+	}})
+	chain.RegisterHandler(pkgrpc.ServerChainName, testChain)
+	pkgrpc.RegisterService(func(s *grpc.Server) {
+		proto.RegisterServiceInstanceCtrlServer(s, &fakeInstanceCtrl{})
+	})
+}
+
+func newTestConn(t *testing.T) *grpc.ClientConn {
+	srv, err := newServer("127.0.0.1:0", nil)
+	assert.NoError(t, err)
+	go srv.Serve()
+	t.Cleanup(srv.Shutdown)
+
+	conn, err := grpc.Dial(srv.Listener.Addr().String(), grpc.WithInsecure(),
+		grpc.WithDefaultCallOptions(grpc.CallContentSubtype(pkgrpc.CodecJSON)))
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func testContext(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(),
+		"domain", "d1", "project", "p1", MetaAuthorization, token)
+}
+
+func TestServer_Unary(t *testing.T) {
+	conn := newTestConn(t)
+	find := instancePrefix + "Find"
+
+	t.Run("authenticated request should get the domain project context", func(t *testing.T) {
+		resp := &discovery.FindInstancesResponse{}
+		err := conn.Invoke(testContext(testToken), find,
+			&discovery.FindInstancesRequest{AppId: "app", ServiceName: "svc"}, resp)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(resp.Instances))
+		assert.Equal(t, "d1/p1", resp.Instances[0].InstanceId)
+
+		r := testAuth.last()
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/v4/p1/registry/instances", r.Path)
+		assert.Equal(t, "d1", r.Domain)
+		assert.Equal(t, "p1", r.Project)
+		assert.Contains(t, r.Query, "serviceName=svc")
+
+		pattern, statusCode := testChain.last()
+		assert.Equal(t, "/v4/:project/registry/instances", pattern)
+		assert.Equal(t, http.StatusOK, statusCode)
+	})
+
+	t.Run("invalid token should be unauthenticated", func(t *testing.T) {
+		err := conn.Invoke(testContext("Bearer invalid"), find,
+			&discovery.FindInstancesRequest{ServiceName: "svc"}, &discovery.FindInstancesResponse{})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		scErr := &errsvc.Error{}
+		assert.NoError(t, json.Unmarshal([]byte(status.Convert(err).Message()), scErr))
+		assert.Equal(t, int32(rbac.ErrUnauthorized), scErr.Code)
+
+		_, statusCode := testChain.last()
+		assert.Equal(t, http.StatusUnauthorized, statusCode)
+	})
+
+	t.Run("failed response should be converted to status error", func(t *testing.T) {
+		err := conn.Invoke(testContext(testToken), find,
+			&discovery.FindInstancesRequest{ServiceName: "not-exist"}, &discovery.FindInstancesResponse{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		scErr := &errsvc.Error{}
+		assert.NoError(t, json.Unmarshal([]byte(status.Convert(err).Message()), scErr))
+		assert.Equal(t, int32(discovery.ErrServiceNotExists), scErr.Code)
+
+		_, statusCode := testChain.last()
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+}
+
+func TestServer_Watch(t *testing.T) {
+	conn := newTestConn(t)
+	desc := &grpc.StreamDesc{StreamName: "Watch", ServerStreams: true}
+	method := instancePrefix + "Watch"
+
+	t.Run("authenticated stream should receive the event", func(t *testing.T) {
+		stream, err := conn.NewStream(testContext(testToken), desc, method)
+		assert.NoError(t, err)
+		assert.NoError(t, stream.SendMsg(&discovery.WatchInstanceRequest{SelfServiceId: "svc1"}))
+		assert.NoError(t, stream.CloseSend())
+
+		resp := &discovery.WatchInstanceResponse{}
+		assert.NoError(t, stream.RecvMsg(resp))
+		assert.Equal(t, "d1/p1", resp.Key.Tenant)
+		assert.Equal(t, "svc1", resp.Instance.ServiceId)
+
+		r := testAuth.last()
+		assert.Equal(t, "/v4/p1/registry/microservices/svc1/watcher", r.Path)
+	})
+
+	t.Run("invalid token should close the stream", func(t *testing.T) {
+		stream, err := conn.NewStream(testContext("Bearer invalid"), desc, method)
+		assert.NoError(t, err)
+		assert.NoError(t, stream.SendMsg(&discovery.WatchInstanceRequest{SelfServiceId: "svc1"}))
+		assert.NoError(t, stream.CloseSend())
+
+		err = stream.RecvMsg(&discovery.WatchInstanceResponse{})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
+
+func TestFieldString(t *testing.T) {
+	req := &discovery.RegisterInstanceRequest{Instance: &discovery.MicroServiceInstance{ServiceId: "svc1"}}
+	assert.Equal(t, "svc1", fieldString(req, "Instance.ServiceId"))
+	assert.Equal(t, "", fieldString(req, "Instance.NotExist"))
+	assert.Equal(t, "", fieldString(&discovery.RegisterInstanceRequest{}, "Instance.ServiceId"))
+	assert.Equal(t, "", fieldString(nil, "ServiceId"))
+}
+
+func TestToStatusError(t *testing.T) {
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{discovery.NewError(discovery.ErrServiceNotExists, ""), codes.InvalidArgument},
+		{discovery.NewError(rbac.ErrAccountBlocked, ""), codes.PermissionDenied},
+		{discovery.NewError(discovery.ErrInternal, ""), codes.Internal},
+		{status.Error(codes.Canceled, ""), codes.Canceled},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.code, status.Code(toStatusError(tt.err)))
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/apache/servicecomb-service-center/pkg/plugin"
 	"github.com/apache/servicecomb-service-center/pkg/signal"
 	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/alarm"
 	"github.com/apache/servicecomb-service-center/server/command"
 	"github.com/apache/servicecomb-service-center/server/config"
 	"github.com/apache/servicecomb-service-center/server/core"
@@ -110,20 +111,27 @@ func (s *ServiceCenterServer) initEndpoints() {
 func (s *ServiceCenterServer) initDatasource() {
 	// init datasource
 	kind := datasource.Kind(config.GetString("registry.kind", "", config.WithStandby("registry_plugin")))
-	ReleaseAccountAfter := config.GetString("rbac.releaseLockAfter", "15m")
-	d, err := time.ParseDuration(ReleaseAccountAfter)
-	if err != nil {
-		log.Warn("releaseAfter is invalid, use default config")
-	}
 	if err := datasource.Init(datasource.Options{
 		Kind:                kind,
 		SslEnabled:          config.GetSSL().SslEnabled,
 		InstanceTTL:         config.GetRegistry().InstanceTTL,
 		SchemaNotEditable:   config.GetRegistry().SchemaNotEditable,
-		ReleaseAccountAfter: d,
+		ReleaseAccountAfter: releaseAccountAfter(),
 	}); err != nil {
 		log.Fatal("init datasource failed", err)
 	}
+	config.RegisterReloadFunc(func() {
+		datasource.GetAccountLockManager().SetReleaseAfter(releaseAccountAfter())
+	})
+}
+
+func releaseAccountAfter() time.Duration {
+	d, err := time.ParseDuration(config.GetString("rbac.releaseLockAfter", "15m"))
+	if err != nil {
+		log.Warn("releaseAfter is invalid, use default config")
+		return 15 * time.Minute
+	}
+	return d
 }
 
 func (s *ServiceCenterServer) initMetrics() {
@@ -195,6 +203,9 @@ func (s *ServiceCenterServer) startServices() {
 	if err := gov.Init(); err != nil {
 		log.Fatal("init gov failed", err)
 	}
+	if err := alarm.Init(); err != nil {
+		log.Fatal("init alarm failed", err)
+	}
 	// check version
 	if config.GetRegistry().SelfRegister {
 		if err := datasource.GetSCManager().UpgradeVersion(context.Background()); err != nil {
@@ -208,7 +219,13 @@ func (s *ServiceCenterServer) startServices() {
 func (s *ServiceCenterServer) startAPIService() {
 	core.Instance.HostName = util.HostName()
 	s.apiService.AddListener(REST, s.REST.Host, s.REST.Port)
-	s.apiService.AddListener(RPC, s.REST.Host, s.GRPC.Port)
+	if len(s.GRPC.Port) > 0 {
+		host := s.GRPC.Host
+		if len(host) == 0 {
+			host = s.REST.Host
+		}
+		s.apiService.AddListener(RPC, host, s.GRPC.Port)
+	}
 	s.apiService.Start()
 }
 
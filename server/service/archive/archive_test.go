@@ -0,0 +1,189 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package archive_test
+
+import (
+	_ "github.com/apache/servicecomb-service-center/test"
+
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/dump"
+	"github.com/apache/servicecomb-service-center/pkg/gov"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/config"
+	"github.com/apache/servicecomb-service-center/server/service/archive"
+	govsvc "github.com/apache/servicecomb-service-center/server/service/gov"
+	_ "github.com/apache/servicecomb-service-center/server/service/gov/mock"
+	pb "github.com/go-chassis/cari/discovery"
+	"github.com/stretchr/testify/assert"
+)
+
+func findService(a *dump.Archive, serviceID string) *dump.ServiceArchive {
+	for _, pa := range a.Projects {
+		for _, sa := range pa.Services {
+			if sa.Service.ServiceId == serviceID {
+				return sa
+			}
+		}
+	}
+	return nil
+}
+
+func TestExportImport(t *testing.T) {
+	ctx := util.WithNoCache(util.SetDomainProject(context.Background(), "archive", "archive"))
+	resp, err := datasource.GetMetadataManager().RegisterService(ctx, &pb.CreateServiceRequest{
+		Service: &pb.MicroService{
+			AppId:       "archive",
+			ServiceName: "archive",
+			Version:     "1.0.0",
+			Schemas:     []string{"schema1"},
+		},
+	})
+	assert.NoError(t, err)
+	serviceID := resp.ServiceId
+	defer datasource.GetMetadataManager().UnregisterService(ctx, &pb.DeleteServiceRequest{
+		ServiceId: serviceID, Force: true})
+
+	_, err = datasource.GetMetadataManager().ModifySchemas(ctx, &pb.ModifySchemasRequest{
+		ServiceId: serviceID,
+		Schemas:   []*pb.Schema{{SchemaId: "schema1", Summary: "summary1", Schema: "schema1"}},
+	})
+	assert.NoError(t, err)
+	_, err = datasource.GetMetadataManager().AddTags(ctx, &pb.AddServiceTagsRequest{
+		ServiceId: serviceID,
+		Tags:      map[string]string{"a": "b"},
+	})
+	assert.NoError(t, err)
+	providerResp, err := datasource.GetMetadataManager().RegisterService(ctx, &pb.CreateServiceRequest{
+		Service: &pb.MicroService{AppId: "archive", ServiceName: "archive_provider", Version: "1.0.0"},
+	})
+	assert.NoError(t, err)
+	defer datasource.GetMetadataManager().UnregisterService(ctx, &pb.DeleteServiceRequest{
+		ServiceId: providerResp.ServiceId, Force: true})
+	_, err = datasource.GetDependencyManager().AddOrUpdateDependencies(ctx, []*pb.ConsumerDependency{{
+		Consumer:  &pb.MicroServiceKey{AppId: "archive", ServiceName: "archive", Version: "1.0.0"},
+		Providers: []*pb.MicroServiceKey{{AppId: "archive", ServiceName: "archive_provider", Version: "latest"}},
+	}}, true)
+	assert.NoError(t, err)
+	assert.NoError(t, datasource.GetDependencyManager().DependencyHandle(ctx))
+
+	var a *dump.Archive
+	t.Run("export, should contain the service and its resources", func(t *testing.T) {
+		a, err = archive.Export(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, dump.ArchiveVersion, a.Version)
+		sa := findService(a, serviceID)
+		if assert.NotNil(t, sa) {
+			assert.Equal(t, 1, len(sa.Schemas))
+			assert.Equal(t, "schema1", sa.Schemas[0].Schema)
+			assert.Equal(t, "b", sa.Tags["a"])
+		}
+		for _, pa := range a.Projects {
+			if pa.Domain != "archive" {
+				continue
+			}
+			// the version rule is exported instead of the resolved providers
+			if assert.Equal(t, 1, len(pa.Dependencies)) {
+				assert.Equal(t, "latest", pa.Dependencies[0].Providers[0].Version)
+				assert.Empty(t, pa.Dependencies[0].Providers[0].Tenant)
+			}
+		}
+	})
+
+	t.Run("import the existing services, should skip them", func(t *testing.T) {
+		result, err := archive.Import(ctx, a)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, result.Imported[dump.ResourceService])
+		assert.True(t, result.Skipped[dump.ResourceService] > 0)
+	})
+
+	t.Run("import after the service deleted, should keep the service id", func(t *testing.T) {
+		_, err := datasource.GetMetadataManager().UnregisterService(ctx, &pb.DeleteServiceRequest{
+			ServiceId: serviceID, Force: true})
+		assert.NoError(t, err)
+
+		result, err := archive.Import(ctx, a)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.Imported[dump.ResourceService])
+		assert.Equal(t, 1, result.Imported[dump.ResourceSchema])
+		assert.Equal(t, 1, result.Imported[dump.ResourceTag])
+
+		schemaResp, err := datasource.GetMetadataManager().GetSchema(ctx, &pb.GetSchemaRequest{
+			ServiceId: serviceID,
+			SchemaId:  "schema1",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "schema1", schemaResp.Schema)
+	})
+
+	t.Run("import the unsupported version, should be failed", func(t *testing.T) {
+		_, err := archive.Import(ctx, &dump.Archive{Version: dump.ArchiveVersion + 1})
+		assert.Equal(t, archive.ErrUnsupportedVersion, err)
+	})
+}
+
+func TestExportPolicies(t *testing.T) {
+	if config.App == nil {
+		config.App = &config.AppConfig{}
+	}
+	old := config.App.Gov
+	config.App.Gov = &config.Gov{DistOptions: []config.DistributorOptions{{Name: "mock", Type: "mock"}}}
+	assert.NoError(t, govsvc.Init())
+	defer func() {
+		config.App.Gov = old
+		assert.NoError(t, govsvc.Init())
+	}()
+
+	ctx := util.WithNoCache(util.SetDomainProject(context.Background(), "archive_gov", "archive_gov"))
+	for _, name := range []string{"archive_gov1", "archive_gov2"} {
+		resp, err := datasource.GetMetadataManager().RegisterService(ctx, &pb.CreateServiceRequest{
+			Service: &pb.MicroService{AppId: "archive_gov", ServiceName: name, Version: "1.0.0"},
+		})
+		assert.NoError(t, err)
+		defer datasource.GetMetadataManager().UnregisterService(ctx, &pb.DeleteServiceRequest{
+			ServiceId: resp.ServiceId, Force: true})
+	}
+	for name, selector := range map[string]*gov.Selector{
+		"selected":  {App: "archive_gov"},
+		"other_app": {App: "other_app"},
+		"empty":     {},
+	} {
+		b, _ := json.Marshal(&gov.Policy{
+			GovernancePolicy: &gov.GovernancePolicy{Name: name, Selector: selector},
+			Spec:             &gov.RetrySpec{MaxAttempts: 3},
+		})
+		_, _, err := govsvc.Create(gov.KindRetry, "archive_gov", b)
+		assert.NoError(t, err)
+	}
+
+	a, err := archive.Export(ctx)
+	assert.NoError(t, err)
+	var names []string
+	for _, pa := range a.Projects {
+		if pa.Project != "archive_gov" {
+			continue
+		}
+		for _, policy := range pa.Policies {
+			names = append(names, policy.Name)
+		}
+	}
+	assert.Equal(t, []string{"selected"}, names)
+}
@@ -0,0 +1,252 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/dump"
+	"github.com/apache/servicecomb-service-center/pkg/gov"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/config"
+	govsvc "github.com/apache/servicecomb-service-center/server/service/gov"
+	pb "github.com/go-chassis/cari/discovery"
+	"github.com/go-chassis/cari/rbac"
+)
+
+// Export reads the services, schemas, tags, rules, dependencies, accounts, roles
+// and governance policies from the datasource, the instances are not exported
+// because they will register again after the migration
+func Export(ctx context.Context) (*dump.Archive, error) {
+	archive := &dump.Archive{
+		Version:   dump.ArchiveVersion,
+		Kind:      config.GetString("registry.kind", "", config.WithStandby("registry_plugin")),
+		Timestamp: strconv.FormatInt(time.Now().Unix(), 10),
+	}
+
+	all, err := datasource.GetMetadataManager().GetServicesAcrossDomainProject(ctx)
+	if err != nil {
+		return nil, err
+	}
+	domainProjects := make([]string, 0, len(all))
+	for domainProject := range all {
+		domainProjects = append(domainProjects, domainProject)
+	}
+	sort.Strings(domainProjects)
+
+	// governance policies belong to project, export them once with the first archive of the project
+	projects := make(map[string][]*dump.ProjectArchive)
+	for _, domainProject := range domainProjects {
+		pa, err := exportProject(ctx, domainProject, all[domainProject])
+		if err != nil {
+			return nil, err
+		}
+		if len(pa.Services) == 0 {
+			continue
+		}
+		projects[pa.Project] = append(projects[pa.Project], pa)
+		archive.Projects = append(archive.Projects, pa)
+	}
+	for project, pas := range projects {
+		if pas[0].Policies, err = exportPolicies(project, pas); err != nil {
+			return nil, err
+		}
+	}
+
+	if archive.Accounts, err = exportAccounts(ctx); err != nil {
+		return nil, err
+	}
+	if archive.Roles, _, err = datasource.GetRoleManager().ListRole(ctx); err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+func exportProject(ctx context.Context, domainProject string, services []*pb.MicroService) (*dump.ProjectArchive, error) {
+	domain, project := util.FromDomainProject(domainProject)
+	ctx = util.SetDomainProject(ctx, domain, project)
+	pa := &dump.ProjectArchive{
+		Domain:  domain,
+		Project: project,
+	}
+	for _, service := range services {
+		if datasource.IsGlobal(datasource.TransServiceToKey(domainProject, service)) {
+			continue
+		}
+		sa, err := exportService(ctx, service)
+		if err != nil {
+			return nil, err
+		}
+		pa.Services = append(pa.Services, sa)
+
+		dependency, err := exportDependency(ctx, service)
+		if err != nil {
+			return nil, err
+		}
+		if dependency != nil {
+			pa.Dependencies = append(pa.Dependencies, dependency)
+		}
+	}
+	return pa, nil
+}
+
+func exportService(ctx context.Context, service *pb.MicroService) (*dump.ServiceArchive, error) {
+	serviceID := service.ServiceId
+	sa := &dump.ServiceArchive{Service: service}
+
+	schemasResp, err := datasource.GetMetadataManager().GetAllSchemas(ctx, &pb.GetAllSchemaRequest{
+		ServiceId:  serviceID,
+		WithSchema: true,
+	})
+	if err == nil {
+		err = responseError(schemasResp.Response)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("export service[%s] schemas failed: %w", serviceID, err)
+	}
+	for _, schema := range schemasResp.Schemas {
+		if len(schema.Schema) > 0 {
+			sa.Schemas = append(sa.Schemas, schema)
+		}
+	}
+
+	tagsResp, err := datasource.GetMetadataManager().GetTags(ctx, &pb.GetServiceTagsRequest{ServiceId: serviceID})
+	if err == nil {
+		err = responseError(tagsResp.Response)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("export service[%s] tags failed: %w", serviceID, err)
+	}
+	if len(tagsResp.Tags) > 0 {
+		sa.Tags = tagsResp.Tags
+	}
+
+	rulesResp, err := datasource.GetMetadataManager().GetRules(ctx, &pb.GetServiceRulesRequest{ServiceId: serviceID})
+	if err == nil {
+		err = responseError(rulesResp.Response)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("export service[%s] rules failed: %w", serviceID, err)
+	}
+	sa.Rules = rulesResp.Rules
+	return sa, nil
+}
+
+// exportDependency returns the stored dependency rule of the consumer, the version
+// rules of the providers are kept, so they are resolved again after importing
+func exportDependency(ctx context.Context, consumer *pb.MicroService) (*pb.ConsumerDependency, error) {
+	rules, err := datasource.GetDependencyManager().GetConsumerDependencyRules(ctx, consumer)
+	if err != nil {
+		return nil, fmt.Errorf("export service[%s] dependencies failed: %w", consumer.ServiceId, err)
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	providers := make([]*pb.MicroServiceKey, 0, len(rules))
+	for _, rule := range rules {
+		provider := *rule
+		provider.Tenant = ""
+		providers = append(providers, &provider)
+	}
+	return &pb.ConsumerDependency{
+		Consumer:  datasource.TransServiceToKey("", consumer),
+		Providers: providers,
+	}, nil
+}
+
+// exportPolicies lists the policies of the project by the app and environment of
+// the exported services, the policies selecting no service are not exported
+func exportPolicies(project string, pas []*dump.ProjectArchive) ([]*gov.Policy, error) {
+	if govsvc.Primary() == nil {
+		return nil, nil
+	}
+	var selectors []*gov.Selector
+	seen := make(map[gov.Selector]struct{})
+	for _, pa := range pas {
+		for _, sa := range pa.Services {
+			selector := gov.Selector{App: sa.Service.AppId, Environment: sa.Service.Environment}
+			if _, ok := seen[selector]; ok {
+				continue
+			}
+			seen[selector] = struct{}{}
+			selectors = append(selectors, &selector)
+		}
+	}
+
+	var policies []*gov.Policy
+	for _, kind := range gov.Kinds() {
+		// the distributor may return the same policy for different selectors
+		names := make(map[string]struct{})
+		for _, selector := range selectors {
+			b, err := govsvc.List(kind, project, selector.App, selector.Environment)
+			if err != nil {
+				return nil, fmt.Errorf("export %s policies of project[%s] failed: %w", kind, project, err)
+			}
+			var list []*gov.Policy
+			if len(b) > 0 {
+				if err := json.Unmarshal(b, &list); err != nil {
+					return nil, err
+				}
+			}
+			for _, policy := range list {
+				if policy == nil || policy.GovernancePolicy == nil {
+					continue
+				}
+				if _, ok := names[policy.Name]; ok {
+					continue
+				}
+				names[policy.Name] = struct{}{}
+				policy.Kind = kind
+				policies = append(policies, policy)
+			}
+		}
+	}
+	return policies, nil
+}
+
+// exportAccounts returns the accounts with the hashed passwords, so they can
+// login the target with the same passwords
+func exportAccounts(ctx context.Context) ([]*rbac.Account, error) {
+	list, _, err := datasource.GetAccountManager().ListAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	accounts := make([]*rbac.Account, 0, len(list))
+	for _, a := range list {
+		account, err := datasource.GetAccountManager().GetAccount(ctx, a.Name)
+		if err != nil {
+			return nil, fmt.Errorf("export account[%s] failed: %w", a.Name, err)
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+func responseError(resp *pb.Response) error {
+	if resp.GetCode() != pb.ResponseSuccess {
+		return errors.New(resp.GetMessage())
+	}
+	return nil
+}
@@ -0,0 +1,287 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/dump"
+	"github.com/apache/servicecomb-service-center/pkg/gov"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	govsvc "github.com/apache/servicecomb-service-center/server/service/gov"
+	pb "github.com/go-chassis/cari/discovery"
+	"github.com/go-chassis/cari/rbac"
+)
+
+var ErrUnsupportedVersion = errors.New("unsupported archive version")
+
+// Import writes the archive into the datasource, the service ids are kept.
+// The resources already exist are skipped, and the failures do not stop the
+// import, they are returned in the result
+func Import(ctx context.Context, archive *dump.Archive) (*dump.ImportResult, error) {
+	if archive == nil || archive.Version <= 0 || archive.Version > dump.ArchiveVersion {
+		return nil, ErrUnsupportedVersion
+	}
+	r := newImporter()
+	for _, role := range archive.Roles {
+		r.importRole(ctx, role)
+	}
+	for _, account := range archive.Accounts {
+		r.importAccount(ctx, account)
+	}
+	for _, pa := range archive.Projects {
+		pctx := util.SetDomainProject(ctx, pa.Domain, pa.Project)
+		for _, sa := range pa.Services {
+			r.importService(pctx, sa)
+		}
+		for _, dependency := range pa.Dependencies {
+			r.importDependency(pctx, dependency)
+		}
+		for _, policy := range pa.Policies {
+			r.importPolicy(pa.Project, policy)
+		}
+	}
+	log.Info(fmt.Sprintf("import archive exported from %s, imported: %v, skipped: %v, failures: %d",
+		archive.Kind, r.result.Imported, r.result.Skipped, len(r.result.Errors)))
+	return r.result, nil
+}
+
+type importer struct {
+	result *dump.ImportResult
+}
+
+func newImporter() *importer {
+	return &importer{result: &dump.ImportResult{
+		Imported: make(map[string]int),
+		Skipped:  make(map[string]int),
+	}}
+}
+
+func (r *importer) imported(resource string) {
+	r.result.Imported[resource]++
+}
+
+func (r *importer) skipped(resource string) {
+	r.result.Skipped[resource]++
+}
+
+func (r *importer) failed(err error, format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	log.Error(msg, err)
+	r.result.Errors = append(r.result.Errors, msg+": "+err.Error())
+}
+
+func (r *importer) importRole(ctx context.Context, role *rbac.Role) {
+	exist, err := datasource.GetRoleManager().RoleExist(ctx, role.Name)
+	if err != nil {
+		r.failed(err, "import role[%s] failed", role.Name)
+		return
+	}
+	if exist {
+		r.skipped(dump.ResourceRole)
+		return
+	}
+	if err := datasource.GetRoleManager().CreateRole(ctx, role); err != nil {
+		r.failed(err, "import role[%s] failed", role.Name)
+		return
+	}
+	r.imported(dump.ResourceRole)
+}
+
+// importAccount keeps the hashed password of the account, CreateAccount
+// always hashes the password, so the account is updated after creating
+func (r *importer) importAccount(ctx context.Context, account *rbac.Account) {
+	exist, err := datasource.GetAccountManager().AccountExist(ctx, account.Name)
+	if err != nil {
+		r.failed(err, "import account[%s] failed", account.Name)
+		return
+	}
+	if exist {
+		r.skipped(dump.ResourceAccount)
+		return
+	}
+	a := *account
+	if err := datasource.GetAccountManager().CreateAccount(ctx, &a); err != nil {
+		r.failed(err, "import account[%s] failed", account.Name)
+		return
+	}
+	if err := datasource.GetAccountManager().UpdateAccount(ctx, account.Name, account); err != nil {
+		r.failed(err, "import account[%s] password failed", account.Name)
+		return
+	}
+	r.imported(dump.ResourceAccount)
+}
+
+// importService registers the service with the exported id, the schemas,
+// tags and rules of the existing service are not changed
+func (r *importer) importService(ctx context.Context, sa *dump.ServiceArchive) {
+	if sa.Service == nil {
+		return
+	}
+	serviceID := sa.Service.ServiceId
+	existResp, err := datasource.GetMetadataManager().ExistServiceByID(ctx, &pb.GetExistenceByIDRequest{
+		ServiceId: serviceID,
+	})
+	if err == nil {
+		err = responseError(existResp.Response)
+	}
+	if err != nil {
+		r.failed(err, "import service[%s] failed", serviceID)
+		return
+	}
+	if existResp.Exist {
+		r.skipped(dump.ResourceService)
+		return
+	}
+
+	createResp, err := datasource.GetMetadataManager().RegisterService(ctx, &pb.CreateServiceRequest{
+		Service: sa.Service,
+	})
+	if err == nil {
+		err = responseError(createResp.Response)
+	}
+	if err == nil && createResp.ServiceId != serviceID {
+		err = fmt.Errorf("found the same service with different id %s", createResp.ServiceId)
+	}
+	if err != nil {
+		r.failed(err, "import service[%s] failed", serviceID)
+		return
+	}
+	r.imported(dump.ResourceService)
+
+	if len(sa.Schemas) > 0 {
+		resp, err := datasource.GetMetadataManager().ModifySchemas(ctx, &pb.ModifySchemasRequest{
+			ServiceId: serviceID,
+			Schemas:   sa.Schemas,
+		})
+		if err == nil {
+			err = responseError(resp.Response)
+		}
+		if err != nil {
+			r.failed(err, "import service[%s] schemas failed", serviceID)
+		} else {
+			r.result.Imported[dump.ResourceSchema] += len(sa.Schemas)
+		}
+	}
+
+	if len(sa.Tags) > 0 {
+		resp, err := datasource.GetMetadataManager().AddTags(ctx, &pb.AddServiceTagsRequest{
+			ServiceId: serviceID,
+			Tags:      sa.Tags,
+		})
+		if err == nil {
+			err = responseError(resp.Response)
+		}
+		if err != nil {
+			r.failed(err, "import service[%s] tags failed", serviceID)
+		} else {
+			r.result.Imported[dump.ResourceTag] += len(sa.Tags)
+		}
+	}
+
+	if len(sa.Rules) > 0 {
+		rules := make([]*pb.AddOrUpdateServiceRule, 0, len(sa.Rules))
+		for _, rule := range sa.Rules {
+			rules = append(rules, &pb.AddOrUpdateServiceRule{
+				RuleType:    rule.RuleType,
+				Attribute:   rule.Attribute,
+				Pattern:     rule.Pattern,
+				Description: rule.Description,
+			})
+		}
+		resp, err := datasource.GetMetadataManager().AddRule(ctx, &pb.AddServiceRulesRequest{
+			ServiceId: serviceID,
+			Rules:     rules,
+		})
+		if err == nil {
+			err = responseError(resp.Response)
+		}
+		if err != nil {
+			r.failed(err, "import service[%s] rules failed", serviceID)
+		} else {
+			r.result.Imported[dump.ResourceRule] += len(rules)
+		}
+	}
+}
+
+func (r *importer) importDependency(ctx context.Context, dependency *pb.ConsumerDependency) {
+	if dependency.Consumer == nil {
+		return
+	}
+	consumerFlag := util.StringJoin([]string{dependency.Consumer.Environment, dependency.Consumer.AppId,
+		dependency.Consumer.ServiceName, dependency.Consumer.Version}, "/")
+	resp, err := datasource.GetDependencyManager().AddOrUpdateDependencies(ctx,
+		[]*pb.ConsumerDependency{dependency}, false)
+	if err == nil {
+		err = responseError(resp)
+	}
+	if err != nil {
+		r.failed(err, "import consumer[%s] dependencies failed", consumerFlag)
+		return
+	}
+	r.imported(dump.ResourceDependency)
+}
+
+// importPolicy creates the policy in the governance distributors, the policy
+// with the same kind, name and selector is skipped
+func (r *importer) importPolicy(project string, policy *gov.Policy) {
+	if govsvc.Primary() == nil || policy.GovernancePolicy == nil {
+		return
+	}
+	if policy.Selector == nil {
+		policy.Selector = &gov.Selector{}
+	}
+	b, err := govsvc.List(policy.Kind, project, policy.Selector.App, policy.Selector.Environment)
+	if err != nil {
+		r.failed(err, "import %s policy[%s] failed", policy.Kind, policy.Name)
+		return
+	}
+	var list []*gov.Policy
+	if len(b) > 0 {
+		if err := json.Unmarshal(b, &list); err != nil {
+			r.failed(err, "import %s policy[%s] failed", policy.Kind, policy.Name)
+			return
+		}
+	}
+	for _, p := range list {
+		if p != nil && p.GovernancePolicy != nil && p.Name == policy.Name {
+			r.skipped(dump.ResourcePolicy)
+			return
+		}
+	}
+
+	p := *policy
+	gp := *policy.GovernancePolicy
+	gp.ID, gp.Status = "", ""
+	p.GovernancePolicy = &gp
+	spec, err := json.Marshal(&p)
+	if err != nil {
+		r.failed(err, "import %s policy[%s] failed", policy.Kind, policy.Name)
+		return
+	}
+	if _, _, err := govsvc.Create(policy.Kind, project, spec); err != nil {
+		r.failed(err, "import %s policy[%s] failed", policy.Kind, policy.Name)
+		return
+	}
+	r.imported(dump.ResourcePolicy)
+}
@@ -202,7 +202,7 @@ var _ = Describe("'Rule' service", func() {
 
 		Context("when create rule out of gauge", func() {
 			It("should be failed", func() {
-				size := quota.DefaultRuleQuota + 1
+				size := quota.DefaultRuleQuota() + 1
 				rules := make([]*pb.AddOrUpdateServiceRule, 0, size)
 				for i := 0; i < size; i++ {
 					rules = append(rules, &pb.AddOrUpdateServiceRule{
@@ -538,7 +538,7 @@ var _ = Describe("'Rule' service", func() {
 
 				By("rules is invalid")
 				var arr []string
-				for i := 0; i < quota.DefaultRuleQuota+1; i++ {
+				for i := 0; i < quota.DefaultRuleQuota()+1; i++ {
 					arr = append(arr, strconv.Itoa(i))
 				}
 				respAddRule, err = serviceResource.DeleteRule(getContext(), &pb.DeleteServiceRulesRequest{
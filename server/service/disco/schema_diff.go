@@ -0,0 +1,155 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package disco
+
+import (
+	"context"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/openapi"
+	"github.com/apache/servicecomb-service-center/pkg/semver"
+	"github.com/apache/servicecomb-service-center/server/service/validator"
+	pb "github.com/go-chassis/cari/discovery"
+)
+
+type DiffSchemaRequest struct {
+	ServiceId string
+	SchemaId  string
+}
+
+type DiffSchemaResponse struct {
+	Response *pb.Response `json:"-"`
+	// PreviousServiceId is the service id of the previous version
+	PreviousServiceId string            `json:"previousServiceId,omitempty"`
+	PreviousVersion   string            `json:"previousVersion,omitempty"`
+	Compatible        bool              `json:"compatible"`
+	Changes           []*openapi.Change `json:"changes,omitempty"`
+}
+
+// DiffSchema compares the schema with the one registered by the previous
+// version of the service, the previous version is the highest version lower
+// than the service's in the same app and environment.
+// If the previous version does not have the schema, all the operations are
+// treated as added
+func DiffSchema(ctx context.Context, in *DiffSchemaRequest) (*DiffSchemaResponse, error) {
+	getReq := &pb.GetSchemaRequest{ServiceId: in.ServiceId, SchemaId: in.SchemaId}
+	if err := validator.Validate(getReq); err != nil {
+		log.Errorf(err, "diff schema[%s/%s] failed", in.ServiceId, in.SchemaId)
+		return &DiffSchemaResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, err.Error()),
+		}, nil
+	}
+
+	schemaResp, err := datasource.GetMetadataManager().GetSchema(ctx, getReq)
+	if err != nil {
+		return internalDiffError(err)
+	}
+	if schemaResp.Response.GetCode() != pb.ResponseSuccess {
+		return &DiffSchemaResponse{Response: schemaResp.Response}, nil
+	}
+	cur, err := openapi.Parse(schemaResp.Schema)
+	if err != nil {
+		log.Errorf(err, "diff schema[%s/%s] failed, schema is malformed", in.ServiceId, in.SchemaId)
+		return &DiffSchemaResponse{
+			Response: pb.CreateResponse(pb.ErrInvalidParams, "schema is malformed: "+err.Error()),
+		}, nil
+	}
+
+	serviceResp, err := datasource.GetMetadataManager().GetService(ctx, &pb.GetServiceRequest{ServiceId: in.ServiceId})
+	if err != nil {
+		return internalDiffError(err)
+	}
+	if serviceResp.Response.GetCode() != pb.ResponseSuccess {
+		return &DiffSchemaResponse{Response: serviceResp.Response}, nil
+	}
+	previous, err := getPreviousVersion(ctx, serviceResp.Service)
+	if err != nil {
+		log.Errorf(err, "diff schema[%s/%s] failed, get previous version failed", in.ServiceId, in.SchemaId)
+		return internalDiffError(err)
+	}
+	if previous == nil {
+		return &DiffSchemaResponse{
+			Response: pb.CreateResponse(pb.ErrServiceNotExists, "Service does not have a previous version."),
+		}, nil
+	}
+
+	var prev *openapi.Document
+	prevResp, err := datasource.GetMetadataManager().GetSchema(ctx, &pb.GetSchemaRequest{
+		ServiceId: previous.ServiceId,
+		SchemaId:  in.SchemaId,
+	})
+	switch {
+	case err != nil:
+		return internalDiffError(err)
+	case prevResp.Response.GetCode() == pb.ResponseSuccess:
+		prev, err = openapi.Parse(prevResp.Schema)
+		if err != nil {
+			log.Errorf(err, "diff schema[%s/%s] failed, previous schema is malformed", previous.ServiceId, in.SchemaId)
+			return &DiffSchemaResponse{
+				Response: pb.CreateResponse(pb.ErrInvalidParams, "previous schema is malformed: "+err.Error()),
+			}, nil
+		}
+	case prevResp.Response.GetCode() != pb.ErrSchemaNotExists:
+		return &DiffSchemaResponse{Response: prevResp.Response}, nil
+	}
+
+	changes := openapi.Diff(prev, cur)
+	return &DiffSchemaResponse{
+		Response:          pb.CreateResponse(pb.ResponseSuccess, "Diff schema successfully."),
+		PreviousServiceId: previous.ServiceId,
+		PreviousVersion:   previous.Version,
+		Compatible:        openapi.IsCompatible(changes),
+		Changes:           changes,
+	}, nil
+}
+
+func internalDiffError(err error) (*DiffSchemaResponse, error) {
+	return &DiffSchemaResponse{
+		Response: pb.CreateResponse(pb.ErrInternal, err.Error()),
+	}, err
+}
+
+func getPreviousVersion(ctx context.Context, service *pb.MicroService) (*pb.MicroService, error) {
+	version, err := semver.Parse(service.Version)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := datasource.GetMetadataManager().GetServices(ctx, &pb.GetServicesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	var (
+		previous    *pb.MicroService
+		prevVersion *semver.Version
+	)
+	for _, s := range resp.Services {
+		if s.AppId != service.AppId || s.ServiceName != service.ServiceName ||
+			s.Environment != service.Environment {
+			continue
+		}
+		v, err := semver.Parse(s.Version)
+		if err != nil || semver.Compare(v, version) >= 0 {
+			continue
+		}
+		if previous == nil || semver.Compare(v, prevVersion) > 0 {
+			previous, prevVersion = s, v
+		}
+	}
+	return previous, nil
+}
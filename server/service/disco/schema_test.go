@@ -22,8 +22,11 @@ import (
 
 	"github.com/apache/servicecomb-service-center/datasource"
 
+	"github.com/apache/servicecomb-service-center/pkg/openapi"
 	"github.com/apache/servicecomb-service-center/server/plugin/quota"
+	"github.com/apache/servicecomb-service-center/server/service/disco"
 	pb "github.com/go-chassis/cari/discovery"
+	"github.com/go-chassis/go-archaius"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -210,7 +213,7 @@ var _ = Describe("'Schema' service", func() {
 		})
 
 		Context("when create schemas out of gauge", func() {
-			size := quota.DefaultSchemaQuota + 1
+			size := quota.DefaultSchemaQuota() + 1
 			schemaIds := make([]string, 0, size)
 			schemas := make([]*pb.Schema, 0, size)
 			for i := 0; i < size; i++ {
@@ -236,14 +239,14 @@ var _ = Describe("'Schema' service", func() {
 				By("batch modify schemas 2")
 				respCreateSchemas, err = serviceResource.ModifySchemas(getContext(), &pb.ModifySchemasRequest{
 					ServiceId: serviceIdDev,
-					Schemas:   schemas[:quota.DefaultSchemaQuota],
+					Schemas:   schemas[:quota.DefaultSchemaQuota()],
 				})
 				Expect(err).To(BeNil())
 				Expect(respCreateSchemas.Response.GetCode()).To(Equal(pb.ResponseSuccess))
 
 				By("modify one schema")
 				respCreateService := &pb.ModifySchemaResponse{}
-				schema := schemas[quota.DefaultSchemaQuota]
+				schema := schemas[quota.DefaultSchemaQuota()]
 				respCreateService, err = serviceResource.ModifySchema(getContext(), &pb.ModifySchemaRequest{
 					ServiceId: serviceIdDev,
 					SchemaId:  schema.SchemaId,
@@ -914,4 +917,119 @@ var _ = Describe("'Schema' service", func() {
 			})
 		})
 	})
+
+	Describe("execute 'diff' operation", func() {
+		var (
+			serviceIdV1 string
+			serviceIdV2 string
+		)
+		const (
+			v1 = `{"swagger": "2.0", "info": {"title": "diff", "version": "1.0.0"}, "paths": {
+				"/users": {"get": {"responses": {"200": {}}}},
+				"/users/{id}": {"get": {"parameters": [{"name": "id", "in": "path", "required": true, "type": "string"}], "responses": {"200": {}}}}}}`
+			v2 = `{"swagger": "2.0", "info": {"title": "diff", "version": "2.0.0"}, "paths": {
+				"/users": {"get": {"parameters": [{"name": "limit", "in": "query", "type": "integer"}], "responses": {"200": {}}}}}}`
+		)
+
+		It("should be passed, create services", func() {
+			for _, version := range []string{"1.0.0", "2.0.0"} {
+				respCreateService, err := serviceResource.Create(getContext(), &pb.CreateServiceRequest{
+					Service: &pb.MicroService{
+						AppId:       "diff_schema_group",
+						ServiceName: "diff_schema_service",
+						Version:     version,
+						Level:       "FRONT",
+						Status:      pb.MS_UP,
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(respCreateService.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+				if version == "1.0.0" {
+					serviceIdV1 = respCreateService.ServiceId
+				} else {
+					serviceIdV2 = respCreateService.ServiceId
+				}
+			}
+		})
+
+		Context("when schema is malformed", func() {
+			It("should be failed if it declares the openapi version", func() {
+				resp, err := serviceResource.ModifySchemas(getContext(), &pb.ModifySchemasRequest{
+					ServiceId: serviceIdV1,
+					Schemas: []*pb.Schema{
+						{SchemaId: "diff", Schema: `{"openapi": "3.0.0", "paths": {}}`, Summary: "diff"},
+					},
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.GetCode()).To(Equal(pb.ErrInvalidParams))
+			})
+
+			It("should be passed if it is not an openapi document", func() {
+				resp, err := serviceResource.ModifySchema(getContext(), &pb.ModifySchemaRequest{
+					ServiceId: serviceIdV1,
+					SchemaId:  "malformed",
+					Schema:    "not an openapi document",
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+			})
+
+			It("should be failed if the openapi validation is enabled", func() {
+				archaius.Set("registry.schema.validateOpenAPI", true)
+				defer archaius.Set("registry.schema.validateOpenAPI", false)
+
+				resp, err := serviceResource.ModifySchema(getContext(), &pb.ModifySchemaRequest{
+					ServiceId: serviceIdV1,
+					SchemaId:  "diff",
+					Schema:    "not an openapi document",
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.GetCode()).To(Equal(pb.ErrInvalidParams))
+			})
+		})
+
+		Context("when previous version exists", func() {
+			It("should return the changes", func() {
+				resp, err := serviceResource.ModifySchema(getContext(), &pb.ModifySchemaRequest{
+					ServiceId: serviceIdV1,
+					SchemaId:  "diff",
+					Schema:    v1,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+				resp, err = serviceResource.ModifySchema(getContext(), &pb.ModifySchemaRequest{
+					ServiceId: serviceIdV2,
+					SchemaId:  "diff",
+					Schema:    v2,
+				})
+				Expect(err).To(BeNil())
+				Expect(resp.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+
+				respDiff, err := disco.DiffSchema(getContext(), &disco.DiffSchemaRequest{
+					ServiceId: serviceIdV2,
+					SchemaId:  "diff",
+				})
+				Expect(err).To(BeNil())
+				Expect(respDiff.Response.GetCode()).To(Equal(pb.ResponseSuccess))
+				Expect(respDiff.PreviousServiceId).To(Equal(serviceIdV1))
+				Expect(respDiff.Compatible).To(BeFalse())
+				Expect(len(respDiff.Changes)).To(Equal(2))
+				Expect(respDiff.Changes[0].Kind).To(Equal(openapi.KindParameterAdded))
+				Expect(respDiff.Changes[0].Level).To(Equal(openapi.LevelCompatible))
+				Expect(respDiff.Changes[1].Kind).To(Equal(openapi.KindOperationRemoved))
+				Expect(respDiff.Changes[1].Level).To(Equal(openapi.LevelBreaking))
+			})
+		})
+
+		Context("when previous version does not exist", func() {
+			It("should be failed", func() {
+				respDiff, err := disco.DiffSchema(getContext(), &disco.DiffSchemaRequest{
+					ServiceId: serviceIdV1,
+					SchemaId:  "diff",
+				})
+				Expect(err).To(BeNil())
+				Expect(respDiff.Response.GetCode()).To(Equal(pb.ErrServiceNotExists))
+			})
+		})
+	})
 })
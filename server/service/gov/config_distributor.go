@@ -18,7 +18,16 @@
 package gov
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/apache/servicecomb-service-center/pkg/alarm/model"
+	"github.com/apache/servicecomb-service-center/pkg/gov"
 	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/server/alarm"
 	"github.com/apache/servicecomb-service-center/server/config"
 )
 
@@ -28,11 +37,24 @@ const (
 	ConfigDistributorMock  = "mock"
 )
 
+// FieldDrifts is the drift alarm field of all the drifts encoded in JSON
+const FieldDrifts = "drifts"
+
+var ErrMultiplePrimary = errors.New("only one primary config distributor is allowed")
+
 type NewDistributors func(opts config.DistributorOptions) (ConfigDistributor, error)
 
-var distributors = map[string]ConfigDistributor{}
+// distributors is the ordered distributors, the first one is the primary
+var distributors []ConfigDistributor
 var distributorPlugins = map[string]NewDistributors{}
 
+var (
+	driftLock sync.Mutex
+	// drifts is the set of the policies failed to distribute, the drift alarm
+	// is cleared after all of them are distributed successfully
+	drifts = map[string]*Drift{}
+)
+
 //ConfigDistributor persist and distribute Governance policy
 //typically, a ConfigDistributor interact with a config server, like ctrip apollo, kie.
 //or service mesh system like istio, linkerd.
@@ -48,71 +70,345 @@ type ConfigDistributor interface {
 	Name() string
 }
 
+// Result is the result of distributing a policy by a secondary distributor
+type Result struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Error string `json:"error,omitempty"`
+}
+
+// Drift is a policy failed to distribute to a secondary distributor
+type Drift struct {
+	Distributor string `json:"distributor"`
+	Type        string `json:"type"`
+	Kind        string `json:"kind"`
+	Policy      string `json:"policy"`
+	Error       string `json:"error,omitempty"`
+}
+
+func (d *Drift) key() string {
+	return d.Distributor + "::" + d.Type + "/" + d.Kind + "/" + d.Policy
+}
+
 //InstallDistributor install a plugin to distribute and persist config
 func InstallDistributor(t string, newDistributors NewDistributors) {
 	distributorPlugins[t] = newDistributors
 }
 
 //Init create distributors according to gov config.
-//it may creates multiple distributors. and distribute policy one by one
+//it may creates multiple distributors, the one marked as primary(or the first one if no primary)
+//serves the reads, and the writes are distributed to all of them
 func Init() error {
-	distOptions := config.GetGov().DistOptions
-	for _, opts := range distOptions {
+	var (
+		list       []ConfigDistributor
+		hasPrimary bool
+	)
+	for _, opts := range config.GetGov().DistOptions {
 		if opts.Type == "" {
 			log.Warn("empty plugin, skip")
+			continue
 		}
 		f, ok := distributorPlugins[opts.Type]
 		if !ok {
 			log.Warn("unsupported plugin " + opts.Type)
+			continue
 		}
 		cd, err := f(opts)
 		if err != nil {
 			log.Error("can not init config distributor", err)
 			return err
 		}
-		distributors[opts.Name+"::"+opts.Type] = cd
+		if !opts.Primary {
+			list = append(list, cd)
+			continue
+		}
+		if hasPrimary {
+			return ErrMultiplePrimary
+		}
+		hasPrimary = true
+		list = append([]ConfigDistributor{cd}, list...)
+	}
+	distributors = list
+	alarm.OnLoaded(restoreDrifts)
+	if len(distributors) > 0 {
+		log.Info(fmt.Sprintf("primary config distributor is %s::%s",
+			distributors[0].Name(), distributors[0].Type()))
 	}
 	return nil
 }
 
-func Create(kind, project string, spec []byte) ([]byte, error) {
-	for _, cd := range distributors {
-		return cd.Create(kind, project, spec)
+// Primary returns the distributor serves the reads
+func Primary() ConfigDistributor {
+	if len(distributors) == 0 {
+		return nil
+	}
+	return distributors[0]
+}
+
+func secondaries() []ConfigDistributor {
+	if len(distributors) == 0 {
+		return nil
+	}
+	return distributors[1:]
+}
+
+func Create(kind, project string, spec []byte) ([]byte, []*Result, error) {
+	primary := Primary()
+	if primary == nil {
+		return nil, nil, nil
+	}
+	id, err := primary.Create(kind, project, spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(secondaries()) == 0 {
+		return id, nil, nil
+	}
+
+	// keep the same policy name, e.g. the generated match group name, in all distributors
+	policy, err := getPolicy(primary, kind, string(id), project)
+	if err != nil {
+		log.Error(fmt.Sprintf("get %s policy[%s] from primary distributor failed", kind, id), err)
+		return id, distribute(kind, nil, func(cd ConfigDistributor) error { return err }), nil
 	}
-	return nil, nil
+	spec = withName(spec, policy.Name)
+	results := distribute(kind, policy, func(cd ConfigDistributor) error {
+		_, err := cd.Create(kind, project, spec)
+		return err
+	})
+	return id, results, nil
 }
 
 func List(kind, project, app, env string) ([]byte, error) {
-	for _, cd := range distributors {
-		return cd.List(kind, project, app, env)
+	primary := Primary()
+	if primary == nil {
+		return nil, nil
 	}
-	return nil, nil
+	return primary.List(kind, project, app, env)
 }
 
 func Display(project, app, env string) ([]byte, error) {
-	for _, cd := range distributors {
-		return cd.Display(project, app, env)
+	primary := Primary()
+	if primary == nil {
+		return nil, nil
 	}
-	return nil, nil
+	return primary.Display(project, app, env)
 }
 
 func Get(kind, id, project string) ([]byte, error) {
-	for _, cd := range distributors {
-		return cd.Get(kind, id, project)
+	primary := Primary()
+	if primary == nil {
+		return nil, nil
 	}
-	return nil, nil
+	return primary.Get(kind, id, project)
 }
 
-func Delete(kind, id, project string) error {
-	for _, cd := range distributors {
-		return cd.Delete(kind, id, project)
+func Delete(kind, id, project string) ([]*Result, error) {
+	primary := Primary()
+	if primary == nil {
+		return nil, nil
 	}
-	return nil
+	var (
+		policy *gov.Policy
+		err    error
+	)
+	if len(secondaries()) > 0 {
+		policy, err = getPolicy(primary, kind, id, project)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err = primary.Delete(kind, id, project); err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return nil, nil
+	}
+	return distribute(kind, policy, func(cd ConfigDistributor) error {
+		sid, err := findID(cd, kind, project, policy)
+		if err != nil || len(sid) == 0 {
+			return err
+		}
+		return cd.Delete(kind, sid, project)
+	}), nil
 }
 
-func Update(kind, id, project string, spec []byte) error {
-	for _, cd := range distributors {
-		return cd.Update(kind, id, project, spec)
+func Update(kind, id, project string, spec []byte) ([]*Result, error) {
+	primary := Primary()
+	if primary == nil {
+		return nil, nil
 	}
-	return nil
+	var (
+		policy *gov.Policy
+		err    error
+	)
+	if len(secondaries()) > 0 {
+		policy, err = getPolicy(primary, kind, id, project)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err = primary.Update(kind, id, project, spec); err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return nil, nil
+	}
+	return distribute(kind, policy, func(cd ConfigDistributor) error {
+		sid, err := findID(cd, kind, project, policy)
+		if err != nil {
+			return err
+		}
+		if len(sid) == 0 {
+			// the secondary drifted, re-create the policy
+			_, err = cd.Create(kind, project, withSelector(withName(spec, policy.Name), policy.Selector))
+			return err
+		}
+		return cd.Update(kind, sid, project, spec)
+	}), nil
+}
+
+// distribute calls f with every secondary distributor, and raises an alarm
+// if the secondary distributor failed, that means it drifts from the primary,
+// the alarm is cleared when all the drifted policies are distributed again
+func distribute(kind string, policy *gov.Policy, f func(cd ConfigDistributor) error) []*Result {
+	var name string
+	if policy != nil {
+		name = policy.Name
+	}
+	results := make([]*Result, 0, len(secondaries()))
+	for _, cd := range secondaries() {
+		r := &Result{Name: cd.Name(), Type: cd.Type()}
+		err := f(cd)
+		if err != nil {
+			log.Error(fmt.Sprintf("distribute %s policy[%s] to %s::%s failed", kind, name, cd.Name(), cd.Type()), err)
+			r.Error = err.Error()
+		}
+		markDrift(cd, kind, name, err)
+		results = append(results, r)
+	}
+	return results
+}
+
+func markDrift(cd ConfigDistributor, kind, name string, err error) {
+	d := &Drift{Distributor: cd.Name(), Type: cd.Type(), Kind: kind, Policy: name}
+	driftLock.Lock()
+	defer driftLock.Unlock()
+	if err != nil {
+		d.Error = err.Error()
+		drifts[d.key()] = d
+		raiseDrifts()
+		return
+	}
+	if _, ok := drifts[d.key()]; !ok {
+		return
+	}
+	delete(drifts, d.key())
+	raiseDrifts()
+}
+
+// raiseDrifts raises the alarm with all the drifts or clears it if no drift,
+// the caller must hold the driftLock
+func raiseDrifts() {
+	if len(drifts) == 0 {
+		if err := alarm.Clear(alarm.IDGovernanceDistributorDrift); err != nil {
+			log.Error("", err)
+		}
+		return
+	}
+	list := make([]*Drift, 0, len(drifts))
+	for _, d := range drifts {
+		list = append(list, d)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].key() < list[j].key() })
+	b, err := json.Marshal(list)
+	if err != nil {
+		log.Error("", err)
+		return
+	}
+	if err := alarm.Raise(alarm.IDGovernanceDistributorDrift,
+		alarm.FieldString(FieldDrifts, string(b))); err != nil {
+		log.Error("", err)
+	}
+}
+
+// restoreDrifts merges the drifts of the persisted alarm after restarting, so the alarm
+// is still cleared when they are distributed successfully
+func restoreDrifts(persisted []*model.Alarm) {
+	var restored []*Drift
+	for _, a := range persisted {
+		field := a.FieldString(FieldDrifts)
+		if a.ID != alarm.IDGovernanceDistributorDrift || a.Status != alarm.Activated || len(field) == 0 {
+			continue
+		}
+		if err := json.Unmarshal([]byte(field), &restored); err != nil {
+			log.Error("the persisted distributor drifts are malformed", err)
+		}
+	}
+	driftLock.Lock()
+	defer driftLock.Unlock()
+	for _, d := range restored {
+		if _, ok := drifts[d.key()]; !ok {
+			drifts[d.key()] = d
+		}
+	}
+	raiseDrifts()
+}
+
+func getPolicy(cd ConfigDistributor, kind, id, project string) (*gov.Policy, error) {
+	b, err := cd.Get(kind, id, project)
+	if err != nil {
+		return nil, err
+	}
+	policy := &gov.Policy{}
+	if err := json.Unmarshal(b, policy); err != nil {
+		return nil, err
+	}
+	if policy.GovernancePolicy == nil {
+		return nil, fmt.Errorf("%s policy[%s] not exist", kind, id)
+	}
+	if policy.Selector == nil {
+		policy.Selector = &gov.Selector{}
+	}
+	return policy, nil
+}
+
+// findID returns the id of the policy in distributor, the policy is identified
+// by kind, name and selector in all distributors
+func findID(cd ConfigDistributor, kind, project string, policy *gov.Policy) (string, error) {
+	b, err := cd.List(kind, project, policy.Selector.App, policy.Selector.Environment)
+	if err != nil {
+		return "", err
+	}
+	var policies []*gov.Policy
+	if err := json.Unmarshal(b, &policies); err != nil {
+		return "", err
+	}
+	for _, p := range policies {
+		if p != nil && p.GovernancePolicy != nil && p.Name == policy.Name {
+			return p.ID, nil
+		}
+	}
+	return "", nil
+}
+
+func withName(spec []byte, name string) []byte {
+	return withField(spec, "name", name)
+}
+
+func withSelector(spec []byte, selector *gov.Selector) []byte {
+	return withField(spec, "selector", selector)
+}
+
+func withField(spec []byte, key string, value interface{}) []byte {
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(spec, &m); err != nil {
+		return spec
+	}
+	m[key] = value
+	b, err := json.Marshal(m)
+	if err != nil {
+		return spec
+	}
+	return b
 }
@@ -19,6 +19,7 @@ package gov_test
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/apache/servicecomb-service-center/pkg/gov"
@@ -41,9 +42,14 @@ func init() {
 		Gov: &config.Gov{
 			DistOptions: []config.DistributorOptions{
 				{
-					Name: "mockServer",
+					Name: "mockSecondary",
 					Type: "mock",
 				},
+				{
+					Name:    "mockServer",
+					Type:    "mock",
+					Primary: true,
+				},
 			},
 		},
 	}
@@ -64,10 +70,14 @@ func TestCreate(t *testing.T) {
 		},
 		Spec: &gov.LBSpec{RetryNext: 3, MarkerName: "traffic2adminAPI"},
 	}, "", "  ")
-	res, err := svc.Create(MockKind, Project, b)
+	res, results, err := svc.Create(MockKind, Project, b)
 	id = string(res)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, id)
+	assert.Equal(t, "mockServer", svc.Primary().Name())
+	assert.Equal(t, 1, len(results))
+	assert.Equal(t, "mockSecondary", results[0].Name)
+	assert.Empty(t, results[0].Error)
 }
 
 func TestUpdate(t *testing.T) {
@@ -81,8 +91,10 @@ func TestUpdate(t *testing.T) {
 		},
 		Spec: &gov.LBSpec{RetryNext: 3, MarkerName: "traffic2adminAPI"},
 	}, "", "  ")
-	err := svc.Update(MockKind, id, Project, b)
+	results, err := svc.Update(MockKind, id, Project, b)
 	assert.NoError(t, err)
+	assert.Equal(t, 1, len(results))
+	assert.Empty(t, results[0].Error)
 }
 
 func TestDisplay(t *testing.T) {
@@ -95,7 +107,7 @@ func TestDisplay(t *testing.T) {
 			},
 		},
 	}, "", "  ")
-	res, err := svc.Create(MatchGroup, Project, b)
+	res, _, err := svc.Create(MatchGroup, Project, b)
 	id = string(res)
 	assert.NoError(t, err)
 	policies := &[]*gov.DisplayData{}
@@ -125,8 +137,69 @@ func TestGet(t *testing.T) {
 }
 
 func TestDelete(t *testing.T) {
-	err := svc.Delete(MockKind, id, Project)
+	results, err := svc.Delete(MockKind, id, Project)
 	assert.NoError(t, err)
+	assert.Equal(t, 1, len(results))
+	assert.Empty(t, results[0].Error)
 	res, _ := svc.Get(MockKind, id, Project)
 	assert.Nil(t, res)
+
+	t.Run("delete the policy not exist in primary should be failed", func(t *testing.T) {
+		results, err := svc.Delete(MockKind, "not-exist", Project)
+		assert.Error(t, err)
+		assert.Empty(t, results)
+	})
+}
+
+type brokenDistributor struct {
+	svc.ConfigDistributor
+}
+
+func (d *brokenDistributor) Create(kind, project string, spec []byte) ([]byte, error) {
+	return nil, errors.New("broken")
+}
+
+func (d *brokenDistributor) Name() string {
+	return "broken"
+}
+
+func (d *brokenDistributor) Type() string {
+	return "broken"
+}
+
+func TestDistribute(t *testing.T) {
+	svc.InstallDistributor("broken", func(opts config.DistributorOptions) (svc.ConfigDistributor, error) {
+		return &brokenDistributor{}, nil
+	})
+	old := config.App.Gov
+	defer func() {
+		config.App.Gov = old
+		assert.NoError(t, svc.Init())
+	}()
+
+	t.Run("multiple primary distributors should fail", func(t *testing.T) {
+		config.App.Gov = &config.Gov{DistOptions: []config.DistributorOptions{
+			{Name: "mock1", Type: "mock", Primary: true},
+			{Name: "mock2", Type: "mock", Primary: true},
+		}}
+		assert.Equal(t, svc.ErrMultiplePrimary, svc.Init())
+	})
+
+	t.Run("secondary distributor failed should report the error and raise alarm", func(t *testing.T) {
+		config.App.Gov = &config.Gov{DistOptions: []config.DistributorOptions{
+			{Name: "mock", Type: "mock"},
+			{Name: "broken", Type: "broken"},
+		}}
+		assert.NoError(t, svc.Init())
+
+		b, _ := json.Marshal(&gov.Policy{
+			GovernancePolicy: &gov.GovernancePolicy{Name: "drift", Selector: &gov.Selector{}},
+			Spec:             &gov.LBSpec{RetryNext: 3, MarkerName: "drift"},
+		})
+		res, results, err := svc.Create(MockKind, Project, b)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, res)
+		assert.Equal(t, 1, len(results))
+		assert.Equal(t, "broken", results[0].Error)
+	})
 }
@@ -0,0 +1,656 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package istio
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/apache/servicecomb-service-center/pkg/gov"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/service/gov/kie"
+)
+
+const (
+	APIVersionNetworking = "networking.istio.io/v1alpha3"
+
+	KindVirtualService  = "VirtualService"
+	KindDestinationRule = "DestinationRule"
+	KindEnvoyFilter     = "EnvoyFilter"
+	KindConfigMap       = "ConfigMap"
+
+	defaultRetryAttempts        = 3
+	defaultRetryOn              = "5xx,connect-failure,refused-stream"
+	defaultConsecutiveErrors    = 5
+	defaultEjectionInterval     = 10 * time.Second
+	defaultBaseEjectionTime     = 30 * time.Second
+	defaultMaxEjectionPercent   = 100
+	defaultLimitRefreshPeriod   = time.Second
+	defaultLoadBalancer         = "ROUND_ROBIN"
+	specDataKey                 = "spec.yaml"
+	localRateLimitFilterName    = "envoy.filters.http.local_ratelimit"
+	localRateLimitFilterType    = "type.googleapis.com/envoy.extensions.filters.http.local_ratelimit.v3.LocalRateLimit"
+	typedStructType             = "type.googleapis.com/udpa.type.v1.TypedStruct"
+	httpConnectionManagerName   = "envoy.filters.network.http_connection_manager"
+	httpRouterFilterName        = "envoy.filters.http.router"
+	localRateLimitStatPrefix    = "http_local_rate_limiter"
+	localRateLimitRuntimePrefix = "local_rate_limit_"
+)
+
+var (
+	// loadBalancers maps the servicecomb load balance rules to istio simple load balancers,
+	// WeightedResponse and SessionStickiness have no equivalent
+	loadBalancers = map[string]string{
+		"roundrobin":      "ROUND_ROBIN",
+		"random":          "RANDOM",
+		"leastconnection": "LEAST_CONN",
+		"passthrough":     "PASSTHROUGH",
+	}
+	// stringMatchOperators is the order to choose the match operator,
+	// istio StringMatch accepts only one of them
+	stringMatchOperators = []string{"exact", "prefix", "regex", "suffix", "contains"}
+	invalidNameChars     = regexp.MustCompile(`[^a-z0-9.-]+`)
+)
+
+// Match is the request match item of the servicecomb match group
+type Match struct {
+	Name        string                       `json:"name,omitempty"`
+	ServiceName string                       `json:"serviceName,omitempty"`
+	APIPath     map[string]string            `json:"apiPath,omitempty"`
+	Headers     map[string]map[string]string `json:"headers,omitempty"`
+	Method      []string                     `json:"method,omitempty"`
+}
+
+// MatchGroupSpec is the spec of the servicecomb match group
+type MatchGroupSpec struct {
+	Alias   string   `json:"alias,omitempty"`
+	Matches []*Match `json:"matches,omitempty"`
+}
+
+// RetrySpec is the spec of retry kind, it is compatible with gov.LBSpec
+type RetrySpec struct {
+	gov.LBSpec
+	MaxAttempts int `json:"maxAttempts,omitempty"`
+}
+
+// LoadBalancerSpec is the spec of loadbalancer kind
+type LoadBalancerSpec struct {
+	gov.LBSpec
+	Rule string `json:"rule,omitempty"`
+}
+
+// RateLimitingSpec is the spec of rate-limiting kind
+type RateLimitingSpec struct {
+	gov.LimiterSpec
+	LimitRefreshPeriod interface{} `json:"limitRefreshPeriod,omitempty"`
+}
+
+// CircuitBreakerSpec is the spec of circuit-breaker kind
+type CircuitBreakerSpec struct {
+	MarkerName                            string      `json:"match,omitempty"`
+	MinimumNumberOfCalls                  int         `json:"minimumNumberOfCalls,omitempty"`
+	FailureRateThreshold                  int         `json:"failureRateThreshold,omitempty"`
+	SlowCallRateThreshold                 int         `json:"slowCallRateThreshold,omitempty"`
+	SlowCallDurationThreshold             interface{} `json:"slowCallDurationThreshold,omitempty"`
+	SlidingWindowType                     string      `json:"slidingWindowType,omitempty"`
+	SlidingWindowSize                     interface{} `json:"slidingWindowSize,omitempty"`
+	WaitDurationInOpenState               interface{} `json:"waitDurationInOpenState,omitempty"`
+	PermittedNumberOfCallsInHalfOpenState int         `json:"permittedNumberOfCallsInHalfOpenState,omitempty"`
+}
+
+// Object is a kubernetes resource, the Spec is the istio spec or
+// the Data of ConfigMap
+type Object struct {
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Metadata   Metadata          `json:"metadata"`
+	Spec       interface{}       `json:"spec,omitempty"`
+	Data       map[string]string `json:"data,omitempty"`
+}
+
+type Metadata struct {
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type VirtualServiceSpec struct {
+	Hosts []string     `json:"hosts"`
+	HTTP  []*HTTPRoute `json:"http"`
+}
+
+type HTTPRoute struct {
+	Name    string                  `json:"name,omitempty"`
+	Match   []*HTTPMatchRequest     `json:"match,omitempty"`
+	Route   []*HTTPRouteDestination `json:"route"`
+	Retries *HTTPRetry              `json:"retries,omitempty"`
+}
+
+type HTTPMatchRequest struct {
+	Name    string                       `json:"name,omitempty"`
+	URI     map[string]string            `json:"uri,omitempty"`
+	Method  map[string]string            `json:"method,omitempty"`
+	Headers map[string]map[string]string `json:"headers,omitempty"`
+}
+
+type HTTPRouteDestination struct {
+	Destination *Destination `json:"destination"`
+}
+
+type Destination struct {
+	Host string `json:"host"`
+}
+
+type HTTPRetry struct {
+	Attempts int    `json:"attempts"`
+	RetryOn  string `json:"retryOn,omitempty"`
+}
+
+type DestinationRuleSpec struct {
+	Host          string         `json:"host"`
+	TrafficPolicy *TrafficPolicy `json:"trafficPolicy"`
+}
+
+type TrafficPolicy struct {
+	LoadBalancer     *LoadBalancerSettings `json:"loadBalancer,omitempty"`
+	OutlierDetection *OutlierDetection     `json:"outlierDetection,omitempty"`
+}
+
+type LoadBalancerSettings struct {
+	Simple string `json:"simple"`
+}
+
+type OutlierDetection struct {
+	Consecutive5xxErrors int    `json:"consecutive5xxErrors"`
+	Interval             string `json:"interval"`
+	BaseEjectionTime     string `json:"baseEjectionTime"`
+	MaxEjectionPercent   int    `json:"maxEjectionPercent"`
+}
+
+type EnvoyFilterSpec struct {
+	WorkloadSelector *WorkloadSelector        `json:"workloadSelector"`
+	ConfigPatches    []map[string]interface{} `json:"configPatches"`
+}
+
+type WorkloadSelector struct {
+	Labels map[string]string `json:"labels"`
+}
+
+// ToYAML converts the servicecomb policy to the istio resource YAML,
+// the group is the match group which the policy applies to, it is nil
+// if the policy kind is match-group.
+func ToYAML(kind, namespace string, p *gov.Policy, group *gov.Policy) ([]byte, error) {
+	obj, err := ToObject(kind, namespace, p, group)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(obj)
+}
+
+// ToObject converts the servicecomb policy to the istio resource.
+// The match group has no equivalent resource in istio, so it is persisted
+// as a ConfigMap. Istio does not merge the DestinationRules of the same host,
+// so the load balancer and circuit breaker are persisted as ConfigMaps labeled
+// by the host, and merged into one DestinationRule by ToDestinationRule.
+func ToObject(kind, namespace string, p *gov.Policy, group *gov.Policy) (*Object, error) {
+	if kind == kie.KindMatchGroup {
+		return toConfigMap(kind, namespace, p, p.Name)
+	}
+	if group == nil {
+		return nil, kie.NewErrIllegalItem("match group not found", p.Name)
+	}
+	host, groupSpec, err := destination(group)
+	if err != nil {
+		return nil, err
+	}
+	switch kind {
+	case KindRetry:
+		return toVirtualService(namespace, p, host, groupSpec)
+	case KindLoadBalancer, KindCircuitBreaker:
+		// convert in advance to reject the invalid policy
+		if _, err := toTrafficPolicy(&TrafficPolicy{}, kind, p); err != nil {
+			return nil, err
+		}
+		obj, err := toConfigMap(kind, namespace, p, GroupName(p))
+		if err != nil {
+			return nil, err
+		}
+		obj.Metadata.Labels[LabelHost] = host
+		return obj, nil
+	case KindRateLimiting:
+		return toRateLimiting(namespace, p, host, groupSpec)
+	default:
+		return nil, kie.NewErrIllegalItem("istio distributor does not support kind yet", kind)
+	}
+}
+
+// GroupName returns the name of match group which the policy applies to,
+// it is the 'match' of spec, 'rules.match' of spec or the policy name
+func GroupName(p *gov.Policy) string {
+	spec, ok := p.Spec.(map[string]interface{})
+	if !ok {
+		return p.Name
+	}
+	if name, ok := spec["match"].(string); ok && len(name) > 0 {
+		return name
+	}
+	if rules, ok := spec[kie.Rules].(map[string]interface{}); ok {
+		if name, ok := rules["match"].(string); ok && len(name) > 0 {
+			return name
+		}
+	}
+	return p.Name
+}
+
+// ObjectName returns the kubernetes resource name of policy
+func ObjectName(kind string, p *gov.Policy) string {
+	var parts []string
+	for _, s := range []string{kind, p.Selector.App, p.Selector.Environment, p.Name} {
+		if len(s) > 0 {
+			parts = append(parts, s)
+		}
+	}
+	name := invalidNameChars.ReplaceAllString(strings.ToLower(strings.Join(parts, "-")), "-")
+	return strings.Trim(name, "-.")
+}
+
+func destination(group *gov.Policy) (string, *MatchGroupSpec, error) {
+	spec := &MatchGroupSpec{}
+	if err := convertSpec(group.Spec, spec); err != nil {
+		return "", nil, err
+	}
+	var host string
+	for _, m := range spec.Matches {
+		if len(m.ServiceName) == 0 {
+			continue
+		}
+		if len(host) > 0 && host != m.ServiceName {
+			return "", nil, kie.NewErrIllegalItem("match group must target exactly one serviceName in istio", group.Name)
+		}
+		host = m.ServiceName
+	}
+	if len(host) == 0 {
+		return "", nil, kie.NewErrIllegalItem("match group requires serviceName as the istio destination host", group.Name)
+	}
+	return host, spec, nil
+}
+
+func toConfigMap(kind, namespace string, p *gov.Policy, group string) (*Object, error) {
+	b, err := yaml.Marshal(p.Spec)
+	if err != nil {
+		return nil, err
+	}
+	return &Object{
+		APIVersion: "v1",
+		Kind:       KindConfigMap,
+		Metadata:   newMetadata(kind, namespace, p, group),
+		Data:       map[string]string{specDataKey: string(b)},
+	}, nil
+}
+
+// toVirtualService converts retry to the VirtualService routes with retries,
+// the requests matched by match group will be retried and the others will
+// be routed by default
+func toVirtualService(namespace string, p *gov.Policy, host string, group *MatchGroupSpec) (*Object, error) {
+	spec := &RetrySpec{}
+	if err := convertSpec(p.Spec, spec); err != nil {
+		return nil, err
+	}
+	attempts := spec.MaxAttempts
+	if attempts <= 0 {
+		attempts = spec.RetryNext + spec.RetrySame
+	}
+	if attempts <= 0 {
+		attempts = defaultRetryAttempts
+	}
+
+	matches, err := toHTTPMatches(group)
+	if err != nil {
+		return nil, err
+	}
+	route := []*HTTPRouteDestination{{Destination: &Destination{Host: host}}}
+	metadata := newMetadata(KindRetry, namespace, p, GroupName(p))
+	// a host has only one retry VirtualService, or istio merges them randomly
+	metadata.Labels[LabelHost] = host
+	return &Object{
+		APIVersion: APIVersionNetworking,
+		Kind:       KindVirtualService,
+		Metadata:   metadata,
+		Spec: &VirtualServiceSpec{
+			Hosts: []string{host},
+			HTTP: []*HTTPRoute{
+				{
+					Name:    p.Name,
+					Match:   matches,
+					Route:   route,
+					Retries: &HTTPRetry{Attempts: attempts, RetryOn: defaultRetryOn},
+				},
+				{Name: "default", Route: route},
+			},
+		},
+	}, nil
+}
+
+func toHTTPMatches(group *MatchGroupSpec) ([]*HTTPMatchRequest, error) {
+	var requests []*HTTPMatchRequest
+	for _, m := range group.Matches {
+		uri, err := toStringMatch(m.APIPath)
+		if err != nil {
+			return nil, err
+		}
+		headers := make(map[string]map[string]string, len(m.Headers))
+		for name, header := range m.Headers {
+			sm, err := toStringMatch(header)
+			if err != nil {
+				return nil, err
+			}
+			headers[name] = sm
+		}
+		if len(headers) == 0 {
+			headers = nil
+		}
+		if len(m.Method) == 0 {
+			requests = append(requests, &HTTPMatchRequest{Name: m.Name, URI: uri, Headers: headers})
+			continue
+		}
+		for _, method := range m.Method {
+			requests = append(requests, &HTTPMatchRequest{Name: m.Name, URI: uri, Headers: headers,
+				Method: map[string]string{"exact": method}})
+		}
+	}
+	return requests, nil
+}
+
+// toStringMatch converts the servicecomb match operators to the istio StringMatch,
+// istio accepts only one operator, so the first one in the order of exact, prefix,
+// regex, suffix and contains is chosen, the others are ignored
+func toStringMatch(operators map[string]string) (map[string]string, error) {
+	for op := range operators {
+		if !util.SliceHave(stringMatchOperators, op) {
+			return nil, kie.NewErrIllegalItem("istio does not support match operator", op)
+		}
+	}
+	for _, op := range stringMatchOperators {
+		value, ok := operators[op]
+		if !ok {
+			continue
+		}
+		switch op {
+		case "suffix":
+			return map[string]string{"regex": ".*" + regexp.QuoteMeta(value)}, nil
+		case "contains":
+			return map[string]string{"regex": ".*" + regexp.QuoteMeta(value) + ".*"}, nil
+		default:
+			return map[string]string{op: value}, nil
+		}
+	}
+	return nil, nil
+}
+
+// DestinationRuleName returns the name of the DestinationRule of the host
+func DestinationRuleName(host string) string {
+	name := invalidNameChars.ReplaceAllString(strings.ToLower("destination-"+host), "-")
+	return strings.Trim(name, "-.")
+}
+
+// ToDestinationRule merges the load balancer and circuit breaker policies of
+// the host into one DestinationRule, each kind can have only one policy,
+// as the traffic policy applies to all the requests to the host
+func ToDestinationRule(namespace, host string, policies []*gov.Policy) (*Object, error) {
+	tp := &TrafficPolicy{}
+	for _, p := range policies {
+		var err error
+		if tp, err = toTrafficPolicy(tp, p.Kind, p); err != nil {
+			return nil, err
+		}
+	}
+	return &Object{
+		APIVersion: APIVersionNetworking,
+		Kind:       KindDestinationRule,
+		Metadata: Metadata{
+			Name:      DestinationRuleName(host),
+			Namespace: namespace,
+			Labels:    map[string]string{LabelHost: host},
+		},
+		Spec: &DestinationRuleSpec{
+			Host:          host,
+			TrafficPolicy: tp,
+		},
+	}, nil
+}
+
+// toTrafficPolicy sets the policy to the traffic policy, returns error if the policy
+// can not be converted or the traffic policy has been set by the same kind
+func toTrafficPolicy(tp *TrafficPolicy, kind string, p *gov.Policy) (*TrafficPolicy, error) {
+	switch kind {
+	case KindLoadBalancer:
+		if tp.LoadBalancer != nil {
+			return nil, kie.NewErrIllegalItem("istio applies only one load balancer to a host", p.Name)
+		}
+		lb, err := toLoadBalancer(p)
+		if err != nil {
+			return nil, err
+		}
+		tp.LoadBalancer = lb
+	case KindCircuitBreaker:
+		if tp.OutlierDetection != nil {
+			return nil, kie.NewErrIllegalItem("istio applies only one circuit breaker to a host", p.Name)
+		}
+		od, err := toOutlierDetection(p)
+		if err != nil {
+			return nil, err
+		}
+		tp.OutlierDetection = od
+	default:
+		return nil, kie.NewErrIllegalItem("istio DestinationRule does not support kind", kind)
+	}
+	return tp, nil
+}
+
+func toLoadBalancer(p *gov.Policy) (*LoadBalancerSettings, error) {
+	spec := &LoadBalancerSpec{}
+	if err := convertSpec(p.Spec, spec); err != nil {
+		return nil, err
+	}
+	simple := defaultLoadBalancer
+	if len(spec.Rule) > 0 {
+		var ok bool
+		simple, ok = loadBalancers[strings.ToLower(spec.Rule)]
+		if !ok {
+			return nil, kie.NewErrIllegalItem("istio does not support load balance rule", spec.Rule)
+		}
+	}
+	return &LoadBalancerSettings{Simple: simple}, nil
+}
+
+// toOutlierDetection converts circuit breaker to the outlier detection, istio ejects
+// the host by the consecutive errors instead of the failure rate, so only the time
+// based sliding window and the open state duration have the equivalents, they are
+// the analysis interval and the base ejection time, the other fields are rejected
+func toOutlierDetection(p *gov.Policy) (*OutlierDetection, error) {
+	spec := &CircuitBreakerSpec{}
+	if err := convertSpec(p.Spec, spec); err != nil {
+		return nil, err
+	}
+	switch {
+	case spec.FailureRateThreshold > 0:
+		return nil, kie.NewErrIllegalItem("istio does not support failureRateThreshold", spec.FailureRateThreshold)
+	case spec.MinimumNumberOfCalls > 0:
+		return nil, kie.NewErrIllegalItem("istio does not support minimumNumberOfCalls", spec.MinimumNumberOfCalls)
+	case spec.SlowCallRateThreshold > 0 || spec.SlowCallDurationThreshold != nil:
+		return nil, kie.NewErrIllegalItem("istio does not support slow call thresholds", p.Name)
+	case spec.PermittedNumberOfCallsInHalfOpenState > 0:
+		return nil, kie.NewErrIllegalItem("istio does not support permittedNumberOfCallsInHalfOpenState",
+			spec.PermittedNumberOfCallsInHalfOpenState)
+	case spec.SlidingWindowSize != nil && spec.SlidingWindowType != "time":
+		return nil, kie.NewErrIllegalItem("istio supports the time based sliding window only", spec.SlidingWindowType)
+	}
+	interval, err := toDuration(spec.SlidingWindowSize, time.Second, defaultEjectionInterval)
+	if err != nil {
+		return nil, err
+	}
+	ejection, err := toDuration(spec.WaitDurationInOpenState, time.Millisecond, defaultBaseEjectionTime)
+	if err != nil {
+		return nil, err
+	}
+	return &OutlierDetection{
+		Consecutive5xxErrors: defaultConsecutiveErrors,
+		Interval:             interval.String(),
+		BaseEjectionTime:     ejection.String(),
+		MaxEjectionPercent:   defaultMaxEjectionPercent,
+	}, nil
+}
+
+// toRateLimiting converts rate limiting to the EnvoyFilter which inserts
+// a local rate limit filter into the inbound sidecar of provider workloads.
+// The filter limits all the inbound requests of the workload, so the match
+// group must match all the requests, i.e. apiPath prefix '/' only
+func toRateLimiting(namespace string, p *gov.Policy, host string, group *MatchGroupSpec) (*Object, error) {
+	for _, m := range group.Matches {
+		if len(m.Headers) > 0 || len(m.Method) > 0 || len(m.APIPath) > 1 || m.APIPath["prefix"] != "/" {
+			return nil, kie.NewErrIllegalItem("istio rate limiting applies to all the requests of the service, "+
+				"supports apiPath prefix '/' match only", GroupName(p))
+		}
+	}
+	spec := &RateLimitingSpec{}
+	if err := convertSpec(p.Spec, spec); err != nil {
+		return nil, err
+	}
+	if spec.Rate <= 0 {
+		return nil, kie.NewErrIllegalItem("rate must be greater than 0", spec.Rate)
+	}
+	period, err := toDuration(spec.LimitRefreshPeriod, time.Millisecond, defaultLimitRefreshPeriod)
+	if err != nil {
+		return nil, err
+	}
+	maxTokens := spec.Burst
+	if maxTokens < spec.Rate {
+		maxTokens = spec.Rate
+	}
+	runtimeKey := localRateLimitRuntimePrefix + strings.ReplaceAll(ObjectName(KindRateLimiting, p), "-", "_")
+	percent := map[string]interface{}{
+		"default_value": map[string]interface{}{"numerator": 100, "denominator": "HUNDRED"},
+	}
+	enabled, enforced := map[string]interface{}{"runtime_key": runtimeKey + "_enabled"},
+		map[string]interface{}{"runtime_key": runtimeKey + "_enforced"}
+	for k, v := range percent {
+		enabled[k], enforced[k] = v, v
+	}
+	patch := map[string]interface{}{
+		"applyTo": "HTTP_FILTER",
+		"match": map[string]interface{}{
+			"context": "SIDECAR_INBOUND",
+			"listener": map[string]interface{}{
+				"filterChain": map[string]interface{}{
+					"filter": map[string]interface{}{
+						"name":      httpConnectionManagerName,
+						"subFilter": map[string]interface{}{"name": httpRouterFilterName},
+					},
+				},
+			},
+		},
+		"patch": map[string]interface{}{
+			"operation": "INSERT_BEFORE",
+			"value": map[string]interface{}{
+				"name": localRateLimitFilterName,
+				"typed_config": map[string]interface{}{
+					"@type":    typedStructType,
+					"type_url": localRateLimitFilterType,
+					"value": map[string]interface{}{
+						"stat_prefix": localRateLimitStatPrefix,
+						"token_bucket": map[string]interface{}{
+							"max_tokens":      maxTokens,
+							"tokens_per_fill": spec.Rate,
+							"fill_interval":   period.String(),
+						},
+						"filter_enabled":  enabled,
+						"filter_enforced": enforced,
+					},
+				},
+			},
+		},
+	}
+	metadata := newMetadata(KindRateLimiting, namespace, p, GroupName(p))
+	// the filters of the same workload all apply, so a host has only one
+	metadata.Labels[LabelHost] = host
+	return &Object{
+		APIVersion: APIVersionNetworking,
+		Kind:       KindEnvoyFilter,
+		Metadata:   metadata,
+		Spec: &EnvoyFilterSpec{
+			WorkloadSelector: &WorkloadSelector{Labels: map[string]string{"app": host}},
+			ConfigPatches:    []map[string]interface{}{patch},
+		},
+	}, nil
+}
+
+func newMetadata(kind, namespace string, p *gov.Policy, group string) Metadata {
+	b, _ := json.Marshal(p)
+	return Metadata{
+		Name:      ObjectName(kind, p),
+		Namespace: namespace,
+		Labels: map[string]string{
+			LabelKind:        kind,
+			LabelApp:         p.Selector.App,
+			LabelEnvironment: p.Selector.Environment,
+			LabelGroup:       group,
+		},
+		Annotations: map[string]string{AnnotationPolicy: string(b)},
+	}
+}
+
+func convertSpec(spec interface{}, v interface{}) error {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return kie.NewErrIllegalItem(err.Error(), spec)
+	}
+	return nil
+}
+
+// toDuration parses the duration string, e.g. '1s', or the number with unit
+func toDuration(v interface{}, unit time.Duration, def time.Duration) (time.Duration, error) {
+	switch d := v.(type) {
+	case nil:
+		return def, nil
+	case float64:
+		if d <= 0 {
+			return def, nil
+		}
+		return time.Duration(d * float64(unit)), nil
+	case string:
+		if len(d) == 0 {
+			return def, nil
+		}
+		duration, err := time.ParseDuration(d)
+		if err != nil {
+			return 0, kie.NewErrIllegalItem(err.Error(), d)
+		}
+		return duration, nil
+	default:
+		return 0, kie.NewErrIllegalItem(fmt.Sprintf("invalid duration type %T", v), v)
+	}
+}
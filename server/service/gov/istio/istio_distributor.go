@@ -0,0 +1,559 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package istio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/apache/servicecomb-service-center/pkg/gov"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/server/config"
+	svc "github.com/apache/servicecomb-service-center/server/service/gov"
+	"github.com/apache/servicecomb-service-center/server/service/gov/kie"
+)
+
+const (
+	KindRetry          = gov.KindRetry
+	KindRateLimiting   = gov.KindRateLimiting
+	KindCircuitBreaker = gov.KindCircuitBreaker
+	KindLoadBalancer   = gov.KindLoadBalancer
+
+	LabelKind        = "servicecomb.apache.org/kind"
+	LabelApp         = "servicecomb.apache.org/app"
+	LabelEnvironment = "servicecomb.apache.org/environment"
+	LabelGroup       = "servicecomb.apache.org/match-group"
+	LabelHost        = "servicecomb.apache.org/host"
+	AnnotationPolicy = "servicecomb.apache.org/policy"
+)
+
+var (
+	PolicyNames = []string{KindRetry, KindRateLimiting, KindCircuitBreaker, KindLoadBalancer}
+
+	resources = map[string]schema.GroupVersionResource{
+		kie.KindMatchGroup: {Version: "v1", Resource: "configmaps"},
+		KindRetry:          {Group: "networking.istio.io", Version: "v1alpha3", Resource: "virtualservices"},
+		KindLoadBalancer:   {Version: "v1", Resource: "configmaps"},
+		KindCircuitBreaker: {Version: "v1", Resource: "configmaps"},
+		KindRateLimiting:   {Group: "networking.istio.io", Version: "v1alpha3", Resource: "envoyfilters"},
+	}
+	destinationRules = schema.GroupVersionResource{Group: "networking.istio.io", Version: "v1alpha3", Resource: "destinationrules"}
+	// trafficPolicies are the kinds merged into the DestinationRule of the host
+	trafficPolicies = []string{KindLoadBalancer, KindCircuitBreaker}
+
+	rule = kie.Validator{}
+)
+
+// NewKubeClient creates the kubernetes client used to persist the istio resources,
+// if KUBERNETES_CONFIG_PATH is unset, then service center must be deployed in the
+// same k8s cluster. It can be replaced, e.g. by the client-go fake client in tests.
+var NewKubeClient = func(opts config.DistributorOptions) (dynamic.Interface, error) {
+	cfg, err := clientcmd.BuildConfigFromFlags(opts.Endpoint, os.Getenv("KUBERNETES_CONFIG_PATH"))
+	if err != nil {
+		return nil, err
+	}
+	return dynamic.NewForConfig(cfg)
+}
+
+// Distributor converts the servicecomb governance policies to istio resources,
+// the project is mapped to the kubernetes namespace
+type Distributor struct {
+	name   string
+	client dynamic.Interface
+}
+
+func (d *Distributor) Create(kind, project string, spec []byte) ([]byte, error) {
+	p := &gov.Policy{
+		GovernancePolicy: &gov.GovernancePolicy{Selector: &gov.Selector{}},
+	}
+	err := json.Unmarshal(spec, p)
+	if err != nil {
+		return nil, err
+	}
+	if err = d.validate(kind, p); err != nil {
+		return nil, err
+	}
+	if kind == kie.KindMatchGroup && p.Name == "" {
+		p.Name, err = d.generateName(project, p)
+		if err != nil {
+			return nil, err
+		}
+	}
+	log.Info(fmt.Sprintf("create %+v", p))
+	p.Kind = kind
+	p.Status = statusOrDefault(p.Status)
+	p.CreatTime = time.Now().Unix()
+	p.UpdateTime = p.CreatTime
+	obj, err := d.toUnstructured(kind, project, p)
+	if err != nil {
+		return nil, err
+	}
+	host := obj.GetLabels()[LabelHost]
+	if err = d.checkHost(kind, project, host, obj.GetName()); err != nil {
+		return nil, err
+	}
+	res, err := d.resource(kind, project).Create(context.TODO(), obj, metav1.CreateOptions{})
+	if err != nil {
+		log.Error("istio create failed", err)
+		return nil, err
+	}
+	if err = d.syncDestinationRule(project, host); err != nil {
+		return nil, err
+	}
+	return []byte(res.GetName()), nil
+}
+
+func (d *Distributor) Update(kind, id, project string, spec []byte) error {
+	p := &gov.Policy{
+		GovernancePolicy: &gov.GovernancePolicy{Selector: &gov.Selector{}},
+	}
+	err := json.Unmarshal(spec, p)
+	if err != nil {
+		return err
+	}
+	old, err := d.getPolicy(kind, id, project)
+	if err != nil {
+		return err
+	}
+	// the name and selector decide the resource name, can not be changed
+	p.Name, p.Selector, p.Kind, p.CreatTime = old.Name, old.Selector, kind, old.CreatTime
+	log.Info(fmt.Sprintf("update %s %s", kind, p.Name))
+	p.Status = statusOrDefault(p.Status)
+	p.UpdateTime = time.Now().Unix()
+	if err = d.validate(kind, p); err != nil {
+		return err
+	}
+	if err = d.apply(kind, project, p); err != nil {
+		return err
+	}
+	if kind == kie.KindMatchGroup {
+		// re-generate the istio resources of policies which apply to this group
+		return d.refreshGroupPolicies(project, p)
+	}
+	return nil
+}
+
+func (d *Distributor) Delete(kind, id, project string) error {
+	if kind == kie.KindMatchGroup {
+		// should remove all policies of this group
+		if err := d.deleteGroupPolicies(id, project); err != nil {
+			return err
+		}
+	}
+	return d.deletePolicy(kind, id, project)
+}
+
+func (d *Distributor) Display(project, app, env string) ([]byte, error) {
+	groups, err := d.listPolicies(kie.KindMatchGroup, project, app, env)
+	if err != nil {
+		return nil, err
+	}
+	policyMap := make(map[string]*gov.Policy)
+	for _, kind := range PolicyNames {
+		policies, err := d.listPolicies(kind, project, app, env)
+		if err != nil {
+			continue
+		}
+		for _, policy := range policies {
+			policyMap[GroupName(policy)+kind] = policy
+		}
+	}
+	r := make([]*gov.DisplayData, 0, len(groups))
+	for _, group := range groups {
+		var policies []*gov.Policy
+		for _, kind := range PolicyNames {
+			if policyMap[group.Name+kind] != nil {
+				policies = append(policies, policyMap[group.Name+kind])
+			}
+		}
+		r = append(r, &gov.DisplayData{
+			Policies:   policies,
+			MatchGroup: group,
+		})
+	}
+	b, _ := json.MarshalIndent(r, "", "  ")
+	return b, nil
+}
+
+func (d *Distributor) List(kind, project, app, env string) ([]byte, error) {
+	r, err := d.listPolicies(kind, project, app, env)
+	if err != nil {
+		return nil, err
+	}
+	b, _ := json.MarshalIndent(r, "", "  ")
+	return b, nil
+}
+
+func (d *Distributor) Get(kind, id, project string) ([]byte, error) {
+	policy, err := d.getPolicy(kind, id, project)
+	if err != nil {
+		return nil, err
+	}
+	b, _ := json.MarshalIndent(policy, "", "  ")
+	return b, nil
+}
+
+func (d *Distributor) Type() string {
+	return svc.ConfigDistributorIstio
+}
+
+func (d *Distributor) Name() string {
+	return d.name
+}
+
+func (d *Distributor) validate(kind string, p *gov.Policy) error {
+	if _, ok := resources[kind]; !ok {
+		return kie.NewErrIllegalItem("istio distributor does not support kind yet", kind)
+	}
+	if p.Selector == nil {
+		p.Selector = &gov.Selector{}
+	}
+	return rule.Validate(kind, p.Spec)
+}
+
+func (d *Distributor) resource(kind, project string) dynamic.ResourceInterface {
+	gvr, ok := resources[kind]
+	if !ok {
+		gvr = resources[KindRetry]
+	}
+	return d.client.Resource(gvr).Namespace(project)
+}
+
+// toUnstructured converts the policy to the istio resource, the match group
+// of the policy should be created before
+func (d *Distributor) toUnstructured(kind, project string, p *gov.Policy) (*unstructured.Unstructured, error) {
+	var group *gov.Policy
+	if kind != kie.KindMatchGroup {
+		var err error
+		group, err = d.getPolicy(kie.KindMatchGroup, ObjectName(kie.KindMatchGroup, &gov.Policy{
+			GovernancePolicy: &gov.GovernancePolicy{Name: GroupName(p), Selector: p.Selector},
+		}), project)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	b, err := ToYAML(kind, project, p, group)
+	if err != nil {
+		return nil, err
+	}
+	b, err = yaml.YAMLToJSON(b)
+	if err != nil {
+		return nil, err
+	}
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(b); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (d *Distributor) apply(kind, project string, p *gov.Policy) error {
+	obj, err := d.toUnstructured(kind, project, p)
+	if err != nil {
+		return err
+	}
+	ri := d.resource(kind, project)
+	old, err := ri.Get(context.TODO(), obj.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	host, oldHost := obj.GetLabels()[LabelHost], old.GetLabels()[LabelHost]
+	if err = d.checkHost(kind, project, host, obj.GetName()); err != nil {
+		return err
+	}
+	obj.SetResourceVersion(old.GetResourceVersion())
+	_, err = ri.Update(context.TODO(), obj, metav1.UpdateOptions{})
+	if err != nil {
+		log.Error("istio update failed", err)
+		return err
+	}
+	if oldHost != host {
+		// the match group may change the host, refresh the previous one
+		if err = d.syncDestinationRule(project, oldHost); err != nil {
+			return err
+		}
+	}
+	return d.syncDestinationRule(project, host)
+}
+
+func (d *Distributor) deletePolicy(kind, id, project string) error {
+	ri := d.resource(kind, project)
+	var host string
+	if util.SliceHave(trafficPolicies, kind) {
+		old, err := ri.Get(context.TODO(), id, metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			log.Error("istio get failed", err)
+			return err
+		}
+		host = old.GetLabels()[LabelHost]
+	}
+	err := ri.Delete(context.TODO(), id, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		log.Error("istio delete failed", err)
+		return err
+	}
+	return d.syncDestinationRule(project, host)
+}
+
+// checkHost returns error if another policy of the same kind has been applied to
+// the host, as the DestinationRule contains only one load balancer or circuit breaker,
+// and istio can not tell apart the retry VirtualServices or the rate limiting
+// EnvoyFilters of the same host
+func (d *Distributor) checkHost(kind, project, host, name string) error {
+	if len(host) == 0 {
+		return nil
+	}
+	list, err := d.listByHost(kind, project, host)
+	if err != nil {
+		return err
+	}
+	for _, item := range list {
+		if item.ID != name {
+			return kie.NewErrIllegalItem(fmt.Sprintf("host %s has been applied %s policy", host, kind), item.Name)
+		}
+	}
+	return nil
+}
+
+func (d *Distributor) listByHost(kind, project, host string) ([]*gov.Policy, error) {
+	return d.listBySelector(kind, project, labels.Set{LabelKind: kind, LabelHost: host})
+}
+
+// syncDestinationRule merges the load balancer and circuit breaker policies of
+// the host into the DestinationRule, and deletes it if there are no policies
+func (d *Distributor) syncDestinationRule(project, host string) error {
+	if len(host) == 0 {
+		return nil
+	}
+	var policies []*gov.Policy
+	for _, kind := range trafficPolicies {
+		list, err := d.listByHost(kind, project, host)
+		if err != nil {
+			return err
+		}
+		policies = append(policies, list...)
+	}
+	ri := d.client.Resource(destinationRules).Namespace(project)
+	name := DestinationRuleName(host)
+	if len(policies) == 0 {
+		err := ri.Delete(context.TODO(), name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			log.Error("istio delete failed", err)
+			return err
+		}
+		return nil
+	}
+	dr, err := ToDestinationRule(project, host, policies)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(dr)
+	if err != nil {
+		return err
+	}
+	obj := &unstructured.Unstructured{}
+	if err = obj.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	old, err := ri.Get(context.TODO(), name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		_, err = ri.Create(context.TODO(), obj, metav1.CreateOptions{})
+	} else if err == nil {
+		obj.SetResourceVersion(old.GetResourceVersion())
+		_, err = ri.Update(context.TODO(), obj, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		log.Error("istio sync DestinationRule failed", err)
+		return err
+	}
+	return nil
+}
+
+func (d *Distributor) getPolicy(kind, id, project string) (*gov.Policy, error) {
+	obj, err := d.resource(kind, project).Get(context.TODO(), id, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return d.transform(obj, kind)
+}
+
+func (d *Distributor) listPolicies(kind, project, app, env string) ([]*gov.Policy, error) {
+	selector := labels.Set{LabelKind: kind}
+	if env != kie.EnvAll {
+		selector[LabelEnvironment] = env
+	}
+	if app != "" {
+		selector[LabelApp] = app
+	}
+	return d.listBySelector(kind, project, selector)
+}
+
+func (d *Distributor) listBySelector(kind, project string, selector labels.Set) ([]*gov.Policy, error) {
+	list, err := d.resource(kind, project).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		log.Error("istio list failed", err)
+		return nil, err
+	}
+	r := make([]*gov.Policy, 0, len(list.Items))
+	for i := range list.Items {
+		item := &list.Items[i]
+		policy, err := d.transform(item, kind)
+		if err != nil {
+			log.Warn(fmt.Sprintf("transform istio resource failed: name is [%s]", item.GetName()))
+			continue
+		}
+		r = append(r, policy)
+	}
+	return r, nil
+}
+
+func (d *Distributor) groupPolicies(project string, group *gov.Policy) (map[string][]*gov.Policy, error) {
+	selector := labels.Set{
+		LabelGroup:       group.Name,
+		LabelApp:         group.Selector.App,
+		LabelEnvironment: group.Selector.Environment,
+	}
+	m := make(map[string][]*gov.Policy, len(PolicyNames))
+	for _, kind := range PolicyNames {
+		selector[LabelKind] = kind
+		policies, err := d.listBySelector(kind, project, selector)
+		if err != nil {
+			return nil, err
+		}
+		m[kind] = policies
+	}
+	return m, nil
+}
+
+func (d *Distributor) refreshGroupPolicies(project string, group *gov.Policy) error {
+	m, err := d.groupPolicies(project, group)
+	if err != nil {
+		return err
+	}
+	for kind, policies := range m {
+		for _, policy := range policies {
+			if err := d.apply(kind, project, policy); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *Distributor) deleteGroupPolicies(id, project string) error {
+	group, err := d.getPolicy(kie.KindMatchGroup, id, project)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		log.Error("istio get failed", err)
+		return err
+	}
+	m, err := d.groupPolicies(project, group)
+	if err != nil {
+		return err
+	}
+	for kind, policies := range m {
+		for _, policy := range policies {
+			if err := d.deletePolicy(kind, policy.ID, project); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// transform restores the servicecomb policy from the annotation of istio resource
+func (d *Distributor) transform(obj *unstructured.Unstructured, kind string) (*gov.Policy, error) {
+	p := &gov.Policy{
+		GovernancePolicy: &gov.GovernancePolicy{
+			Selector: &gov.Selector{},
+		},
+	}
+	err := json.Unmarshal([]byte(obj.GetAnnotations()[AnnotationPolicy]), p)
+	if err != nil {
+		log.Error("istio transform resource failed", err)
+		return nil, err
+	}
+	p.Kind = kind
+	p.ID = obj.GetName()
+	return p, nil
+}
+
+func (d *Distributor) generateName(project string, p *gov.Policy) (string, error) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for {
+		name := kie.GroupNamePrefix + randomString(r, 4)
+		_, err := d.resource(kie.KindMatchGroup, project).Get(context.TODO(), ObjectName(kie.KindMatchGroup,
+			&gov.Policy{GovernancePolicy: &gov.GovernancePolicy{Name: name, Selector: p.Selector}}), metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return name, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+func randomString(r *rand.Rand, n int) string {
+	const str = "0123456789abcdefghijklmnopqrstuvwxyz"
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = str[r.Intn(len(str))]
+	}
+	return string(b)
+}
+
+func statusOrDefault(status string) string {
+	if len(status) == 0 {
+		return kie.StatusEnabled
+	}
+	return status
+}
+
+func new(opts config.DistributorOptions) (svc.ConfigDistributor, error) {
+	client, err := NewKubeClient(opts)
+	if err != nil {
+		log.Error("init kubernetes client failed", err)
+		return nil, err
+	}
+	return &Distributor{name: opts.Name, client: client}, nil
+}
+
+func init() {
+	svc.InstallDistributor(svc.ConfigDistributorIstio, new)
+}
@@ -0,0 +1,348 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package istio_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/fake"
+
+	"github.com/apache/servicecomb-service-center/pkg/gov"
+	"github.com/apache/servicecomb-service-center/server/config"
+	svc "github.com/apache/servicecomb-service-center/server/service/gov"
+	"github.com/apache/servicecomb-service-center/server/service/gov/istio"
+	"github.com/apache/servicecomb-service-center/server/service/gov/kie"
+)
+
+const (
+	Project = "default"
+	MockApp = "app"
+	MockEnv = "dev"
+)
+
+var (
+	client = fake.NewSimpleDynamicClient(runtime.NewScheme())
+
+	virtualServices  = schema.GroupVersionResource{Group: "networking.istio.io", Version: "v1alpha3", Resource: "virtualservices"}
+	destinationRules = schema.GroupVersionResource{Group: "networking.istio.io", Version: "v1alpha3", Resource: "destinationrules"}
+	envoyFilters     = schema.GroupVersionResource{Group: "networking.istio.io", Version: "v1alpha3", Resource: "envoyfilters"}
+)
+
+func init() {
+	istio.NewKubeClient = func(opts config.DistributorOptions) (dynamic.Interface, error) {
+		return client, nil
+	}
+	config.App = &config.AppConfig{
+		Gov: &config.Gov{
+			DistOptions: []config.DistributorOptions{
+				{
+					Name: "istio",
+					Type: "istio",
+				},
+			},
+		},
+	}
+	err := svc.Init()
+	if err != nil {
+		panic(err)
+	}
+}
+
+func newPolicy(name string, spec interface{}) []byte {
+	b, _ := json.Marshal(&gov.Policy{
+		GovernancePolicy: &gov.GovernancePolicy{
+			Name:     name,
+			Selector: &gov.Selector{App: MockApp, Environment: MockEnv},
+		},
+		Spec: spec,
+	})
+	return b
+}
+
+func newMatchGroup(name, method string) []byte {
+	return newPolicy(name, map[string]interface{}{
+		"alias": name,
+		"matches": []interface{}{
+			map[string]interface{}{
+				"name":        "admin",
+				"serviceName": "provider",
+				"apiPath":     map[string]interface{}{"prefix": "/admin"},
+				"method":      []interface{}{method},
+			},
+		},
+	})
+}
+
+func newServiceMatchGroup(name string) []byte {
+	return newPolicy(name, map[string]interface{}{
+		"alias": name,
+		"matches": []interface{}{
+			map[string]interface{}{
+				"name":        "all",
+				"serviceName": "provider",
+				"apiPath":     map[string]interface{}{"prefix": "/"},
+			},
+		},
+	})
+}
+
+func getDestinationRule(t *testing.T, host string) *unstructured.Unstructured {
+	dr, err := client.Resource(destinationRules).Namespace(Project).Get(context.TODO(),
+		istio.DestinationRuleName(host), metav1.GetOptions{})
+	assert.NoError(t, err)
+	return dr
+}
+
+func TestDistributor(t *testing.T) {
+	var groupID, retryID, lbID string
+
+	t.Run("create match group and policies should pass", func(t *testing.T) {
+		res, _, err := svc.Create(kie.KindMatchGroup, Project, newMatchGroup("group1", "GET"))
+		assert.NoError(t, err)
+		groupID = string(res)
+		assert.NotEmpty(t, groupID)
+
+		res, _, err = svc.Create(istio.KindRetry, Project, newPolicy("group1", &gov.LBSpec{RetryNext: 2, RetrySame: 1}))
+		assert.NoError(t, err)
+		retryID = string(res)
+
+		vs, err := client.Resource(virtualServices).Namespace(Project).Get(context.TODO(), retryID, metav1.GetOptions{})
+		assert.NoError(t, err)
+		hosts, _, _ := unstructured.NestedStringSlice(vs.Object, "spec", "hosts")
+		assert.Equal(t, []string{"provider"}, hosts)
+
+		res, _, err = svc.Create(istio.KindLoadBalancer, Project, newPolicy("group1", map[string]interface{}{"rule": "Random"}))
+		assert.NoError(t, err)
+		lbID = string(res)
+		simple, _, _ := unstructured.NestedString(getDestinationRule(t, "provider").Object,
+			"spec", "trafficPolicy", "loadBalancer", "simple")
+		assert.Equal(t, "RANDOM", simple)
+
+		_, _, err = svc.Create(istio.KindCircuitBreaker, Project, newPolicy("group1", map[string]interface{}{
+			"waitDurationInOpenState": "1m",
+		}))
+		assert.NoError(t, err)
+		dr := getDestinationRule(t, "provider")
+		simple, _, _ = unstructured.NestedString(dr.Object, "spec", "trafficPolicy", "loadBalancer", "simple")
+		assert.Equal(t, "RANDOM", simple)
+		ejection, _, _ := unstructured.NestedString(dr.Object, "spec", "trafficPolicy", "outlierDetection", "baseEjectionTime")
+		assert.Equal(t, "1m0s", ejection)
+		list, err := client.Resource(destinationRules).Namespace(Project).List(context.TODO(), metav1.ListOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(list.Items))
+
+		_, _, err = svc.Create(istio.KindRateLimiting, Project, newPolicy("group1", &gov.LimiterSpec{Rate: 10}))
+		assert.Error(t, err)
+	})
+
+	t.Run("create rate limiting to the whole service should pass", func(t *testing.T) {
+		res, _, err := svc.Create(kie.KindMatchGroup, Project, newServiceMatchGroup("group5"))
+		assert.NoError(t, err)
+		groupID := string(res)
+		res, _, err = svc.Create(istio.KindRateLimiting, Project, newPolicy("group5", &gov.LimiterSpec{Rate: 10}))
+		assert.NoError(t, err)
+		_, err = client.Resource(envoyFilters).Namespace(Project).Get(context.TODO(), string(res), metav1.GetOptions{})
+		assert.NoError(t, err)
+
+		res, _, err = svc.Create(kie.KindMatchGroup, Project, newServiceMatchGroup("group6"))
+		assert.NoError(t, err)
+		_, _, err = svc.Create(istio.KindRateLimiting, Project, newPolicy("group6", &gov.LimiterSpec{Rate: 10}))
+		assert.Error(t, err)
+		_, err = svc.Delete(kie.KindMatchGroup, string(res), Project)
+		assert.NoError(t, err)
+
+		_, err = svc.Delete(kie.KindMatchGroup, groupID, Project)
+		assert.NoError(t, err)
+		list, err := client.Resource(envoyFilters).Namespace(Project).List(context.TODO(), metav1.ListOptions{})
+		assert.NoError(t, err)
+		assert.Empty(t, list.Items)
+	})
+
+	t.Run("create policy without match group should fail", func(t *testing.T) {
+		_, _, err := svc.Create(istio.KindRetry, Project, newPolicy("not-exist", &gov.LBSpec{RetryNext: 1}))
+		assert.Error(t, err)
+		_, ok := err.(*kie.ErrIllegalItem)
+		assert.True(t, ok)
+	})
+
+	t.Run("create policy of the same kind to the same host should fail", func(t *testing.T) {
+		res, _, err := svc.Create(kie.KindMatchGroup, Project, newMatchGroup("group3", "PUT"))
+		assert.NoError(t, err)
+		_, _, err = svc.Create(istio.KindLoadBalancer, Project, newPolicy("group3", map[string]interface{}{"rule": "RoundRobin"}))
+		assert.Error(t, err)
+		_, ok := err.(*kie.ErrIllegalItem)
+		assert.True(t, ok)
+		_, _, err = svc.Create(istio.KindRetry, Project, newPolicy("group3", &gov.LBSpec{RetryNext: 1}))
+		assert.Error(t, err)
+		_, err = svc.Delete(kie.KindMatchGroup, string(res), Project)
+		assert.NoError(t, err)
+	})
+
+	t.Run("create policy without istio equivalent should fail", func(t *testing.T) {
+		_, _, err := svc.Create(istio.KindLoadBalancer, Project, newPolicy("group1", map[string]interface{}{"rule": "WeightedResponse"}))
+		assert.Error(t, err)
+		_, _, err = svc.Create(istio.KindCircuitBreaker, Project, newPolicy("group1", map[string]interface{}{
+			"failureRateThreshold": 50,
+		}))
+		assert.Error(t, err)
+	})
+
+	t.Run("update policy should refresh the destination rule", func(t *testing.T) {
+		_, err := svc.Update(istio.KindLoadBalancer, lbID, Project, newPolicy("group1", map[string]interface{}{"rule": "LeastConnection"}))
+		assert.NoError(t, err)
+		simple, _, _ := unstructured.NestedString(getDestinationRule(t, "provider").Object,
+			"spec", "trafficPolicy", "loadBalancer", "simple")
+		assert.Equal(t, "LEAST_CONN", simple)
+	})
+
+	t.Run("create unsupported kind should fail", func(t *testing.T) {
+		_, _, err := svc.Create("bulkhead", Project, newPolicy("group1", map[string]interface{}{}))
+		assert.Error(t, err)
+	})
+
+	t.Run("get and list should return the servicecomb policies", func(t *testing.T) {
+		policy := &gov.Policy{}
+		res, err := svc.Get(istio.KindRetry, retryID, Project)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(res, policy))
+		assert.Equal(t, "group1", policy.Name)
+		assert.Equal(t, retryID, policy.ID)
+
+		var policies []*gov.Policy
+		res, err = svc.List(istio.KindRetry, Project, MockApp, MockEnv)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(res, &policies))
+		assert.Equal(t, 1, len(policies))
+
+		var display []*gov.DisplayData
+		res, err = svc.Display(Project, MockApp, MockEnv)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(res, &display))
+		assert.Equal(t, 1, len(display))
+		assert.Equal(t, 3, len(display[0].Policies))
+	})
+
+	t.Run("update match group should refresh the policies", func(t *testing.T) {
+		_, err := svc.Update(kie.KindMatchGroup, groupID, Project, newMatchGroup("group1", "POST"))
+		assert.NoError(t, err)
+
+		vs, err := client.Resource(virtualServices).Namespace(Project).Get(context.TODO(), retryID, metav1.GetOptions{})
+		assert.NoError(t, err)
+		b, _ := json.Marshal(vs.Object)
+		assert.Contains(t, string(b), `"method":{"exact":"POST"}`)
+	})
+
+	t.Run("delete match group should delete the policies", func(t *testing.T) {
+		_, err := svc.Delete(kie.KindMatchGroup, groupID, Project)
+		assert.NoError(t, err)
+		_, err = svc.Get(istio.KindRetry, retryID, Project)
+		assert.Error(t, err)
+		list, err := client.Resource(destinationRules).Namespace(Project).List(context.TODO(), metav1.ListOptions{})
+		assert.NoError(t, err)
+		assert.Empty(t, list.Items)
+	})
+}
+
+func TestToYAML(t *testing.T) {
+	group := &gov.Policy{}
+	assert.NoError(t, json.Unmarshal(newMatchGroup("group2", "GET"), group))
+	p := &gov.Policy{}
+	assert.NoError(t, json.Unmarshal(newPolicy("group2", map[string]interface{}{
+		"slidingWindowType":       "time",
+		"slidingWindowSize":       20,
+		"waitDurationInOpenState": "1m",
+	}), p))
+
+	b, err := istio.ToYAML(istio.KindCircuitBreaker, Project, p, group)
+	assert.NoError(t, err)
+	s := string(b)
+	assert.Contains(t, s, "kind: ConfigMap")
+	assert.Contains(t, s, "name: circuit-breaker-app-dev-group2")
+	assert.Contains(t, s, istio.LabelHost+": provider")
+
+	_, err = istio.ToYAML(istio.KindCircuitBreaker, Project, p, nil)
+	assert.Error(t, err)
+
+	p.Kind = istio.KindCircuitBreaker
+	obj, err := istio.ToDestinationRule(Project, "provider", []*gov.Policy{p})
+	assert.NoError(t, err)
+	b, err = yaml.Marshal(obj)
+	assert.NoError(t, err)
+	s = string(b)
+	assert.Contains(t, s, "kind: DestinationRule")
+	assert.Contains(t, s, "host: provider")
+	assert.Contains(t, s, "consecutive5xxErrors: 5")
+	assert.Contains(t, s, "interval: 20s")
+	assert.Contains(t, s, "baseEjectionTime: 1m0s")
+	assert.Contains(t, s, "maxEjectionPercent: 100")
+
+	_, err = istio.ToDestinationRule(Project, "provider", []*gov.Policy{p, p})
+	assert.Error(t, err)
+}
+
+func TestToYAMLStringMatch(t *testing.T) {
+	group := &gov.Policy{}
+	assert.NoError(t, json.Unmarshal(newPolicy("group4", map[string]interface{}{
+		"matches": []interface{}{
+			map[string]interface{}{
+				"serviceName": "provider",
+				"apiPath":     map[string]interface{}{"suffix": "/a", "prefix": "/b", "contains": "c"},
+			},
+		},
+	}), group))
+	p := &gov.Policy{}
+	assert.NoError(t, json.Unmarshal(newPolicy("group4", &gov.LBSpec{RetryNext: 1}), p))
+
+	for i := 0; i < 10; i++ {
+		b, err := istio.ToYAML(istio.KindRetry, Project, p, group)
+		assert.NoError(t, err)
+		assert.Contains(t, string(b), "prefix: /b")
+	}
+}
+
+func TestToYAMLRateLimiting(t *testing.T) {
+	group := &gov.Policy{}
+	assert.NoError(t, json.Unmarshal(newServiceMatchGroup("group7"), group))
+	p := &gov.Policy{}
+	assert.NoError(t, json.Unmarshal(newPolicy("group7", map[string]interface{}{
+		"rate":               10,
+		"limitRefreshPeriod": 1.5,
+	}), p))
+
+	b, err := istio.ToYAML(istio.KindRateLimiting, Project, p, group)
+	assert.NoError(t, err)
+	s := string(b)
+	assert.Contains(t, s, "kind: EnvoyFilter")
+	assert.Contains(t, s, istio.LabelHost+": provider")
+	assert.Contains(t, s, "fill_interval: 1.5ms")
+
+	_, err = istio.ToYAML(istio.KindRateLimiting, Project, p, nil)
+	assert.Error(t, err)
+	assert.NoError(t, json.Unmarshal(newMatchGroup("group7", "GET"), group))
+	_, err = istio.ToYAML(istio.KindRateLimiting, Project, p, group)
+	assert.Error(t, err)
+}
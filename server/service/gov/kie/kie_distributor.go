@@ -44,7 +44,7 @@ type Distributor struct {
 
 const (
 	KeyPrefix       = "servicecomb."
-	KindMatchGroup  = "match-group"
+	KindMatchGroup  = gov.KindMatchGroup
 	GroupNamePrefix = "scene-"
 	StatusEnabled   = "enabled"
 	TypeText        = "text"
@@ -57,7 +57,7 @@ const (
 	Rules           = "rules"
 )
 
-var PolicyNames = []string{"retry", "rateLimiting", "circuitBreaker", "bulkhead"}
+var PolicyNames = []string{"retry", "rateLimiting", "circuitBreaker", "bulkhead", "faultInjection", "instanceIsolation"}
 
 var rule = Validator{}
 
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/apache/servicecomb-service-center/datasource"
@@ -45,8 +46,9 @@ const (
 )
 
 var (
-	once       sync.Once
-	pingPeriod time.Duration
+	once sync.Once
+	// pingPeriod is time.Duration, it can be reloaded at runtime
+	pingPeriod int64
 )
 
 type client struct {
@@ -58,13 +60,23 @@ type client struct {
 
 func configuration() {
 	once.Do(func() {
-		pingPeriod = config.GetDuration("heartbeat.websocket.pingInterval", defaultPingPeriod)
-		if pingPeriod < minPeriod || pingPeriod > maxPeriod {
-			pingPeriod = defaultPingPeriod
-		}
+		loadPingPeriod()
+		config.RegisterReloadFunc(loadPingPeriod)
 	})
 }
 
+func loadPingPeriod() {
+	period := config.GetDuration("heartbeat.websocket.pingInterval", defaultPingPeriod)
+	if period < minPeriod || period > maxPeriod {
+		period = defaultPingPeriod
+	}
+	atomic.StoreInt64(&pingPeriod, int64(period))
+}
+
+func getPingPeriod() time.Duration {
+	return time.Duration(atomic.LoadInt64(&pingPeriod))
+}
+
 func newClient(ctx context.Context, conn *websocket.Conn, serviceID string, instanceID string) *client {
 	configuration()
 	return &client{
@@ -91,13 +103,18 @@ func (c *client) sendClose(code int, text string) error {
 
 func (c *client) heartbeat() {
 	remoteAddr := c.conn.RemoteAddr().String()
-	ticker := time.NewTicker(pingPeriod)
+	period := getPingPeriod()
+	ticker := time.NewTicker(period)
 	defer func() {
 		ticker.Stop()
 		c.conn.Close()
 	}()
 	for {
 		<-ticker.C
+		if p := getPingPeriod(); p != period {
+			period = p
+			ticker.Reset(period)
+		}
 		err := c.conn.SetWriteDeadline(time.Now().Add(connection.SendTimeout))
 		if err != nil {
 			log.Error("", err)
@@ -19,11 +19,23 @@ package quota
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/quota/model"
+	"github.com/apache/servicecomb-service-center/pkg/util"
 	"github.com/go-chassis/cari/discovery"
+	"github.com/patrickmn/go-cache"
 )
 
+// limitsCacheTTL limits the time to see the overrides changed by the other nodes
+const limitsCacheTTL = 30 * time.Second
+
+// limitsCache caches the quota limits overrides by domain project
+var limitsCache = cache.New(limitsCacheTTL, limitsCacheTTL)
+
 func ServiceUsage(ctx context.Context, request *discovery.GetServiceCountRequest) (int64, error) {
 	resp, err := datasource.GetMetadataManager().GetServiceCount(ctx, request)
 	if err != nil {
@@ -61,6 +73,16 @@ func SchemaUsage(ctx context.Context, serviceID string) (int64, error) {
 	return int64(len(resp.Schemas)), nil
 }
 
+func TagUsage(ctx context.Context, serviceID string) (int64, error) {
+	resp, err := datasource.GetMetadataManager().GetTags(ctx, &discovery.GetServiceTagsRequest{
+		ServiceId: serviceID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(resp.Tags)), nil
+}
+
 func RoleUsage(ctx context.Context) (int64, error) {
 	_, used, err := datasource.GetRoleManager().ListRole(ctx)
 	if err != nil {
@@ -76,3 +98,69 @@ func AccountUsage(ctx context.Context) (int64, error) {
 	}
 	return used, nil
 }
+
+// GetLimits returns the quota limits override of the domain project,
+// the Limits is empty if no override was set
+func GetLimits(ctx context.Context, domain, project string) (*model.Quota, error) {
+	q, err := datasource.GetQuotaManager().GetQuota(ctx, domain, project)
+	if err == datasource.ErrQuotaNotExist {
+		return &model.Quota{Domain: domain, Project: project, Limits: map[string]int64{}}, nil
+	}
+	return q, err
+}
+
+// GetCachedLimits returns the same as GetLimits for the domain project in ctx, it is called
+// by every quota check, so the override is cached, and invalidated by PutLimits and DeleteLimits
+func GetCachedLimits(ctx context.Context) (*model.Quota, error) {
+	domain, project := util.ParseDomain(ctx), util.ParseProject(ctx)
+	domainProject := domain + util.SPLIT + project
+	if q, ok := limitsCache.Get(domainProject); ok {
+		return q.(*model.Quota), nil
+	}
+	q, err := GetLimits(ctx, domain, project)
+	if err != nil {
+		return nil, err
+	}
+	limitsCache.SetDefault(domainProject, q)
+	return q, nil
+}
+
+// PutLimits replaces the quota limits override of the domain project
+func PutLimits(ctx context.Context, domain, project string, limits map[string]int64) (*model.Quota, error) {
+	if err := ValidateLimits(limits); err != nil {
+		return nil, err
+	}
+	q := &model.Quota{
+		Domain:       domain,
+		Project:      project,
+		Limits:       limits,
+		ModTimestamp: strconv.FormatInt(time.Now().Unix(), 10),
+	}
+	if err := datasource.GetQuotaManager().UpsertQuota(ctx, q); err != nil {
+		return nil, err
+	}
+	limitsCache.Delete(domain + util.SPLIT + project)
+	return q, nil
+}
+
+// DeleteLimits removes the quota limits override of the domain project,
+// then the global limits take effect again
+func DeleteLimits(ctx context.Context, domain, project string) error {
+	defer limitsCache.Delete(domain + util.SPLIT + project)
+	return datasource.GetQuotaManager().DeleteQuota(ctx, domain, project)
+}
+
+func ValidateLimits(limits map[string]int64) error {
+	if len(limits) == 0 {
+		return fmt.Errorf("limits is empty")
+	}
+	for name, limit := range limits {
+		if !model.IsResource(name) {
+			return fmt.Errorf("unknown quota resource '%s'", name)
+		}
+		if limit < 0 {
+			return fmt.Errorf("quota limit of '%s' must not be negative", name)
+		}
+	}
+	return nil
+}
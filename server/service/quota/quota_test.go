@@ -101,3 +101,53 @@ func TestInstanceUsage(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestLimits(t *testing.T) {
+	domain, project := "domain_with_quota", "project_with_quota"
+	ctx := util.SetDomainProject(context.Background(), domain, project)
+	t.Run("put invalid limits, should be failed", func(t *testing.T) {
+		_, err := quota.PutLimits(ctx, domain, project, nil)
+		assert.Error(t, err)
+		_, err = quota.PutLimits(ctx, domain, project, map[string]int64{"unknown": 1})
+		assert.Error(t, err)
+		_, err = quota.PutLimits(ctx, domain, project, map[string]int64{"instance": -1})
+		assert.Error(t, err)
+	})
+
+	t.Run("put limits, should be get the override", func(t *testing.T) {
+		q, err := quota.GetLimits(ctx, domain, project)
+		assert.NoError(t, err)
+		assert.Empty(t, q.Limits)
+
+		_, err = quota.PutLimits(ctx, domain, project, map[string]int64{"service": 10, "instance": 100})
+		assert.NoError(t, err)
+		q, err = quota.GetLimits(ctx, domain, project)
+		assert.NoError(t, err)
+		assert.Equal(t, "domain_with_quota", q.Domain)
+		assert.Equal(t, "project_with_quota", q.Project)
+		assert.Equal(t, map[string]int64{"service": 10, "instance": 100}, q.Limits)
+	})
+
+	t.Run("get cached limits, should be invalidated after put", func(t *testing.T) {
+		q, err := quota.GetCachedLimits(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), q.Limits["service"])
+
+		_, err = quota.PutLimits(ctx, domain, project, map[string]int64{"service": 20})
+		assert.NoError(t, err)
+		q, err = quota.GetCachedLimits(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int64{"service": 20}, q.Limits)
+	})
+
+	t.Run("delete limits, should be get empty override", func(t *testing.T) {
+		err := quota.DeleteLimits(ctx, domain, project)
+		assert.NoError(t, err)
+		q, err := quota.GetLimits(ctx, domain, project)
+		assert.NoError(t, err)
+		assert.Empty(t, q.Limits)
+		q, err = quota.GetCachedLimits(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, q.Limits)
+	})
+}
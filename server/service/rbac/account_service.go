@@ -89,10 +89,12 @@ func UpdateAccount(ctx context.Context, name string, a *rbac.Account) error {
 		log.Errorf(err, "get account [%s] failed", name)
 		return err
 	}
+	rolesChanged := false
 	if len(a.Status) != 0 {
 		oldAccount.Status = a.Status
 	}
 	if len(a.Roles) != 0 {
+		rolesChanged = !sameRoles(oldAccount.Roles, a.Roles)
 		oldAccount.Roles = a.Roles
 	}
 	if err = checkRoleNames(ctx, oldAccount.Roles); err != nil {
@@ -104,6 +106,10 @@ func UpdateAccount(ctx context.Context, name string, a *rbac.Account) error {
 		return err
 	}
 	log.Infof("account [%s] is edit", oldAccount.ID)
+	if rolesChanged {
+		// the roles in the tokens are out of date
+		return RevokeAccountTokens(ctx, name)
+	}
 	return nil
 }
 
@@ -138,7 +144,11 @@ func DeleteAccount(ctx context.Context, name string) error {
 		return rbac.NewError(rbac.ErrAccountNotExist, msg)
 	}
 	_, err = datasource.GetAccountManager().DeleteAccount(ctx, []string{name})
-	return err
+	if err != nil {
+		return err
+	}
+	// revoke the tokens, in case of an account with the same name is created later
+	return RevokeAccountTokens(ctx, name)
 }
 
 //CreateAccount save 2 kv
@@ -176,6 +186,22 @@ func checkRoleNames(ctx context.Context, roles []string) error {
 	return nil
 }
 
+func sameRoles(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[string]struct{}, len(a))
+	for _, r := range a {
+		set[r] = struct{}{}
+	}
+	for _, r := range b {
+		if _, ok := set[r]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
 func illegalAccountCheck(ctx context.Context, target string) error {
 	if target == RootName {
 		return rbac.NewError(rbac.ErrForbidOperateBuildInAccount, errorsEx.MsgCantOperateRoot)
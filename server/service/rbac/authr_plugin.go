@@ -70,26 +70,23 @@ func (a *EmbeddedAuthenticator) Login(ctx context.Context, user string, password
 		return "", rbac.NewError(rbac.ErrUserOrPwdWrong, "")
 	}
 
-	secret, err := GetPrivateKey()
+	return SignToken(account, TokenTypeAccess, opt.ExpireAfter)
+}
+
+//Authenticate parse a token to claims, refresh tokens are not allowed
+func (a *EmbeddedAuthenticator) Authenticate(ctx context.Context, tokenStr string) (interface{}, error) {
+	claims, err := a.verify(ctx, tokenStr)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	tokenStr, err := token.Sign(map[string]interface{}{
-		rbac.ClaimsUser:  user,
-		rbac.ClaimsRoles: account.Roles,
-	},
-		secret,
-		token.WithExpTime(opt.ExpireAfter),
-		token.WithSigningMethod(token.RS512)) //TODO config for each user
-	if err != nil {
-		log.Errorf(err, "can not sign a token")
-		return "", err
+	if TokenTypeFromClaims(claims) == TokenTypeRefresh {
+		return nil, rbac.NewError(rbac.ErrUnauthorized, ErrRefreshTokenNotAllowed.Error())
 	}
-	return tokenStr, nil
+	return claims, nil
 }
 
-//Authenticate parse a token to claims
-func (a *EmbeddedAuthenticator) Authenticate(ctx context.Context, tokenStr string) (interface{}, error) {
+// verify checks the token signature, expiration and revocation, also its owner account should exist
+func (a *EmbeddedAuthenticator) verify(ctx context.Context, tokenStr string) (map[string]interface{}, error) {
 	p, err := jwt.ParseRSAPublicKeyFromPEM([]byte(PublicKey()))
 	if err != nil {
 		log.Error("can not parse public key", err)
@@ -115,6 +112,9 @@ func (a *EmbeddedAuthenticator) Authenticate(ctx context.Context, tokenStr strin
 		msg := fmt.Sprintf("account [%s] is deleted", n)
 		return nil, rbac.NewError(rbac.ErrTokenOwnedAccountDeleted, msg)
 	}
+	if err = checkTokenRevoked(ctx, n, claims); err != nil {
+		return nil, err
+	}
 	return claims, nil
 }
 
@@ -19,52 +19,213 @@ package rbac
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/go-chassis/cari/rbac"
 
 	"github.com/apache/servicecomb-service-center/datasource"
 	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/util"
 	"github.com/apache/servicecomb-service-center/server/plugin/auth"
 )
 
+const (
+	// LabelDomain and LabelProject are the reserved label keys to scope the
+	// resource permission in the matched domains and projects
+	LabelDomain  = "domain"
+	LabelProject = "project"
+	// DenyPrefix marks the verb denied, e.g. "!delete", deny rules override the allows
+	DenyPrefix = "!"
+)
+
 // return: allow, matched labels(empty if no label defined), error
 func Allow(ctx context.Context, project string, roleList []string,
 	targetResouce *auth.ResourceScope) (bool, []map[string]string, error) {
-	//TODO check project
 	allPerms, err := getPermsByRoles(ctx, roleList)
 	if err != nil {
 		log.Error("get role list errors", err)
 		return false, nil, err
 	}
-	if len(allPerms) == 0 {
+	allow, labelList := Decide(allPerms, util.ParseDomain(ctx), project, targetResouce)
+	return allow, labelList, nil
+}
+
+// Decide checks if the perms in the domain and project allow to operate the resource,
+// return: allow, matched labels(empty if no label defined, the denied ones are marked by auth.LabelDeny)
+func Decide(perms []*rbac.Permission, domain, project string,
+	targetResouce *auth.ResourceScope) (bool, []map[string]string) {
+	perms = FilterPermsByScope(perms, domain, project)
+	if len(perms) == 0 {
 		log.Warn("role list has no any permissions")
-		return false, nil, nil
+		return false, nil
 	}
-	allow, labelList := GetLabel(allPerms, targetResouce.Type, targetResouce.Verb)
+	if Denied(perms, targetResouce) {
+		return false, nil
+	}
+	allow, labelList := GetLabel(perms, targetResouce.Type, targetResouce.Verb)
 	if !allow {
-		return false, nil, nil
+		return false, nil
 	}
+	denyList := GetDenyLabel(perms, targetResouce.Type, targetResouce.Verb)
 	// allow, but no label found, means we can ignore the labels
+	if len(labelList) == 0 && len(denyList) == 0 {
+		return true, nil
+	}
+	// allow all but the denied, the empty label matches all resources
 	if len(labelList) == 0 {
-		return true, nil, nil
+		labelList = []map[string]string{{}}
 	}
+	labelList = append(labelList, denyList...)
 	// target resource needs no label, return without filter
 	if len(targetResouce.Labels) == 0 {
-		return true, labelList, nil
+		return true, labelList
 	}
 	// allow, and labels found, filter the labels
 	filteredLabelList := FilterLabel(targetResouce.Labels, labelList)
 	// target resource label matches no label in permission, means not allow
 	if len(filteredLabelList) == 0 {
-		return false, nil, nil
+		return false, nil
+	}
+	return true, append(filteredLabelList, denyList...)
+}
+
+// FilterPermsByScope removes the resources out of the domain and project scope, the
+// scope labels are removed from the result, a resource without scope labels matches all.
+// APIs without project, like account APIs, match no project scoped resource
+func FilterPermsByScope(perms []*rbac.Permission, domain, project string) []*rbac.Permission {
+	scoped := make([]*rbac.Permission, 0, len(perms))
+	for _, perm := range perms {
+		resources := make([]*rbac.Resource, 0, len(perm.Resources))
+		for _, resource := range perm.Resources {
+			if !scopeMatched(resource.Labels, LabelDomain, domain) ||
+				!scopeMatched(resource.Labels, LabelProject, project) {
+				continue
+			}
+			resources = append(resources, &rbac.Resource{Type: resource.Type, Labels: withoutScope(resource.Labels)})
+		}
+		if len(resources) == 0 {
+			continue
+		}
+		scoped = append(scoped, &rbac.Permission{Resources: resources, Verbs: perm.Verbs})
+	}
+	return scoped
+}
+
+func scopeMatched(labels map[string]string, key, target string) bool {
+	pattern, ok := labels[key]
+	if !ok {
+		return true
+	}
+	return len(target) > 0 && util.PatternMatch(pattern, target)
+}
+
+func withoutScope(labels map[string]string) map[string]string {
+	_, hasDomain := labels[LabelDomain]
+	_, hasProject := labels[LabelProject]
+	if !hasDomain && !hasProject {
+		return labels
 	}
-	return true, filteredLabelList, nil
+	l := make(map[string]string, len(labels))
+	for k, v := range labels {
+		if k == LabelDomain || k == LabelProject {
+			continue
+		}
+		l[k] = v
+	}
+	if len(l) == 0 {
+		return nil
+	}
+	return l
 }
 
+// Denied checks if any perm denies the verb on the resource, a deny rule with labels
+// only takes effect when one of the target resource labels matches them,
+// the list results are filtered by the labels of GetDenyLabel instead
+func Denied(perms []*rbac.Permission, targetResource *auth.ResourceScope) bool {
+	for _, perm := range perms {
+		if !denyVerb(perm.Verbs, targetResource.Verb) {
+			continue
+		}
+		for _, resource := range perm.Resources {
+			if resource.Type != targetResource.Type {
+				continue
+			}
+			if len(resource.Labels) == 0 {
+				return true
+			}
+			for _, labels := range targetResource.Labels {
+				if LabelMatched(labels, resource.Labels) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func denyVerb(haystack []string, needle string) bool {
+	for _, e := range haystack {
+		if !strings.HasPrefix(e, DenyPrefix) {
+			continue
+		}
+		if v := strings.TrimPrefix(e, DenyPrefix); v == "*" || v == needle {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidatePerms checks the verbs and label patterns of the perms
+func ValidatePerms(perms []*rbac.Permission) error {
+	for _, perm := range perms {
+		for _, verb := range perm.Verbs {
+			if len(strings.TrimPrefix(verb, DenyPrefix)) == 0 {
+				return fmt.Errorf("invalid verb '%s'", verb)
+			}
+		}
+		for _, resource := range perm.Resources {
+			for k, v := range resource.Labels {
+				if _, err := util.CompilePattern(v); err != nil {
+					return fmt.Errorf("invalid pattern '%s' of label '%s': %s", v, k, err.Error())
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// GetDenyLabel returns the labels of the deny rules on the verb of the resource,
+// the labels are marked by auth.LabelDeny
+func GetDenyLabel(perms []*rbac.Permission, targetResource, verb string) (labelList []map[string]string) {
+	for _, perm := range perms {
+		if !denyVerb(perm.Verbs, verb) {
+			continue
+		}
+		for _, resource := range perm.Resources {
+			if resource.Type != targetResource || len(resource.Labels) == 0 {
+				continue
+			}
+			labels := map[string]string{auth.LabelDeny: ""}
+			for k, v := range resource.Labels {
+				labels[k] = v
+			}
+			labelList = append(labelList, labels)
+		}
+	}
+	return
+}
+
+// FilterLabel returns the allowed permission labels matched by the target resource labels,
+// the target resource labels matched by any denied permission label are skipped
 func FilterLabel(targetResourceLabel []map[string]string, permLabelList []map[string]string) []map[string]string {
+	allows, denies := auth.SplitLabels(permLabelList)
 	l := make([]map[string]string, 0)
 	for _, resourceLabel := range targetResourceLabel {
-		for _, label := range permLabelList {
+		if labelDenied(resourceLabel, denies) {
+			continue
+		}
+		for _, label := range allows {
 			if LabelMatched(resourceLabel, label) {
 				l = append(l, label)
 			}
@@ -73,9 +234,21 @@ func FilterLabel(targetResourceLabel []map[string]string, permLabelList []map[st
 	return l
 }
 
+func labelDenied(targetResourceLabel map[string]string, denies []map[string]string) bool {
+	for _, deny := range denies {
+		if LabelMatched(targetResourceLabel, deny) {
+			return true
+		}
+	}
+	return false
+}
+
+// LabelMatched returns true if the target resource label matches all the permission
+// label patterns, a key missing in the target resource label means not matched
 func LabelMatched(targetResourceLabel map[string]string, permLabel map[string]string) bool {
 	for k, v := range permLabel {
-		if vv := targetResourceLabel[k]; vv != v {
+		vv, ok := targetResourceLabel[k]
+		if !ok || !util.PatternMatch(v, vv) {
 			return false
 		}
 	}
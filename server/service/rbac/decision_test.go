@@ -23,6 +23,7 @@ import (
 	"github.com/go-chassis/cari/rbac"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/apache/servicecomb-service-center/server/plugin/auth"
 	rbacsvc "github.com/apache/servicecomb-service-center/server/service/rbac"
 )
 
@@ -172,6 +173,19 @@ func TestLabelMatched(t *testing.T) {
 		}
 		assert.True(t, rbacsvc.LabelMatched(targetResourceLabel, permResourceLabel))
 	})
+	t.Run("target resource label matches permission resource label patterns, should match", func(t *testing.T) {
+		permResourceLabel := map[string]string{
+			"environment": "prod*",
+			"appId":       "regex:^(default|test)$",
+		}
+		assert.True(t, rbacsvc.LabelMatched(targetResourceLabel, permResourceLabel))
+	})
+	t.Run("wildcard permission label and key not in target resource label, should not match", func(t *testing.T) {
+		permResourceLabel := map[string]string{
+			"serviceName": "*",
+		}
+		assert.False(t, rbacsvc.LabelMatched(targetResourceLabel, permResourceLabel))
+	})
 }
 func TestFilterLabel(t *testing.T) {
 	targetResourceLabel := []map[string]string{
@@ -188,4 +202,234 @@ func TestFilterLabel(t *testing.T) {
 	}
 	l := rbacsvc.FilterLabel(targetResourceLabel, permResourceLabel)
 	assert.Equal(t, 3, len(l))
+
+	// the target resource label matched by the denied label is skipped
+	permResourceLabel = append(permResourceLabel, map[string]string{auth.LabelDeny: "", "appId": "default"})
+	l = rbacsvc.FilterLabel(targetResourceLabel, permResourceLabel)
+	assert.Equal(t, 1, len(l))
+}
+
+func TestDecide(t *testing.T) {
+	perm := func(verbs []string, resourceType string, labels map[string]string) *rbac.Permission {
+		return &rbac.Permission{
+			Resources: []*rbac.Resource{{Type: resourceType, Labels: labels}},
+			Verbs:     verbs,
+		}
+	}
+	target := func(verb string, labels ...map[string]string) *auth.ResourceScope {
+		return &auth.ResourceScope{Type: rbacsvc.ResourceService, Verb: verb, Labels: labels}
+	}
+	cases := []struct {
+		name    string
+		perms   []*rbac.Permission
+		domain  string
+		project string
+		target  *auth.ResourceScope
+		allow   bool
+		labels  []map[string]string
+	}{
+		{
+			name:    "no perms, should not allow",
+			project: "default",
+			target:  target("get"),
+		},
+		{
+			name:    "verb matched and no label, should allow",
+			perms:   []*rbac.Permission{perm([]string{"*"}, rbacsvc.ResourceService, nil)},
+			project: "default",
+			target:  target("get", map[string]string{"serviceName": "a"}),
+			allow:   true,
+		},
+		{
+			name:    "verb not matched, should not allow",
+			perms:   []*rbac.Permission{perm([]string{"get"}, rbacsvc.ResourceService, nil)},
+			project: "default",
+			target:  target("delete"),
+		},
+		{
+			name: "project scope matched, should allow",
+			perms: []*rbac.Permission{perm([]string{"*"}, rbacsvc.ResourceService,
+				map[string]string{rbacsvc.LabelProject: "prod-*"})},
+			project: "prod-a",
+			target:  target("get", map[string]string{"serviceName": "a"}),
+			allow:   true,
+		},
+		{
+			name: "project scope not matched, should not allow",
+			perms: []*rbac.Permission{perm([]string{"*"}, rbacsvc.ResourceService,
+				map[string]string{rbacsvc.LabelProject: "prod-*"})},
+			project: "test",
+			target:  target("get"),
+		},
+		{
+			name: "api without project and project scoped perm, should not allow",
+			perms: []*rbac.Permission{perm([]string{"*"}, rbacsvc.ResourceService,
+				map[string]string{rbacsvc.LabelProject: "*"})},
+			target: target("get"),
+		},
+		{
+			name: "domain scope matched by regex, should allow",
+			perms: []*rbac.Permission{perm([]string{"*"}, rbacsvc.ResourceService,
+				map[string]string{rbacsvc.LabelDomain: "regex:^(default|tenant-[0-9]+)$"})},
+			domain:  "tenant-1",
+			project: "default",
+			target:  target("get"),
+			allow:   true,
+		},
+		{
+			name: "domain scope not matched, should not allow",
+			perms: []*rbac.Permission{perm([]string{"*"}, rbacsvc.ResourceService,
+				map[string]string{rbacsvc.LabelDomain: "default"})},
+			domain:  "tenant-1",
+			project: "default",
+			target:  target("get"),
+		},
+		{
+			name: "list resources with scoped labels, should return labels without scope",
+			perms: []*rbac.Permission{perm([]string{"get"}, rbacsvc.ResourceService,
+				map[string]string{rbacsvc.LabelProject: "default", "serviceName": "order-*"})},
+			project: "default",
+			target:  target("get"),
+			allow:   true,
+			labels:  []map[string]string{{"serviceName": "order-*"}},
+		},
+		{
+			name: "wildcard label matched, should allow",
+			perms: []*rbac.Permission{perm([]string{"*"}, rbacsvc.ResourceService,
+				map[string]string{"serviceName": "order-*"})},
+			project: "default",
+			target:  target("update", map[string]string{"serviceName": "order-api"}),
+			allow:   true,
+			labels:  []map[string]string{{"serviceName": "order-*"}},
+		},
+		{
+			name: "regex label not matched, should not allow",
+			perms: []*rbac.Permission{perm([]string{"*"}, rbacsvc.ResourceService,
+				map[string]string{"serviceName": "regex:^order-[0-9]+$"})},
+			project: "default",
+			target:  target("update", map[string]string{"serviceName": "order-api"}),
+		},
+		{
+			name: "deny rule overrides allow, should not allow",
+			perms: []*rbac.Permission{
+				perm([]string{"*"}, rbacsvc.ResourceService, nil),
+				perm([]string{"!delete"}, rbacsvc.ResourceService, nil),
+			},
+			project: "default",
+			target:  target("delete", map[string]string{"serviceName": "a"}),
+		},
+		{
+			name:    "deny rule and allow rule in one perm, should allow other verbs",
+			perms:   []*rbac.Permission{perm([]string{"*", "!delete"}, rbacsvc.ResourceService, nil)},
+			project: "default",
+			target:  target("update"),
+			allow:   true,
+		},
+		{
+			name:    "deny all verbs, should not allow",
+			perms:   []*rbac.Permission{perm([]string{"*", "!*"}, rbacsvc.ResourceService, nil)},
+			project: "default",
+			target:  target("get"),
+		},
+		{
+			name: "deny rule labels matched, should not allow",
+			perms: []*rbac.Permission{
+				perm([]string{"*"}, rbacsvc.ResourceService, nil),
+				perm([]string{"!update"}, rbacsvc.ResourceService, map[string]string{"serviceName": "order-*"}),
+			},
+			project: "default",
+			target: target("update", map[string]string{"serviceName": "user"},
+				map[string]string{"serviceName": "order-api"}),
+		},
+		{
+			name: "deny rule labels not matched, should allow",
+			perms: []*rbac.Permission{
+				perm([]string{"*"}, rbacsvc.ResourceService, nil),
+				perm([]string{"!update"}, rbacsvc.ResourceService, map[string]string{"serviceName": "order-*"}),
+			},
+			project: "default",
+			target:  target("update", map[string]string{"serviceName": "user"}),
+			allow:   true,
+			labels:  []map[string]string{{}, {auth.LabelDeny: "", "serviceName": "order-*"}},
+		},
+		{
+			name: "list resources with deny rule labels, should return the denied labels",
+			perms: []*rbac.Permission{
+				perm([]string{"*"}, rbacsvc.ResourceService, nil),
+				perm([]string{"!get"}, rbacsvc.ResourceService, map[string]string{"appId": "secret"}),
+			},
+			project: "default",
+			target:  target("get"),
+			allow:   true,
+			labels:  []map[string]string{{}, {auth.LabelDeny: "", "appId": "secret"}},
+		},
+		{
+			name: "labels matched both allow and deny rules, should not allow",
+			perms: []*rbac.Permission{
+				perm([]string{"get"}, rbacsvc.ResourceService, map[string]string{"appId": "*"}),
+				perm([]string{"!get"}, rbacsvc.ResourceService, map[string]string{"appId": "secret"}),
+			},
+			project: "default",
+			target:  target("get", map[string]string{"appId": "secret", "serviceName": "a"}),
+		},
+		{
+			name: "deny rule in other project, should allow",
+			perms: []*rbac.Permission{
+				perm([]string{"*"}, rbacsvc.ResourceService, nil),
+				perm([]string{"!delete"}, rbacsvc.ResourceService, map[string]string{rbacsvc.LabelProject: "prod"}),
+			},
+			project: "test",
+			target:  target("delete"),
+			allow:   true,
+		},
+		{
+			name:    "only deny rule, should not allow",
+			perms:   []*rbac.Permission{perm([]string{"!delete"}, rbacsvc.ResourceService, nil)},
+			project: "default",
+			target:  target("get"),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			allow, labels := rbacsvc.Decide(c.perms, c.domain, c.project, c.target)
+			assert.Equal(t, c.allow, allow)
+			assert.Equal(t, c.labels, labels)
+		})
+	}
+}
+
+func TestValidatePerms(t *testing.T) {
+	cases := []struct {
+		name  string
+		perms []*rbac.Permission
+		valid bool
+	}{
+		{
+			name: "valid perms",
+			perms: []*rbac.Permission{{
+				Resources: []*rbac.Resource{{Type: rbacsvc.ResourceService,
+					Labels: map[string]string{rbacsvc.LabelProject: "prod-*", "serviceName": "regex:^order-[0-9]+$"}}},
+				Verbs: []string{"*", "!delete"},
+			}},
+			valid: true,
+		},
+		{
+			name:  "empty deny verb",
+			perms: []*rbac.Permission{{Verbs: []string{"!"}}},
+		},
+		{
+			name: "invalid regex",
+			perms: []*rbac.Permission{{
+				Resources: []*rbac.Resource{{Type: rbacsvc.ResourceService,
+					Labels: map[string]string{"serviceName": "regex:order-("}}},
+				Verbs: []string{"get"},
+			}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := rbacsvc.ValidatePerms(c.perms)
+			assert.Equal(t, c.valid, err == nil)
+		})
+	}
 }
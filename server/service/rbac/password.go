@@ -106,5 +106,5 @@ func doChangePassword(ctx context.Context, old *rbac.Account, pwd string) error
 		log.Error("can not change pwd", err)
 		return err
 	}
-	return nil
+	return RevokeAccountTokens(ctx, old.Name)
 }
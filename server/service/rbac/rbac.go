@@ -70,7 +70,7 @@ func Init() {
 	}
 	readPrivateKey()
 	readPublicKey()
-	rbac.Add2WhiteAPIList(APITokenGranter)
+	rbac.Add2WhiteAPIList(APITokenGranter, APITokenRefresh)
 	log.Info("rbac is enabled")
 }
 
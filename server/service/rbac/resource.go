@@ -33,6 +33,8 @@ const (
 var (
 	APITokenGranter = "/v4/token"
 
+	APITokenRefresh = "/v4/token/refresh"
+
 	APIAccountList = "/v4/accounts"
 
 	APIRoleList = "/v4/roles"
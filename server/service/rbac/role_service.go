@@ -38,6 +38,10 @@ func CreateRole(ctx context.Context, r *rbac.Role) error {
 		log.Errorf(err, "create role [%s] failed", r.Name)
 		return discovery.NewError(discovery.ErrInvalidParams, err.Error())
 	}
+	if err = ValidatePerms(r.Perms); err != nil {
+		log.Errorf(err, "create role [%s] failed", r.Name)
+		return discovery.NewError(discovery.ErrInvalidParams, err.Error())
+	}
 	quotaErr := quota.Apply(ctx, quota.NewApplyQuotaResource(quota.TypeRole,
 		util.ParseDomainProject(ctx), "", 1))
 	if quotaErr != nil {
@@ -106,6 +110,10 @@ func EditRole(ctx context.Context, name string, a *rbac.Role) error {
 	if err := illegalRoleCheck(name); err != nil {
 		return err
 	}
+	if err := ValidatePerms(a.Perms); err != nil {
+		log.Errorf(err, "edit role [%s] failed", name)
+		return discovery.NewError(discovery.ErrInvalidParams, err.Error())
+	}
 	exist, err := RoleExist(ctx, name)
 	if err != nil {
 		log.Errorf(err, "check role [%s] exist failed", name)
@@ -0,0 +1,280 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rbac
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/go-chassis/cari/discovery"
+	"github.com/go-chassis/cari/rbac"
+	"github.com/go-chassis/go-chassis/v2/security/authr"
+	"github.com/go-chassis/go-chassis/v2/security/token"
+
+	"github.com/apache/servicecomb-service-center/datasource"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/pkg/validate"
+	"github.com/apache/servicecomb-service-center/server/config"
+)
+
+const (
+	ClaimsTokenID   = "jti"
+	ClaimsIssuedAt  = "iat"
+	ClaimsTokenType = "typ"
+
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+
+	DefaultRefreshTokenExpiration = "168h"
+)
+
+var (
+	ErrTokenRevoked           = errors.New("token is revoked")
+	ErrTokenTypeMismatch      = errors.New("token type mismatch")
+	ErrTokenOwnerMismatch     = errors.New("tokens are not owned by the same account")
+	ErrTokenCanNotBeRevoked   = errors.New("token has no id, can not be revoked")
+	ErrEmptyRefreshToken      = errors.New("refresh token should not be empty")
+	ErrInvalidExpirationTime  = errors.New("token expiration time should >= 15m and <= 24h")
+	ErrRefreshTokenNotAllowed = errors.New("refresh token can not be used to access the apis")
+)
+
+// Token is the response of login and refresh, the refresh token is used
+// to get a new token pair when the token expires
+type Token struct {
+	rbac.Token
+	RefreshTokenStr string `json:"refreshToken,omitempty"`
+}
+
+// RefreshTokenRequest exchanges the refresh token for a new token pair
+type RefreshTokenRequest struct {
+	RefreshTokenStr     string `json:"refreshToken,omitempty"`
+	TokenExpirationTime string `json:"tokenExpirationTime,omitempty"`
+}
+
+// RefreshTokenExpiration returns the duration of the refresh tokens
+func RefreshTokenExpiration() string {
+	return config.GetString("rbac.refreshTokenExpiration", DefaultRefreshTokenExpiration)
+}
+
+// SignToken signs a token for the account, the token carries an unique id
+// and the issue time, so that it can be revoked
+func SignToken(account *rbac.Account, tokenType, expireAfter string) (string, error) {
+	secret, err := GetPrivateKey()
+	if err != nil {
+		return "", err
+	}
+	tokenStr, err := token.Sign(map[string]interface{}{
+		rbac.ClaimsUser:  account.Name,
+		rbac.ClaimsRoles: account.Roles,
+		ClaimsTokenID:    util.GenerateUUID(),
+		ClaimsIssuedAt:   issuedAt(time.Now()),
+		ClaimsTokenType:  tokenType,
+	},
+		secret,
+		token.WithExpTime(expireAfter),
+		token.WithSigningMethod(token.RS512)) //TODO config for each user
+	if err != nil {
+		log.Errorf(err, "can not sign a token")
+		return "", err
+	}
+	return tokenStr, nil
+}
+
+// IssueRefreshToken signs a refresh token for the account which has logged in
+func IssueRefreshToken(ctx context.Context, name string) (string, error) {
+	account, err := GetAccount(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	return SignToken(account, TokenTypeRefresh, RefreshTokenExpiration())
+}
+
+// RefreshToken exchanges a valid refresh token for a new token pair,
+// the refresh token is revoked after use
+func RefreshToken(ctx context.Context, r *RefreshTokenRequest) (*Token, error) {
+	if len(r.RefreshTokenStr) == 0 {
+		return nil, discovery.NewError(discovery.ErrInvalidParams, ErrEmptyRefreshToken.Error())
+	}
+	if !(&validate.TokenExpirationTimeChecker{}).MatchString(r.TokenExpirationTime) {
+		return nil, discovery.NewError(discovery.ErrInvalidParams, ErrInvalidExpirationTime.Error())
+	}
+	// check the revocation list in the store, the refresh token may have been
+	// rotated on another node and the cache of this node is not synced yet
+	claims, err := VerifyToken(util.WithNoCache(ctx), r.RefreshTokenStr, TokenTypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+	account, err := GetAccount(ctx, claims[rbac.ClaimsUser].(string))
+	if err != nil {
+		return nil, err
+	}
+	if err = RevokeToken(ctx, claims); err != nil {
+		return nil, err
+	}
+	tokenStr, err := SignToken(account, TokenTypeAccess, r.TokenExpirationTime)
+	if err != nil {
+		return nil, err
+	}
+	refreshTokenStr, err := SignToken(account, TokenTypeRefresh, RefreshTokenExpiration())
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("account [%s] refreshed token", account.Name)
+	return &Token{Token: rbac.Token{TokenStr: tokenStr}, RefreshTokenStr: refreshTokenStr}, nil
+}
+
+// Logout revokes the token and the refresh token owned by the same account,
+// refreshTokenStr can be empty
+func Logout(ctx context.Context, tokenStr, refreshTokenStr string) error {
+	i, err := authr.Authenticate(ctx, tokenStr)
+	if err != nil {
+		return err
+	}
+	claims, ok := i.(map[string]interface{})
+	if !ok {
+		return rbac.ErrConvert
+	}
+	if len(refreshTokenStr) > 0 {
+		refreshClaims, err := VerifyToken(ctx, refreshTokenStr, TokenTypeRefresh)
+		if err != nil {
+			return err
+		}
+		if refreshClaims[rbac.ClaimsUser] != claims[rbac.ClaimsUser] {
+			return discovery.NewError(discovery.ErrInvalidParams, ErrTokenOwnerMismatch.Error())
+		}
+		if err = RevokeToken(ctx, refreshClaims); err != nil {
+			return err
+		}
+	}
+	return RevokeToken(ctx, claims)
+}
+
+// VerifyToken verifies the token signature, type and whether it is revoked,
+// returns the claims of the token
+func VerifyToken(ctx context.Context, tokenStr string, tokenType string) (map[string]interface{}, error) {
+	claims, err := (&EmbeddedAuthenticator{}).verify(ctx, tokenStr)
+	if err != nil {
+		return nil, err
+	}
+	if TokenTypeFromClaims(claims) != tokenType {
+		return nil, rbac.NewError(rbac.ErrUnauthorized, ErrTokenTypeMismatch.Error())
+	}
+	return claims, nil
+}
+
+// TokenTypeFromClaims returns the type of the token, the tokens issued
+// by the old versions have no type and are treated as access tokens
+func TokenTypeFromClaims(claims map[string]interface{}) string {
+	t, ok := claims[ClaimsTokenType].(string)
+	if !ok || len(t) == 0 {
+		return TokenTypeAccess
+	}
+	return t
+}
+
+// RevokeToken adds the token to the revocation list
+func RevokeToken(ctx context.Context, claims map[string]interface{}) error {
+	id, ok := claims[ClaimsTokenID].(string)
+	if !ok || len(id) == 0 {
+		return discovery.NewError(discovery.ErrInvalidParams, ErrTokenCanNotBeRevoked.Error())
+	}
+	name, _ := claims[rbac.ClaimsUser].(string)
+	err := datasource.GetTokenManager().Revoke(ctx, &datasource.RevokedToken{
+		ID:       id,
+		Account:  name,
+		ExpireAt: numericClaim(claims, token.JWTClaimsExp),
+	})
+	if err != nil {
+		log.Error(fmt.Sprintf("revoke token of account [%s] failed", name), err)
+		return err
+	}
+	return nil
+}
+
+// RevokeAccountTokens revokes all the tokens of the account issued before now,
+// the tokens issued in the same millisecond are revoked as well
+func RevokeAccountTokens(ctx context.Context, name string) error {
+	err := datasource.GetTokenManager().RevokeAccount(ctx, name, unixMilli(time.Now()))
+	if err != nil {
+		log.Error(fmt.Sprintf("revoke tokens of account [%s] failed", name), err)
+		return err
+	}
+	return nil
+}
+
+func checkTokenRevoked(ctx context.Context, name string, claims map[string]interface{}) error {
+	if id, ok := claims[ClaimsTokenID].(string); ok && len(id) > 0 {
+		revoked, err := datasource.GetTokenManager().IsRevoked(ctx, id)
+		if err != nil {
+			return err
+		}
+		if revoked {
+			return rbac.NewError(rbac.ErrUnauthorized, ErrTokenRevoked.Error())
+		}
+	}
+	revokeAt, err := datasource.GetTokenManager().GetAccountRevokeAt(ctx, name)
+	if err != nil {
+		return err
+	}
+	// the tokens without iat are issued by the old versions, they are revoked as well
+	if revokeAt > 0 && issuedAtMilli(claims) <= revokeAt {
+		return rbac.NewError(rbac.ErrUnauthorized, ErrTokenRevoked.Error())
+	}
+	return nil
+}
+
+// issuedAt returns the iat claim in seconds with the millisecond fraction,
+// JWT allows the non-integer NumericDate
+func issuedAt(t time.Time) float64 {
+	return float64(unixMilli(t)) / 1000
+}
+
+func unixMilli(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func issuedAtMilli(claims map[string]interface{}) int64 {
+	switch v := claims[ClaimsIssuedAt].(type) {
+	case float64:
+		return int64(math.Round(v * 1000))
+	case json.Number:
+		f, _ := v.Float64()
+		return int64(math.Round(f * 1000))
+	default:
+		return numericClaim(claims, ClaimsIssuedAt) * 1000
+	}
+}
+
+func numericClaim(claims map[string]interface{}, key string) int64 {
+	switch v := claims[key].(type) {
+	case float64:
+		return int64(v)
+	case int64:
+		return v
+	case json.Number:
+		n, _ := v.Int64()
+		return n
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,191 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rbac_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-chassis/cari/pkg/errsvc"
+	"github.com/go-chassis/cari/rbac"
+	"github.com/go-chassis/go-chassis/v2/security/authr"
+	"github.com/stretchr/testify/assert"
+
+	rbacsvc "github.com/apache/servicecomb-service-center/server/service/rbac"
+)
+
+// assertRevoked waits for the revocation list cache synced
+func assertRevoked(t *testing.T, f func() error) {
+	assert.Eventually(t, func() bool {
+		return errsvc.IsErrEqualCode(f(), rbac.ErrUnauthorized)
+	}, 3*time.Second, 10*time.Millisecond)
+}
+
+func TestRefreshToken(t *testing.T) {
+	name := "TestRefreshToken"
+	err := rbacsvc.CreateAccount(context.Background(), newAccount(name))
+	assert.NoError(t, err)
+	refreshToken, err := rbacsvc.IssueRefreshToken(context.Background(), name)
+	assert.NoError(t, err)
+
+	t.Run("use refresh token to access apis, should fail", func(t *testing.T) {
+		_, err := authr.Authenticate(context.Background(), refreshToken)
+		assert.True(t, errsvc.IsErrEqualCode(err, rbac.ErrUnauthorized))
+	})
+	t.Run("refresh with an access token, should fail", func(t *testing.T) {
+		to, err := authr.Login(context.Background(), name, testPwd0)
+		assert.NoError(t, err)
+		_, err = rbacsvc.RefreshToken(context.Background(), &rbacsvc.RefreshTokenRequest{
+			RefreshTokenStr: to, TokenExpirationTime: "1h"})
+		assert.True(t, errsvc.IsErrEqualCode(err, rbac.ErrUnauthorized))
+	})
+	t.Run("refresh with an invalid expiration time, should fail", func(t *testing.T) {
+		_, err := rbacsvc.RefreshToken(context.Background(), &rbacsvc.RefreshTokenRequest{
+			RefreshTokenStr: refreshToken, TokenExpirationTime: "10s"})
+		assert.Error(t, err)
+	})
+	t.Run("refresh token, should get a new token pair and revoke the old one", func(t *testing.T) {
+		to, err := rbacsvc.RefreshToken(context.Background(), &rbacsvc.RefreshTokenRequest{
+			RefreshTokenStr: refreshToken, TokenExpirationTime: "1h"})
+		assert.NoError(t, err)
+		claims, err := authr.Authenticate(context.Background(), to.TokenStr)
+		assert.NoError(t, err)
+		assert.Equal(t, name, claims.(map[string]interface{})[rbac.ClaimsUser])
+
+		assertRevoked(t, func() error {
+			_, err := rbacsvc.RefreshToken(context.Background(), &rbacsvc.RefreshTokenRequest{
+				RefreshTokenStr: refreshToken, TokenExpirationTime: "1h"})
+			return err
+		})
+
+		_, err = rbacsvc.RefreshToken(context.Background(), &rbacsvc.RefreshTokenRequest{
+			RefreshTokenStr: to.RefreshTokenStr, TokenExpirationTime: "1h"})
+		assert.NoError(t, err)
+	})
+}
+
+func TestLogout(t *testing.T) {
+	name := "TestLogout"
+	err := rbacsvc.CreateAccount(context.Background(), newAccount(name))
+	assert.NoError(t, err)
+
+	t.Run("logout, should revoke both tokens", func(t *testing.T) {
+		to, err := authr.Login(context.Background(), name, testPwd0)
+		assert.NoError(t, err)
+		refreshToken, err := rbacsvc.IssueRefreshToken(context.Background(), name)
+		assert.NoError(t, err)
+
+		err = rbacsvc.Logout(context.Background(), to, refreshToken)
+		assert.NoError(t, err)
+
+		assertRevoked(t, func() error {
+			_, err := authr.Authenticate(context.Background(), to)
+			return err
+		})
+		assertRevoked(t, func() error {
+			_, err := rbacsvc.RefreshToken(context.Background(), &rbacsvc.RefreshTokenRequest{
+				RefreshTokenStr: refreshToken, TokenExpirationTime: "1h"})
+			return err
+		})
+	})
+	t.Run("logout with other account refresh token, should fail", func(t *testing.T) {
+		to, err := authr.Login(context.Background(), name, testPwd0)
+		assert.NoError(t, err)
+		refreshToken, err := rbacsvc.IssueRefreshToken(context.Background(), "root")
+		assert.NoError(t, err)
+
+		err = rbacsvc.Logout(context.Background(), to, refreshToken)
+		assert.Error(t, err)
+		_, err = authr.Authenticate(context.Background(), to)
+		assert.NoError(t, err)
+	})
+}
+
+func TestRevokeAccountTokens(t *testing.T) {
+	t.Run("change password, should revoke the tokens", func(t *testing.T) {
+		name := "TestRevokeAccountTokens_change_pwd"
+		err := rbacsvc.CreateAccount(context.Background(), newAccount(name))
+		assert.NoError(t, err)
+		to, err := authr.Login(context.Background(), name, testPwd0)
+		assert.NoError(t, err)
+		refreshToken, err := rbacsvc.IssueRefreshToken(context.Background(), name)
+		assert.NoError(t, err)
+
+		claims := map[string]interface{}{
+			rbac.ClaimsUser:  name,
+			rbac.ClaimsRoles: []interface{}{rbac.RoleAdmin},
+		}
+		ctx := context.WithValue(context.Background(), rbacsvc.CtxRequestClaims, claims)
+		err = rbacsvc.ChangePassword(ctx, &rbac.Account{Name: name, CurrentPassword: testPwd0, Password: testPwd1})
+		assert.NoError(t, err)
+
+		assertRevoked(t, func() error {
+			_, err := authr.Authenticate(context.Background(), to)
+			return err
+		})
+		assertRevoked(t, func() error {
+			_, err := rbacsvc.RefreshToken(context.Background(), &rbacsvc.RefreshTokenRequest{
+				RefreshTokenStr: refreshToken, TokenExpirationTime: "1h"})
+			return err
+		})
+
+		to, err = authr.Login(context.Background(), name, testPwd1)
+		assert.NoError(t, err)
+		_, err = authr.Authenticate(context.Background(), to)
+		assert.NoError(t, err)
+	})
+	t.Run("change roles, should revoke the tokens", func(t *testing.T) {
+		name := "TestRevokeAccountTokens_change_roles"
+		err := rbacsvc.CreateAccount(context.Background(), newAccount(name))
+		assert.NoError(t, err)
+		to, err := authr.Login(context.Background(), name, testPwd0)
+		assert.NoError(t, err)
+
+		err = rbacsvc.UpdateAccount(context.Background(), name, &rbac.Account{Status: "active", Roles: []string{rbac.RoleAdmin}})
+		assert.NoError(t, err)
+		_, err = authr.Authenticate(context.Background(), to)
+		assert.NoError(t, err)
+
+		err = rbacsvc.UpdateAccount(context.Background(), name, &rbac.Account{Roles: []string{rbac.RoleDeveloper}})
+		assert.NoError(t, err)
+		assertRevoked(t, func() error {
+			_, err := authr.Authenticate(context.Background(), to)
+			return err
+		})
+	})
+	t.Run("delete and create the account again, should not accept the old tokens", func(t *testing.T) {
+		name := "TestRevokeAccountTokens_delete"
+		err := rbacsvc.CreateAccount(context.Background(), newAccount(name))
+		assert.NoError(t, err)
+		to, err := authr.Login(context.Background(), name, testPwd0)
+		assert.NoError(t, err)
+
+		err = rbacsvc.DeleteAccount(context.Background(), name)
+		assert.NoError(t, err)
+		_, err = authr.Authenticate(context.Background(), to)
+		assert.True(t, errsvc.IsErrEqualCode(err, rbac.ErrTokenOwnedAccountDeleted))
+
+		err = rbacsvc.CreateAccount(context.Background(), newAccount(name))
+		assert.NoError(t, err)
+		assertRevoked(t, func() error {
+			_, err := authr.Authenticate(context.Background(), to)
+			return err
+		})
+	})
+}
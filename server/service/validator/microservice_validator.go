@@ -96,7 +96,7 @@ func CreateServiceReqValidator() *validate.Validator {
 		microServiceValidator.AddRule("Description", &validate.Rule{Max: 256})
 		microServiceValidator.AddRule("Level", &validate.Rule{Regexp: levelRegex})
 		microServiceValidator.AddRule("Status", &validate.Rule{Regexp: statusRegex})
-		microServiceValidator.AddRule("Schemas", &validate.Rule{Max: quota.DefaultSchemaQuota, Regexp: schemaIDRegex})
+		microServiceValidator.AddRule("Schemas", &validate.Rule{MaxFunc: quota.DefaultSchemaQuota, Regexp: schemaIDRegex})
 		microServiceValidator.AddSub("Paths", &pathValidator)
 		microServiceValidator.AddRule("Alias", &validate.Rule{Max: 128, Regexp: aliasRegex})
 		microServiceValidator.AddRule("RegisterBy", &validate.Rule{Max: 64, Regexp: registerByRegex})
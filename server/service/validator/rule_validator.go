@@ -59,7 +59,7 @@ func UpdateRuleReqValidator() *validate.Validator {
 func AddRulesReqValidator() *validate.Validator {
 	return addRulesReqValidator.Init(func(v *validate.Validator) {
 		v.AddRule("ServiceId", GetServiceReqValidator().GetRule("ServiceId"))
-		v.AddRule("Rules", &validate.Rule{Min: 1, Max: quota.DefaultRuleQuota})
+		v.AddRule("Rules", &validate.Rule{Min: 1, MaxFunc: quota.DefaultRuleQuota})
 		v.AddSub("Rules", UpdateRuleReqValidator().GetSub("Rule"))
 	})
 }
@@ -67,6 +67,6 @@ func AddRulesReqValidator() *validate.Validator {
 func DeleteRulesReqValidator() *validate.Validator {
 	return deleteRulesReqValidator.Init(func(v *validate.Validator) {
 		v.AddRule("ServiceId", GetServiceReqValidator().GetRule("ServiceId"))
-		v.AddRule("RuleIds", &validate.Rule{Min: 1, Max: quota.DefaultRuleQuota})
+		v.AddRule("RuleIds", &validate.Rule{Min: 1, MaxFunc: quota.DefaultRuleQuota})
 	})
 }
@@ -20,7 +20,9 @@ package validator
 import (
 	"regexp"
 
+	"github.com/apache/servicecomb-service-center/pkg/openapi"
 	"github.com/apache/servicecomb-service-center/pkg/validate"
+	"github.com/apache/servicecomb-service-center/server/config"
 	"github.com/apache/servicecomb-service-center/server/plugin/quota"
 )
 
@@ -33,8 +35,27 @@ var (
 var (
 	schemaIDUnlimitedRegex, _ = regexp.Compile(`^[a-zA-Z0-9]+$|^[a-zA-Z0-9][a-zA-Z0-9_\-.]*[a-zA-Z0-9]$`)
 	schemaSummaryRegex, _     = regexp.Compile(`^[a-zA-Z0-9]*$`)
+	schemaContentChecker      = &openAPIChecker{}
 )
 
+// openAPIChecker checks the schema content is a swagger 2.0 or openapi 3.0 document,
+// the content declaring neither 'swagger' nor 'openapi' is accepted as before unless
+// the strict validation is enabled
+type openAPIChecker struct {
+}
+
+func (c *openAPIChecker) MatchString(s string) bool {
+	_, err := openapi.Parse(s)
+	if err == openapi.ErrNotObject || err == openapi.ErrUndeclared {
+		return !config.GetBool("registry.schema.validateOpenAPI", false, config.WithENV("SCHEMA_VALIDATE_OPENAPI"))
+	}
+	return err == nil
+}
+
+func (c *openAPIChecker) String() string {
+	return "swagger 2.0 or openapi 3.0 document in YAML or JSON"
+}
+
 func GetSchemaReqValidator() *validate.Validator {
 	return getSchemaReqValidator.Init(func(v *validate.Validator) {
 		v.AddRule("ServiceId", GetServiceReqValidator().GetRule("ServiceId"))
@@ -47,10 +68,10 @@ func ModifySchemasReqValidator() *validate.Validator {
 		var subSchemaValidator validate.Validator
 		subSchemaValidator.AddRule("SchemaId", GetSchemaReqValidator().GetRule("SchemaId"))
 		subSchemaValidator.AddRule("Summary", &validate.Rule{Min: 1, Max: 128, Regexp: schemaSummaryRegex})
-		subSchemaValidator.AddRule("Schema", &validate.Rule{Min: 1})
+		subSchemaValidator.AddRule("Schema", &validate.Rule{Min: 1, Regexp: schemaContentChecker, Hide: true})
 
 		v.AddRule("ServiceId", GetServiceReqValidator().GetRule("ServiceId"))
-		v.AddRule("Schemas", &validate.Rule{Min: 1, Max: quota.DefaultSchemaQuota})
+		v.AddRule("Schemas", &validate.Rule{Min: 1, MaxFunc: quota.DefaultSchemaQuota})
 		v.AddSub("Schemas", &subSchemaValidator)
 	})
 }
@@ -44,7 +44,7 @@ func GetTagsReqValidator() *validate.Validator {
 func AddTagsReqValidator() *validate.Validator {
 	return addTagsReqValidator.Init(func(v *validate.Validator) {
 		v.AddRule("ServiceId", GetServiceReqValidator().GetRule("ServiceId"))
-		v.AddRule("Tags", &validate.Rule{Max: quota.DefaultTagQuota, Regexp: tagRegex})
+		v.AddRule("Tags", &validate.Rule{MaxFunc: quota.DefaultTagQuota, Regexp: tagRegex})
 	})
 }
 
@@ -60,6 +60,6 @@ func UpdateTagReqValidator() *validate.Validator {
 func DeleteTagReqValidator() *validate.Validator {
 	return deleteTagReqValidator.Init(func(v *validate.Validator) {
 		v.AddRule("ServiceId", GetServiceReqValidator().GetRule("ServiceId"))
-		v.AddRule("Keys", &validate.Rule{Min: 1, Max: quota.DefaultTagQuota, Regexp: tagRegex})
+		v.AddRule("Keys", &validate.Rule{Min: 1, MaxFunc: quota.DefaultTagQuota, Regexp: tagRegex})
 	})
 }
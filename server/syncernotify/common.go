@@ -23,4 +23,7 @@ const (
 	SendTimeout            = 5 * time.Second
 	InstanceEventQueueSize = 5000
 	ReadMaxBody            = 64
+	// EventHistorySize must not exceed InstanceEventQueueSize,
+	// so the new watcher can receive all the replayed events
+	EventHistorySize = InstanceEventQueueSize
 )
@@ -16,12 +16,20 @@
 package syncernotify
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	pb "github.com/apache/servicecomb-service-center/pkg/dump"
 	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/server/metrics"
 )
 
+var ErrInvalidToken = errors.New("invalid resume token")
+
 var syncerNotifyService *Service
 
 func init() {
@@ -33,21 +41,90 @@ func GetSyncerNotifyCenter() *Service {
 }
 
 type Service struct {
-	instEventCh chan *pb.WatchInstanceChangedEvent
-	mux         sync.RWMutex
-	isClose     bool
+	mux     sync.RWMutex
+	isClose bool
+	// epoch changes every time the service starts, so the tokens
+	// issued before restarting can be recognized
+	epoch    int64
+	revision int64
+	history  []*pb.WatchInstanceChangedEvent
+	watchers map[*Watcher]struct{}
 }
 
 func NewSyncerNotifyService() *Service {
 	return &Service{
-		instEventCh: make(chan *pb.WatchInstanceChangedEvent, InstanceEventQueueSize),
-		isClose:     true,
+		isClose:  true,
+		epoch:    time.Now().UnixNano(),
+		history:  make([]*pb.WatchInstanceChangedEvent, EventHistorySize),
+		watchers: make(map[*Watcher]struct{}),
 	}
 }
 
+// AddEvent assigns a resume token to the event, keeps it in history
+// and pushes it to every watcher
 func (s *Service) AddEvent(event *pb.WatchInstanceChangedEvent) {
-	s.instEventCh <- event
-	log.Debugf("add instance event to instance event channel, instEventCh len is:%s", len(s.instEventCh))
+	s.mux.Lock()
+	s.revision++
+	event.Token = FormatToken(s.epoch, s.revision)
+	s.history[(s.revision-1)%EventHistorySize] = event
+	for w := range s.watchers {
+		w.Push(event)
+	}
+	n := len(s.watchers)
+	s.mux.Unlock()
+	log.Debugf("add instance event[%s] to %d watcher(s)", event.Token, n)
+}
+
+// Subscribe registers the watcher, the events after the resume token
+// are replayed to the watcher if token is not empty
+func (s *Service) Subscribe(w *Watcher, token string) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.watchers[w] = struct{}{}
+	metrics.ReportSyncerWatcher(1)
+
+	if len(token) == 0 {
+		return
+	}
+	s.replay(w, token)
+}
+
+func (s *Service) replay(w *Watcher, token string) {
+	epoch, rev, err := ParseToken(token)
+	if err != nil {
+		log.Warnf("skip replaying instance events, %s", err.Error())
+		return
+	}
+	from := rev + 1
+	if epoch != s.epoch {
+		// service center restarted, all events since starting are missed
+		from = 1
+	}
+	oldest := s.revision - EventHistorySize + 1
+	if oldest < 1 {
+		oldest = 1
+	}
+	if from < oldest {
+		log.Warnf("resume token[%s] is out of event history, events before revision %d are lost", token, oldest)
+		metrics.ReportSyncerResumeOverflow()
+		from = oldest
+	}
+	for i := from; i <= s.revision; i++ {
+		w.Push(s.history[(i-1)%EventHistorySize])
+	}
+	log.Infof("replay instance events[%d, %d] from resume token[%s]", from, s.revision, token)
+}
+
+func (s *Service) Unsubscribe(w *Watcher) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if _, ok := s.watchers[w]; !ok {
+		return
+	}
+	delete(s.watchers, w)
+	metrics.ReportSyncerWatcher(-1)
 }
 
 func (s *Service) Start() {
@@ -80,3 +157,24 @@ func (s *Service) Stop() {
 
 	log.Debug("syncer notify service stopped")
 }
+
+// FormatToken returns the resume token like '{epoch}-{revision}'
+func FormatToken(epoch, revision int64) string {
+	return strconv.FormatInt(epoch, 10) + "-" + strconv.FormatInt(revision, 10)
+}
+
+func ParseToken(token string) (epoch int64, revision int64, err error) {
+	arr := strings.Split(token, "-")
+	if len(arr) != 2 {
+		return 0, 0, fmt.Errorf("%w: %s", ErrInvalidToken, token)
+	}
+	epoch, err = strconv.ParseInt(arr[0], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %s", ErrInvalidToken, token)
+	}
+	revision, err = strconv.ParseInt(arr[1], 10, 64)
+	if err != nil || revision < 0 {
+		return 0, 0, fmt.Errorf("%w: %s", ErrInvalidToken, token)
+	}
+	return epoch, revision, nil
+}
@@ -0,0 +1,149 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncernotify_test
+
+import (
+	"testing"
+
+	"github.com/apache/servicecomb-service-center/pkg/dump"
+	. "github.com/apache/servicecomb-service-center/server/syncernotify"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEvent(domain, project string) *dump.WatchInstanceChangedEvent {
+	return &dump.WatchInstanceChangedEvent{
+		Action: "CREATE",
+		Service: &dump.Microservice{
+			KV: &dump.KV{Key: "/cse-sr/ms/files/" + domain + "/" + project + "/service_id"},
+		},
+	}
+}
+
+func TestService_AddEvent(t *testing.T) {
+	t.Run("every watcher should receive the event", func(t *testing.T) {
+		s := NewSyncerNotifyService()
+		w1, w2 := NewWatcher(WatchFilter{}), NewWatcher(WatchFilter{})
+		s.Subscribe(w1, "")
+		s.Subscribe(w2, "")
+
+		evt := newEvent("default", "default")
+		s.AddEvent(evt)
+		assert.NotEmpty(t, evt.Token)
+		assert.Equal(t, evt, <-w1.Events())
+		assert.Equal(t, evt, <-w2.Events())
+
+		s.Unsubscribe(w2)
+		s.AddEvent(newEvent("default", "default"))
+		assert.Equal(t, 1, w1.Len())
+		assert.Equal(t, 0, w2.Len())
+	})
+
+	t.Run("watcher should receive the events matching the filter", func(t *testing.T) {
+		s := NewSyncerNotifyService()
+		w1 := NewWatcher(WatchFilter{Domain: "d1"})
+		w2 := NewWatcher(WatchFilter{Domain: "d1", Project: "p2"})
+		s.Subscribe(w1, "")
+		s.Subscribe(w2, "")
+
+		s.AddEvent(newEvent("d1", "p1"))
+		s.AddEvent(newEvent("d1", "p2"))
+		s.AddEvent(newEvent("d2", "p2"))
+		assert.Equal(t, 2, w1.Len())
+		assert.Equal(t, 1, w2.Len())
+
+		w3 := NewWatcher(WatchFilter{Project: "p2"})
+		assert.True(t, w3.Push(&dump.WatchInstanceChangedEvent{
+			Instance: &dump.Instance{
+				KV: &dump.KV{Key: "/cse-sr/inst/files/d3/p2/service_id/instance_id"},
+			},
+		}))
+	})
+
+	t.Run("watcher should drop the events when queue is full", func(t *testing.T) {
+		s := NewSyncerNotifyService()
+		w := NewWatcher(WatchFilter{})
+		s.Subscribe(w, "")
+		for i := 0; i < InstanceEventQueueSize+10; i++ {
+			s.AddEvent(newEvent("default", "default"))
+		}
+		assert.Equal(t, InstanceEventQueueSize, w.Len())
+	})
+}
+
+func TestService_Subscribe(t *testing.T) {
+	s := NewSyncerNotifyService()
+	var events []*dump.WatchInstanceChangedEvent
+	for i := 0; i < 3; i++ {
+		evt := newEvent("default", "default")
+		s.AddEvent(evt)
+		events = append(events, evt)
+	}
+
+	t.Run("resume from token should replay the missed events", func(t *testing.T) {
+		w := NewWatcher(WatchFilter{})
+		s.Subscribe(w, events[0].Token)
+		assert.Equal(t, 2, w.Len())
+		assert.Equal(t, events[1], <-w.Events())
+		assert.Equal(t, events[2], <-w.Events())
+	})
+
+	t.Run("resume from the latest token should replay nothing", func(t *testing.T) {
+		w := NewWatcher(WatchFilter{})
+		s.Subscribe(w, events[2].Token)
+		assert.Equal(t, 0, w.Len())
+	})
+
+	t.Run("resume from the token before restarting should replay all", func(t *testing.T) {
+		w := NewWatcher(WatchFilter{})
+		s.Subscribe(w, FormatToken(1, 100))
+		assert.Equal(t, 3, w.Len())
+	})
+
+	t.Run("resume from the invalid token should replay nothing", func(t *testing.T) {
+		w := NewWatcher(WatchFilter{})
+		s.Subscribe(w, "xxx")
+		assert.Equal(t, 0, w.Len())
+	})
+
+	t.Run("resume from the token out of history should replay all the retained", func(t *testing.T) {
+		s := NewSyncerNotifyService()
+		var events []*dump.WatchInstanceChangedEvent
+		for i := 0; i < EventHistorySize+2; i++ {
+			evt := newEvent("default", "default")
+			s.AddEvent(evt)
+			events = append(events, evt)
+		}
+		w := NewWatcher(WatchFilter{})
+		s.Subscribe(w, events[0].Token)
+		assert.Equal(t, EventHistorySize, w.Len())
+		for _, evt := range events[2:] {
+			assert.Equal(t, evt, <-w.Events())
+		}
+		assert.Equal(t, 0, w.Len())
+	})
+}
+
+func TestParseToken(t *testing.T) {
+	epoch, rev, err := ParseToken(FormatToken(123, 45))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(123), epoch)
+	assert.Equal(t, int64(45), rev)
+
+	for _, token := range []string{"", "1", "a-1", "1-b", "1-2-3", "1--2"} {
+		_, _, err = ParseToken(token)
+		assert.ErrorIs(t, err, ErrInvalidToken, token)
+	}
+}
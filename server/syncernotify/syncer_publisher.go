@@ -19,6 +19,7 @@ package syncernotify
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/apache/servicecomb-service-center/pkg/gopool"
@@ -34,7 +35,8 @@ func init() {
 }
 
 type Publisher struct {
-	ws        *WebSocket
+	wss       map[*WebSocket]struct{}
+	lock      sync.RWMutex
 	goroutine *gopool.Pool
 }
 
@@ -61,41 +63,63 @@ func (wh *Publisher) loop(ctx context.Context) {
 			// server shutdown
 			return
 		case <-ticker.C:
-			wsIsActive := true
-			if wh.ws != nil {
-				if payload := wh.ws.Pick(); payload != nil {
-					if _, ok := payload.(error); ok {
-						wsIsActive = false
-					}
-					wh.dispatch(wh.ws, payload)
-				}
+			for _, ws := range wh.sockets() {
+				wh.pick(ws)
 			}
-			if !wsIsActive {
-				log.Debugf("release websocket conn :%s", wh.ws.conn.RemoteAddr())
+		}
+	}
+}
 
-				err := wh.ws.conn.Close()
-				if err != nil {
-					log.Errorf(err, "conn close failed")
-				}
+func (wh *Publisher) pick(ws *WebSocket) {
+	payload := ws.Pick()
+	if payload == nil {
+		return
+	}
+	wh.dispatch(ws, payload)
+	if _, ok := payload.(error); !ok {
+		return
+	}
 
-				wh.ws = nil
+	log.Debugf("release websocket conn :%s", ws.conn.RemoteAddr())
+	wh.remove(ws)
 
-				err = alarm.Raise(alarm.IDWebsocketOfScSyncerLost, alarm.AdditionalContext("%v", err))
-				if err != nil {
-					log.Error("alarm error", err)
-				}
-			}
-		}
+	err := ws.conn.Close()
+	if err != nil {
+		log.Errorf(err, "conn close failed")
 	}
+
+	err = alarm.Raise(alarm.IDWebsocketOfScSyncerLost, alarm.AdditionalContext("%v", err))
+	if err != nil {
+		log.Error("alarm error", err)
+	}
+}
+
+func (wh *Publisher) sockets() []*WebSocket {
+	wh.lock.RLock()
+	defer wh.lock.RUnlock()
+	wss := make([]*WebSocket, 0, len(wh.wss))
+	for ws := range wh.wss {
+		wss = append(wss, ws)
+	}
+	return wss
+}
+
+func (wh *Publisher) remove(ws *WebSocket) {
+	wh.lock.Lock()
+	delete(wh.wss, ws)
+	wh.lock.Unlock()
 }
 
 func (wh *Publisher) Accept(ws *WebSocket) {
 	log.Debugf("get a new websocket:%s", ws.conn.RemoteAddr())
-	wh.ws = ws
+	wh.lock.Lock()
+	wh.wss[ws] = struct{}{}
+	wh.lock.Unlock()
 }
 
 func NewPublisher() *Publisher {
 	return &Publisher{
+		wss:       make(map[*WebSocket]struct{}),
 		goroutine: gopool.New(context.Background()),
 	}
 }
@@ -0,0 +1,101 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncernotify
+
+import (
+	"strings"
+
+	pb "github.com/apache/servicecomb-service-center/pkg/dump"
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	"github.com/apache/servicecomb-service-center/server/metrics"
+)
+
+// WatchFilter limits the instance events pushed to a watcher,
+// the empty field matches any value
+type WatchFilter struct {
+	Domain  string
+	Project string
+}
+
+func (f WatchFilter) Match(event *pb.WatchInstanceChangedEvent) bool {
+	if len(f.Domain) == 0 && len(f.Project) == 0 {
+		return true
+	}
+	domain, project := eventDomainProject(event)
+	if len(f.Domain) > 0 && f.Domain != domain {
+		return false
+	}
+	if len(f.Project) > 0 && f.Project != project {
+		return false
+	}
+	return true
+}
+
+// eventDomainProject parses the domain and project from the kv key,
+// service key is like '/cse-sr/ms/files/{domain}/{project}/{serviceId}'
+// and instance key is like '/cse-sr/inst/files/{domain}/{project}/{serviceId}/{instanceId}'
+func eventDomainProject(event *pb.WatchInstanceChangedEvent) (string, string) {
+	if event.Service != nil && event.Service.KV != nil {
+		arr := strings.Split(event.Service.KV.Key, "/")
+		if l := len(arr); l >= 3 {
+			return arr[l-3], arr[l-2]
+		}
+	}
+	if event.Instance != nil && event.Instance.KV != nil {
+		arr := strings.Split(event.Instance.KV.Key, "/")
+		if l := len(arr); l >= 4 {
+			return arr[l-4], arr[l-3]
+		}
+	}
+	return "", ""
+}
+
+// Watcher holds the bounded queue of instance events for one syncer
+type Watcher struct {
+	Filter WatchFilter
+	queue  chan *pb.WatchInstanceChangedEvent
+}
+
+func NewWatcher(filter WatchFilter) *Watcher {
+	return &Watcher{
+		Filter: filter,
+		queue:  make(chan *pb.WatchInstanceChangedEvent, InstanceEventQueueSize),
+	}
+}
+
+// Push adds the event to queue without blocking, the event will be
+// dropped if it does not match the filter or the queue is full
+func (w *Watcher) Push(event *pb.WatchInstanceChangedEvent) bool {
+	if !w.Filter.Match(event) {
+		return false
+	}
+	select {
+	case w.queue <- event:
+		return true
+	default:
+		metrics.ReportSyncerEventDropped()
+		log.Warnf("watcher queue is full, drop instance event[%s]", event.Token)
+		return false
+	}
+}
+
+func (w *Watcher) Events() <-chan *pb.WatchInstanceChangedEvent {
+	return w.queue
+}
+
+func (w *Watcher) Len() int {
+	return len(w.queue)
+}
@@ -30,26 +30,49 @@ import (
 )
 
 type WebSocket struct {
-	ctx    context.Context
-	conn   *websocket.Conn
-	err    error
-	free   chan struct{}
-	closed chan struct{}
+	ctx     context.Context
+	conn    *websocket.Conn
+	err     error
+	free    chan struct{}
+	closed  chan struct{}
+	filter  WatchFilter
+	token   string
+	watcher *Watcher
 }
 
-func DoWebSocketWatch(ctx context.Context, conn *websocket.Conn) {
+type Option func(ws *WebSocket)
+
+// WithFilter only pushes the instance events of the domain/project
+func WithFilter(filter WatchFilter) Option {
+	return func(ws *WebSocket) {
+		ws.filter = filter
+	}
+}
+
+// WithResumeToken replays the instance events after the token
+func WithResumeToken(token string) Option {
+	return func(ws *WebSocket) {
+		ws.token = token
+	}
+}
+
+func DoWebSocketWatch(ctx context.Context, conn *websocket.Conn, opts ...Option) {
 	log.Debugf("begin do websocket watch")
 
-	socket := NewWebSocket(ctx, conn)
+	socket := NewWebSocket(ctx, conn, opts...)
 
 	process(socket)
 }
 
-func NewWebSocket(ctx context.Context, conn *websocket.Conn) *WebSocket {
-	return &WebSocket{
+func NewWebSocket(ctx context.Context, conn *websocket.Conn, opts ...Option) *WebSocket {
+	ws := &WebSocket{
 		ctx:  ctx,
 		conn: conn,
 	}
+	for _, opt := range opts {
+		opt(ws)
+	}
+	return ws
 }
 
 func process(socket *WebSocket) {
@@ -66,6 +89,9 @@ func (wh *WebSocket) Init() {
 	wh.SetReady()
 	remoteAddr := wh.conn.RemoteAddr().String()
 
+	wh.watcher = NewWatcher(wh.filter)
+	GetSyncerNotifyCenter().Subscribe(wh.watcher, wh.token)
+
 	Instance().Accept(wh)
 	log.Debugf("start watching instance status, watcher[%s]", remoteAddr)
 }
@@ -78,7 +104,7 @@ func (wh *WebSocket) Pick() interface{} {
 		}
 
 		select {
-		case e := <-GetSyncerNotifyCenter().instEventCh:
+		case e := <-wh.watcher.Events():
 			return e
 		default:
 			// reset if idle
@@ -234,6 +260,7 @@ func (wh *WebSocket) SetReady() {
 }
 
 func (wh *WebSocket) Stop() {
+	GetSyncerNotifyCenter().Unsubscribe(wh.watcher)
 	close(wh.closed)
 }
 
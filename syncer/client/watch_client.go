@@ -50,6 +50,9 @@ type WatchClient struct {
 	conn     *websocket.Conn
 	ready    bool
 	mux      sync.RWMutex
+	// token of the last received event, used to resume
+	// the missed events after reconnecting
+	token string
 }
 
 // NewWatchClient Get the client from the client caches with addr
@@ -87,6 +90,9 @@ func (c *WatchClient) WebsocketDial() error {
 		Host:   wsHost,
 		Path:   watchInstanceURL,
 	}
+	if token := c.resumeToken(); len(token) > 0 {
+		u.RawQuery = url.Values{"resumeToken": []string{token}}.Encode()
+	}
 
 	conn, _, err := c.wsDialer.Dial(u.String(), c.GetDefaultHeaders())
 
@@ -136,6 +142,7 @@ func (c *WatchClient) WatchInstances(callback func(*dump.WatchInstanceChangedEve
 				if err != nil {
 					break
 				}
+				c.setResumeToken(response.Token)
 				callback(&response)
 
 			}
@@ -157,6 +164,21 @@ func (c *WatchClient) WatchInstances(callback func(*dump.WatchInstanceChangedEve
 	return err
 }
 
+func (c *WatchClient) resumeToken() string {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	return c.token
+}
+
+func (c *WatchClient) setResumeToken(token string) {
+	if len(token) == 0 {
+		return
+	}
+	c.mux.Lock()
+	c.token = token
+	c.mux.Unlock()
+}
+
 func (c *WatchClient) WatchInstanceHeartbeat(callback func(*dump.WatchInstanceChangedEvent)) {
 	ticker := time.NewTicker(30 * time.Second)
 
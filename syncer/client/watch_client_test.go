@@ -44,6 +44,17 @@ func TestWatchInstance(t *testing.T) {
 
 }
 
+func TestWatchClient_ResumeToken(t *testing.T) {
+	cli := NewWatchClient("127.0.0.1:8888")
+	assert.Empty(t, cli.resumeToken())
+
+	cli.setResumeToken("1-1")
+	assert.Equal(t, "1-1", cli.resumeToken())
+
+	cli.setResumeToken("")
+	assert.Equal(t, "1-1", cli.resumeToken())
+}
+
 func fakeAddToQueue(event *dump.WatchInstanceChangedEvent) {
 	log.Debugf("success add instance event to queue:%s", event)
 }
@@ -0,0 +1,190 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mockconsul
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+const (
+	apiCatalogServices = "/v1/catalog/services"
+	apiHealthService   = "/v1/health/service/"
+	apiRegister        = "/v1/agent/service/register"
+	apiDeregister      = "/v1/agent/service/deregister/"
+	apiCheckPass       = "/v1/agent/check/pass/"
+
+	nodeName    = "mock-node"
+	nodeAddress = "127.0.0.1"
+)
+
+// Service is the registration of the service in the mock agent
+type Service struct {
+	ID      string            `json:"ID"`
+	Name    string            `json:"Name"`
+	Tags    []string          `json:"Tags,omitempty"`
+	Address string            `json:"Address"`
+	Port    int               `json:"Port"`
+	Meta    map[string]string `json:"Meta,omitempty"`
+	Check   *Check            `json:"Check,omitempty"`
+}
+
+type Check struct {
+	CheckID string `json:"CheckID"`
+	TTL     string `json:"TTL,omitempty"`
+	Status  string `json:"Status"`
+}
+
+// Server is a consul stand-in serving the catalog, health and agent apis,
+// the services are kept in memory
+type Server struct {
+	*httptest.Server
+	// Token is the required ACL token if it is not empty
+	Token string
+
+	lock     sync.RWMutex
+	services map[string]*Service
+}
+
+func NewMockServer() *Server {
+	s := &Server{services: make(map[string]*Service)}
+	mux := http.NewServeMux()
+	mux.HandleFunc(apiCatalogServices, s.catalogServices)
+	mux.HandleFunc(apiHealthService, s.healthService)
+	mux.HandleFunc(apiRegister, s.register)
+	mux.HandleFunc(apiDeregister, s.deregister)
+	mux.HandleFunc(apiCheckPass, s.checkPass)
+	s.Server = httptest.NewServer(s.acl(mux))
+	return s
+}
+
+// Put adds the service, the check status is critical if not specified
+func (s *Server) Put(service *Service) {
+	if service.Check == nil {
+		service.Check = &Check{}
+	}
+	if len(service.Check.CheckID) == 0 {
+		service.Check.CheckID = "service:" + service.ID
+	}
+	if len(service.Check.Status) == 0 {
+		service.Check.Status = "critical"
+	}
+	s.lock.Lock()
+	s.services[service.ID] = service
+	s.lock.Unlock()
+}
+
+// Get returns the registered service by id
+func (s *Server) Get(id string) (*Service, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	service, ok := s.services[id]
+	return service, ok
+}
+
+func (s *Server) acl(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if len(s.Token) > 0 && req.Header.Get("X-Consul-Token") != s.Token {
+			http.Error(rw, "Permission denied", http.StatusForbidden)
+			return
+		}
+		h.ServeHTTP(rw, req)
+	})
+}
+
+func (s *Server) catalogServices(rw http.ResponseWriter, req *http.Request) {
+	s.lock.RLock()
+	services := map[string][]string{"consul": {}}
+	for _, service := range s.services {
+		services[service.Name] = append(services[service.Name], service.Tags...)
+	}
+	s.lock.RUnlock()
+	writeJSON(rw, services)
+}
+
+func (s *Server) healthService(rw http.ResponseWriter, req *http.Request) {
+	name := strings.TrimPrefix(req.URL.Path, apiHealthService)
+	entries := make([]interface{}, 0)
+	s.lock.RLock()
+	for _, service := range s.services {
+		if service.Name != name {
+			continue
+		}
+		entries = append(entries, map[string]interface{}{
+			"Node": map[string]string{"Node": nodeName, "Address": nodeAddress},
+			"Service": map[string]interface{}{
+				"ID": service.ID, "Service": service.Name, "Tags": service.Tags,
+				"Address": service.Address, "Port": service.Port, "Meta": service.Meta,
+			},
+			"Checks": []map[string]string{
+				{"Node": nodeName, "CheckID": "serfHealth", "Status": "passing"},
+				{"Node": nodeName, "CheckID": service.Check.CheckID, "Status": service.Check.Status, "ServiceID": service.ID},
+			},
+		})
+	}
+	s.lock.RUnlock()
+	writeJSON(rw, entries)
+}
+
+func (s *Server) register(rw http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPut {
+		http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	service := &Service{}
+	if err := json.NewDecoder(req.Body).Decode(service); err != nil || len(service.Name) == 0 {
+		http.Error(rw, "Request decode failed", http.StatusBadRequest)
+		return
+	}
+	if len(service.ID) == 0 {
+		service.ID = service.Name
+	}
+	s.Put(service)
+}
+
+func (s *Server) deregister(rw http.ResponseWriter, req *http.Request) {
+	id := strings.TrimPrefix(req.URL.Path, apiDeregister)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.services[id]; !ok {
+		http.Error(rw, "Unknown service ID "+id, http.StatusNotFound)
+		return
+	}
+	delete(s.services, id)
+}
+
+func (s *Server) checkPass(rw http.ResponseWriter, req *http.Request) {
+	checkID := strings.TrimPrefix(req.URL.Path, apiCheckPass)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, service := range s.services {
+		if service.Check.CheckID == checkID && len(service.Check.TTL) > 0 {
+			service.Check.Status = "passing"
+			return
+		}
+	}
+	http.Error(rw, "Unknown check ID "+checkID, http.StatusNotFound)
+}
+
+func writeJSON(rw http.ResponseWriter, v interface{}) {
+	rw.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(rw).Encode(v)
+}
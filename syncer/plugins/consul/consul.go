@@ -0,0 +1,140 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package consul
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/servicecomb-service-center/client"
+	"github.com/apache/servicecomb-service-center/pkg/util"
+	"github.com/apache/servicecomb-service-center/syncer/plugins"
+	pb "github.com/apache/servicecomb-service-center/syncer/proto"
+)
+
+const (
+	PluginName = "consul"
+
+	apiCatalogServices = "/v1/catalog/services"
+	apiHealthService   = "/v1/health/service/"
+
+	// the service of consul server itself
+	consulService = "consul"
+)
+
+func init() {
+	// Register self as a repository plugin
+	plugins.RegisterPlugin(&plugins.Plugin{
+		Kind: plugins.PluginServicecenter,
+		Name: PluginName,
+		New:  New,
+	})
+}
+
+type adaptor struct{}
+
+func New() plugins.PluginInstance {
+	return &adaptor{}
+}
+
+// New repository with endpoints
+func (*adaptor) New(opts ...plugins.SCConfigOption) (plugins.Servicecenter, error) {
+	cfg := plugins.ToSCConfig(opts...)
+	client, err := client.NewLBClient(cfg.Endpoints, cfg.Merge())
+	if err != nil {
+		return nil, err
+	}
+	return &Client{LBClient: client, Cfg: cfg}, nil
+}
+
+type Client struct {
+	*client.LBClient
+	Cfg client.Config
+}
+
+// GetAll get and transform consul catalog to SyncData
+func (c *Client) GetAll(ctx context.Context) (*pb.SyncData, error) {
+	services := make(map[string][]string)
+	if err := c.do(ctx, http.MethodGet, apiCatalogServices, nil, &services); err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string][]*ServiceEntry, len(services))
+	for name := range services {
+		if name == consulService {
+			continue
+		}
+		var list []*ServiceEntry
+		if err := c.do(ctx, http.MethodGet, apiHealthService+url.PathEscape(name), nil, &list); err != nil {
+			return nil, err
+		}
+		entries[name] = list
+	}
+	return toSyncData(entries), nil
+}
+
+// CommonHeaders Set the common header of the request
+func (c *Client) CommonHeaders() http.Header {
+	var headers = make(http.Header)
+	if len(c.Cfg.Token) > 0 {
+		headers.Set("X-Consul-Token", c.Cfg.Token)
+	}
+	headers.Set("Accept", "application/json")
+	headers.Set("Content-Type", "application/json")
+	return headers
+}
+
+// do sends the request with the JSON encoded reqObj, and decodes the response
+// body to respObj if it is not nil
+func (c *Client) do(ctx context.Context, method, api string, reqObj, respObj interface{}) error {
+	var body []byte
+	if reqObj != nil {
+		var err error
+		body, err = json.Marshal(reqObj)
+		if err != nil {
+			return err
+		}
+	}
+	resp, err := c.RestDoWithContext(ctx, method, api, c.CommonHeaders(), body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return c.toError(body)
+	}
+	if respObj == nil {
+		return nil
+	}
+	return json.Unmarshal(body, respObj)
+}
+
+// toError response body to error
+func (c *Client) toError(body []byte) error {
+	return errors.New(util.BytesToStringWithNoCopy(body))
+}
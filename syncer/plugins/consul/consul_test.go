@@ -0,0 +1,124 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package consul
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/servicecomb-service-center/syncer/pkg/mock/mockconsul"
+	"github.com/apache/servicecomb-service-center/syncer/plugins"
+	pb "github.com/apache/servicecomb-service-center/syncer/proto"
+)
+
+func newConsul(t *testing.T) (*mockconsul.Server, plugins.Servicecenter) {
+	plugins.SetPluginConfig(plugins.PluginServicecenter.String(), PluginName)
+	adaptor := plugins.Plugins().Servicecenter()
+	if adaptor == nil {
+		t.Fatalf("get repository adaptor %s failed", PluginName)
+	}
+	svr := mockconsul.NewMockServer()
+	t.Cleanup(svr.Close)
+
+	repo, err := adaptor.New(plugins.WithEndpoints([]string{svr.URL}))
+	if err != nil {
+		t.Fatalf("new repository %s failed, error: %s", PluginName, err)
+	}
+	return svr, repo
+}
+
+func TestClient_GetAll(t *testing.T) {
+	svr, repo := newConsul(t)
+	svr.Put(&mockconsul.Service{ID: "a-1", Name: "a", Address: "10.0.0.1", Port: 8080,
+		Meta: map[string]string{"version": "1.0.0"}, Check: &mockconsul.Check{Status: HealthPassing}})
+	svr.Put(&mockconsul.Service{ID: "a-2", Name: "a", Port: 8081,
+		Check: &mockconsul.Check{Status: HealthWarning}})
+	svr.Put(&mockconsul.Service{ID: "b-1", Name: "b", Address: "10.0.0.2", Port: 8080})
+
+	data, err := repo.GetAll(context.Background())
+	assert.NoError(t, err)
+	// the consul service and the service without UP instance are ignored
+	assert.Equal(t, 1, len(data.Services))
+	assert.Equal(t, "a", data.Services[0].ServiceId)
+	assert.Equal(t, PluginName, data.Services[0].PluginName)
+
+	assert.Equal(t, 2, len(data.Instances))
+	instances := map[string]*pb.SyncInstance{}
+	for _, inst := range data.Instances {
+		instances[inst.InstanceId] = inst
+	}
+	assert.Equal(t, []string{"http://10.0.0.1:8080"}, instances["a-1"].Endpoints)
+	assert.Equal(t, "1.0.0", instances["a-1"].Version)
+	assert.Equal(t, "mock-node", instances["a-1"].HostName)
+	// use the node address if the service address is empty
+	assert.Equal(t, []string{"http://127.0.0.1:8081"}, instances["a-2"].Endpoints)
+	assert.Equal(t, "latest", instances["a-2"].Version)
+}
+
+func TestClient_Instance(t *testing.T) {
+	svr, repo := newConsul(t)
+	ctx := context.Background()
+
+	serviceID, err := repo.ServiceExistence(ctx, "default/default", &pb.SyncService{Name: "svc"})
+	assert.NoError(t, err)
+	assert.Equal(t, "svc", serviceID)
+
+	instanceID, err := repo.RegisterInstance(ctx, "default/default", serviceID, &pb.SyncInstance{
+		InstanceId:  "inst-1",
+		Endpoints:   []string{"rest://10.0.0.1:30100/"},
+		Version:     "1.0.0",
+		HealthCheck: &pb.HealthCheck{Interval: 30, Times: 3},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "inst-1", instanceID)
+
+	service, ok := svr.Get(instanceID)
+	assert.True(t, ok)
+	assert.Equal(t, "svc", service.Name)
+	assert.Equal(t, "10.0.0.1", service.Address)
+	assert.Equal(t, 30100, service.Port)
+	assert.Equal(t, map[string]string{"version": "1.0.0", "scheme": "rest"}, service.Meta)
+	assert.Equal(t, "service:inst-1", service.Check.CheckID)
+	assert.Equal(t, "2m0s", service.Check.TTL)
+
+	service.Check.Status = HealthCritical
+	assert.NoError(t, repo.Heartbeat(ctx, "default/default", serviceID, instanceID))
+	service, _ = svr.Get(instanceID)
+	assert.Equal(t, HealthPassing, service.Check.Status)
+
+	assert.NoError(t, repo.UnregisterInstance(ctx, "default/default", serviceID, instanceID))
+	_, ok = svr.Get(instanceID)
+	assert.False(t, ok)
+
+	assert.Error(t, repo.Heartbeat(ctx, "default/default", serviceID, instanceID))
+	assert.Error(t, repo.UnregisterInstance(ctx, "default/default", serviceID, instanceID))
+}
+
+func TestClient_Token(t *testing.T) {
+	svr, repo := newConsul(t)
+	svr.Token = "secret"
+
+	_, err := repo.GetAll(context.Background())
+	assert.Error(t, err)
+
+	repo.(*Client).Cfg.Token = "secret"
+	_, err = repo.GetAll(context.Background())
+	assert.NoError(t, err)
+}
@@ -0,0 +1,51 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package consul
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	pb "github.com/apache/servicecomb-service-center/syncer/proto"
+)
+
+const (
+	apiRegister   = "/v1/agent/service/register"
+	apiDeregister = "/v1/agent/service/deregister/"
+	apiCheckPass  = "/v1/agent/check/pass/"
+)
+
+// RegisterInstance register instance to consul agent with a TTL check
+func (c *Client) RegisterInstance(ctx context.Context, domainProject, serviceID string, syncInstance *pb.SyncInstance) (string, error) {
+	registration := toRegistration(serviceID, syncInstance)
+	if err := c.do(ctx, http.MethodPut, apiRegister, registration, nil); err != nil {
+		return "", err
+	}
+	return registration.ID, nil
+}
+
+// UnregisterInstance unregister instance from consul agent
+func (c *Client) UnregisterInstance(ctx context.Context, domainProject, serviceID, instanceID string) error {
+	return c.do(ctx, http.MethodPut, apiDeregister+url.PathEscape(instanceID), nil, nil)
+}
+
+// Heartbeat passes the TTL check of instance
+func (c *Client) Heartbeat(ctx context.Context, domainProject, serviceID, instanceID string) error {
+	return c.do(ctx, http.MethodPut, apiCheckPass+url.PathEscape(checkID(instanceID)), nil, nil)
+}
@@ -0,0 +1,239 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package consul
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/servicecomb-service-center/pkg/log"
+	pb "github.com/apache/servicecomb-service-center/syncer/proto"
+)
+
+const (
+	defaultApp     = "consul"
+	defaultScheme  = "http"
+	defaultVersion = "0.0.1"
+
+	metaScheme  = "scheme"
+	metaVersion = "version"
+
+	// the TTL of the check is (times + 1) * interval of the instance health
+	// check, defaultCheckTTL is used if the instance has no health check
+	defaultCheckTTL = 120 * time.Second
+	// the critical instance is deregistered by consul if the syncer stops
+	// sending heartbeat
+	deregisterCriticalAfter = "10m"
+
+	expansionDatasource = "datasource"
+)
+
+// toSyncData transform consul service entries to SyncData
+func toSyncData(entries map[string][]*ServiceEntry) (data *pb.SyncData) {
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	data = &pb.SyncData{
+		Services:  make([]*pb.SyncService, 0, len(entries)),
+		Instances: make([]*pb.SyncInstance, 0, 10),
+	}
+	for _, name := range names {
+		service := toSyncService(name)
+
+		instances := toSyncInstances(service.ServiceId, entries[name])
+		if len(instances) == 0 {
+			continue
+		}
+
+		data.Services = append(data.Services, service)
+		data.Instances = append(data.Instances, instances...)
+	}
+	return
+}
+
+// toSyncService transform consul service to SyncService
+func toSyncService(name string) (service *pb.SyncService) {
+	service = &pb.SyncService{
+		ServiceId:     name,
+		Name:          name,
+		App:           defaultApp,
+		Version:       defaultVersion,
+		DomainProject: "default/default",
+		Status:        pb.SyncService_UP,
+		PluginName:    PluginName,
+	}
+	return
+}
+
+// toSyncInstances transform consul service entries to SyncInstances
+func toSyncInstances(serviceID string, entries []*ServiceEntry) []*pb.SyncInstance {
+	instList := make([]*pb.SyncInstance, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Service == nil || toStatus(entry.Checks) != pb.SyncInstance_UP {
+			continue
+		}
+
+		instList = append(instList, toSyncInstance(serviceID, entry))
+	}
+	return instList
+}
+
+// toSyncInstance transform consul service entry to SyncInstance
+func toSyncInstance(serviceID string, entry *ServiceEntry) (syncInstance *pb.SyncInstance) {
+	service := entry.Service
+	syncInstance = &pb.SyncInstance{
+		InstanceId: service.ID,
+		ServiceId:  serviceID,
+		Endpoints:  make([]string, 0, 1),
+		Status:     toStatus(entry.Checks),
+		PluginName: PluginName,
+		Version:    "latest",
+	}
+	if v := service.Meta[metaVersion]; len(v) > 0 {
+		syncInstance.Version = v
+	}
+
+	address := service.Address
+	if entry.Node != nil {
+		syncInstance.HostName = entry.Node.Node
+		if len(address) == 0 {
+			address = entry.Node.Address
+		}
+	}
+	if len(address) > 0 && service.Port > 0 {
+		scheme := service.Meta[metaScheme]
+		if len(scheme) == 0 {
+			scheme = defaultScheme
+		}
+		syncInstance.Endpoints = append(syncInstance.Endpoints,
+			fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(address, strconv.Itoa(service.Port))))
+	}
+
+	content, err := json.Marshal(service)
+	if err != nil {
+		log.Errorf(err, "transform consul service to syncer instance failed: %s", err)
+		return
+	}
+	syncInstance.Expansions = []*pb.Expansion{{
+		Kind:   expansionDatasource,
+		Bytes:  content,
+		Labels: map[string]string{},
+	}}
+	return
+}
+
+// toStatus returns the worst status of the checks, the warning is treated
+// as UP like the consul DNS interface
+func toStatus(checks []*HealthCheck) pb.SyncInstance_Status {
+	status := pb.SyncInstance_UP
+	for _, check := range checks {
+		if check.Status != HealthCritical {
+			continue
+		}
+		if check.CheckID == nodeMaintenance || strings.HasPrefix(check.CheckID, serviceMaintenancePrefix) {
+			return pb.SyncInstance_OUTOFSERVICE
+		}
+		status = pb.SyncInstance_DOWN
+	}
+	return status
+}
+
+// toRegistration transform SyncInstance to consul service registration
+func toRegistration(serviceID string, syncInstance *pb.SyncInstance) (registration *AgentServiceRegistration) {
+	registration = expansionRegistration(syncInstance)
+	if registration == nil {
+		registration = &AgentServiceRegistration{
+			ID:   syncInstance.InstanceId,
+			Name: serviceID,
+			Meta: map[string]string{metaVersion: syncInstance.Version},
+		}
+		for _, ep := range syncInstance.Endpoints {
+			addr, err := url.Parse(ep)
+			if err != nil {
+				log.Error("parse the endpoint of instance failed", err)
+				continue
+			}
+			port, err := strconv.Atoi(addr.Port())
+			if err != nil {
+				log.Error("Illegal value of port", err)
+				continue
+			}
+			// consul service has only one address
+			registration.Address = addr.Hostname()
+			registration.Port = port
+			registration.Meta[metaScheme] = addr.Scheme
+			break
+		}
+	}
+	registration.Check = toCheck(registration.ID, syncInstance.HealthCheck)
+	return
+}
+
+// expansionRegistration returns the registration of the instance synced
+// from another consul, nil if not found
+func expansionRegistration(syncInstance *pb.SyncInstance) *AgentServiceRegistration {
+	if syncInstance.PluginName != PluginName || len(syncInstance.Expansions) == 0 {
+		return nil
+	}
+	matches := pb.Expansions(syncInstance.Expansions).Find(expansionDatasource, map[string]string{})
+	if len(matches) == 0 {
+		return nil
+	}
+	service := &AgentService{}
+	err := json.Unmarshal(matches[0].Bytes, service)
+	if err != nil {
+		log.Errorf(err, "proto unmarshal %s instance, instanceID = %s, kind = %v, content = %v failed",
+			PluginName, syncInstance.InstanceId, matches[0].Kind, matches[0].Bytes)
+		return nil
+	}
+	return &AgentServiceRegistration{
+		ID:      service.ID,
+		Name:    service.Service,
+		Tags:    service.Tags,
+		Address: service.Address,
+		Port:    service.Port,
+		Meta:    service.Meta,
+	}
+}
+
+// toCheck returns the TTL check of the instance, the syncer heartbeat passes it
+func toCheck(instanceID string, hc *pb.HealthCheck) *AgentServiceCheck {
+	ttl := defaultCheckTTL
+	if hc != nil && hc.Interval > 0 {
+		ttl = time.Duration(hc.Interval*(hc.Times+1)) * time.Second
+	}
+	return &AgentServiceCheck{
+		CheckID:                        checkID(instanceID),
+		TTL:                            ttl.String(),
+		Status:                         HealthPassing,
+		DeregisterCriticalServiceAfter: deregisterCriticalAfter,
+	}
+}
+
+func checkID(instanceID string) string {
+	return "service:" + instanceID
+}
@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package consul
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/apache/servicecomb-service-center/syncer/proto"
+)
+
+func TestToStatus(t *testing.T) {
+	assert.Equal(t, pb.SyncInstance_UP, toStatus(nil))
+	assert.Equal(t, pb.SyncInstance_UP, toStatus([]*HealthCheck{
+		{CheckID: "serfHealth", Status: HealthPassing},
+		{CheckID: "service:a", Status: HealthWarning},
+	}))
+	assert.Equal(t, pb.SyncInstance_DOWN, toStatus([]*HealthCheck{
+		{CheckID: "serfHealth", Status: HealthCritical},
+	}))
+	assert.Equal(t, pb.SyncInstance_OUTOFSERVICE, toStatus([]*HealthCheck{
+		{CheckID: "service:a", Status: HealthCritical},
+		{CheckID: "_service_maintenance:a", Status: HealthCritical},
+	}))
+	assert.Equal(t, pb.SyncInstance_OUTOFSERVICE, toStatus([]*HealthCheck{
+		{CheckID: "_node_maintenance", Status: HealthCritical},
+	}))
+}
+
+func TestToSyncData(t *testing.T) {
+	data := toSyncData(map[string][]*ServiceEntry{
+		"b": {{Service: &AgentService{ID: "b-1", Service: "b", Address: "::1", Port: 80,
+			Meta: map[string]string{"scheme": "https"}}}},
+		"a": {{Service: &AgentService{ID: "a-1", Service: "a", Address: "10.0.0.1", Port: 80},
+			Checks: []*HealthCheck{{Status: HealthCritical}}}},
+	})
+	assert.Equal(t, 1, len(data.Services))
+	assert.Equal(t, "b", data.Services[0].Name)
+	assert.Equal(t, 1, len(data.Instances))
+	assert.Equal(t, []string{"https://[::1]:80"}, data.Instances[0].Endpoints)
+}
+
+func TestToRegistration(t *testing.T) {
+	t.Run("instance from other plugin", func(t *testing.T) {
+		r := toRegistration("svc", &pb.SyncInstance{
+			InstanceId: "inst-1",
+			Endpoints:  []string{"invalid", "http://10.0.0.1:8080"},
+			Version:    "1.0.0",
+			PluginName: "servicecenter",
+		})
+		assert.Equal(t, "inst-1", r.ID)
+		assert.Equal(t, "svc", r.Name)
+		assert.Equal(t, "10.0.0.1", r.Address)
+		assert.Equal(t, 8080, r.Port)
+		assert.Equal(t, map[string]string{"version": "1.0.0", "scheme": "http"}, r.Meta)
+		assert.Equal(t, &AgentServiceCheck{
+			CheckID: "service:inst-1", TTL: "2m0s", Status: HealthPassing, DeregisterCriticalServiceAfter: "10m",
+		}, r.Check)
+	})
+
+	t.Run("instance from consul should keep the origin registration", func(t *testing.T) {
+		inst := toSyncInstance("svc", &ServiceEntry{
+			Service: &AgentService{ID: "inst-1", Service: "svc", Tags: []string{"t"}, Address: "10.0.0.1", Port: 8080,
+				Meta: map[string]string{"k": "v"}},
+		})
+		r := toRegistration("svc", inst)
+		assert.Equal(t, "inst-1", r.ID)
+		assert.Equal(t, "svc", r.Name)
+		assert.Equal(t, []string{"t"}, r.Tags)
+		assert.Equal(t, "10.0.0.1", r.Address)
+		assert.Equal(t, 8080, r.Port)
+		assert.Equal(t, map[string]string{"k": "v"}, r.Meta)
+		assert.Equal(t, "service:inst-1", r.Check.CheckID)
+	})
+}
@@ -0,0 +1,80 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package consul
+
+const (
+	HealthPassing  = "passing"
+	HealthWarning  = "warning"
+	HealthCritical = "critical"
+
+	// the check id prefix of the service maintenance mode
+	serviceMaintenancePrefix = "_service_maintenance:"
+	// the check id of the node maintenance mode
+	nodeMaintenance = "_node_maintenance"
+)
+
+// AgentServiceRegistration is the request body of the agent service register api
+type AgentServiceRegistration struct {
+	ID      string             `json:"ID,omitempty"`
+	Name    string             `json:"Name,omitempty"`
+	Tags    []string           `json:"Tags,omitempty"`
+	Address string             `json:"Address,omitempty"`
+	Port    int                `json:"Port,omitempty"`
+	Meta    map[string]string  `json:"Meta,omitempty"`
+	Check   *AgentServiceCheck `json:"Check,omitempty"`
+}
+
+// AgentServiceCheck is the health check of the registered service, the
+// syncer uses the TTL check and heartbeats it
+type AgentServiceCheck struct {
+	CheckID                        string `json:"CheckID,omitempty"`
+	TTL                            string `json:"TTL,omitempty"`
+	Status                         string `json:"Status,omitempty"`
+	DeregisterCriticalServiceAfter string `json:"DeregisterCriticalServiceAfter,omitempty"`
+}
+
+// ServiceEntry is the item of the health service api response
+type ServiceEntry struct {
+	Node    *Node          `json:"Node"`
+	Service *AgentService  `json:"Service"`
+	Checks  []*HealthCheck `json:"Checks"`
+}
+
+type Node struct {
+	ID         string `json:"ID"`
+	Node       string `json:"Node"`
+	Address    string `json:"Address"`
+	Datacenter string `json:"Datacenter"`
+}
+
+type AgentService struct {
+	ID      string            `json:"ID"`
+	Service string            `json:"Service"`
+	Tags    []string          `json:"Tags,omitempty"`
+	Address string            `json:"Address"`
+	Port    int               `json:"Port"`
+	Meta    map[string]string `json:"Meta,omitempty"`
+}
+
+type HealthCheck struct {
+	Node      string `json:"Node"`
+	CheckID   string `json:"CheckID"`
+	Name      string `json:"Name"`
+	Status    string `json:"Status"`
+	ServiceID string `json:"ServiceID"`
+}
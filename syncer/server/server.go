@@ -44,6 +44,7 @@ import (
 	ggrpc "google.golang.org/grpc"
 
 	// import plugins
+	_ "github.com/apache/servicecomb-service-center/syncer/plugins/consul"
 	_ "github.com/apache/servicecomb-service-center/syncer/plugins/eureka"
 	_ "github.com/apache/servicecomb-service-center/syncer/plugins/servicecenter"
 
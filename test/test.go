@@ -19,6 +19,8 @@
 package test
 
 import (
+	"io/ioutil"
+	"path/filepath"
 	"time"
 
 	_ "github.com/apache/servicecomb-service-center/server/init"
@@ -41,6 +43,25 @@ func init() {
 		archaius.Set("registry.cache.mode", 0)
 		archaius.Set("discovery.kind", "etcd")
 		archaius.Set("registry.kind", "etcd")
+	} else if t == "local" {
+		dir, err := ioutil.TempDir("", "sc-ut")
+		if err != nil {
+			panic(err)
+		}
+		archaius.Set("registry.cache.mode", 0)
+		archaius.Set("discovery.kind", "etcd")
+		archaius.Set("registry.kind", "local")
+		archaius.Set("registry.local.path", filepath.Join(dir, "local.db"))
+	} else if t == "sql" {
+		dir, err := ioutil.TempDir("", "sc-ut")
+		if err != nil {
+			panic(err)
+		}
+		archaius.Set("registry.cache.mode", 0)
+		archaius.Set("discovery.kind", "etcd")
+		archaius.Set("registry.kind", "sql")
+		archaius.Set("registry.sql.driver", "sqlite3")
+		archaius.Set("registry.sql.dsn", filepath.Join(dir, "sc.db")+"?_busy_timeout=5000")
 	} else {
 		archaius.Set("registry.heartbeat.kind", "checker")
 	}